@@ -3,7 +3,15 @@ package behaviors
 import (
 	"context"
 	"fmt"
+	"github.com/Lincyaw/loadgenerator/httpclient"
 	"github.com/Lincyaw/loadgenerator/service"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
 	"log"
 	"math/rand"
 	"os"
@@ -17,6 +25,12 @@ import (
 
 const Client = "client"
 
+const tracerName = "github.com/Lincyaw/loadgenerator/behaviors"
+
+// nodeExecutions 通过 otel 全局 MeterProvider 创建，LoadGenerator 设置 MeterProvider 后生效。
+var nodeExecutions, _ = otel.Meter(tracerName).Int64Counter("loadgen.node.executions",
+	metric.WithDescription("Node executions by chain, node and status."))
+
 type ContextKey string
 
 const dataKey = ContextKey("data")
@@ -27,7 +41,47 @@ type Context struct {
 }
 
 func NewContext(ctx context.Context) *Context {
-	return &Context{ctx: ctx}
+	// 预先放入数据 map，保证在子 span 上下文中 Set 的值在 span 结束后仍然可见
+	return &Context{ctx: context.WithValue(ctx, dataKey, make(map[string]interface{}))}
+}
+
+// contextSetter 由需要感知当前 span 的客户端实现，例如 *service.SvcImpl。
+type contextSetter interface {
+	SetContext(ctx context.Context)
+}
+
+// startSpan 在当前上下文下开始一个 span，并让上下文中的客户端以它作为父 span。
+// 返回的函数结束 span 并恢复原来的上下文。
+func (c *Context) startSpan(name string, opts ...trace.SpanStartOption) func(err error) {
+	parent := c.ctx
+	ctx, span := otel.Tracer(tracerName).Start(parent, name, opts...)
+	c.setSpanContext(ctx)
+	return func(err error) {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+		c.setSpanContext(parent)
+	}
+}
+
+// setLabels 更新随请求发送的 VU、迭代、行为链和节点标识，返回的函数恢复原来的上下文。
+func (c *Context) setLabels(update func(labels *httpclient.Labels)) func() {
+	parent := c.ctx
+	labels := httpclient.LabelsFromContext(parent)
+	update(&labels)
+	c.setSpanContext(httpclient.ContextWithLabels(parent, labels))
+	return func() {
+		c.setSpanContext(parent)
+	}
+}
+
+func (c *Context) setSpanContext(ctx context.Context) {
+	c.ctx = ctx
+	if setter, ok := c.Get(Client).(contextSetter); ok {
+		setter.SetContext(ctx)
+	}
 }
 
 // Set sets a value in the context
@@ -104,7 +158,23 @@ func (c *Chain) AddNextChain(next *Chain, probability float64) {
 
 func (c *Chain) Execute(ctx *Context) (*NodeResult, error) {
 	for _, node := range c.nodes {
+		restore := ctx.setLabels(func(labels *httpclient.Labels) {
+			labels.Chain = c.Name
+			labels.Node = node.GetName()
+		})
+		end := ctx.startSpan(node.GetName(), trace.WithAttributes(attribute.String("chain", c.Name)))
 		result, err := node.Execute(ctx)
+		end(err)
+		restore()
+		status := "ok"
+		if err != nil {
+			status = "error"
+		}
+		nodeExecutions.Add(ctx.ctx, 1, metric.WithAttributes(
+			attribute.String("chain", c.Name),
+			attribute.String("node", node.GetName()),
+			attribute.String("status", status),
+		))
 		if err != nil {
 			return nil, err
 		}
@@ -159,6 +229,22 @@ type Config struct {
 	Thread    int
 	SleepTime int
 	Chain     *Chain
+	// TracerProvider 用于创建迭代、节点和 HTTP 请求的 span，为空时使用不导出的 SDK TracerProvider，
+	// 此时仍会生成 trace id 并通过 traceparent 头传播。
+	TracerProvider trace.TracerProvider
+	// MeterProvider 用于记录活跃 VU、迭代次数以及 HTTP 请求指标，为空时指标不导出。
+	MeterProvider metric.MeterProvider
+	// Rate 是所有 VU 合计的目标迭代速率（次/秒）。大于 0 时按固定速率发放迭代，
+	// 没有空闲 VU 时该次迭代被丢弃并计入 loadgen.iterations.dropped；为 0 时每次迭代后随机休眠 SleepTime。
+	// 不能为负数或超过 MaxRate。
+	Rate float64
+	// CatalogRefresh 是共享参考数据（Catalog）的后台刷新间隔，决定参考数据查询产生的流量，
+	// 与迭代速率无关。为 0 时使用 DefaultCatalogRefresh；小于 0 时不共享，节点每次迭代自行查询。
+	CatalogRefresh time.Duration
+	// StationPopularity 是采样起止站时各车站的权重，未列出的车站为 1，见 WithPopularity。
+	StationPopularity map[string]float64
+	// ClientOptions 用于创建 VU 和 Catalog 刷新使用的服务客户端，例如 service.WithRecorder。
+	ClientOptions []service.ClientOption
 }
 
 func WithThread(thread int) func(*Config) {
@@ -176,11 +262,53 @@ func WithChain(c *Chain) func(*Config) {
 		conf.Chain = c
 	}
 }
+func WithTracerProvider(tp trace.TracerProvider) func(*Config) {
+	return func(conf *Config) {
+		conf.TracerProvider = tp
+	}
+}
+func WithMeterProvider(mp metric.MeterProvider) func(*Config) {
+	return func(conf *Config) {
+		conf.MeterProvider = mp
+	}
+}
+func WithRate(perSecond float64) func(*Config) {
+	return func(conf *Config) {
+		conf.Rate = perSecond
+	}
+}
+func WithCatalogRefresh(interval time.Duration) func(*Config) {
+	return func(conf *Config) {
+		conf.CatalogRefresh = interval
+	}
+}
+func WithStationPopularity(popularity map[string]float64) func(*Config) {
+	return func(conf *Config) {
+		conf.StationPopularity = popularity
+	}
+}
+func WithClientOptions(opts ...service.ClientOption) func(*Config) {
+	return func(conf *Config) {
+		conf.ClientOptions = append(conf.ClientOptions, opts...)
+	}
+}
+
+// MaxRate 是 Config.Rate 的上限，更高的速率下 ticker 的间隔小于 1 微秒，已无法按时发放。
+const MaxRate = 1e6
 
 type LoadGenerator struct {
 }
 
+// Start 运行 VU 直到收到 SIGINT 或 SIGTERM，并在所有 VU 结束当前迭代后返回。
 func (l *LoadGenerator) Start(conf ...func(*Config)) {
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sigs)
+	l.run(sigs, conf...)
+}
+
+// run 运行 VU 直到 interrupt 收到信号。
+func (l *LoadGenerator) run(interrupt <-chan os.Signal, conf ...func(*Config)) {
 	config := Config{}
 	for _, fn := range conf {
 		fn(&config)
@@ -193,13 +321,98 @@ func (l *LoadGenerator) Start(conf ...func(*Config)) {
 	if config.Chain == nil {
 		panic("LoadGenerator needs chain")
 	}
+	if !(config.Rate >= 0 && config.Rate <= MaxRate) {
+		panic(fmt.Sprintf("LoadGenerator rate must be between 0 and %g, got %g", float64(MaxRate), config.Rate))
+	}
+
+	if config.TracerProvider == nil {
+		config.TracerProvider = sdktrace.NewTracerProvider()
+	}
+	otel.SetTracerProvider(config.TracerProvider)
+	if config.MeterProvider == nil {
+		config.MeterProvider = sdkmetric.NewMeterProvider()
+	}
+	otel.SetMeterProvider(config.MeterProvider)
+
+	meter := config.MeterProvider.Meter(tracerName)
+	activeVUs, _ := meter.Int64UpDownCounter("loadgen.vus.active",
+		metric.WithDescription("Virtual users currently running iterations."))
+	iterations, _ := meter.Int64Counter("loadgen.iterations",
+		metric.WithDescription("Chain iterations executed by virtual users."))
+	dropped, _ := meter.Int64Counter("loadgen.iterations.dropped",
+		metric.WithDescription("Iterations scheduled by the target rate while no virtual user was idle."))
+	chainAttr := attribute.String("chain", config.Chain.GetName())
+	rate := newRateObserver()
+	_, _ = meter.Float64ObservableGauge("loadgen.rate.target",
+		metric.WithDescription("Target iteration rate per second, 0 when not rate limited."),
+		metric.WithFloat64Callback(func(_ context.Context, o metric.Float64Observer) error {
+			o.Observe(config.Rate, metric.WithAttributes(chainAttr))
+			return nil
+		}))
+	_, _ = meter.Float64ObservableGauge("loadgen.rate.achieved",
+		metric.WithDescription("Achieved iteration rate per second over the last 10 seconds."),
+		metric.WithFloat64Callback(func(_ context.Context, o metric.Float64Observer) error {
+			o.Observe(rate.achieved(), metric.WithAttributes(chainAttr))
+			return nil
+		}))
 
 	var wg sync.WaitGroup
 	wg.Add(config.Thread)
+	stop := make(chan struct{})
+
+	// 参考数据在 VU 启动前拉取一次，之后由单独的 goroutine 按 CatalogRefresh 刷新
+	var catalog *Catalog
+	if config.CatalogRefresh >= 0 {
+		if config.CatalogRefresh == 0 {
+			config.CatalogRefresh = DefaultCatalogRefresh
+		}
+		catalog = &Catalog{
+			networkOptions: []NetworkOption{WithPopularity(config.StationPopularity)},
+			clientOptions:  config.ClientOptions,
+		}
+		catalog.refresh()
+		go func() {
+			ticker := time.NewTicker(config.CatalogRefresh)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-stop:
+					return
+				case <-ticker.C:
+					catalog.refresh()
+				}
+			}
+		}()
+	}
+
+	// 固定速率模式下由 ticker 发放迭代令牌，只有空闲的 VU 能拿到令牌
+	var tokens chan struct{}
+	if config.Rate > 0 {
+		tokens = make(chan struct{})
+		go func() {
+			ticker := time.NewTicker(time.Duration(float64(time.Second) / config.Rate))
+			defer ticker.Stop()
+			for {
+				select {
+				case <-stop:
+					return
+				case <-ticker.C:
+					select {
+					case tokens <- struct{}{}:
+					default:
+						dropped.Add(context.Background(), 1, metric.WithAttributes(chainAttr))
+					}
+				}
+			}
+		}()
+	}
 
 	for i := 0; i < config.Thread; i++ {
 		go func(index int) {
 			defer wg.Done()
+			v := newVU(index, config.ClientOptions...)
+			activeVUs.Add(context.Background(), 1, metric.WithAttributes(chainAttr))
+			defer activeVUs.Add(context.Background(), -1, metric.WithAttributes(chainAttr))
 			defer func() {
 				if r := recover(); r != nil {
 					buf := make([]byte, 1024)
@@ -210,26 +423,122 @@ func (l *LoadGenerator) Start(conf ...func(*Config)) {
 				}
 			}()
 
-			for {
-				ctx := NewContext(context.Background())
-				ctx.Set(Client, service.NewSvcClients())
-				_, err := config.Chain.Execute(ctx)
+			for iteration := 1; ; iteration++ {
+				if tokens != nil {
+					select {
+					case <-stop:
+						return
+					case <-tokens:
+					}
+				}
+
+				err := v.iterate(iteration, config.Chain, catalog)
+				status := "ok"
 				if err != nil {
+					status = "error"
 					log.Printf("Error executing chain: %v", err)
 				}
-				time.Sleep(time.Millisecond * time.Duration(rand.Intn(config.SleepTime)))
+				iterations.Add(context.Background(), 1, metric.WithAttributes(chainAttr, attribute.String("status", status)))
+				rate.inc()
+
+				if tokens != nil {
+					continue
+				}
+				select {
+				case <-stop:
+					return
+				case <-time.After(time.Millisecond * time.Duration(rand.Intn(config.SleepTime))):
+				}
 			}
 		}(i)
 	}
 
-	sigs := make(chan os.Signal, 1)
-	done := make(chan bool, 1)
-	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
-	go func() {
-		<-sigs
-		done <- true
-	}()
-
-	<-done
+	<-interrupt
+	// 通知所有 VU 在当前迭代结束后退出，以便调用方刷新遥测数据
+	close(stop)
 	wg.Wait()
 }
+
+// vu 是一个虚拟用户。它的服务客户端在迭代之间保持，cookie jar 和登录 token 像浏览器会话一样延续。
+type vu struct {
+	index int
+	cli   *service.SvcImpl
+}
+
+func newVU(index int, opts ...service.ClientOption) *vu {
+	return &vu{index: index, cli: service.NewSvcClients(opts...)}
+}
+
+// iterate 以 VU 的客户端执行一次 chain，catalog 不为空时共享给节点。
+func (v *vu) iterate(iteration int, chain *Chain, catalog *Catalog) error {
+	ctx := NewContext(context.Background())
+	ctx.Set(Client, v.cli)
+	if catalog != nil {
+		ctx.Set(CatalogKey, catalog)
+	}
+	ctx.setLabels(func(labels *httpclient.Labels) {
+		labels.VU = v.index
+		labels.Iteration = iteration
+	})
+	// 每次迭代一个 trace，节点和 HTTP 请求的 span 都是它的子孙
+	end := ctx.startSpan("iteration", trace.WithAttributes(
+		attribute.Int("vu", v.index),
+		attribute.Int("iteration", iteration),
+		attribute.String("chain", chain.GetName()),
+	))
+	_, err := chain.Execute(ctx)
+	end(err)
+	return err
+}
+
+// rateWindow 是实际速率的统计窗口，单位为秒。
+const rateWindow = 10
+
+// rateObserver 按秒分桶统计最近 rateWindow 秒内的实际迭代速率。采集时不重置计数，
+// 所以 OTLP 和 Prometheus 等多个 reader 读到的值互不影响。
+type rateObserver struct {
+	mu      sync.Mutex
+	now     func() time.Time
+	start   time.Time
+	counts  [rateWindow]int
+	seconds [rateWindow]int64
+}
+
+func newRateObserver() *rateObserver {
+	return &rateObserver{now: time.Now, start: time.Now()}
+}
+
+func (r *rateObserver) inc() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	sec := r.now().Unix()
+	i := sec % rateWindow
+	if r.seconds[i] != sec {
+		r.seconds[i] = sec
+		r.counts[i] = 0
+	}
+	r.counts[i]++
+}
+
+func (r *rateObserver) achieved() float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := r.now()
+	sec := now.Unix()
+	count := 0
+	for i, s := range r.seconds {
+		if s > sec-rateWindow {
+			count += r.counts[i]
+		}
+	}
+	// 窗口从 rateWindow-1 秒前的整秒开始，到当前时刻结束；启动不足一个窗口时只计算已运行的时间
+	from := time.Unix(sec-rateWindow+1, 0)
+	if r.start.After(from) {
+		from = r.start
+	}
+	elapsed := now.Sub(from).Seconds()
+	if elapsed <= 0 {
+		return 0
+	}
+	return float64(count) / elapsed
+}
@@ -2,9 +2,28 @@ package behaviors
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"sync/atomic"
 	"testing"
 	"time"
+
+	"github.com/Lincyaw/loadgenerator/fake"
+	"github.com/Lincyaw/loadgenerator/httpclient"
+	"github.com/Lincyaw/loadgenerator/service"
+	"github.com/Lincyaw/loadgenerator/service/servicetest"
+	"go.opentelemetry.io/otel"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 )
 
 func TestContext_SetAndGet(t *testing.T) {
@@ -109,3 +128,687 @@ func TestFuncNode_Execute(t *testing.T) {
 		t.Errorf("Function was not executed")
 	}
 }
+
+type spanContextRecorder struct {
+	ctxs []context.Context
+}
+
+func (r *spanContextRecorder) SetContext(ctx context.Context) {
+	r.ctxs = append(r.ctxs, ctx)
+}
+
+func TestChain_ExecuteCreatesNodeSpans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	node1 := NewFuncNode(func(ctx *Context) (*NodeResult, error) {
+		ctx.Set("key", "value")
+		return nil, nil
+	}, "node1")
+	node2 := NewFuncNode(func(ctx *Context) (*NodeResult, error) {
+		return nil, nil
+	}, "node2")
+	chain := NewChain(node1, node2)
+
+	ctx := NewContext(context.Background())
+	client := &spanContextRecorder{}
+	ctx.Set(Client, client)
+	end := ctx.startSpan("iteration")
+	if _, err := chain.Execute(ctx); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	end(nil)
+
+	if val := ctx.Get("key"); val != "value" {
+		t.Errorf("Expected 'value' after span ended, got %v", val)
+	}
+	spans := recorder.Ended()
+	if len(spans) != 3 {
+		t.Fatalf("Expected 3 spans, got %d", len(spans))
+	}
+	iteration := spans[2]
+	for _, span := range spans[:2] {
+		if span.Parent().SpanID() != iteration.SpanContext().SpanID() {
+			t.Errorf("Span %s is not a child of the iteration span", span.Name())
+		}
+	}
+	if len(client.ctxs) == 0 {
+		t.Errorf("Client did not receive the span context")
+	}
+}
+
+func TestChain_ExecuteSetsRequestLabels(t *testing.T) {
+	var labels []httpclient.Labels
+	node := NewFuncNode(func(ctx *Context) (*NodeResult, error) {
+		labels = append(labels, httpclient.LabelsFromContext(ctx.ctx))
+		return nil, nil
+	}, "node1")
+	chain := NewChain(node)
+	chain.Name = "login"
+
+	ctx := NewContext(context.Background())
+	client := &spanContextRecorder{}
+	ctx.Set(Client, client)
+	ctx.setLabels(func(l *httpclient.Labels) {
+		l.VU = 3
+		l.Iteration = 7
+	})
+	if _, err := chain.Execute(ctx); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	want := httpclient.Labels{VU: 3, Iteration: 7, Chain: "login", Node: "node1"}
+	if len(labels) != 1 || labels[0] != want {
+		t.Errorf("Labels = %+v, want %+v", labels, want)
+	}
+	if got := httpclient.LabelsFromContext(client.ctxs[len(client.ctxs)-1]); got.Node != "" || got.VU != 3 {
+		t.Errorf("Labels after node = %+v, want node label removed", got)
+	}
+}
+
+func TestIsHighSpeedTrip(t *testing.T) {
+	cases := map[string]bool{"G1234": true, "D1345": true, "g1234": true, "Z1234": false, "T1235": false, "K1345": false, "": false}
+	for tripId, want := range cases {
+		if got := isHighSpeedTrip(tripId); got != want {
+			t.Errorf("isHighSpeedTrip(%q) = %v, want %v", tripId, got, want)
+		}
+	}
+}
+
+// withVerifyCode makes the logins of the test submit code, which the fake backend accepts.
+func withVerifyCode(t *testing.T, code string) {
+	old := VerifyCodeSolver
+	VerifyCodeSolver = service.FixedVerifyCodeSolver(code)
+	t.Cleanup(func() { VerifyCodeSolver = old })
+}
+
+// TestLoginChain_Offline runs both login branches against the in-process fake backend.
+func TestLoginChain_Offline(t *testing.T) {
+	withVerifyCode(t, "123")
+	srv := fake.NewServer()
+	defer srv.Close()
+	t.Setenv("BASE_URL", srv.URL)
+
+	for _, chain := range LoginChain.nextChains {
+		ctx := NewContext(context.Background())
+		ctx.Set(Client, service.NewSvcClients())
+		if _, err := chain.chain.Execute(ctx); err != nil {
+			t.Errorf("%s: %v", chain.chain.GetName(), err)
+		}
+	}
+	if srv.Requests() == 0 {
+		t.Error("no requests reached the fake backend")
+	}
+}
+
+// TestPreserveBehaviorChain_Offline books a searched journey and rebooks a paid order on the fake backend.
+func TestPreserveBehaviorChain_Offline(t *testing.T) {
+	withVerifyCode(t, "123")
+	srv := fake.NewServer()
+	defer srv.Close()
+	t.Setenv("BASE_URL", srv.URL)
+
+	ctx := NewContext(context.Background())
+	cli := service.NewSvcClients()
+	ctx.Set(Client, cli)
+	if _, err := LoginAdmin(ctx); err != nil {
+		t.Fatal(err)
+	}
+	// Pay the seeded G1234 order of the basic user, Rebook moves paid orders only.
+	unpaid := &service.Qi{LoginId: fake.BasicUserId, EnableStateQuery: true, State: fake.OrderNotPaid}
+	orders, err := cli.ReqQueryOrders(unpaid)
+	if err != nil || len(orders.Data) != 1 {
+		t.Fatalf("ReqQueryOrders() = %+v, %v, want the seeded order", orders, err)
+	}
+	paid := orders.Data[0].Id
+	if _, err := cli.ReqPayOrder(paid); err != nil {
+		t.Fatal(err)
+	}
+
+	date := time.Now().AddDate(0, 0, 1).Format(time.DateOnly)
+	ctx.Set(From, "nanjing")
+	ctx.Set(To, "shanghai")
+	ctx.Set(Date, date)
+	ctx.Set(TripID, "G1235")
+	if _, err := PreserveBehaviorChain.Execute(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if got := ctx.Get(OrderId); got != paid {
+		t.Errorf("OrderId = %v, want the rebooked order %s", got, paid)
+	}
+	if orders, err = cli.ReqQueryOrders(unpaid); err != nil || len(orders.Data) != 1 || orders.Data[0].TrainNumber != "G1235" {
+		t.Errorf("unpaid orders after Preserve = %+v, %v, want one on G1235", orders, err)
+	}
+}
+
+// TestVU_KeepsSession checks that a VU's cookies outlive the iteration that received them.
+func TestVU_KeepsSession(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/verifycode/generate":
+			http.SetCookie(w, &http.Cookie{Name: "YsbCaptcha", Value: "session", Path: "/"})
+			w.Write([]byte("captcha"))
+		default:
+			cookie, err := r.Cookie("YsbCaptcha")
+			w.Write([]byte(fmt.Sprint(err == nil && cookie.Value == "session")))
+		}
+	}))
+	defer srv.Close()
+	t.Setenv("BASE_URL", srv.URL)
+
+	v := newVU(0)
+	generate := NewChain(NewFuncNode(func(ctx *Context) (*NodeResult, error) {
+		_, err := ctx.Get(Client).(service.Client).GenerateVerifyCode()
+		return nil, err
+	}, "GenerateVerifyCode"))
+	if err := v.iterate(1, generate, nil); err != nil {
+		t.Fatal(err)
+	}
+	verify := NewChain(NewFuncNode(func(ctx *Context) (*NodeResult, error) {
+		if ok, err := ctx.Get(Client).(service.Client).VerifyCode("code"); err != nil || !ok {
+			return nil, fmt.Errorf("captcha cookie of the previous iteration not sent: %v", err)
+		}
+		return nil, nil
+	}, "VerifyCode"))
+	if err := v.iterate(2, verify, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestVU_RefreshesToken checks that a token issued in one iteration is refreshed before it expires in a
+// later iteration of the same VU, instead of each iteration starting without a session.
+func TestVU_RefreshesToken(t *testing.T) {
+	withVerifyCode(t, "123")
+	var logins atomic.Int32
+	var issued, sent atomic.Value
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/verifycode/generate":
+			w.Write([]byte("captcha"))
+		case "/api/v1/users/login":
+			// the token expires in 30s, within the default refresh window of a minute
+			payload := fmt.Sprintf(`{"exp":%d,"n":%d}`, time.Now().Add(30*time.Second).Unix(), logins.Add(1))
+			token := "e30." + base64.RawURLEncoding.EncodeToString([]byte(payload)) + ".sig"
+			issued.Store(token)
+			fmt.Fprintf(w, `{"status":1,"data":{"token":%q}}`, token)
+		default:
+			sent.Store(r.Header.Get("Authorization"))
+			w.Write([]byte(`{"status":1}`))
+		}
+	}))
+	defer srv.Close()
+	t.Setenv("BASE_URL", srv.URL)
+
+	v := newVU(0)
+	if err := v.iterate(1, NewChain(NewFuncNode(LoginBasic, "LoginBasic")), nil); err != nil {
+		t.Fatal(err)
+	}
+	query := NewChain(NewFuncNode(func(ctx *Context) (*NodeResult, error) {
+		_, err := ctx.Get(Client).(service.Client).QueryStations()
+		return nil, err
+	}, "QueryStations"))
+	if err := v.iterate(2, query, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := logins.Load(); got != 2 {
+		t.Errorf("logins = %d, want 2: one by LoginBasic, one proactive refresh", got)
+	}
+	if auth, want := sent.Load(), "Bearer "+issued.Load().(string); auth != want {
+		t.Errorf("iteration 2 sent Authorization %q, want the refreshed token %q", auth, want)
+	}
+}
+
+func TestQueryConsign(t *testing.T) {
+	errUnavailable := errors.New("consign-service unavailable")
+	tests := []struct {
+		name    string
+		status  int
+		err     error
+		wantErr bool
+	}{
+		{"found", 1, nil, false},
+		{"business failure", 0, nil, true},
+		{"request failure", 0, errUnavailable, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cli := &servicetest.Client{
+				QueryByOrderIdFunc: func(orderId string) (*service.QueryByOrderIdResponse, error) {
+					return &service.QueryByOrderIdResponse{Status: tt.status}, tt.err
+				},
+			}
+			ctx := NewContext(context.Background())
+			ctx.Set(Client, cli)
+			ctx.Set(OrderId, "order-1")
+
+			_, err := QueryConsign(ctx)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("QueryConsign() err = %v, want error %v", err, tt.wantErr)
+			}
+			if tt.err != nil && !errors.Is(err, tt.err) {
+				t.Errorf("QueryConsign() err = %v, want wrapping %v", err, tt.err)
+			}
+			if calls := cli.CallsTo("QueryByOrderId"); len(calls) != 1 || calls[0].Args[0] != "order-1" {
+				t.Errorf("QueryByOrderId calls = %v, want one for order-1", calls)
+			}
+		})
+	}
+}
+
+func TestQueryAssurance_SetsOrderForCreateAssurance(t *testing.T) {
+	var types service.GetallAssuranceType
+	var assurances service.GetAllAssuranceResponse
+	json.Unmarshal([]byte(`{"status":1,"data":[{"index":1,"name":"TRAFFIC_ACCIDENT","price":3}]}`), &types)
+	json.Unmarshal([]byte(`{"status":1,"data":[{"orderId":"order-1","typeIndex":1}]}`), &assurances)
+	cli := &servicetest.Client{
+		GetAllAssuranceTypesFunc: func() (*service.GetallAssuranceType, error) { return &types, nil },
+		GetAllAssurancesFunc:     func() (*service.GetAllAssuranceResponse, error) { return &assurances, nil },
+	}
+	ctx := NewContext(context.Background())
+	ctx.Set(Client, cli)
+	if _, err := QueryAssurance(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if got := ctx.Get(OrderId); got != "order-1" {
+		t.Errorf("OrderId = %v, want order-1", got)
+	}
+
+	// without an order CreateAssurance fails instead of panicking
+	ctx = NewContext(context.Background())
+	ctx.Set(Client, cli)
+	if _, err := CreateAssurance(ctx); err == nil {
+		t.Error("CreateAssurance() without an order succeeded")
+	}
+}
+
+func TestCreateAssurance_ReturnsServiceError(t *testing.T) {
+	rejected := &service.Error{Service: "assuranceservice", Status: 0, Msg: "Order not found"}
+	cli := &servicetest.Client{
+		CreateNewAssuranceFunc: func(typeIndex int, orderID string) (*service.CreateAssuranceResponse, error) {
+			return &service.CreateAssuranceResponse{}, rejected
+		},
+	}
+	ctx := NewContext(context.Background())
+	ctx.Set(Client, cli)
+	ctx.Set(OrderId, "order-1")
+
+	// a rejected request fails the node, it must not exit the load generator
+	if _, err := CreateAssurance(ctx); !errors.Is(err, rejected) {
+		t.Errorf("CreateAssurance() err = %v, want %v", err, rejected)
+	}
+}
+
+func TestRebook(t *testing.T) {
+	paid := func(qi *service.Qi) (*service.OrderArrResp, error) {
+		return &service.OrderArrResp{Status: 1, Data: []service.Order{{Id: "order-1", TrainNumber: "G1234"}}}, nil
+	}
+	rebooked := func(info *service.RebookInfo) (*service.RebookResp, error) {
+		return &service.RebookResp{Status: 1, Data: service.Order{Id: "order-2"}}, nil
+	}
+	tests := []struct {
+		name        string
+		tripId      string
+		cli         *servicetest.Client
+		wantCalls   []string
+		wantOrderId string
+	}{
+		{
+			name:      "no paid order",
+			tripId:    "G1235",
+			cli:       &servicetest.Client{},
+			wantCalls: []string{"ReqQueryOrders"},
+		},
+		{
+			name:        "high speed trip",
+			tripId:      "G1235",
+			cli:         &servicetest.Client{ReqQueryOrdersFunc: paid, ReqRebookFunc: rebooked},
+			wantCalls:   []string{"ReqQueryOrders", "ReqRebook"},
+			wantOrderId: "order-2",
+		},
+		{
+			name:        "other trip",
+			tripId:      "Z1234",
+			cli:         &servicetest.Client{ReqQueryOrdersOtherFunc: paid, ReqRebookFunc: rebooked},
+			wantCalls:   []string{"ReqQueryOrdersOther", "ReqRebook"},
+			wantOrderId: "order-2",
+		},
+		{
+			name:   "pay difference",
+			tripId: "G1235",
+			cli: &servicetest.Client{
+				ReqQueryOrdersFunc: paid,
+				ReqRebookFunc: func(info *service.RebookInfo) (*service.RebookResp, error) {
+					return &service.RebookResp{Status: service.RebookStatusPayDifference},
+						&service.Error{Service: "rebookservice", Status: service.RebookStatusPayDifference}
+				},
+				ReqRebookPayDifferenceFunc: rebooked,
+			},
+			wantCalls:   []string{"ReqQueryOrders", "ReqRebook", "ReqRebookPayDifference"},
+			wantOrderId: "order-2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := NewContext(context.Background())
+			ctx.Set(Client, tt.cli)
+			ctx.Set(AccountID, "account-1")
+			ctx.Set(TripID, tt.tripId)
+			ctx.Set(Date, "2024-06-06")
+
+			if _, err := Rebook(ctx); err != nil {
+				t.Fatalf("Rebook() err = %v", err)
+			}
+			if got := tt.cli.Methods(); !reflect.DeepEqual(got, tt.wantCalls) {
+				t.Errorf("calls = %v, want %v", got, tt.wantCalls)
+			}
+			if orderId, _ := ctx.Get(OrderId).(string); orderId != tt.wantOrderId {
+				t.Errorf("OrderId = %q, want %q", orderId, tt.wantOrderId)
+			}
+			for _, call := range tt.cli.CallsTo("ReqRebook") {
+				info := call.Args[0].(*service.RebookInfo)
+				if info.OrderId != "order-1" || info.OldTripId != "G1234" || info.TripId != tt.tripId {
+					t.Errorf("ReqRebook(%+v), want order-1 moved from G1234 to %s", info, tt.tripId)
+				}
+			}
+		})
+	}
+}
+
+func TestCatalog_Refresh(t *testing.T) {
+	errDown := errors.New("route-service unavailable")
+	routes := &service.QueryMultiResponse{}
+	json.Unmarshal([]byte(`{"status":1,"data":[{"id":"route-1","stations":["shanghai","suzhou","nanjing"],
+		"startStation":"shanghai","endStation":"nanjing"}]}`), routes)
+	cli := &servicetest.Client{
+		QueryAllRoutesFunc: func() (*service.QueryMultiResponse, error) {
+			return routes, nil
+		},
+	}
+	catalog := &Catalog{}
+	if err := catalog.Refresh(cli); err != nil {
+		t.Fatalf("Refresh() err = %v", err)
+	}
+	if catalog.Refreshed().IsZero() {
+		t.Error("Refreshed() is zero after a successful refresh")
+	}
+
+	// A failed refresh keeps the previous routes.
+	cli.QueryAllRoutesFunc = func() (*service.QueryMultiResponse, error) {
+		return nil, errDown
+	}
+	if err := catalog.Refresh(cli); !errors.Is(err, errDown) {
+		t.Fatalf("Refresh() err = %v, want %v", err, errDown)
+	}
+	if got := catalog.Routes(); got != routes {
+		t.Errorf("Routes() = %v, want the previous routes", got)
+	}
+
+	// Nodes sample from the shared catalog without querying the collection again.
+	nodeCli := &servicetest.Client{}
+	ctx := NewContext(context.Background())
+	ctx.Set(Client, nodeCli)
+	ctx.Set(CatalogKey, catalog)
+	if _, err := QueryRoute(ctx); err != nil {
+		t.Fatalf("QueryRoute() err = %v", err)
+	}
+	if ctx.Get(RouteID) != "route-1" || ctx.Get(From) != "shanghai" || ctx.Get(To) != "nanjing" {
+		t.Errorf("QueryRoute() set route %v from %v to %v, want route-1 from shanghai to nanjing", ctx.Get(RouteID), ctx.Get(From), ctx.Get(To))
+	}
+	if calls := nodeCli.Calls(); len(calls) != 0 {
+		t.Errorf("QueryRoute() made calls %v, want none", calls)
+	}
+	if _, err := QueryStation(ctx); err == nil {
+		t.Error("QueryStation() with no stations in catalog succeeded, want error")
+	}
+}
+
+func TestQueryRoute_WithoutCatalog(t *testing.T) {
+	cli := &servicetest.Client{}
+	ctx := NewContext(context.Background())
+	ctx.Set(Client, cli)
+	QueryRoute(ctx)
+	QueryStation(ctx)
+
+	// The iteration fetches its own catalog once and reuses it.
+	if n := len(cli.CallsTo("QueryAllRoutes")); n != 1 {
+		t.Errorf("QueryAllRoutes called %d times, want 1", n)
+	}
+	if _, ok := ctx.Get(CatalogKey).(*Catalog); !ok {
+		t.Error("no catalog in context after QueryRoute")
+	}
+}
+
+func newTestNetwork(t *testing.T, opts ...NetworkOption) *Network {
+	t.Helper()
+	routes := &service.QueryMultiResponse{}
+	trips := &service.QueryAllTravelInfo{}
+	if err := json.Unmarshal([]byte(`{"status":1,"data":[
+		{"id":"r1","stations":["a","b","c"]},
+		{"id":"r2","stations":["c","d"]}]}`), routes); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(`{"status":1,"data":[
+		{"tripId":{"type":"G","number":"1"},"routeId":"r1","startTime":"2013-05-04 09:00:00"},
+		{"tripId":{"type":"Z","number":"2"},"routeId":"r2","startTime":"2024-07-01 10:00:00"}]}`), trips); err != nil {
+		t.Fatal(err)
+	}
+	n := NewNetwork(routes, trips, opts...)
+	n.now = func() time.Time { return time.Date(2024, 6, 6, 15, 0, 0, 0, time.Local) }
+	return n
+}
+
+func TestNetwork(t *testing.T) {
+	n := newTestNetwork(t, WithScheduleDays(10))
+	if n.DirectPairs() != 4 || n.TransferPairs() != 3 {
+		t.Errorf("DirectPairs() = %d, TransferPairs() = %d, want 4 and 3", n.DirectPairs(), n.TransferPairs())
+	}
+	for i := 0; i < 100; i++ {
+		journey, ok := n.Transfer()
+		if !ok {
+			t.Fatal("Transfer() found nothing")
+		}
+		if len(journey.Legs) != 2 || journey.Legs[0].From != journey.From || journey.Legs[0].To != journey.Via ||
+			journey.Legs[1].From != journey.Via || journey.Legs[1].To != journey.To {
+			t.Fatalf("Transfer() = %+v, legs don't connect %s, %s and %s", journey, journey.From, journey.Via, journey.To)
+		}
+		// Z2 only runs from 2024-07-01, G1 has run for years
+		first := "2024-06-06"
+		if journey.To == "d" {
+			first = "2024-07-01"
+		}
+		last, _ := time.Parse(time.DateOnly, first)
+		if journey.Date < first || journey.Date > last.AddDate(0, 0, 9).Format(time.DateOnly) {
+			t.Errorf("Transfer() to %s on %s, want 10 days from %s", journey.To, journey.Date, first)
+		}
+	}
+}
+
+func TestNetwork_Popularity(t *testing.T) {
+	n := newTestNetwork(t, WithPopularity(map[string]float64{"d": 0, "a": 8}))
+	if n.DirectPairs() != 3 || n.TransferPairs() != 1 {
+		t.Errorf("DirectPairs() = %d, TransferPairs() = %d, want 3 and 1 without d", n.DirectPairs(), n.TransferPairs())
+	}
+	// a-b and a-c weigh 8 each, b-c weighs 1
+	fromA := 0
+	for i := 0; i < 1700; i++ {
+		journey, _ := n.Direct()
+		if journey.To == "d" {
+			t.Fatalf("Direct() = %+v, want no journey to d", journey)
+		}
+		if journey.From == "a" {
+			fromA++
+		}
+	}
+	if fromA < 1400 || fromA > 1800 {
+		t.Errorf("%d of 1700 journeys from a, want about 1600", fromA)
+	}
+	if _, ok := NewNetwork(&service.QueryMultiResponse{}, &service.QueryAllTravelInfo{}).Direct(); ok {
+		t.Error("Direct() on an empty network found a journey")
+	}
+}
+
+func TestNetwork_TransferWeights(t *testing.T) {
+	// a-b-c and a-c-d weigh 8 each, b-c-d weighs 1
+	n := newTestNetwork(t, WithPopularity(map[string]float64{"a": 8}))
+	fromA := 0
+	for i := 0; i < 1700; i++ {
+		journey, _ := n.Transfer()
+		if journey.From == journey.To {
+			t.Fatalf("Transfer() = %+v, want different origin and destination", journey)
+		}
+		if journey.From == "a" {
+			fromA++
+		}
+	}
+	if fromA < 1400 || fromA > 1800 {
+		t.Errorf("%d of 1700 transfers from a, want about 1600", fromA)
+	}
+
+	// Transfers on one long route are counted, not enumerated: every three stations in route order.
+	stations := make([]string, 300)
+	for i := range stations {
+		stations[i] = fmt.Sprintf("s%03d", i)
+	}
+	routes := &service.QueryMultiResponse{}
+	trips := &service.QueryAllTravelInfo{}
+	data, _ := json.Marshal(stations)
+	if err := json.Unmarshal([]byte(`{"data":[{"id":"r","stations":`+string(data)+`}]}`), routes); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(`{"data":[{"tripId":{"type":"G","number":"1"},"routeId":"r"}]}`), trips); err != nil {
+		t.Fatal(err)
+	}
+	long := NewNetwork(routes, trips)
+	if got, want := long.TransferPairs(), 300*299*298/6; got != want {
+		t.Errorf("TransferPairs() = %d, want %d", got, want)
+	}
+	if journey, ok := long.Transfer(); !ok || !(journey.From < journey.Via && journey.Via < journey.To) {
+		t.Errorf("Transfer() = %+v, %v, want stations in route order", journey, ok)
+	}
+}
+
+// TestNetwork_Offline checks that the sampled journeys have tickets on the fake backend.
+func TestNetwork_Offline(t *testing.T) {
+	withVerifyCode(t, "123")
+	srv := fake.NewServer()
+	defer srv.Close()
+	t.Setenv("BASE_URL", srv.URL)
+
+	ctx := NewContext(context.Background())
+	cli := service.NewSvcClients()
+	ctx.Set(Client, cli)
+	if _, err := LoginBasic(ctx); err != nil {
+		t.Fatal(err)
+	}
+	catalog, _ := catalogFrom(ctx)
+	network := catalog.Network()
+	if network.DirectPairs() == 0 || network.TransferPairs() == 0 {
+		t.Fatalf("DirectPairs() = %d, TransferPairs() = %d, want reachable stations", network.DirectPairs(), network.TransferPairs())
+	}
+	for i := 0; i < 20; i++ {
+		journey, _ := network.Direct()
+		tripInfo := service.TripInfo{StartPlace: journey.From, EndPlace: journey.To, DepartureTime: journey.Date}
+		var trips []interface{}
+		if journey.HighSpeed() {
+			resp, err := cli.QueryInfo(tripInfo)
+			if err != nil {
+				t.Fatalf("QueryInfo(%+v) err = %v", tripInfo, err)
+			}
+			trips = resp.Data
+		} else {
+			resp, err := cli.QueryByBatch(&tripInfo)
+			if err != nil {
+				t.Fatalf("QueryByBatch(%+v) err = %v", tripInfo, err)
+			}
+			trips = resp.Data
+		}
+		if len(trips) == 0 {
+			t.Errorf("no trips from %s to %s on %s", journey.From, journey.To, journey.Date)
+		}
+		if _, err := SearchTransfer(ctx); err != nil {
+			t.Errorf("SearchTransfer() err = %v", err)
+		}
+	}
+}
+
+func TestRateObserver_Achieved(t *testing.T) {
+	now := time.Unix(1000, 0)
+	r := &rateObserver{now: func() time.Time { return now }, start: now}
+	for i := 0; i < 10; i++ {
+		r.inc()
+		now = now.Add(time.Second)
+	}
+	// the window holds the 9 whole seconds before now; collecting doesn't reset it, so two readers see the same rate
+	if first, second := r.achieved(), r.achieved(); first != 1 || second != 1 {
+		t.Errorf("achieved() = %v, %v, want 1 for both readers", first, second)
+	}
+
+	now = now.Add(5 * time.Second)
+	if got, want := r.achieved(), 4.0/9; got != want {
+		t.Errorf("achieved() 5s later = %v, want %v", got, want)
+	}
+	now = now.Add(rateWindow * time.Second)
+	if got := r.achieved(); got != 0 {
+		t.Errorf("achieved() after the window = %v, want 0", got)
+	}
+}
+
+func TestLoadGenerator_InvalidRate(t *testing.T) {
+	chain := NewChain(NewFuncNode(func(ctx *Context) (*NodeResult, error) { return nil, nil }, "node"))
+	for _, rate := range []float64{-1, MaxRate * 2, math.Inf(1), math.NaN()} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Start(WithRate(%v)) didn't panic", rate)
+				}
+			}()
+			(&LoadGenerator{}).Start(WithChain(chain), WithRate(rate), WithCatalogRefresh(-1))
+		}()
+	}
+}
+
+// TestLoadGenerator_RateMetrics runs one VU that is slower than the target rate and checks the exported
+// target and achieved rate gauges and the dropped iterations counter.
+func TestLoadGenerator_RateMetrics(t *testing.T) {
+	chain := NewChain(NewFuncNode(func(ctx *Context) (*NodeResult, error) {
+		time.Sleep(100 * time.Millisecond)
+		return nil, nil
+	}, "slow"))
+	t.Setenv("BASE_URL", "http://127.0.0.1:1")
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	interrupt := make(chan os.Signal, 1)
+	time.AfterFunc(time.Second, func() { interrupt <- os.Interrupt })
+	(&LoadGenerator{}).run(interrupt, WithChain(chain), WithRate(50), WithMeterProvider(mp), WithCatalogRefresh(-1))
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("Collect failed: %v", err)
+	}
+	values := make(map[string]float64)
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			switch data := m.Data.(type) {
+			case metricdata.Gauge[float64]:
+				values[m.Name] = data.DataPoints[0].Value
+			case metricdata.Sum[int64]:
+				for _, dp := range data.DataPoints {
+					values[m.Name] += float64(dp.Value)
+				}
+			}
+		}
+	}
+	if got := values["loadgen.rate.target"]; got != 50 {
+		t.Errorf("loadgen.rate.target = %v, want 50", got)
+	}
+	// the VU takes 100ms per iteration, so it achieves about 10 of the 50 iterations per second
+	if got := values["loadgen.rate.achieved"]; got <= 0 || got > 12 {
+		t.Errorf("loadgen.rate.achieved = %v, want about 10", got)
+	}
+	if got := values["loadgen.iterations.dropped"]; got < 20 {
+		t.Errorf("loadgen.iterations.dropped = %v, want about 40", got)
+	}
+	if got, dropped := values["loadgen.iterations"], values["loadgen.iterations.dropped"]; got+dropped > 51 {
+		t.Errorf("loadgen.iterations + dropped = %v, more than the 50 scheduled in a second", got+dropped)
+	}
+}
@@ -0,0 +1,214 @@
+package behaviors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/Lincyaw/loadgenerator/service"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// CatalogKey 是上下文中共享 *Catalog 的键。
+const CatalogKey = "catalog"
+
+// DefaultCatalogRefresh 是 Config.CatalogRefresh 为 0 时的刷新间隔。
+const DefaultCatalogRefresh = 5 * time.Minute
+
+// Catalog 保存节点随机取样用的参考数据：车站、车型、路线、车次、价格配置和保险类型。
+// LoadGenerator 为所有 VU 共享一个 Catalog 并在后台按固定间隔刷新，
+// 这些全量集合每个刷新间隔只请求一次，而不是每次迭代都请求。返回的响应只读。
+type Catalog struct {
+	mu             sync.RWMutex
+	refreshed      time.Time
+	stations       *service.GetStationResponse
+	trains         *service.TrainResponseType
+	routes         *service.QueryMultiResponse
+	trips          *service.QueryAllTravelInfo
+	prices         *service.AllPriceResponse
+	assuranceTypes *service.GetallAssuranceType
+
+	// networkOptions 用于由路线和车次构建 Network，network 在刷新后按需重建
+	networkOptions []NetworkOption
+	network        *Network
+	// clientOptions 用于创建刷新使用的服务客户端
+	clientOptions []service.ClientOption
+}
+
+// Refresh 通过 cli 重新拉取全部参考数据。某个集合拉取失败时保留它上一次的数据，
+// 其余集合照常更新，返回的错误包含所有失败的集合。
+func (c *Catalog) Refresh(cli service.Client) error {
+	var errs []error
+	stations, err := cli.QueryStations()
+	errs = appendCatalogErr(errs, "stations", err)
+	trains, err := cli.Query()
+	errs = appendCatalogErr(errs, "trains", err)
+	routes, err := cli.QueryAllRoutes()
+	errs = appendCatalogErr(errs, "routes", err)
+	trips, err := cli.QueryAllTrip()
+	errs = appendCatalogErr(errs, "trips", err)
+	prices, err := cli.FindAllPriceConfig()
+	errs = appendCatalogErr(errs, "prices", err)
+	assuranceTypes, err := cli.GetAllAssuranceTypes()
+	errs = appendCatalogErr(errs, "assurance types", err)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if stations != nil && stations.Status == 1 {
+		c.stations = stations
+	}
+	if trains != nil && trains.Status == 1 {
+		c.trains = trains
+	}
+	if routes != nil && routes.Status == 1 {
+		c.routes = routes
+	}
+	if trips != nil && trips.Status == 1 {
+		c.trips = trips
+	}
+	c.network = nil
+	if prices != nil && prices.Status == 1 {
+		c.prices = prices
+	}
+	if assuranceTypes != nil && assuranceTypes.Status == 1 {
+		c.assuranceTypes = assuranceTypes
+	}
+	if len(errs) == 0 {
+		c.refreshed = time.Now()
+	}
+	return errors.Join(errs...)
+}
+
+func appendCatalogErr(errs []error, collection string, err error) []error {
+	if err != nil {
+		errs = append(errs, fmt.Errorf("refresh %s: %w", collection, err))
+	}
+	return errs
+}
+
+// Refreshed 返回最近一次完整刷新成功的时间，从未成功时为零值。
+func (c *Catalog) Refreshed() time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.refreshed
+}
+
+// Stations 返回最近一次成功的 QueryStations 响应，尚未拉取成功时 Data 为空。
+func (c *Catalog) Stations() *service.GetStationResponse {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.stations == nil {
+		return &service.GetStationResponse{}
+	}
+	return c.stations
+}
+
+// Trains 返回最近一次成功的车型查询响应，尚未拉取成功时 Data 为空。
+func (c *Catalog) Trains() *service.TrainResponseType {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.trains == nil {
+		return &service.TrainResponseType{}
+	}
+	return c.trains
+}
+
+// Routes 返回最近一次成功的 QueryAllRoutes 响应，尚未拉取成功时 Data 为空。
+func (c *Catalog) Routes() *service.QueryMultiResponse {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.routes == nil {
+		return &service.QueryMultiResponse{}
+	}
+	return c.routes
+}
+
+// Trips 返回最近一次成功的 QueryAllTrip 响应，尚未拉取成功时 Data 为空。
+func (c *Catalog) Trips() *service.QueryAllTravelInfo {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.trips == nil {
+		return &service.QueryAllTravelInfo{}
+	}
+	return c.trips
+}
+
+// Prices 返回最近一次成功的 FindAllPriceConfig 响应，尚未拉取成功时 Data 为空。
+func (c *Catalog) Prices() *service.AllPriceResponse {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.prices == nil {
+		return &service.AllPriceResponse{}
+	}
+	return c.prices
+}
+
+// AssuranceTypes 返回最近一次成功的 GetAllAssuranceTypes 响应，尚未拉取成功时 Data 为空。
+func (c *Catalog) AssuranceTypes() *service.GetallAssuranceType {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.assuranceTypes == nil {
+		return &service.GetallAssuranceType{}
+	}
+	return c.assuranceTypes
+}
+
+// Network 返回由当前路线和车次构建的车站图，刷新后第一次调用时重建。
+func (c *Catalog) Network() *Network {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.network == nil {
+		routes, trips := c.routes, c.trips
+		if routes == nil {
+			routes = &service.QueryMultiResponse{}
+		}
+		if trips == nil {
+			trips = &service.QueryAllTravelInfo{}
+		}
+		c.network = NewNetwork(routes, trips, c.networkOptions...)
+	}
+	return c.network
+}
+
+// catalogFrom 返回上下文中共享的 Catalog。没有共享 Catalog 时（例如单独执行节点）
+// 用当前客户端拉取一份只供本次迭代使用的 Catalog，拉取失败的集合为空。
+func catalogFrom(ctx *Context) (*Catalog, error) {
+	if catalog, ok := ctx.Get(CatalogKey).(*Catalog); ok {
+		return catalog, nil
+	}
+	cli, ok := ctx.Get(Client).(service.Client)
+	if !ok {
+		return nil, fmt.Errorf("service client not found in context")
+	}
+	catalog := &Catalog{}
+	if err := catalog.Refresh(cli); err != nil {
+		log.Printf("Refresh catalog failed: %v", err)
+	}
+	ctx.Set(CatalogKey, catalog)
+	return catalog, nil
+}
+
+// refresh 以 catalog 行为链登录并刷新，请求、span 和节点指标都带 chain=catalog 标签，
+// 便于与迭代流量区分。失败时保留旧数据并记录日志。
+func (c *Catalog) refresh() {
+	chain := NewChain(
+		NewFuncNode(LoginBasic, "LoginBasic"),
+		NewFuncNode(func(ctx *Context) (*NodeResult, error) {
+			return nil, c.Refresh(ctx.Get(Client).(service.Client))
+		}, "RefreshCatalog"),
+	)
+	chain.Name = "catalog"
+
+	ctx := NewContext(context.Background())
+	ctx.Set(Client, service.NewSvcClients(c.clientOptions...))
+	end := ctx.startSpan("catalog", trace.WithAttributes(attribute.String("chain", chain.Name)))
+	_, err := chain.Execute(ctx)
+	end(err)
+	if err != nil {
+		log.Printf("Refresh catalog failed: %v", err)
+	}
+}
@@ -19,6 +19,10 @@ const (
 
 var LoginChain *Chain
 
+// VerifyCodeSolver reads the captcha fetched during login. main sets it with service.VerifyCodeSolverFromEnv;
+// while it is nil, logins fail with service.ErrNoVerifyCodeSolver.
+var VerifyCodeSolver service.VerifyCodeSolver
+
 func init() {
 	LoginChain = NewChain(NewFuncNode(func(context *Context) (*NodeResult, error) {
 		return nil, nil
@@ -27,16 +31,12 @@ func init() {
 	LoginChain.AddNextChain(NewChain(NewFuncNode(CreateUser, "CreateUser"), NewFuncNode(LoginNormal, "LoginNormal")), 0.8)
 }
 func LoginAdmin(ctx *Context) (*NodeResult, error) {
-	cli, ok := ctx.Get(Client).(*service.SvcImpl)
+	cli, ok := ctx.Get(Client).(service.Client)
 	if !ok {
 		return nil, fmt.Errorf("service client not found in context")
 	}
 	// login
-	loginResult, err := cli.ReqUserLogin(&service.UserLoginInfoReq{
-		Password:         "222222",
-		UserName:         "admin",
-		VerificationCode: "123",
-	})
+	loginResult, err := cli.LoginWithVerifyCode("admin", "222222", VerifyCodeSolver)
 	if err != nil {
 		return nil, err
 	}
@@ -45,16 +45,12 @@ func LoginAdmin(ctx *Context) (*NodeResult, error) {
 }
 
 func LoginBasic(ctx *Context) (*NodeResult, error) {
-	cli, ok := ctx.Get(Client).(*service.SvcImpl)
+	cli, ok := ctx.Get(Client).(service.Client)
 	if !ok {
 		return nil, fmt.Errorf("service client not found in context")
 	}
 	// login
-	loginResult, err := cli.ReqUserLogin(&service.UserLoginInfoReq{
-		Password:         "111111",
-		UserName:         "fdse_microservice",
-		VerificationCode: "123",
-	})
+	loginResult, err := cli.LoginWithVerifyCode("fdse_microservice", "111111", VerifyCodeSolver)
 	if err != nil {
 		return nil, err
 	}
@@ -63,20 +59,16 @@ func LoginBasic(ctx *Context) (*NodeResult, error) {
 }
 
 func LoginNormal(ctx *Context) (*NodeResult, error) {
-	cli, ok := ctx.Get(Client).(*service.SvcImpl)
+	cli, ok := ctx.Get(Client).(service.Client)
 	if !ok {
 		return nil, fmt.Errorf("service client not found in context")
 	}
-	_, err := cli.ReqUserLogin(&service.UserLoginInfoReq{
-		Password:         ctx.Get(Password).(string),
-		UserName:         ctx.Get(UserName).(string),
-		VerificationCode: "123",
-	})
+	_, err := cli.LoginWithVerifyCode(ctx.Get(UserName).(string), ctx.Get(Password).(string), VerifyCodeSolver)
 	return nil, err
 }
 
 func CreateUser(ctx *Context) (*NodeResult, error) {
-	cli, ok := ctx.Get(Client).(*service.SvcImpl)
+	cli, ok := ctx.Get(Client).(service.Client)
 	if !ok {
 		return nil, fmt.Errorf("service client not found in context")
 	}
@@ -0,0 +1,305 @@
+package behaviors
+
+import (
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Lincyaw/loadgenerator/service"
+)
+
+// DefaultScheduleDays 是 Network 采样出发日期的默认天数范围。
+const DefaultScheduleDays = 30
+
+// Leg 是某个车次从 From 到 To 的一段行程，From 在该车次路线上位于 To 之前。
+type Leg struct {
+	From      string
+	To        string
+	TripId    string
+	TrainType string
+	// StartTime 是车次的发车时间，TrainTicket 只使用其中的时刻，日期是车次开始运行的日期
+	StartTime string
+}
+
+// Journey 是一次有车可乘的出行。直达时 Via 为空、Legs 只有一段；换乘时 Legs 依次为 From→Via 和 Via→To。
+type Journey struct {
+	From string
+	Via  string
+	To   string
+	Legs []Leg
+	// Date 是出发日期（2006-01-02），落在所有 Legs 车次的运行日期内
+	Date string
+}
+
+// HighSpeed 报告 Journey 是否只乘坐 G/D 车次。
+func (j Journey) HighSpeed() bool {
+	for _, leg := range j.Legs {
+		if !isHighSpeedTrip(leg.TripId) {
+			return false
+		}
+	}
+	return true
+}
+
+// NetworkOption 配置 Network。
+type NetworkOption func(*Network)
+
+// WithPopularity 设置车站热度，站对的采样权重是起点与终点热度之积。未列出的车站热度为 1，
+// 热度为 0 的车站不会被采样为起点或终点。
+func WithPopularity(popularity map[string]float64) NetworkOption {
+	return func(n *Network) {
+		n.popularity = popularity
+	}
+}
+
+// WithScheduleDays 设置出发日期的范围：从今天（或车次开始运行的日期，取较晚者）起的 days 天内。
+func WithScheduleDays(days int) NetworkOption {
+	return func(n *Network) {
+		n.scheduleDays = days
+	}
+}
+
+// Network 是由路线和车次构成的车站图，用来采样确实有车次的起止站，
+// 替代随机城市名产生的大多查不到结果的查询。
+type Network struct {
+	popularity   map[string]float64
+	scheduleDays int
+	now          func() time.Time
+
+	direct odPairs
+	// 换乘不预先枚举所有起点、换乘站和终点的组合：firstLegs 是换乘的第一段，终点在采样时从换乘站的
+	// outgoing 中选取。legs 是每对车站之间可乘坐的车次。
+	legs          map[[2]string][]Leg
+	outgoing      map[string]*destinations
+	firstLegs     odPairs
+	transferPairs int
+}
+
+// odPair 是一组起止站（换乘时含换乘站），sections 是每一段可乘坐的车次。
+type odPair struct {
+	from, via, to string
+	sections      [][]Leg
+}
+
+// odPairs 按热度加权采样，cumulative[i] 是前 i+1 个站对的权重之和。
+type odPairs struct {
+	pairs      []odPair
+	cumulative []float64
+}
+
+// destinations 是从某个车站直达、热度大于 0 的终点，cumulative[i] 是前 i+1 个终点的热度之和。
+type destinations struct {
+	stations   []string
+	cumulative []float64
+	index      map[string]int
+}
+
+func (d *destinations) add(station string, weight float64) {
+	total := weight
+	if len(d.cumulative) > 0 {
+		total += d.cumulative[len(d.cumulative)-1]
+	}
+	d.index[station] = len(d.stations)
+	d.stations = append(d.stations, station)
+	d.cumulative = append(d.cumulative, total)
+}
+
+func (d *destinations) total() float64 {
+	return d.cumulative[len(d.cumulative)-1]
+}
+
+func (d *destinations) weight(i int) float64 {
+	if i == 0 {
+		return d.cumulative[0]
+	}
+	return d.cumulative[i] - d.cumulative[i-1]
+}
+
+// without 返回除 except 以外的终点数量和热度之和。
+func (d *destinations) without(except string) (int, float64) {
+	if i, ok := d.index[except]; ok {
+		return len(d.stations) - 1, d.total() - d.weight(i)
+	}
+	return len(d.stations), d.total()
+}
+
+// sampleExcept 按热度采样一个不是 except 的终点，调用方需保证这样的终点存在。
+func (d *destinations) sampleExcept(except string) string {
+	_, total := d.without(except)
+	r := rand.Float64() * total
+	skip, ok := d.index[except]
+	if ok && r >= d.cumulative[skip]-d.weight(skip) {
+		// 跳过 except 所占的区间
+		r += d.weight(skip)
+	}
+	i := sort.SearchFloat64s(d.cumulative, r)
+	if i == len(d.stations) {
+		i--
+	}
+	if ok && i == skip {
+		// 浮点误差落在 except 的边界上时取相邻的终点
+		if i+1 < len(d.stations) {
+			i++
+		} else {
+			i--
+		}
+	}
+	return d.stations[i]
+}
+
+// NewNetwork 由路线和车次构建车站图。车次停靠其路线上的每一站，路线未知时使用车次的 StationsName。
+func NewNetwork(routes *service.QueryMultiResponse, trips *service.QueryAllTravelInfo, opts ...NetworkOption) *Network {
+	n := &Network{scheduleDays: DefaultScheduleDays, now: time.Now}
+	for _, opt := range opts {
+		opt(n)
+	}
+
+	stationsOf := make(map[string][]string, len(routes.Data))
+	for _, route := range routes.Data {
+		stationsOf[route.Id] = route.Stations
+	}
+	legs := map[[2]string][]Leg{}
+	for _, trip := range trips.Data {
+		stations, ok := stationsOf[trip.RouteId]
+		if !ok {
+			stations = strings.Split(trip.StationsName, ",")
+		}
+		for i := range stations {
+			for j := i + 1; j < len(stations); j++ {
+				from, to := strings.TrimSpace(stations[i]), strings.TrimSpace(stations[j])
+				if from == "" || to == "" || from == to {
+					continue
+				}
+				legs[[2]string{from, to}] = append(legs[[2]string{from, to}], Leg{
+					From:      from,
+					To:        to,
+					TripId:    trip.TripId.Type + trip.TripId.Number,
+					TrainType: trip.TrainTypeName,
+					StartTime: trip.StartTime,
+				})
+			}
+		}
+	}
+
+	// 按站名排序，保证同一份数据在相同随机源下采样结果一致
+	keys := make([][2]string, 0, len(legs))
+	for key := range legs {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i][0] < keys[j][0] || keys[i][0] == keys[j][0] && keys[i][1] < keys[j][1]
+	})
+	n.legs = legs
+	n.outgoing = map[string]*destinations{}
+	for _, key := range keys {
+		n.direct.add(odPair{from: key[0], to: key[1], sections: [][]Leg{legs[key]}}, n.weight(key[0], key[1]))
+		if p := n.popularityOf(key[1]); p > 0 {
+			if n.outgoing[key[0]] == nil {
+				n.outgoing[key[0]] = &destinations{index: map[string]int{}}
+			}
+			n.outgoing[key[0]].add(key[1], p)
+		}
+	}
+	// 第一段的权重是起点热度与换乘站可达终点（不含起点）热度之和的积，再按终点热度采样终点，
+	// 因此每个组合被采样的概率与起点和终点热度之积成正比，与枚举所有组合时相同。
+	for _, key := range keys {
+		next, ok := n.outgoing[key[1]]
+		if !ok {
+			continue
+		}
+		count, total := next.without(key[0])
+		weight := n.popularityOf(key[0]) * total
+		if count == 0 || weight <= 0 {
+			continue
+		}
+		n.firstLegs.add(odPair{from: key[0], via: key[1], sections: [][]Leg{legs[key]}}, weight)
+		n.transferPairs += count
+	}
+	return n
+}
+
+// popularityOf 返回车站的热度，未设置时为 1。
+func (n *Network) popularityOf(station string) float64 {
+	if p, ok := n.popularity[station]; ok {
+		return p
+	}
+	return 1
+}
+
+func (n *Network) weight(from, to string) float64 {
+	return n.popularityOf(from) * n.popularityOf(to)
+}
+
+func (p *odPairs) add(pair odPair, weight float64) {
+	if weight <= 0 {
+		return
+	}
+	total := weight
+	if len(p.cumulative) > 0 {
+		total += p.cumulative[len(p.cumulative)-1]
+	}
+	p.pairs = append(p.pairs, pair)
+	p.cumulative = append(p.cumulative, total)
+}
+
+func (p *odPairs) sample() (odPair, bool) {
+	if len(p.pairs) == 0 {
+		return odPair{}, false
+	}
+	r := rand.Float64() * p.cumulative[len(p.cumulative)-1]
+	i := sort.SearchFloat64s(p.cumulative, r)
+	if i == len(p.pairs) {
+		i--
+	}
+	return p.pairs[i], true
+}
+
+// Direct 按热度采样一对有直达车次的车站，返回其中一个车次及出发日期。没有可达站对时返回 false。
+func (n *Network) Direct() (Journey, bool) {
+	pair, ok := n.direct.sample()
+	if !ok {
+		return Journey{}, false
+	}
+	return n.journey(pair), true
+}
+
+// Transfer 按热度采样一组经一次换乘可达的起点、换乘站和终点，返回每段的一个车次及出发日期。
+// 起点与终点之间也可能有直达车次。没有可达组合时返回 false。
+func (n *Network) Transfer() (Journey, bool) {
+	first, ok := n.firstLegs.sample()
+	if !ok {
+		return Journey{}, false
+	}
+	to := n.outgoing[first.via].sampleExcept(first.from)
+	second := n.legs[[2]string{first.via, to}]
+	return n.journey(odPair{from: first.from, via: first.via, to: to, sections: [][]Leg{first.sections[0], second}}), true
+}
+
+// DirectPairs 返回有直达车次的站对数量。
+func (n *Network) DirectPairs() int {
+	return len(n.direct.pairs)
+}
+
+// TransferPairs 返回经一次换乘可达的起点、换乘站和终点组合的数量。
+func (n *Network) TransferPairs() int {
+	return n.transferPairs
+}
+
+func (n *Network) journey(pair odPair) Journey {
+	journey := Journey{From: pair.from, Via: pair.via, To: pair.to}
+	y, m, d := n.now().Date()
+	first := time.Date(y, m, d, 0, 0, 0, 0, time.Local)
+	for _, section := range pair.sections {
+		leg := section[rand.Intn(len(section))]
+		journey.Legs = append(journey.Legs, leg)
+		if start, err := time.ParseInLocation(time.DateTime, leg.StartTime, time.Local); err == nil {
+			start = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.Local)
+			if start.After(first) {
+				first = start
+			}
+		}
+	}
+	journey.Date = first.AddDate(0, 0, rand.Intn(max(n.scheduleDays, 1))).Format(time.DateOnly)
+	return journey
+}
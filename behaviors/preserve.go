@@ -1,13 +1,13 @@
 package behaviors
 
 import (
+	"errors"
 	"fmt"
 	"github.com/Lincyaw/loadgenerator/service"
 	"github.com/go-faker/faker/v4"
 	"log"
 	"math/rand"
 	"strings"
-	"time"
 )
 
 const (
@@ -81,281 +81,16 @@ const (
 var PreserveBehaviorChain *Chain
 
 func init() {
-	// ------------------------------------- init -------------------------------------------
-	// ------------------------------------- init -------------------------------------------
-	// ------------------------------------- init -------------------------------------------
-	// Main Chain
+	// PreserveBehaviorChain books the journey of a preceding travel search (From, To, Date and TripID) for a
+	// contact of any account, which needs the admin login, and then rebooks a paid order of that account.
 	PreserveBehaviorChain = NewChain(NewFuncNode(func(context *Context) (*NodeResult, error) {
-		fmt.Printf("PreserveBehaviors(Chain) Statrs. Starts time: %v", time.Now().String())
 		return nil, nil
 	}, "Dummy"))
-	// AssuranceBehaviorChain -
-	AssuranceBehaviorChain := NewChain(NewFuncNode(func(context *Context) (*NodeResult, error) {
-		fmt.Printf("CreateAssuranceChain. Starts time: %v", time.Now().String())
-		return nil, nil
-	}, "DummyAssurance"))
-	// UserBehaviorsChain
-	UserBehaviorsChain := NewChain(NewFuncNode(func(context *Context) (*NodeResult, error) {
-		fmt.Printf("UserBehaviorsChain Starts. Starts time: %v", time.Now().String())
-		return nil, nil
-	}, "DummyUserBehaviors"))
-	// VerifyCodeBehaviorChain
-	VerifyCodeBehaviorChain := NewChain(NewFuncNode(func(context *Context) (*NodeResult, error) {
-		fmt.Printf("VerifyCodeBehaviorChain Starts. Start time: %v", time.Now().String())
-		return nil, nil
-	}, "DummyVerifyCodeBehavior"))
-	// AuthBehaviorChain
-	AuthBehaviorChain := NewChain(NewFuncNode(func(context *Context) (*NodeResult, error) {
-		fmt.Printf("AuthBehaviorChain Starts. Start time: %v", time.Now().String())
-		return nil, nil
-	}, "DummyAuthBehavior"))
-	// UserBehaviorChain
-	UserBehaviorChain := NewChain(NewFuncNode(func(context *Context) (*NodeResult, error) {
-		fmt.Printf("UserBehaviorChain Starts. Starts time: %v", time.Now().String())
-		return nil, nil
-	}, "DummyUserBehavior"))
-	// ContactsBehaviorChain
-	ContactsBehaviorChain := NewChain(NewFuncNode(func(context *Context) (*NodeResult, error) {
-		fmt.Printf("ContactsBehaviorChain Starts. Starts time: %v", time.Now().String())
-		return nil, nil
-	}, "DummyContactsBehavior"))
-	// ConsignBehaviorsChain
-	ConsignBehaviorsChain := NewChain(NewFuncNode(func(context *Context) (*NodeResult, error) {
-		fmt.Printf("ConsignBehaviorsChain Starts. Starts time: %v", time.Now().String())
-		return nil, nil
-	}, "DummyConsignBehaviors"))
-	//ConsignPriceBehaviorChain
-	ConsignPriceBehaviorChain := NewChain(NewFuncNode(func(context *Context) (*NodeResult, error) {
-		fmt.Printf("ConsignPriceBehaviorChain Starts. Starts time: %v", time.Now().String())
-		return nil, nil
-	}, "DummyConsignPriceBehavior"))
-	// FoodBehaviorChain
-	FoodBehaviorChain := NewChain(NewFuncNode(func(context *Context) (*NodeResult, error) {
-		fmt.Printf("FoodBehaviorChain Starts. Starts time: %v", time.Now().String())
-		return nil, nil
-	}, "DummyFoodBehavior"))
-	// TravelBehaviorChain
-	TravelBehaviorChain := NewChain(NewFuncNode(func(context *Context) (*NodeResult, error) {
-		fmt.Printf("TravelBehaviorChain Starts. Starts time: %v", time.Now().String())
-		return nil, nil
-	}, "DummyTravelBehavior"))
-	// StationFoodBehaviorChain
-	StationFoodBehaviorChain := NewChain(NewFuncNode(func(context *Context) (*NodeResult, error) {
-		fmt.Printf("StationFoodBehaviorChain Starts. Starts time: %v", time.Now().String())
-		return nil, nil
-	}, "DummyStationFoodBehavior"))
-	//TrainFoodBehaviorChain
-	TrainFoodBehaviorChain := NewChain(NewFuncNode(func(context *Context) (*NodeResult, error) {
-		fmt.Printf("TrainFoodBehaviorChain Starts. Starts time: %v", time.Now().String())
-		return nil, nil
-	}, "DummyTrainFoodBehavior"))
-	// TrainBehaviorChain
-	TrainBehaviorChain := NewChain(NewFuncNode(func(context *Context) (*NodeResult, error) {
-		fmt.Printf("TrainBehaviorChain Starts. Starts time: %v", time.Now().String())
-		return nil, nil
-	}, "DummyTrainBehavior"))
-	//RouteBehaviorChain
-	RouteBehaviorChain := NewChain(NewFuncNode(func(context *Context) (*NodeResult, error) {
-		fmt.Printf("RouteBehaviorChain starts. Starts time: %v", time.Now().String())
-		return nil, nil
-	}, "DummyRouteBehavior"))
-	// BasicBehaviorChain
-	BasicBehaviorChain := NewChain(NewFuncNode(func(context *Context) (*NodeResult, error) {
-		fmt.Printf("BasicBehaviorChain Starts. Start time: %v", time.Now().String())
-		return nil, nil
-	}, "DummyBasicBehavior"))
-	// SeatBehaviorChain
-	SeatBehaviorChain := NewChain(NewFuncNode(func(context *Context) (*NodeResult, error) {
-		fmt.Printf("SeatBehaviorChain Starts. Start time: %v", time.Now().String())
-		return nil, nil
-	}, "DummySeatBehavior"))
-	// StationBehaviorChain
-	StationBehaviorChain := NewChain(NewFuncNode(func(context *Context) (*NodeResult, error) {
-		fmt.Printf("StationBehaviorChain Starts. Start time: %v", time.Now().String())
-		return nil, nil
-	}, "DummyStationBehavior"))
-	//PriceBehaviorChain
-	PriceBehaviorChain := NewChain(NewFuncNode(func(context *Context) (*NodeResult, error) {
-		fmt.Printf("PriceBehaviorChain Starts. Start time: %v", time.Now().String())
-		return nil, nil
-	}, "DummyPriceBehavior"))
-	//ConfigBehaviorChain
-	ConfigBehaviorChain := NewChain(NewFuncNode(func(context *Context) (*NodeResult, error) {
-		fmt.Printf("ConfigBehaviorChain Starts. Start time: %v", time.Now().String())
-		return nil, nil
-	}, "DummyConfigBehavior"))
-	//OrderBehaviorChain
-	OrderBehaviorChain := NewChain(NewFuncNode(func(context *Context) (*NodeResult, error) {
-		fmt.Printf("OrderBehaviorChain Starts. Start time: %v", time.Now().String())
-		return nil, nil
-	}, "DummyOrderBehavior"))
-	//OrderOtherBehaviorChain
-	OrderOtherBehaviorChain := NewChain(NewFuncNode(func(context *Context) (*NodeResult, error) {
-		fmt.Printf("OrderOtherBehaviorChain Starts. Strat time: %v", time.Now().String())
-		return nil, nil
-	}, "DummyOrderOtherBehavior"))
-	// StationBehaviorChain
-	StationBehaviorChain := NewChain(NewFuncNode(func(context *Context) (*NodeResult, error) {
-		fmt.Printf("StationBehaviorChain Starts. Start time: %v", time.Now().String())
-		return nil, nil
-	}, "DummyStationBehavior"))
-	// SecurityBehaviorChain
-	SecurityBehaviorChain := NewChain(NewFuncNode(func(context *Context) (*NodeResult, error) {
-		fmt.Printf("SecurityBehaviorChain Satrts. Start time: %v", time.Now().String())
-		return nil, nil
-	}, "DummySecurityBehavior"))
-
-	// ------------------------------------- NewFuncNode -------------------------------------------
-	// ------------------------------------- NewFuncNode -------------------------------------------
-	// ------------------------------------- NewFuncNode -------------------------------------------
-	//AssuranceBehaviorChain - Assurance
-	QueryAssuranceNode := NewFuncNode(QueryAssurance, "QueryAssurance")
-	// CreateAssuranceNode := NewFuncNode(CreateAssurance, "CreateAssurance")
-
-	//UserBehaviorsChain
-	// AuthBehaviorChain - LoginAdmin/LoginBasic
-	//LoginAdminNode := NewFuncNode(LoginAdmin, "LoginAdmin")
-	LoginBasicNode := NewFuncNode(LoginBasic, "LoginBasic")
-	//	VerifyCodeBehaviorChain
-	VerifyCodeNode := NewFuncNode(VerifyCode, "VerifyCode")
-	// UserBehaviorChain
-	QueryUserNode := NewFuncNode(QueryUser, "QueryUser")
-
-	//ContactsBehaviorChain - Contacts
-	QueryContactsNode := NewFuncNode(QueryContacts, "QueryContacts")
-	CreateContactsNode := NewFuncNode(CreateContacts, "CreateContacts")
-
-	//ConsignBehaviorsChain
-	QueryConsignNode := NewFuncNode(QueryConsign, "QueryConsign")
-	CreateConsignNode := NewFuncNode(CreateConsign, "CreateConsign")
-	// ConsignPriceBehaviorChain
-	QueryConsignPriceNode := NewFuncNode(QueryConsignPric, "QueryConsignPrice")
-	CreateConsignPriceNode := NewFuncNode(CreateConsignPrice, "CreateConsignPrice")
-
-	//FoodBehaviorChain
-	QueryFoodNode := NewFuncNode(QueryFood, "QueryFood")
-	// StationFoodBehaviorChain
-	QueryStationFoodNode := NewFuncNode(QueryStationFood, "QueryStationFood")
-	// TrainFoodBehaviorChain
-	QueryTrainFoodNode := NewFuncNode(QueryTrainFood, "QueryTrainFood")
-	// TravelBehaviorChain
-	QueryTravelNode := NewFuncNode(QueryTrip, "QueryTrip")
-	CreateTravelNode := NewFuncNode(CreateTrip, "CreateTrip")
-
-	//TravelBehaviorChain
-	// TrainBehaviorChain
-	QueryTrainNode := NewFuncNode(QueryTrain, "QueryTrain")
-	// RouteBehaviorChain
-	QueryRouteNode := NewFuncNode(QueryRoute, "QueryRoute")
-	// BasicBehaviorChain
-	QueryBasicNode := NewFuncNode(QueryBasic, "QueryBasic")
-	// SeatBehaviorChain
-	QuerySeatNode := NewFuncNode(QuerySeat, "QuerySeat")
-
-	//BasicBehaviorChain
-	// StationBehaviorChain
-	QueryStationNode := NewFuncNode(QueryStation, "QueryStation")
-	// PriceBehaviorChain
-	QueryPriceNode := NewFuncNode(QueryPrice, "QueryPrice")
-	// RouteBehaviorChain
-	// TrainBehaviorChain
-
-	//SeatBehaviorChain
-	// ConfigBehaviorChain
-	QueryConfigNode := NewFuncNode(QueryConfig, "QueryConfig")
-	// OrderBehaviorChain
-	QueryOrderNode := NewFuncNode(QueryOrder, "QueryOrder")
-	// OrderOtherBehaviorChain
-	QueryOrderOtherNode := NewFuncNode(QueryOrderOther, "QueryOrderOther")
-
-	//OrderBehaviorChain
-	// StationBehaviorChain
-
-	//OrderOtherBehaviorChain
-	// StationBehaviorChain
-
-	//SecurityBehaviorChain
-	// OrderBehaviorChain
-	// OrderOtherBehaviorChain
-
-	// ******* Preserve ********
-	PreserveNode := NewFuncNode(Preserve, "Preserve")
-
-	// ------------------------------------- NewChain -------------------------------------------
-	// ------------------------------------- NewChain -------------------------------------------
-	// ------------------------------------- NewChain -------------------------------------------
-	// AssuranceBehaviorChain - Assurance
-	QueryAssuranceChain := NewChain(QueryAssuranceNode)
-	//CreateAssuranceChain := NewChain(CreateAssuranceNode)
-
-	// UserBehaviorsChain
-	// AuthBehaviorChain - LoginAdmin/LoginBasic
-	LoginBasicChain := NewChain(LoginBasicNode)
-	// VerifyCodeBehaviorChain
-	VerifyCodeChain := NewChain(VerifyCodeNode)
-	// UserBehaviorChain
-	QueryUserChain := NewChain(QueryUserNode)
-
-	// ContactsBehaviorChain - Contacts
-	QueryContactsChain := NewChain(QueryContactsNode)
-	CreateContactsChain := NewChain(CreateContactsNode)
-
-	// ConsignBehaviorsChain
-	QueryConsignChain := NewChain(QueryConsignNode)
-	CreateConsignChain := NewChain(CreateConsignNode)
-	// ConsignPriceBehaviorChain
-	QueryConsignPriceChain := NewChain(QueryConsignPriceNode)
-	CreateConsignPriceChain := NewChain(CreateConsignPriceNode)
-
-	// FoodBehaviorChain
-	QueryFoodChain := NewChain(QueryFoodNode)
-	// StationFoodBehaviorChain
-	QueryStationFoodChain := NewChain(QueryStationFoodNode)
-	// TrainFoodBehaviorChain
-	QueryTrainFoodChain := NewChain(QueryTrainFoodNode)
-	// TravelBehaviorChain
-	QueryTravelChain := NewChain(QueryTravelNode)
-	CreateTravelChain := NewChain(CreateTravelNode)
-
-	// TrainBehaviorChain
-	QueryTrainChain := NewChain(QueryTrainNode)
-	// RouteBehaviorChain
-	QueryRouteChain := NewChain(QueryRouteNode)
-	// BasicBehaviorChain
-	QueryBasicChain := NewChain(QueryBasicNode)
-	// SeatBehaviorChain
-	QuerySeatChain := NewChain(QuerySeatNode)
-
-	// StationBehaviorChain
-	QueryStationChain := NewChain(QueryStationNode)
-	// PriceBehaviorChain
-	QueryPriceChain := NewChain(QueryPriceNode)
-
-	// ConfigBehaviorChain
-	QueryConfigChain := NewChain(QueryConfigNode)
-	// OrderBehaviorChain
-	QueryOrderChain := NewChain(QueryOrderNode)
-	// OrderOtherBehaviorChain
-	QueryOrderOtherChain := NewChain(QueryOrderOtherNode)
-
-	// The Last Chain - Preserve Behavior Chain
-	PreserveChain := NewChain(PreserveNode)
-
-	// ------------------------------------- AddNextChain -------------------------------------------
-	// ------------------------------------- AddNextChain -------------------------------------------
-	// ------------------------------------- AddNextChain -------------------------------------------
-	// 逆序 - 从处理逆序的第一层开始
-
-	// XXX
-	//...
-
-	// &&&&&&&&&&&&&&&&&&&&&&&&&&&&&&&&&&&&&& Main Chain &&&&&&&&&&&&&&&&&&&&&&&&&&&&&&&&&&&&&&&&&&&&
-	//PreserveChain
-	//PreserveChain.AddNextChain(, 1)
-
-	// ------------------------------------- VisualizeChain -------------------------------------------
-	fmt.Println(PreserveChain.VisualizeChain(0))
-	fmt.Println()
+	PreserveBehaviorChain.AddNode(NewFuncNode(QueryContacts, "QueryContacts"))
+	PreserveBehaviorChain.AddNode(NewFuncNode(QueryAssurance, "QueryAssurance"))
+	PreserveBehaviorChain.AddNode(NewFuncNode(Preserve, "Preserve"))
+	RebookChain := NewChain(NewFuncNode(Rebook, "Rebook"))
+	PreserveBehaviorChain.AddNextChain(RebookChain, 1)
 }
 
 // ************************************* NewFuncNode_Function *******************************************
@@ -364,54 +99,62 @@ func init() {
 
 // AssuranceBehaviorChain
 func QueryAssurance(ctx *Context) (*NodeResult, error) {
-	cli, ok := ctx.Get(Client).(*service.SvcImpl)
+	catalog, err := catalogFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	AssuranceTypes := catalog.AssuranceTypes()
+	if len(AssuranceTypes.Data) == 0 {
+		return nil, fmt.Errorf("no assurance types in catalog")
+	}
+
+	randomIndex := rand.Intn(len(AssuranceTypes.Data))
+	ctx.Set(Assurance, AssuranceTypes.Data[randomIndex].Index)
+	ctx.Set(TypeIndex, AssuranceTypes.Data[randomIndex].Index)
+	ctx.Set(TypeName, AssuranceTypes.Data[randomIndex].Name)
+	ctx.Set(TypePrice, AssuranceTypes.Data[randomIndex].Price)
+
+	// Assurances belong to orders, so they are queried per iteration rather than kept in the catalog
+	cli, ok := ctx.Get(Client).(service.Client)
 	if !ok {
 		return nil, fmt.Errorf("service client not found in context")
 	}
-
 	Assurances, err := cli.GetAllAssurances()
 	if err != nil {
-		log.Fatalf("GetAllAssurances failed: %v", err)
 		return nil, err
 	}
-	if Assurances.Status != 1 {
-		log.Fatalf("Assurances status is not 1: %d", Assurances.Status)
-		return nil, nil
+	if len(Assurances.Data) > 0 {
+		ctx.Set(OrderId, Assurances.Data[rand.Intn(len(Assurances.Data))].OrderId)
 	}
 
-	randomIndex := rand.Intn(len(Assurances.Data))
-	ctx.Set(OrderId, Assurances.Data[randomIndex].OrderId)
-	ctx.Set(TypeIndex, Assurances.Data[randomIndex].TypeIndex)
-	ctx.Set(TypeName, Assurances.Data[randomIndex].TypeName)
-	ctx.Set(TypePrice, Assurances.Data[randomIndex].TypePrice)
-
 	return nil, nil
 }
 
 func CreateAssurance(ctx *Context) (*NodeResult, error) {
-	cli, ok := ctx.Get(Client).(*service.SvcImpl)
+	cli, ok := ctx.Get(Client).(service.Client)
 	if !ok {
 		return nil, fmt.Errorf("service client not found in context")
 	}
 
 	//Create a new assurance
-	TheOrderID := ctx.Get(OrderId).(string)
+	TheOrderID, ok := ctx.Get(OrderId).(string)
+	if !ok {
+		return nil, fmt.Errorf("no order to create an assurance for")
+	}
 	addAssuranceResp, err := cli.CreateNewAssurance(1, TheOrderID) // typeIndex 1 -> TRAFFIC_ACCIDENT
-	if err != nil {
-		log.Fatalf("CreateNewAssurance failed: %v", err)
-		return nil, err
+	if service.IsErrorMsg(err, "Already exists") {
+		log.Printf("Order ID found, skip")
+		return nil, nil
 	}
-	if addAssuranceResp.Msg == "Already exists" {
-		log.Fatalf("Order ID found, skip")
+	if err != nil {
 		return nil, err
 	}
 	if addAssuranceResp.Data.OrderId != TheOrderID {
-		log.Fatalf("Request failed, addAssuranceResp.Data.OrderId:%s, expected: %s", addAssuranceResp.Data.OrderId, TheOrderID)
-		return nil, err
+		return nil, fmt.Errorf("assurance created for order %s, expected %s", addAssuranceResp.Data.OrderId, TheOrderID)
 	}
 	if addAssuranceResp.Data.Type != "TRAFFIC_ACCIDENT" {
-		log.Fatalf("Request failed, addAssuranceResp.Data.Type are expected to be 'TRAFFIC_ACCIDENT' but actually: %v", addAssuranceResp.Data.Type)
-		return nil, err
+		return nil, fmt.Errorf("assurance type %v, expected TRAFFIC_ACCIDENT", addAssuranceResp.Data.Type)
 	}
 
 	ctx.Set(OrderId, addAssuranceResp.Data.OrderId)
@@ -425,7 +168,7 @@ func CreateAssurance(ctx *Context) (*NodeResult, error) {
 //UserBehaviorsChain
 // LoginBasicChain
 //func LoginBasic(ctx *Context) (*NodeResult, error) {
-//	cli, ok := ctx.Get(Client).(*service.SvcImpl)
+//	cli, ok := ctx.Get(Client).(service.Client)
 //	if !ok {
 //		return nil, fmt.Errorf("service client not found in context")
 //	}
@@ -444,22 +187,32 @@ func CreateAssurance(ctx *Context) (*NodeResult, error) {
 
 // VerifyCodeBehaviorChain
 func VerifyCode(ctx *Context) (*NodeResult, error) {
-	cli, ok := ctx.Get(Client).(*service.SvcImpl)
+	cli, ok := ctx.Get(Client).(service.Client)
 	if !ok {
 		return nil, fmt.Errorf("service client not found in context")
 	}
 
-	verifyCode := generateVerifyCode()
+	image, err := cli.GenerateVerifyCode()
+	if err != nil {
+		log.Printf("Request failed, err %s", err)
+		return nil, err
+	}
+	if VerifyCodeSolver == nil {
+		return nil, service.ErrNoVerifyCodeSolver
+	}
+	verifyCode, err := VerifyCodeSolver(image)
+	if err != nil {
+		return nil, err
+	}
 	verifyCodeResp, err := cli.VerifyCode(verifyCode)
 	if err != nil {
-		log.Fatalf("Request failed, err %s", err)
+		log.Printf("Request failed, err %s", err)
 		return nil, err
 	}
 	if !verifyCodeResp {
-		log.Fatalf("Verification failed")
-		return nil, err
+		log.Printf("Verification failed")
+		return nil, fmt.Errorf("verification code %s rejected", verifyCode)
 	}
-	log.Fatalf("Verification code verified. The result is %v and verifyCode: %v", verifyCodeResp, verifyCode)
 
 	ctx.Set(BooleanVerifyCode, verifyCodeResp)
 
@@ -467,19 +220,17 @@ func VerifyCode(ctx *Context) (*NodeResult, error) {
 }
 
 func QueryUser(ctx *Context) (*NodeResult, error) {
-	cli, ok := ctx.Get(Client).(*service.SvcImpl)
+	cli, ok := ctx.Get(Client).(service.Client)
 	if !ok {
 		return nil, fmt.Errorf("service client not found in context")
 	}
 
 	allUsersResp, err := cli.GetAllUsers()
 	if err != nil {
-		log.Fatalf("Request failed, err1 %s", err)
 		return nil, err
 	}
-	if allUsersResp.Status != 1 {
-		log.Fatalf("Expected status 200, got %d", allUsersResp.Status)
-		return nil, err
+	if len(allUsersResp.Data) == 0 {
+		return nil, fmt.Errorf("no users found")
 	}
 
 	randomIndex := rand.Intn(len(allUsersResp.Data))
@@ -496,19 +247,17 @@ func QueryUser(ctx *Context) (*NodeResult, error) {
 
 // ContactsBehaviorChain
 func QueryContacts(ctx *Context) (*NodeResult, error) {
-	cli, ok := ctx.Get(Client).(*service.SvcImpl)
+	cli, ok := ctx.Get(Client).(service.Client)
 	if !ok {
 		return nil, fmt.Errorf("service client not found in context")
 	}
 
 	GetAllContacts, err := cli.GetAllContacts()
 	if err != nil {
-		log.Fatalf("[Mock AccountID]GetAllContacts fail. The error occurs: %v", err)
 		return nil, err
 	}
-	if GetAllContacts.Status != 1 {
-		log.Fatalf("[Mock AccountID]GetAllContacts.Status != 1")
-		return nil, err
+	if len(GetAllContacts.Data) == 0 {
+		return nil, fmt.Errorf("no contacts found")
 	}
 
 	randomIndex := rand.Intn(len(GetAllContacts.Data))
@@ -523,7 +272,7 @@ func QueryContacts(ctx *Context) (*NodeResult, error) {
 }
 
 func CreateContacts(ctx *Context) (*NodeResult, error) {
-	cli, ok := ctx.Get(Client).(*service.SvcImpl)
+	cli, ok := ctx.Get(Client).(service.Client)
 	if !ok {
 		return nil, fmt.Errorf("service client not found in context")
 	}
@@ -538,11 +287,6 @@ func CreateContacts(ctx *Context) (*NodeResult, error) {
 	}
 	CreateContacts, err := cli.AddContact(&CreateContactsInput)
 	if err != nil {
-		log.Fatalf("[Mock AccountID] CreateContacts error occurs: %v", err)
-		return nil, err
-	}
-	if CreateContacts.Status != 1 {
-		log.Fatalf("[Mock AccountID] CreateContacts.Status != 1")
 		return nil, err
 	}
 
@@ -558,7 +302,7 @@ func CreateContacts(ctx *Context) (*NodeResult, error) {
 
 // ConsignBehaviorsChain
 func QueryConsign(ctx *Context) (*NodeResult, error) {
-	cli, ok := ctx.Get(Client).(*service.SvcImpl)
+	cli, ok := ctx.Get(Client).(service.Client)
 	if !ok {
 		return nil, fmt.Errorf("service client not found in context")
 	}
@@ -598,12 +342,10 @@ func QueryConsign(ctx *Context) (*NodeResult, error) {
 	TheOrderId := ctx.Get(OrderId).(string)
 	consignsByOrderId, err := cli.QueryByOrderId(TheOrderId)
 	if err != nil {
-		log.Fatalf("QueryByOrderId failed: %v", err)
-		return nil, err
+		return nil, fmt.Errorf("query consign of order %v fail: %w", TheOrderId, err)
 	}
 	if consignsByOrderId.Status != 1 {
-		log.Fatalf("consignsByOrderId.Status = 1")
-		return nil, err
+		return nil, fmt.Errorf("query consign of order %v fail: status %d", TheOrderId, consignsByOrderId.Status)
 	}
 	/*isMatch1 := false
 	if consignsByOrderId.Data.OrderId == existedConsign.OrderID &&
@@ -668,7 +410,7 @@ func QueryConsign(ctx *Context) (*NodeResult, error) {
 }
 
 func CreateConsign(ctx *Context) (*NodeResult, error) {
-	cli, ok := ctx.Get(Client).(*service.SvcImpl)
+	cli, ok := ctx.Get(Client).(service.Client)
 	if !ok {
 		return nil, fmt.Errorf("service client not found in context")
 	}
@@ -700,15 +442,10 @@ func CreateConsign(ctx *Context) (*NodeResult, error) {
 		IsWithin:   BooleanIsWithin(MockedWeight),
 	}
 	insertResp, err := cli.InsertConsignRecord(&insertReq)
-	if err != nil {
-		log.Fatalf("InsertConsignRecord failed: %v", err)
-		return nil, err
-	}
-	if insertResp.Msg == "Already exists" {
-		return nil, fmt.Errorf("Consign already exists")
+	if service.IsErrorMsg(err, "Already exists") {
+		return nil, fmt.Errorf("Consign already exists: %w", err)
 	}
-	if insertResp.Status != 1 {
-		log.Fatalf("InsertConsignRecord failed: %v", insertResp.Status)
+	if err != nil {
 		return nil, err
 	}
 	isMatch := false
@@ -726,8 +463,7 @@ func CreateConsign(ctx *Context) (*NodeResult, error) {
 		isMatch = true
 	}
 	if !isMatch {
-		log.Fatalf("Creation not match. Expect: %v, but get: %v", insertReq, insertResp.Data)
-		return nil, err
+		return nil, fmt.Errorf("consign creation not match. Expect: %v, but get: %v", insertReq, insertResp.Data)
 	}
 	//log.Fatalf("InsertConsignRecord response: %+v", insertResp)
 	//existedConsign := insertResp.Data
@@ -748,8 +484,8 @@ func CreateConsign(ctx *Context) (*NodeResult, error) {
 }
 
 func QueryConsignPric(ctx *Context) (*NodeResult, error) {
-	_, ok := ctx.Get(Client).(*service.SvcImpl)
-	//cli, ok := ctx.Get(Client).(*service.SvcImpl)
+	_, ok := ctx.Get(Client).(service.Client)
+	//cli, ok := ctx.Get(Client).(service.Client)
 	if !ok {
 		return nil, fmt.Errorf("service client not found in context")
 	}
@@ -760,8 +496,8 @@ func QueryConsignPric(ctx *Context) (*NodeResult, error) {
 }
 
 func CreateConsignPrice(ctx *Context) (*NodeResult, error) {
-	_, ok := ctx.Get(Client).(*service.SvcImpl)
-	//cli, ok := ctx.Get(Client).(*service.SvcImpl)
+	_, ok := ctx.Get(Client).(service.Client)
+	//cli, ok := ctx.Get(Client).(service.Client)
 	if !ok {
 		return nil, fmt.Errorf("service client not found in context")
 	}
@@ -773,7 +509,7 @@ func CreateConsignPrice(ctx *Context) (*NodeResult, error) {
 
 // FoodBehaviorChain
 func QueryFood(ctx *Context) (*NodeResult, error) {
-	cli, ok := ctx.Get(Client).(*service.SvcImpl)
+	cli, ok := ctx.Get(Client).(service.Client)
 	if !ok {
 		return nil, fmt.Errorf("service client not found in context")
 	}
@@ -781,16 +517,10 @@ func QueryFood(ctx *Context) (*NodeResult, error) {
 	// Query all
 	allFoodOrders, err := cli.FindAllFoodOrder()
 	if err != nil {
-		log.Fatalf("FindAllFoodOrder request failed, err %s", err)
 		return nil, err
 	}
 	if len(allFoodOrders.Data) == 0 {
-		log.Fatalf("FindAllFoodOrder returned empty results")
-		return nil, err
-	}
-	if allFoodOrders.Status != 1 {
-		log.Fatalf("FindAllFoodOrder failed: %v", allFoodOrders.Status)
-		return nil, err
+		return nil, fmt.Errorf("FindAllFoodOrder returned empty results")
 	}
 
 	randomIndex := rand.Intn(len(allFoodOrders.Data))
@@ -805,7 +535,7 @@ func QueryFood(ctx *Context) (*NodeResult, error) {
 }
 
 func CreateFood(ctx *Context) (*NodeResult, error) {
-	cli, ok := ctx.Get(Client).(*service.SvcImpl)
+	cli, ok := ctx.Get(Client).(service.Client)
 	if !ok {
 		return nil, fmt.Errorf("service client not found in context")
 	}
@@ -826,11 +556,6 @@ func CreateFood(ctx *Context) (*NodeResult, error) {
 	// Create Test
 	newCreateResp, err := cli.CreateFoodOrder(&foodOrder)
 	if err != nil {
-		log.Fatalf("NewCreateFoodOrder request failed, err %s", err)
-		return nil, err
-	}
-	if newCreateResp.Status != 1 {
-		log.Fatalf("NEwCreateFoodOrder failed")
 		return nil, err
 	}
 
@@ -845,18 +570,13 @@ func CreateFood(ctx *Context) (*NodeResult, error) {
 }
 
 func QueryStationFood(ctx *Context) (*NodeResult, error) {
-	cli, ok := ctx.Get(Client).(*service.SvcImpl)
+	cli, ok := ctx.Get(Client).(service.Client)
 	if !ok {
 		return nil, fmt.Errorf("service client not found in context")
 	}
 
-	resp, err := cli.GetAllStationFood()
+	_, err := cli.GetAllStationFood()
 	if err != nil {
-		log.Fatalf("Resp returns err: %v", err)
-		return nil, err
-	}
-	if resp.Status != 1 {
-		log.Fatalf("GetAllStationFood status should be 1, but is %d", resp.Status)
 		return nil, err
 	}
 
@@ -877,7 +597,7 @@ func QueryStationFood(ctx *Context) (*NodeResult, error) {
 }
 
 func QueryTrainFood(ctx *Context) (*NodeResult, error) {
-	cli, ok := ctx.Get(Client).(*service.SvcImpl)
+	_, ok := ctx.Get(Client).(service.Client)
 	if !ok {
 		return nil, fmt.Errorf("service client not found in context")
 	}
@@ -888,18 +608,13 @@ func QueryTrainFood(ctx *Context) (*NodeResult, error) {
 }
 
 func QueryTrip(ctx *Context) (*NodeResult, error) {
-	cli, ok := ctx.Get(Client).(*service.SvcImpl)
-	if !ok {
-		return nil, fmt.Errorf("service client not found in context")
-	}
-	QueryAllTripResp, err := cli.QueryAllTrip()
+	catalog, err := catalogFrom(ctx)
 	if err != nil {
-		log.Fatalf("Request failed, err %s", err)
 		return nil, err
 	}
-	if QueryAllTripResp.Status != 1 {
-		log.Fatalf("Request failed, status: %d", QueryAllTripResp.Status)
-		return nil, err
+	QueryAllTripResp := catalog.Trips()
+	if len(QueryAllTripResp.Data) == 0 {
+		return nil, fmt.Errorf("no trips in catalog")
 	}
 
 	randomIndex := rand.Intn(len(QueryAllTripResp.Data))
@@ -914,7 +629,7 @@ func QueryTrip(ctx *Context) (*NodeResult, error) {
 }
 
 func CreateTrip(ctx *Context) (*NodeResult, error) {
-	cli, ok := ctx.Get(Client).(*service.SvcImpl)
+	cli, ok := ctx.Get(Client).(service.Client)
 	if !ok {
 		return nil, fmt.Errorf("service client not found in context")
 	}
@@ -946,16 +661,10 @@ func CreateTrip(ctx *Context) (*NodeResult, error) {
 
 	// Create Test
 	createResp, err := cli.CreateTrip(&travelInfo)
-	if err != nil {
-		log.Fatalf("CreateTrip request failed, err %s", err)
-		return nil, err
-	}
-	if createResp.Status != 1 {
-		log.Fatalf("CreateTrip failed: %s", createResp.Msg)
-		return nil, err
+	if service.IsErrorMsg(err, "Already exists") {
+		return nil, fmt.Errorf("trip %s already exists: %w", travelInfo.TripID, err)
 	}
-	if createResp.Msg == "Already exists" {
-		log.Fatalf("Already exists: %s", createResp.Msg)
+	if err != nil {
 		return nil, err
 	}
 	isMatch := false
@@ -970,8 +679,7 @@ func CreateTrip(ctx *Context) (*NodeResult, error) {
 		isMatch = true
 	}
 	if !isMatch {
-		log.Fatalf("CreateTrip failed: %s. Except: %v, but get: %v", createResp.Msg, travelInfo, createResp.Data)
-		return nil, err
+		return nil, fmt.Errorf("trip creation not match. Expect: %v, but get: %v", travelInfo, createResp.Data)
 	}
 
 	ctx.Set(TripID, createResp.Data.TripId)
@@ -986,7 +694,7 @@ func CreateTrip(ctx *Context) (*NodeResult, error) {
 
 // TravelBehaviorChain
 func QueryTrain(ctx *Context) (*NodeResult, error) {
-	cli, ok := ctx.Get(Client).(*service.SvcImpl)
+	_, ok := ctx.Get(Client).(service.Client)
 	if !ok {
 		return nil, fmt.Errorf("service client not found in context")
 	}
@@ -997,19 +705,14 @@ func QueryTrain(ctx *Context) (*NodeResult, error) {
 }
 
 func QueryRoute(ctx *Context) (*NodeResult, error) {
-	cli, ok := ctx.Get(Client).(*service.SvcImpl)
-	if !ok {
-		return nil, fmt.Errorf("service client not found in context")
-	}
-
-	AllRoutesByQuery, err := cli.QueryAllRoutes()
+	catalog, err := catalogFrom(ctx)
 	if err != nil {
-		log.Fatalf("Request failed, err2 %s", err)
 		return nil, err
 	}
-	if AllRoutesByQuery.Status != 1 {
-		log.Fatal("AllRoutes_By_Query.Status != 1")
-		return nil, err
+
+	AllRoutesByQuery := catalog.Routes()
+	if len(AllRoutesByQuery.Data) == 0 {
+		return nil, fmt.Errorf("no routes in catalog")
 	}
 
 	randomIndex := rand.Intn(len(AllRoutesByQuery.Data))
@@ -1022,7 +725,7 @@ func QueryRoute(ctx *Context) (*NodeResult, error) {
 }
 
 //func CreateRoute(ctx *Context) (*NodeResult, error) {
-//	cli, ok := ctx.Get(Client).(*service.SvcImpl)
+//	cli, ok := ctx.Get(Client).(service.Client)
 //	if !ok {
 //		return nil, fmt.Errorf("service client not found in context")
 //	}
@@ -1079,7 +782,7 @@ func QueryRoute(ctx *Context) (*NodeResult, error) {
 //}
 
 func QueryBasic(ctx *Context) (*NodeResult, error) {
-	cli, ok := ctx.Get(Client).(*service.SvcImpl)
+	_, ok := ctx.Get(Client).(service.Client)
 	if !ok {
 		return nil, fmt.Errorf("service client not found in context")
 	}
@@ -1090,7 +793,7 @@ func QueryBasic(ctx *Context) (*NodeResult, error) {
 }
 
 func QuerySeat(ctx *Context) (*NodeResult, error) {
-	cli, ok := ctx.Get(Client).(*service.SvcImpl)
+	_, ok := ctx.Get(Client).(service.Client)
 	if !ok {
 		return nil, fmt.Errorf("service client not found in context")
 	}
@@ -1102,30 +805,43 @@ func QuerySeat(ctx *Context) (*NodeResult, error) {
 
 // BasicBehaviorChain
 func QueryStation(ctx *Context) (*NodeResult, error) {
-	cli, ok := ctx.Get(Client).(*service.SvcImpl)
-	if !ok {
-		return nil, fmt.Errorf("service client not found in context")
+	catalog, err := catalogFrom(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	// TODO part; I will generate it.
+	Stations := catalog.Stations()
+	if len(Stations.Data) == 0 {
+		return nil, fmt.Errorf("no stations in catalog")
+	}
+
+	randomIndex := rand.Intn(len(Stations.Data))
+	ctx.Set(StationName, Stations.Data[randomIndex].Name)
 
 	return nil, nil
 }
 
 func QueryPrice(ctx *Context) (*NodeResult, error) {
-	cli, ok := ctx.Get(Client).(*service.SvcImpl)
-	if !ok {
-		return nil, fmt.Errorf("service client not found in context")
+	catalog, err := catalogFrom(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	// TODO part; I will generate it.
+	Prices := catalog.Prices()
+	if len(Prices.Data) == 0 {
+		return nil, fmt.Errorf("no prices in catalog")
+	}
+
+	randomIndex := rand.Intn(len(Prices.Data))
+	ctx.Set(RouteID, Prices.Data[randomIndex].RouteId)
+	ctx.Set(Price, Prices.Data[randomIndex].BasicPriceRate)
 
 	return nil, nil
 }
 
 // SeatBehaviorChain
 func QueryConfig(ctx *Context) (*NodeResult, error) {
-	cli, ok := ctx.Get(Client).(*service.SvcImpl)
+	_, ok := ctx.Get(Client).(service.Client)
 	if !ok {
 		return nil, fmt.Errorf("service client not found in context")
 	}
@@ -1136,7 +852,7 @@ func QueryConfig(ctx *Context) (*NodeResult, error) {
 }
 
 func QueryOrder(ctx *Context) (*NodeResult, error) {
-	cli, ok := ctx.Get(Client).(*service.SvcImpl)
+	_, ok := ctx.Get(Client).(service.Client)
 	if !ok {
 		return nil, fmt.Errorf("service client not found in context")
 	}
@@ -1147,7 +863,7 @@ func QueryOrder(ctx *Context) (*NodeResult, error) {
 }
 
 func QueryOrderOther(ctx *Context) (*NodeResult, error) {
-	cli, ok := ctx.Get(Client).(*service.SvcImpl)
+	_, ok := ctx.Get(Client).(service.Client)
 	if !ok {
 		return nil, fmt.Errorf("service client not found in context")
 	}
@@ -1164,42 +880,106 @@ func QueryOrderOther(ctx *Context) (*NodeResult, error) {
 /////////////////////////////////////////////////////////////////////////////////////
 
 // Preserve Behaviors - The Last One
+
+// Preserve books the trip found by a travel search for the contact picked by QueryContacts. The seat class,
+// assurance, food and consign details are taken from the context when an earlier node set them.
 func Preserve(ctx *Context) (*NodeResult, error) {
-	cli, ok := ctx.Get(Client).(*service.SvcImpl)
+	cli, ok := ctx.Get(Client).(service.Client)
 	if !ok {
 		return nil, fmt.Errorf("service client not found in context")
 	}
 	OrderTicketsInfo := service.OrderTicketsInfo{
-		AccountID:       ctx.Get(AccountID).(string),  // Query:Create = 1 : 0
-		ContactsID:      ctx.Get(ContactsID).(string), // Query:Create = 1 : 0
-		TripID:          ctx.Get(TripID).(string),
-		SeatType:        ctx.Get(SeatType).(int),
-		LoginToken:      ctx.Get(LoginToken).(string),
-		Date:            ctx.Get(Date).(string),
-		From:            ctx.Get(From).(string),
-		To:              ctx.Get(To).(string),
-		Assurance:       ctx.Get(Assurance).(int),
-		FoodType:        ctx.Get(FoodType).(int),
-		StationName:     ctx.Get(StationName).(string),
-		StoreName:       ctx.Get(StoreName).(string),
-		FoodName:        ctx.Get(FoodName).(string),
-		FoodPrice:       ctx.Get(FoodPrice).(float64),
-		HandleDate:      ctx.Get(HandleDate).(string),
-		ConsigneeName:   ctx.Get(ConsigneeName).(string),
-		ConsigneePhone:  ctx.Get(ConsigneePhone).(string),
-		ConsigneeWeight: ctx.Get(ConsigneeWeight).(float64),
-		IsWithin:        ctx.Get(IsWithin).(bool),
-	}
-	PreserveResp, err := cli.Preserve(&OrderTicketsInfo)
+		SeatType: rand.Intn(2) + 2, // 2: first class, 3: second class
+	}
+	for key, field := range map[string]*string{
+		AccountID:  &OrderTicketsInfo.AccountID,
+		ContactsID: &OrderTicketsInfo.ContactsID,
+		TripID:     &OrderTicketsInfo.TripID,
+		Date:       &OrderTicketsInfo.Date,
+		From:       &OrderTicketsInfo.From,
+		To:         &OrderTicketsInfo.To,
+	} {
+		value, ok := ctx.Get(key).(string)
+		if !ok || value == "" {
+			return nil, fmt.Errorf("%s not found in context", key)
+		}
+		*field = value
+	}
+	if seatType, ok := ctx.Get(SeatType).(int); ok {
+		OrderTicketsInfo.SeatType = seatType
+	}
+	OrderTicketsInfo.LoginToken, _ = ctx.Get(LoginToken).(string)
+	OrderTicketsInfo.Assurance, _ = ctx.Get(Assurance).(int)
+	OrderTicketsInfo.FoodType, _ = ctx.Get(FoodType).(int)
+	OrderTicketsInfo.StationName, _ = ctx.Get(StationName).(string)
+	OrderTicketsInfo.StoreName, _ = ctx.Get(StoreName).(string)
+	OrderTicketsInfo.FoodName, _ = ctx.Get(FoodName).(string)
+	OrderTicketsInfo.FoodPrice, _ = ctx.Get(FoodPrice).(float64)
+	OrderTicketsInfo.HandleDate, _ = ctx.Get(HandleDate).(string)
+	OrderTicketsInfo.ConsigneeName, _ = ctx.Get(ConsigneeName).(string)
+	OrderTicketsInfo.ConsigneePhone, _ = ctx.Get(ConsigneePhone).(string)
+	OrderTicketsInfo.ConsigneeWeight, _ = ctx.Get(ConsigneeWeight).(float64)
+	OrderTicketsInfo.IsWithin, _ = ctx.Get(IsWithin).(bool)
+
+	// G/D trips are booked by preserve-service, Z/T/K trips by preserve-other-service
+	preserve := cli.Preserve
+	if !isHighSpeedTrip(OrderTicketsInfo.TripID) {
+		preserve = cli.PreserveOther
+	}
+	PreserveResp, err := preserve(&OrderTicketsInfo)
 	if err != nil {
 		return nil, err
 	}
-	if PreserveResp.Status != 1 {
-		return nil, fmt.Errorf("preserve order tickets fail. PreserveResp.Status != 1, get %v", PreserveResp.Status)
-	}
 	fmt.Printf("The Status is: %v, and PreserveResp Data: %v\n", PreserveResp.Status, PreserveResp.Data)
-	fmt.Printf("PreserveBehaviors(Chain) Ends. End time: %v", time.Now().String())
 
-	//return nil, nil
-	return &(NodeResult{false}), nil // Chain End :D
+	return &(NodeResult{true}), nil // Continue to Rebook
+}
+
+// Rebook moves one of the account's paid orders to the preserved trip with a random seat class,
+// paying the price difference when the new ticket is more expensive.
+func Rebook(ctx *Context) (*NodeResult, error) {
+	cli, ok := ctx.Get(Client).(service.Client)
+	if !ok {
+		return nil, fmt.Errorf("service client not found in context")
+	}
+	qi := service.Qi{
+		LoginId:          ctx.Get(AccountID).(string),
+		EnableStateQuery: true,
+		State:            1, // Paid
+	}
+	// Orders of G/D trips are kept by order-service, orders of Z/T/K trips by order-other-service
+	queryOrders := cli.ReqQueryOrders
+	if !isHighSpeedTrip(ctx.Get(TripID).(string)) {
+		queryOrders = cli.ReqQueryOrdersOther
+	}
+	QueryOrdersResp, err := queryOrders(&qi)
+	if err != nil {
+		return nil, err
+	}
+	if QueryOrdersResp.Status != 1 || len(QueryOrdersResp.Data) == 0 {
+		log.Printf("no paid order to rebook for account %v", ctx.Get(AccountID))
+		return nil, nil
+	}
+	order := QueryOrdersResp.Data[rand.Intn(len(QueryOrdersResp.Data))]
+
+	RebookInfo := service.RebookInfo{
+		LoginId:   ctx.Get(AccountID).(string),
+		OrderId:   order.Id,
+		OldTripId: order.TrainNumber,
+		TripId:    ctx.Get(TripID).(string),
+		SeatType:  rand.Intn(2) + 2, // 2: first class, 3: second class
+		Date:      ctx.Get(Date).(string),
+	}
+	RebookResp, err := cli.ReqRebook(&RebookInfo)
+	var svcErr *service.Error
+	if errors.As(err, &svcErr) && svcErr.Status == service.RebookStatusPayDifference {
+		RebookResp, err = cli.ReqRebookPayDifference(&RebookInfo)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("rebook order %v fail: %w", order.Id, err)
+	}
+	ctx.Set(OrderId, RebookResp.Data.Id)
+	fmt.Printf("The Status is: %v, and RebookResp Data: %v\n", RebookResp.Status, RebookResp.Data)
+
+	return nil, nil
 }
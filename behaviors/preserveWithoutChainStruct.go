@@ -12,7 +12,7 @@ import (
 
 type PreserveBehavior struct{}
 
-func (o *PreserveBehavior) Run(cli *service.SvcImpl) {
+func (o *PreserveBehavior) Run(cli service.Client) {
 	loginResult, err := cli.ReqUserLogin(&service.UserLoginInfoReq{
 		Password:         "111111",
 		UserName:         "fdse_microservice",
@@ -21,6 +21,8 @@ func (o *PreserveBehavior) Run(cli *service.SvcImpl) {
 	if err != nil {
 		log.Fatalln(err)
 	}
+	// Every mocked station of this run lies on one journey that has a trip
+	journey := sampleJourney(legacyNetwork(cli).Direct)
 
 	var preserveSvc service.PreserveService = cli
 
@@ -241,14 +243,14 @@ func (o *PreserveBehavior) Run(cli *service.SvcImpl) {
 			ContactsName:           faker.Name(),
 			DifferenceMoney:        RandomDecimalStringBetween(1, 10),
 			DocumentType:           0,
-			From:                   RandomProvincialCapitalEN(),
+			From:                   journey.From,
 			Id:                     uuid.NewString(),
 			Price:                  RandomDecimalStringBetween(1, 10),
 			SeatClass:              GetTrainTicketClass(),
 			SeatNumber:             service.GenerateSeatNumber(),
 			Status:                 0,
-			To:                     RandomProvincialCapitalEN(),
-			TrainNumber:            GenerateTripId(),
+			To:                     journey.To,
+			TrainNumber:            journey.Legs[0].TripId,
 			TravelDate:             getRandomTime(),
 			TravelTime:             generateRandomTime(),
 		})
@@ -314,14 +316,14 @@ func (o *PreserveBehavior) Run(cli *service.SvcImpl) {
 			ContactsName:           faker.Name(),
 			DifferenceMoney:        RandomDecimalStringBetween(1, 10),
 			DocumentType:           0,
-			From:                   RandomProvincialCapitalEN(),
+			From:                   journey.From,
 			Id:                     faker.UUIDHyphenated(),
 			Price:                  RandomDecimalStringBetween(1, 10),
 			SeatClass:              GetTrainTicketClass(),
 			SeatNumber:             service.GenerateSeatNumber(),
 			Status:                 0,
-			To:                     RandomProvincialCapitalEN(),
-			TrainNumber:            GenerateTripId(),
+			To:                     journey.To,
+			TrainNumber:            journey.Legs[0].TripId,
 			TravelDate:             getRandomTime(),
 			TravelTime:             faker.TimeString(),
 		})
@@ -381,14 +383,14 @@ func (o *PreserveBehavior) Run(cli *service.SvcImpl) {
 			ContactsName:           faker.Name(),
 			DifferenceMoney:        RandomDecimalStringBetween(1, 10),
 			DocumentType:           0,
-			From:                   RandomProvincialCapitalEN(),
+			From:                   journey.From,
 			Id:                     faker.UUIDHyphenated(),
 			Price:                  RandomDecimalStringBetween(1, 10),
 			SeatClass:              GetTrainTicketClass(),
 			SeatNumber:             service.GenerateSeatNumber(),
 			Status:                 0,
-			To:                     RandomProvincialCapitalEN(),
-			TrainNumber:            GenerateTripId(),
+			To:                     journey.To,
+			TrainNumber:            journey.Legs[0].TripId,
 			TravelDate:             getRandomTime(),
 			TravelTime:             generateRandomTime(),
 		})
@@ -411,7 +413,7 @@ func (o *PreserveBehavior) Run(cli *service.SvcImpl) {
 
 		MockedFromCity = GetAllOrder.Data[len(GetAllOrder.Data)-1].From
 	} else {
-		MockedFromCity = faker.GetRealAddress().City
+		MockedFromCity = journey.From
 	}
 
 	// MockedToCity
@@ -446,14 +448,14 @@ func (o *PreserveBehavior) Run(cli *service.SvcImpl) {
 			ContactsName:           faker.Name(),
 			DifferenceMoney:        RandomDecimalStringBetween(1, 10),
 			DocumentType:           0,
-			From:                   RandomProvincialCapitalEN(),
+			From:                   journey.From,
 			Id:                     faker.UUIDHyphenated(),
 			Price:                  RandomDecimalStringBetween(1, 10),
 			SeatClass:              GetTrainTicketClass(),
 			SeatNumber:             service.GenerateSeatNumber(),
 			Status:                 0,
-			To:                     RandomProvincialCapitalEN(),
-			TrainNumber:            GenerateTripId(),
+			To:                     journey.To,
+			TrainNumber:            journey.Legs[0].TripId,
 			TravelDate:             getRandomTime(),
 			TravelTime:             generateRandomTime(),
 		})
@@ -472,7 +474,7 @@ func (o *PreserveBehavior) Run(cli *service.SvcImpl) {
 
 		MockedToCity = GetAllOrder.Data[len(GetAllOrder.Data)-1].To
 	} else {
-		MockedToCity = faker.GetRealAddress().City
+		MockedToCity = journey.To
 	}
 
 	// Assurance Servcie
@@ -544,7 +546,7 @@ func (o *PreserveBehavior) Run(cli *service.SvcImpl) {
 			OrderID:     MockedOrderID,
 			FoodType:    1,
 			FoodName:    generateRandomFood(),
-			StationName: generateRandomCityName(),
+			StationName: journey.From,
 			StoreName:   generateRandomStoreName(),
 			Price:       7.00,
 		}
@@ -553,7 +555,7 @@ func (o *PreserveBehavior) Run(cli *service.SvcImpl) {
 			OrderID:     MockedOrderID,
 			FoodType:    1,
 			FoodName:    generateRandomFood(),
-			StationName: generateRandomCityName(),
+			StationName: journey.From,
 			StoreName:   generateRandomStoreName(),
 			Price:       8.00,
 		}
@@ -608,7 +610,7 @@ func (o *PreserveBehavior) Run(cli *service.SvcImpl) {
 		}
 		MockedStationName = createStationresp.Data.Name
 	} else {
-		MockedStationName = faker.GetRealAddress().City + "Station"
+		MockedStationName = journey.From
 	}
 
 	// Food Servcie
@@ -635,7 +637,7 @@ func (o *PreserveBehavior) Run(cli *service.SvcImpl) {
 			OrderID:     MockedOrderID,
 			FoodType:    1,
 			FoodName:    generateRandomFood(),
-			StationName: generateRandomCityName(),
+			StationName: journey.From,
 			StoreName:   generateRandomStoreName(),
 			Price:       7.00,
 		}
@@ -644,7 +646,7 @@ func (o *PreserveBehavior) Run(cli *service.SvcImpl) {
 			OrderID:     MockedOrderID,
 			FoodType:    1,
 			FoodName:    generateRandomFood(),
-			StationName: generateRandomCityName(),
+			StationName: journey.From,
 			StoreName:   generateRandomStoreName(),
 			Price:       8.00,
 		}
@@ -696,7 +698,7 @@ func (o *PreserveBehavior) Run(cli *service.SvcImpl) {
 			OrderID:     MockedOrderID,
 			FoodType:    1,
 			FoodName:    generateRandomFood(),
-			StationName: generateRandomCityName(),
+			StationName: journey.From,
 			StoreName:   generateRandomStoreName(),
 			Price:       7.00,
 		}
@@ -705,7 +707,7 @@ func (o *PreserveBehavior) Run(cli *service.SvcImpl) {
 			OrderID:     MockedOrderID,
 			FoodType:    1,
 			FoodName:    generateRandomFood(),
-			StationName: generateRandomCityName(),
+			StationName: journey.From,
 			StoreName:   generateRandomStoreName(),
 			Price:       8.00,
 		}
@@ -759,7 +761,7 @@ func (o *PreserveBehavior) Run(cli *service.SvcImpl) {
 			OrderID:     MockedOrderID,
 			FoodType:    1,
 			FoodName:    generateRandomFood(),
-			StationName: generateRandomCityName(),
+			StationName: journey.From,
 			StoreName:   generateRandomStoreName(),
 			Price:       7.00,
 		}
@@ -768,7 +770,7 @@ func (o *PreserveBehavior) Run(cli *service.SvcImpl) {
 			OrderID:     MockedOrderID,
 			FoodType:    1,
 			FoodName:    generateRandomFood(),
-			StationName: generateRandomCityName(),
+			StationName: journey.From,
 			StoreName:   generateRandomStoreName(),
 			Price:       8.00,
 		}
@@ -819,8 +821,8 @@ func (o *PreserveBehavior) Run(cli *service.SvcImpl) {
 		MockedOrderId := faker.UUIDHyphenated()
 		MockedHandleDateInput := getRandomTime()
 		MockedTargetDate := getRandomTime()
-		MockedFromPlace := generateRandomCityName()
-		MockedToPlace := generateRandomCityName()
+		MockedFromPlace := journey.From
+		MockedToPlace := journey.To
 		MockedConsignee := faker.Name()
 		MockedPhone := faker.PhoneNumber
 
@@ -880,8 +882,8 @@ func (o *PreserveBehavior) Run(cli *service.SvcImpl) {
 		MockedOrderId := faker.UUIDHyphenated()
 		MockedHandleDateInput := getRandomTime()
 		MockedTargetDate := getRandomTime()
-		MockedFromPlace := generateRandomCityName()
-		MockedToPlace := generateRandomCityName()
+		MockedFromPlace := journey.From
+		MockedToPlace := journey.To
 		MockedConsignee := faker.Name()
 		MockedPhone := faker.PhoneNumber
 
@@ -941,8 +943,8 @@ func (o *PreserveBehavior) Run(cli *service.SvcImpl) {
 		MockedOrderId := faker.UUIDHyphenated()
 		MockedHandleDateInput := getRandomTime()
 		MockedTargetDate := getRandomTime()
-		MockedFromPlace := generateRandomCityName()
-		MockedToPlace := generateRandomCityName()
+		MockedFromPlace := journey.From
+		MockedToPlace := journey.To
 		MockedConsignee := faker.Name()
 		MockedPhone := faker.PhoneNumber
 
@@ -1003,8 +1005,8 @@ func (o *PreserveBehavior) Run(cli *service.SvcImpl) {
 		MockedOrderId := faker.UUIDHyphenated()
 		MockedHandleDateInput := getRandomTime()
 		MockedTargetDate := getRandomTime()
-		MockedFromPlace := generateRandomCityName()
-		MockedToPlace := generateRandomCityName()
+		MockedFromPlace := journey.From
+		MockedToPlace := journey.To
 		MockedConsignee := faker.Name()
 		MockedPhone := faker.PhoneNumber
 
@@ -1,5 +1,11 @@
 package behaviors
 
+import (
+	"fmt"
+
+	"github.com/Lincyaw/loadgenerator/service"
+)
+
 const ()
 
 var TravelChain *Chain
@@ -8,5 +14,43 @@ func init() {
 	TravelChain = NewChain(NewFuncNode(func(context *Context) (*NodeResult, error) {
 		return nil, nil
 	}, "DummyTravelChain"))
-	LoginChain.AddNextChain(NewChain(NewFuncNode(LoginAdmin, "LoginAdmin")), 1)
+	TravelChain.AddNode(NewFuncNode(SearchTravel, "SearchTravel"))
+	TravelChain.AddNextChain(PreserveBehaviorChain, 1)
+	// The admin searches and books: PreserveBehaviorChain picks a contact of any account
+	searchChain := NewChain(NewFuncNode(LoginAdmin, "LoginAdmin"))
+	searchChain.AddNextChain(TravelChain, 1)
+	LoginChain.AddNextChain(searchChain, 1)
+}
+
+// SearchTravel searches the tickets between two stations with a direct trip, picked by popularity,
+// on a day the trip runs. G/D trips are searched on travel-service, Z/T/K trips on travel2-service.
+func SearchTravel(ctx *Context) (*NodeResult, error) {
+	cli, ok := ctx.Get(Client).(service.Client)
+	if !ok {
+		return nil, fmt.Errorf("service client not found in context")
+	}
+	catalog, err := catalogFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	journey, ok := catalog.Network().Direct()
+	if !ok {
+		return nil, fmt.Errorf("no reachable stations in catalog")
+	}
+
+	tripInfo := service.TripInfo{StartPlace: journey.From, EndPlace: journey.To, DepartureTime: journey.Date}
+	if journey.HighSpeed() {
+		_, err = cli.QueryInfo(tripInfo)
+	} else {
+		_, err = cli.QueryByBatch(&tripInfo)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("search travel from %v to %v on %v fail: %w", journey.From, journey.To, journey.Date, err)
+	}
+	ctx.Set(From, journey.From)
+	ctx.Set(To, journey.To)
+	ctx.Set(Date, journey.Date)
+	ctx.Set(TripID, journey.Legs[0].TripId)
+
+	return nil, nil
 }
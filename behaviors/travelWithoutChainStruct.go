@@ -11,7 +11,7 @@ import (
 
 type TravelBehavior struct{}
 
-func (o *TravelBehavior) Run(cli *service.SvcImpl) {
+func (o *TravelBehavior) Run(cli service.Client) {
 	_, err := cli.ReqUserLogin(&service.UserLoginInfoReq{
 		Password:         "111111",
 		UserName:         "fdse_microservice",
@@ -20,6 +20,8 @@ func (o *TravelBehavior) Run(cli *service.SvcImpl) {
 	if err != nil {
 		log.Fatalln(err)
 	}
+	// The searches and the updated trip use a journey that has a trip, the created routes keep random cities
+	journey := sampleJourney(legacyNetwork(cli).Direct)
 	var travelSvc service.TravelService = cli
 
 	// TravelService_FullIntegration
@@ -272,7 +274,7 @@ func (o *TravelBehavior) Run(cli *service.SvcImpl) {
 
 		MockedStartStationName = GetAllRouteInfo.Data[len(GetAllRouteInfo.Data)-1].StartStation
 	} else {
-		MockedStartStationName = faker.GetRealAddress().City
+		MockedStartStationName = journey.From
 	}
 
 	// Service
@@ -323,7 +325,7 @@ func (o *TravelBehavior) Run(cli *service.SvcImpl) {
 
 		MockedTerminalStationName = GetAllRouteInfo.Data[len(GetAllRouteInfo.Data)-1].EndStation
 	} else {
-		MockedTerminalStationName = faker.GetRealAddress().City
+		MockedTerminalStationName = journey.To
 	}
 
 	// Service
@@ -727,7 +729,7 @@ func (o *TravelBehavior) Run(cli *service.SvcImpl) {
 
 		MockedStartPlace = GetAllRouteInfo.Data[len(GetAllRouteInfo.Data)-1].StartStation
 	} else {
-		MockedStartPlace = faker.GetRealAddress().City
+		MockedStartPlace = journey.From
 	}
 
 	// 10.2. EndPlace
@@ -776,7 +778,7 @@ func (o *TravelBehavior) Run(cli *service.SvcImpl) {
 
 		MockedEndPlace = GetAllRouteInfo.Data[len(GetAllRouteInfo.Data)-1].EndStation
 	} else {
-		MockedEndPlace = faker.GetRealAddress().City
+		MockedEndPlace = journey.To
 	}
 
 	// 10.3. DepartureTime
@@ -1 +1,102 @@
 package behaviors
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/Lincyaw/loadgenerator/service"
+)
+
+var TravelPlanChain *Chain
+
+func init() {
+	TravelPlanChain = NewChain(NewFuncNode(func(context *Context) (*NodeResult, error) {
+		return nil, nil
+	}, "DummyTravelPlanChain"))
+	TravelPlanChain.AddNextChain(NewChain(NewFuncNode(SearchTravelPlan, "SearchTravelPlan")), 0.8)
+	TravelPlanChain.AddNextChain(NewChain(NewFuncNode(SearchTransfer, "SearchTransfer")), 0.2)
+	planChain := NewChain(NewFuncNode(LoginBasic, "LoginBasic"))
+	planChain.AddNextChain(TravelPlanChain, 1)
+	LoginChain.AddNextChain(planChain, 0.5)
+}
+
+// SearchTravelPlan asks travel-plan-service for the cheapest, quickest or fewest-stops plans between
+// two stations with a direct trip, picked by popularity, on a day the trip runs.
+func SearchTravelPlan(ctx *Context) (*NodeResult, error) {
+	cli, ok := ctx.Get(Client).(service.Client)
+	if !ok {
+		return nil, fmt.Errorf("service client not found in context")
+	}
+	catalog, err := catalogFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	journey, ok := catalog.Network().Direct()
+	if !ok {
+		return nil, fmt.Errorf("no reachable stations in catalog")
+	}
+
+	search := []func(*service.TravelQueryInfo) (*service.TravelQueryArrResponse, error){
+		cli.ReqGetByCheapest, cli.ReqGetByQuickest, cli.ReqGetByMinStation,
+	}[rand.Intn(3)]
+	_, err = search(&service.TravelQueryInfo{StartPlace: journey.From, EndPlace: journey.To, DepartureTime: journey.Date})
+	if err != nil {
+		return nil, fmt.Errorf("search travel plan from %v to %v on %v fail: %w", journey.From, journey.To, journey.Date, err)
+	}
+	ctx.Set(From, journey.From)
+	ctx.Set(To, journey.To)
+	ctx.Set(Date, journey.Date)
+
+	return nil, nil
+}
+
+// SearchTransfer asks travel-plan-service for a journey with one change, between stations picked by
+// popularity that are connected through the via station, on a day both trips run.
+func SearchTransfer(ctx *Context) (*NodeResult, error) {
+	cli, ok := ctx.Get(Client).(service.Client)
+	if !ok {
+		return nil, fmt.Errorf("service client not found in context")
+	}
+	catalog, err := catalogFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	journey, ok := catalog.Network().Transfer()
+	if !ok {
+		return nil, fmt.Errorf("no stations reachable with a transfer in catalog")
+	}
+
+	_, err = cli.ReqTransferResult(&service.TransferTravelQueryInfo{
+		StartStation: journey.From,
+		ViaStation:   journey.Via,
+		EndStation:   journey.To,
+		TrainType:    transferTrainType(journey),
+		TravelDate:   journey.Date,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("search transfer from %v via %v to %v on %v fail: %w", journey.From, journey.Via, journey.To, journey.Date, err)
+	}
+	ctx.Set(From, journey.From)
+	ctx.Set(To, journey.To)
+	ctx.Set(Date, journey.Date)
+
+	return nil, nil
+}
+
+// transferTrainType restricts the transfer search to the kind of trips the journey takes:
+// "1" for G/D trips only, "2" for Z/T/K trips only and "0" for both.
+func transferTrainType(journey Journey) string {
+	highSpeed := 0
+	for _, leg := range journey.Legs {
+		if isHighSpeedTrip(leg.TripId) {
+			highSpeed++
+		}
+	}
+	switch highSpeed {
+	case len(journey.Legs):
+		return "1"
+	case 0:
+		return "2"
+	}
+	return "0"
+}
@@ -10,7 +10,7 @@ import (
 
 type TravelplanBehavior struct{}
 
-func (o *TravelplanBehavior) Run(cli *service.SvcImpl) {
+func (o *TravelplanBehavior) Run(cli service.Client) {
 	_, err := cli.ReqUserLogin(&service.UserLoginInfoReq{
 		Password:         "111111",
 		UserName:         "fdse_microservice",
@@ -19,6 +19,10 @@ func (o *TravelplanBehavior) Run(cli *service.SvcImpl) {
 	if err != nil {
 		log.Fatalln(err)
 	}
+	// The cheapest/quickest queries search a direct journey, the transfer query a journey with one change
+	network := legacyNetwork(cli)
+	journey := sampleJourney(network.Direct)
+	transfer := sampleJourney(network.Transfer)
 
 	var travelplanSvc service.TravelplanService = cli
 	// Mock Input Variables
@@ -58,8 +62,8 @@ func (o *TravelplanBehavior) Run(cli *service.SvcImpl) {
 		//MockedTripIDName := faker.Word()
 		MockedTrainTypeName := faker.Word()
 		MockedRouteID := faker.UUIDHyphenated()
-		MockedStartStationName := RandomProvincialCapitalEN()
-		MockedTerminalStationName := RandomProvincialCapitalEN()
+		MockedStartStationName := journey.From
+		MockedTerminalStationName := journey.To
 		MockedStationsName := MockedStartStationName + ", " + MockedTerminalStationName
 		MockedStartTime := faker.Date()
 		MockedEndTime := faker.Date()
@@ -123,8 +127,8 @@ func (o *TravelplanBehavior) Run(cli *service.SvcImpl) {
 		//MockedTripIDName := faker.Word()
 		MockedTrainTypeName := faker.Word()
 		MockedRouteID := faker.UUIDHyphenated()
-		MockedStartStationName := RandomProvincialCapitalEN()
-		MockedTerminalStationName := RandomProvincialCapitalEN()
+		MockedStartStationName := journey.From
+		MockedTerminalStationName := journey.To
 		MockedStationsName := MockedStartStationName + ", " + MockedTerminalStationName
 		MockedStartTime := faker.Date()
 		MockedEndTime := faker.Date()
@@ -157,7 +161,7 @@ func (o *TravelplanBehavior) Run(cli *service.SvcImpl) {
 			log.Fatalf("[MockedDepartureTime]create fail. No data.")
 		}
 	} else {
-		MockedEndPlace = RandomProvincialCapitalEN()
+		MockedEndPlace = journey.To
 	}
 
 	//MockedStartPlace
@@ -188,8 +192,8 @@ func (o *TravelplanBehavior) Run(cli *service.SvcImpl) {
 		//MockedTripIDName := faker.Word()
 		MockedTrainTypeName := faker.Word()
 		MockedRouteID := faker.UUIDHyphenated()
-		MockedStartStationName := RandomProvincialCapitalEN()
-		MockedTerminalStationName := RandomProvincialCapitalEN()
+		MockedStartStationName := journey.From
+		MockedTerminalStationName := journey.To
 		MockedStationsName := MockedStartStationName + ", " + MockedTerminalStationName
 		MockedStartTime := faker.Date()
 		MockedEndTime := faker.Date()
@@ -222,7 +226,7 @@ func (o *TravelplanBehavior) Run(cli *service.SvcImpl) {
 			log.Fatalf("[MockedDepartureTime]create fail. No data.")
 		}
 	} else {
-		MockedStartPlace = RandomProvincialCapitalEN()
+		MockedStartPlace = journey.From
 	}
 
 	travelQueryInfo := service.TravelQueryInfo{
@@ -283,8 +287,8 @@ func (o *TravelplanBehavior) Run(cli *service.SvcImpl) {
 		//MockedTripIDName := faker.Word()
 		MockedTrainTypeName := faker.Word()
 		MockedRouteID := faker.UUIDHyphenated()
-		MockedStartStationName := RandomProvincialCapitalEN()
-		MockedTerminalStationName := RandomProvincialCapitalEN()
+		MockedStartStationName := journey.From
+		MockedTerminalStationName := journey.To
 		MockedStationsName := MockedStartStationName + ", " + MockedTerminalStationName
 		MockedStartTime := faker.Date()
 		MockedEndTime := faker.Date()
@@ -317,7 +321,7 @@ func (o *TravelplanBehavior) Run(cli *service.SvcImpl) {
 			log.Fatalf("[MockedDepartureTime]create fail. No data.")
 		}
 	} else {
-		MockedEndStation = RandomProvincialCapitalEN()
+		MockedEndStation = transfer.To
 	}
 
 	//MockedStartStation
@@ -348,8 +352,8 @@ func (o *TravelplanBehavior) Run(cli *service.SvcImpl) {
 		//MockedTripIDName := faker.Word()
 		MockedTrainTypeName := faker.Word()
 		MockedRouteID := faker.UUIDHyphenated()
-		MockedStartStationName := RandomProvincialCapitalEN()
-		MockedTerminalStationName := RandomProvincialCapitalEN()
+		MockedStartStationName := journey.From
+		MockedTerminalStationName := journey.To
 		MockedStationsName := MockedStartStationName + ", " + MockedTerminalStationName
 		MockedStartTime := faker.Date()
 		MockedEndTime := faker.Date()
@@ -382,7 +386,7 @@ func (o *TravelplanBehavior) Run(cli *service.SvcImpl) {
 			log.Fatalf("[MockedDepartureTime]create fail. No data.")
 		}
 	} else {
-		MockedStartStation = RandomProvincialCapitalEN()
+		MockedStartStation = transfer.From
 	}
 
 	// MockedTrainType
@@ -412,8 +416,8 @@ func (o *TravelplanBehavior) Run(cli *service.SvcImpl) {
 		//MockedTripIDName := faker.Word()
 		MockedTrainTypeName := faker.Word()
 		MockedRouteID := faker.UUIDHyphenated()
-		MockedStartStationName := RandomProvincialCapitalEN()
-		MockedTerminalStationName := RandomProvincialCapitalEN()
+		MockedStartStationName := journey.From
+		MockedTerminalStationName := journey.To
 		MockedStationsName := MockedStartStationName + ", " + MockedTerminalStationName
 		MockedStartTime := faker.Date()
 		MockedEndTime := faker.Date()
@@ -482,8 +486,8 @@ func (o *TravelplanBehavior) Run(cli *service.SvcImpl) {
 		//MockedTripIDName := faker.Word()
 		MockedTrainTypeName := faker.Word()
 		MockedRouteID := faker.UUIDHyphenated()
-		MockedStartStationName := RandomProvincialCapitalEN()
-		MockedTerminalStationName := RandomProvincialCapitalEN()
+		MockedStartStationName := journey.From
+		MockedTerminalStationName := journey.To
 		MockedStationsName := MockedStartStationName + ", " + MockedTerminalStationName
 		MockedStartTime := faker.Date()
 		MockedEndTime := faker.Date()
@@ -546,8 +550,8 @@ func (o *TravelplanBehavior) Run(cli *service.SvcImpl) {
 		//MockedTripIDName := faker.Word()
 		MockedTrainTypeName := faker.Word()
 		MockedRouteID := faker.UUIDHyphenated()
-		MockedStartStationName := RandomProvincialCapitalEN()
-		MockedTerminalStationName := RandomProvincialCapitalEN()
+		MockedStartStationName := journey.From
+		MockedTerminalStationName := journey.To
 		MockedStationsName := MockedStartStationName + ", " + MockedTerminalStationName
 		MockedStartTime := faker.Date()
 		MockedEndTime := faker.Date()
@@ -580,7 +584,7 @@ func (o *TravelplanBehavior) Run(cli *service.SvcImpl) {
 			log.Fatalf("[MockedTrainType]create fail. No data.")
 		}
 	} else {
-		MockedViaStation = RandomProvincialCapitalEN()
+		MockedViaStation = transfer.Via
 	}
 
 	// Mock input
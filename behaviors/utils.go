@@ -2,10 +2,13 @@ package behaviors
 
 import (
 	"fmt"
+	"log"
 	"math/rand"
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/Lincyaw/loadgenerator/service"
 )
 
 func getMiddleElements(input string) string {
@@ -48,19 +51,6 @@ func BooleanIsWithin(value float64) bool {
 	return value <= 7.0
 }
 
-// generateVerifyCode generates a 6-digit verification code consisting of letters and numbers.
-func generateVerifyCode() string {
-	const charset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
-	const length = 6
-	var seededRand *rand.Rand = rand.New(rand.NewSource(time.Now().UnixNano()))
-
-	code := make([]byte, length)
-	for i := range code {
-		code[i] = charset[seededRand.Intn(len(charset))]
-	}
-	return string(code)
-}
-
 func generateTrainTypeName(input string) string {
 	startLetter := strings.ToUpper(string(input[0]))
 
@@ -88,6 +78,19 @@ func generateTrainTypeName(input string) string {
 	return MockedTrainType
 }
 
+// isHighSpeedTrip reports whether a trip is a high-speed (G/D) trip. Other trips (Z/T/K) are
+// served by travel2-service, order-other-service and preserve-other-service.
+func isHighSpeedTrip(tripId string) bool {
+	if tripId == "" {
+		return false
+	}
+	switch strings.ToUpper(tripId[:1]) {
+	case "G", "D":
+		return true
+	}
+	return false
+}
+
 // generateDocumentNumber generates a DocumentNumber with 50% probability for "DocumentNumber_One"
 // and 50% probability for "DocumentNumber_Two".
 func generateDocumentNumber() string {
@@ -256,21 +259,6 @@ func RandomDecimalStringBetween(min, max int) string {
 	return strconv.FormatFloat(decimalValue, 'f', 1, 64) // 转换为一位小数的字符串形式
 }
 
-// RandomProvincialCapitalEN 随机返回一个中国省会城市的英文名称
-func RandomProvincialCapitalEN() string {
-	rand.Seed(time.Now().UnixNano())
-	return provincialCapitalsEN[rand.Intn(len(provincialCapitalsEN))]
-}
-
-// 中国省会城市的英文列表
-var provincialCapitalsEN = []string{
-	"Beijing", "Shanghai", "Tianjin", "Chongqing",
-	"Shijiazhuang", "Taiyuan", "Hohhot", "Shenyang", "Changchun", "Harbin",
-	"Nanjing", "Hangzhou", "Hefei", "Fuzhou", "Nanchang", "Jinan", "Zhengzhou", "Wuhan", "Changsha", "Guangzhou",
-	"Nanning", "Haikou", "Chengdu", "Guiyang", "Kunming", "Lhasa", "Xi'an", "Lanzhou", "Xining", "Yinchuan",
-	"Urumqi", "Taipei",
-}
-
 // GetTrainTicketClass 随机返回高铁票等级。
 // 有5%的概率返回"FirstClass"（头等座），
 // 15%的概率返回"BusinessClass"（一等座），
@@ -303,23 +291,27 @@ func GenerateTrainTypeName() string {
 	return MockedTrainTypeName
 }
 
-// generateRandomCityName generates a random city name from a predefined list of city names.
-func generateRandomCityName() string {
-	// Predefined list of city names
-	cityNames := []string{
-		"nanjing", "shijiazhuang", "wuxi", "shanghaihongqiao", "jiaxingnan",
-		"hangzhou", "shanghai", "zhenjiang", "suzhou", "taiyuan",
-		"xuzhou", "jinan", "beijing",
+// legacyNetwork builds the Network of the deployed routes and trips for the behaviours that run
+// without a chain, and so without the shared Catalog.
+func legacyNetwork(cli service.Client) *Network {
+	routes, err := cli.QueryAllRoutes()
+	if err != nil {
+		log.Fatalf("[legacyNetwork]QueryAllRoutes error occurs: %v", err)
 	}
+	trips, err := cli.QueryAllTrip()
+	if err != nil {
+		log.Fatalf("[legacyNetwork]QueryAllTrip error occurs: %v", err)
+	}
+	return NewNetwork(routes, trips)
+}
 
-	// Seed the random number generator to ensure different results each run
-	rand.Seed(time.Now().UnixNano())
-
-	// Generate a random index to pick a city name
-	randomIndex := rand.Intn(len(cityNames))
-
-	// Return the randomly selected city name
-	return cityNames[randomIndex]
+// sampleJourney returns a journey drawn by sample, one of the Network samplers.
+func sampleJourney(sample func() (Journey, bool)) Journey {
+	journey, ok := sample()
+	if !ok {
+		log.Fatalf("[sampleJourney]no reachable stations in the deployed routes and trips")
+	}
+	return journey
 }
 
 func ListToString(stations []string) string {
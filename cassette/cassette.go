@@ -0,0 +1,469 @@
+// Package cassette records the HTTP calls of a test to a file and replays them offline.
+//
+// A cassette wraps the transport of an httpclient.HttpClient. In Record mode it passes requests on and saves each
+// request/response pair; in Replay mode it answers every request with the recorded response of the
+// first unused interaction whose request matches, without sending anything.
+//
+// Requests are matched on method, path, query and JSON body after masking volatile values (UUIDs, hex
+// ids, dates, times, epoch timestamps and JWTs), so ids generated by the test or handed out by the
+// backend, dates relative to today and login tokens do not break a replay. A request without such a
+// match takes the first unused interaction with the same shape: the same method, the same literal path
+// segments and the same JSON fields and value types, whatever the values. This covers random test data
+// such as faker names. A request that matches no shape either is not in the cassette, which makes it
+// stale.
+package cassette
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Lincyaw/loadgenerator/httpclient"
+)
+
+// Mode selects whether a cassette records or replays.
+type Mode int
+
+const (
+	// Replay answers requests from the cassette file.
+	Replay Mode = iota
+	// Record sends requests to the backend and saves them to the cassette file.
+	Record
+)
+
+// ParseMode parses "record" or "replay".
+func ParseMode(s string) (Mode, error) {
+	switch s {
+	case "record":
+		return Record, nil
+	case "replay":
+		return Replay, nil
+	}
+	return 0, fmt.Errorf("invalid cassette mode %q, expected record or replay", s)
+}
+
+func (m Mode) String() string {
+	if m == Record {
+		return "record"
+	}
+	return "replay"
+}
+
+// ErrNoInteraction is returned in Replay mode for a request that matches no unused interaction.
+var ErrNoInteraction = errors.New("no recorded interaction")
+
+// Interaction is a recorded request/response pair.
+type Interaction struct {
+	Method          string              `json:"method"`
+	URL             string              `json:"url"`
+	RequestBody     string              `json:"request_body,omitempty"`
+	StatusCode      int                 `json:"status_code"`
+	ResponseHeaders map[string][]string `json:"response_headers,omitempty"`
+	ResponseBody    string              `json:"response_body,omitempty"`
+}
+
+// file is the on-disk format of a cassette.
+type file struct {
+	Name         string        `json:"name"`
+	RecordedAt   time.Time     `json:"recorded_at"`
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Cassette records or replays the interactions of one test. It is safe for concurrent use.
+type Cassette struct {
+	path   string
+	mode   Mode
+	ignore map[string]bool
+	maxAge time.Duration
+	now    func() time.Time
+
+	mu         sync.Mutex
+	file       file
+	keys       []string
+	shapes     []string
+	used       []bool
+	misses     []string
+	recordErrs []string
+}
+
+// Option configures a Cassette.
+type Option func(*Cassette)
+
+// WithIgnoreFields masks the values of these JSON fields, at any depth, when matching request bodies,
+// e.g. fields the test fills with random data.
+func WithIgnoreFields(names ...string) Option {
+	return func(c *Cassette) {
+		for _, name := range names {
+			c.ignore[name] = true
+		}
+	}
+}
+
+// WithMaxAge makes Stale report cassettes recorded longer than d ago.
+func WithMaxAge(d time.Duration) Option {
+	return func(c *Cassette) {
+		c.maxAge = d
+	}
+}
+
+// Open opens the cassette at path. In Replay mode the file is read and must exist; the returned error
+// then satisfies errors.Is(err, fs.ErrNotExist) when it doesn't. In Record mode the cassette starts
+// empty and is written by Save.
+func Open(path string, mode Mode, opts ...Option) (*Cassette, error) {
+	c := &Cassette{
+		path:   path,
+		mode:   mode,
+		ignore: make(map[string]bool),
+		now:    time.Now,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	if mode == Record {
+		c.file = file{Name: name, RecordedAt: c.now().UTC()}
+		return c, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &c.file); err != nil {
+		return nil, fmt.Errorf("parse cassette %s: %w", path, err)
+	}
+	c.keys = make([]string, len(c.file.Interactions))
+	c.shapes = make([]string, len(c.file.Interactions))
+	for i, interaction := range c.file.Interactions {
+		c.keys[i] = c.key(interaction.Method, interaction.URL, interaction.RequestBody)
+		c.shapes[i] = shape(interaction.Method, interaction.URL, interaction.RequestBody)
+	}
+	c.used = make([]bool, len(c.file.Interactions))
+	return c, nil
+}
+
+// Mode returns the mode the cassette was opened in.
+func (c *Cassette) Mode() Mode {
+	return c.mode
+}
+
+// Transport records or replays requests; pass it to httpclient.WithTransport. It sits below the client's
+// retries, so every attempt of a retried request is an interaction of its own, and below the cookie jar, so
+// the cookies set by a replayed response are kept for the following requests. Replayed responses still go
+// through all middlewares: the token manager, statistics and breaker.
+func (c *Cassette) Transport(next http.RoundTripper) http.RoundTripper {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		body := requestBody(req)
+		if c.mode == Replay {
+			return c.replay(req, body)
+		}
+		resp, err := next.RoundTrip(req)
+		if err != nil {
+			// Transport errors can't be replayed; the test sees them while recording.
+			c.mu.Lock()
+			c.recordErrs = append(c.recordErrs, fmt.Sprintf("%s %s: %v", req.Method, req.URL.RequestURI(), err))
+			c.mu.Unlock()
+			return nil, err
+		}
+		respBody, err := httpclient.ReadResponseBody(resp)
+		if err != nil {
+			return nil, err
+		}
+		c.mu.Lock()
+		c.file.Interactions = append(c.file.Interactions, Interaction{
+			Method:          req.Method,
+			URL:             req.URL.RequestURI(),
+			RequestBody:     body,
+			StatusCode:      resp.StatusCode,
+			ResponseHeaders: responseHeaders(resp.Header),
+			ResponseBody:    string(respBody),
+		})
+		c.mu.Unlock()
+		return resp, nil
+	})
+}
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func (c *Cassette) replay(req *http.Request, body string) (*http.Response, error) {
+	key := c.key(req.Method, req.URL.RequestURI(), body)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	i := c.next(c.keys, key)
+	if i < 0 {
+		i = c.next(c.shapes, shape(req.Method, req.URL.RequestURI(), body))
+	}
+	if i >= 0 {
+		c.used[i] = true
+		interaction := c.file.Interactions[i]
+		header := http.Header{}
+		for key, values := range interaction.ResponseHeaders {
+			header[key] = values
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.StatusCode, http.StatusText(interaction.StatusCode)),
+			StatusCode:    interaction.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(strings.NewReader(interaction.ResponseBody)),
+			ContentLength: int64(len(interaction.ResponseBody)),
+			Request:       req,
+		}, nil
+	}
+	miss := req.Method + " " + req.URL.RequestURI()
+	if body != "" {
+		miss += " " + body
+	}
+	c.misses = append(c.misses, miss)
+	return nil, fmt.Errorf("cassette %s: %w for %s", c.file.Name, ErrNoInteraction, miss)
+}
+
+// next returns the first unused interaction whose entry in keys is key, or -1.
+func (c *Cassette) next(keys []string, key string) int {
+	for i, k := range keys {
+		if !c.used[i] && k == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// Save writes the recorded interactions to the cassette file, creating its directory. It does nothing
+// in Replay mode.
+func (c *Cassette) Save() error {
+	if c.mode != Record {
+		return nil
+	}
+	c.mu.Lock()
+	data, err := json.MarshalIndent(c.file, "", "  ")
+	c.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(c.path, append(data, '\n'), 0644)
+}
+
+// Stale reports why the cassette no longer matches the code: requests that matched no interaction,
+// interactions that were never requested and, with WithMaxAge, the age of the recording. In Record
+// mode it reports requests that failed without a response and so are missing from the cassette.
+// Call it after the test finished.
+func (c *Cassette) Stale() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.mode == Record {
+		var problems []string
+		for _, e := range c.recordErrs {
+			problems = append(problems, "not recorded, no response: "+e)
+		}
+		return problems
+	}
+	var problems []string
+	for _, miss := range c.misses {
+		problems = append(problems, "request not in cassette: "+miss)
+	}
+	for i, used := range c.used {
+		if !used {
+			interaction := c.file.Interactions[i]
+			problems = append(problems, fmt.Sprintf("interaction %d never requested: %s %s", i, interaction.Method, interaction.URL))
+		}
+	}
+	if age := c.now().Sub(c.file.RecordedAt); c.maxAge > 0 && age > c.maxAge {
+		problems = append(problems, fmt.Sprintf("recorded %s ago at %s, older than %s",
+			age.Round(time.Hour), c.file.RecordedAt.Format(time.DateOnly), c.maxAge))
+	}
+	return problems
+}
+
+// responseHeaders returns the headers worth replaying, e.g. Content-Type and Set-Cookie, without the
+// ones that change every response or no longer hold for the recorded body.
+func responseHeaders(header http.Header) map[string][]string {
+	headers := make(map[string][]string, len(header))
+	for key, values := range header {
+		switch key {
+		case "Date", "Content-Length", "Transfer-Encoding", "Connection", "Keep-Alive":
+			continue
+		}
+		headers[key] = values
+	}
+	return headers
+}
+
+// requestBody returns the serialized body that SendRequest put into the request context.
+func requestBody(req *http.Request) string {
+	if info := httpclient.RequestInfoFromContext(req.Context()); info != nil {
+		return string(info.RequestBody)
+	}
+	if req.GetBody == nil {
+		return ""
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return ""
+	}
+	defer body.Close()
+	data, _ := io.ReadAll(body)
+	return string(data)
+}
+
+// key is the normalized form of a request that interactions are matched on.
+func (c *Cassette) key(method, uri, body string) string {
+	return method + " " + c.normalizeURI(uri) + "\n" + c.normalizeBody(body)
+}
+
+func (c *Cassette) normalizeURI(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return mask(uri)
+	}
+	segments := strings.Split(u.EscapedPath(), "/")
+	for i, segment := range segments {
+		if unescaped, err := url.PathUnescape(segment); err == nil {
+			segment = unescaped
+		}
+		segments[i] = mask(segment)
+	}
+	normalized := strings.Join(segments, "/")
+	if u.RawQuery == "" {
+		return normalized
+	}
+	query := u.Query()
+	for name, values := range query {
+		for i, value := range values {
+			if c.ignore[name] {
+				values[i] = "<ignored>"
+			} else {
+				values[i] = mask(value)
+			}
+		}
+	}
+	// Encode sorts by name, so the order of query parameters doesn't matter.
+	return normalized + "?" + query.Encode()
+}
+
+func (c *Cassette) normalizeBody(body string) string {
+	var v interface{}
+	if err := json.Unmarshal([]byte(body), &v); err != nil {
+		return mask(body)
+	}
+	// Marshal sorts object keys, so the field order doesn't matter either.
+	data, _ := json.Marshal(c.normalizeValue(v))
+	return string(data)
+}
+
+func (c *Cassette) normalizeValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for name, value := range v {
+			if c.ignore[name] {
+				v[name] = "<ignored>"
+			} else {
+				v[name] = c.normalizeValue(value)
+			}
+		}
+		return v
+	case []interface{}:
+		for i, value := range v {
+			v[i] = c.normalizeValue(value)
+		}
+		return v
+	case string:
+		return mask(v)
+	case float64:
+		// Epoch milliseconds between 2001 and 2286.
+		if v >= 1e12 && v < 1e13 {
+			return "<timestamp>"
+		}
+	}
+	return v
+}
+
+// literal matches path segments that name an endpoint rather than carry a value.
+var literal = regexp.MustCompile(`^[A-Za-z_-]*$`)
+
+// shape is the form of a request without its values: path segments that aren't literal are replaced
+// by {}, query values dropped and JSON values replaced by their type.
+func shape(method, uri, body string) string {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return method + " " + uri + "\n" + body
+	}
+	segments := strings.Split(u.Path, "/")
+	for i, segment := range segments {
+		if !literal.MatchString(segment) {
+			segments[i] = "{}"
+		}
+	}
+	var names []string
+	for name := range u.Query() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var v interface{}
+	if err := json.Unmarshal([]byte(body), &v); err != nil {
+		v = "raw"
+	}
+	data, _ := json.Marshal(typeOf(v))
+	return method + " " + strings.Join(segments, "/") + "?" + strings.Join(names, "&") + "\n" + string(data)
+}
+
+// typeOf replaces the values in v by their JSON type.
+func typeOf(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for name, value := range v {
+			v[name] = typeOf(value)
+		}
+		return v
+	case []interface{}:
+		for i, value := range v {
+			v[i] = typeOf(value)
+		}
+		return v
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "bool"
+	}
+	return "null"
+}
+
+// volatile are the patterns of values that differ between runs of the same test, most specific first.
+var volatile = []struct {
+	pattern     *regexp.Regexp
+	placeholder string
+}{
+	{regexp.MustCompile(`eyJ[\w-]*\.[\w-]*\.[\w-]*`), "<jwt>"},
+	{regexp.MustCompile(`(?i)[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}`), "<uuid>"},
+	{regexp.MustCompile(`(?i)\b[0-9a-f]{32}\b`), "<id>"},
+	{regexp.MustCompile(`\b\d{4}-\d{2}-\d{2}(?:[ T]\d{2}:\d{2}(?::\d{2}(?:\.\d+)?)?(?:Z|[+-]\d{2}:?\d{2})?)?`), "<date>"},
+	{regexp.MustCompile(`\b\d{2}:\d{2}:\d{2}\b`), "<time>"},
+	{regexp.MustCompile(`\b1\d{12}\b`), "<timestamp>"},
+}
+
+// mask replaces the volatile values in s by placeholders.
+func mask(s string) string {
+	for _, v := range volatile {
+		s = v.pattern.ReplaceAllString(s, v.placeholder)
+	}
+	return s
+}
@@ -0,0 +1,225 @@
+package cassette
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Lincyaw/loadgenerator/httpclient"
+)
+
+// newBackend returns a server that creates orders with a fresh id and counts the calls it receives.
+func newBackend() (*httptest.Server, *atomic.Int64) {
+	calls := &atomic.Int64{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := calls.Add(1)
+		body, _ := io.ReadAll(r.Body)
+		switch {
+		case r.URL.Path == "/api/v1/users/login":
+			fmt.Fprintf(w, `{"status":1,"data":{"token":"eyJhbGciOiJIUzI1NiJ9.eyJzdWIiOiIlZCJ9.sig%d"}}`, n)
+		case r.Method == "POST":
+			fmt.Fprintf(w, `{"status":1,"data":{"id":"order-%d","request":%s}}`, n, body)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"status":0,"msg":"not found"}`))
+		}
+	}))
+	return server, calls
+}
+
+// session sends the calls of a test run; id and date differ between runs.
+func session(c *httpclient.HttpClient, base, id, date string) []string {
+	var bodies []string
+	for _, call := range []struct {
+		method, path string
+		body         interface{}
+	}{
+		{"POST", "/api/v1/users/login", map[string]string{"username": "fdse", "password": "111111"}},
+		{"POST", "/api/v1/orderservice/order", map[string]string{"id": id, "travelDate": date, "from": "shanghai"}},
+		{"GET", "/api/v1/orderservice/order/" + id + "?date=" + date, nil},
+	} {
+		resp, err := c.SendRequest(call.method, base+call.path, call.body)
+		if err != nil {
+			bodies = append(bodies, err.Error())
+			continue
+		}
+		body, _ := httpclient.ReadResponseBody(resp)
+		bodies = append(bodies, fmt.Sprintf("%d %s", resp.StatusCode, body))
+	}
+	return bodies
+}
+
+func TestCassette_RecordAndReplay(t *testing.T) {
+	backend, calls := newBackend()
+	defer backend.Close()
+	path := filepath.Join(t.TempDir(), "cassettes", "TestOrder.json")
+
+	recording, err := Open(path, Record)
+	if err != nil {
+		t.Fatal(err)
+	}
+	recorded := session(httpclient.NewCustomClient(httpclient.WithTransport(recording.Transport)),
+		backend.URL, "4bd6e5a2-2b1a-4fa5-b0b8-0e6f8c9a4d11", "2024-06-06")
+	if err := recording.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	if n := calls.Load(); n != 3 {
+		t.Fatalf("backend got %d calls while recording, want 3", n)
+	}
+
+	// Replay with a different generated id and date: the recorded responses come back, nothing is sent.
+	replaying, err := Open(path, Replay)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	replayed := session(httpclient.NewCustomClient(httpclient.WithTransport(replaying.Transport)),
+		"http://127.0.0.1:1", "9c3e1f70-55aa-4c1e-8d2b-1f2e3d4c5b6a", "2031-01-02")
+	if strings.Join(replayed, "\n") != strings.Join(recorded, "\n") {
+		t.Errorf("replayed responses\n%s\nwant\n%s", strings.Join(replayed, "\n"), strings.Join(recorded, "\n"))
+	}
+	if n := calls.Load(); n != 3 {
+		t.Errorf("backend got %d calls after replaying, want 3", n)
+	}
+	if stale := replaying.Stale(); len(stale) != 0 {
+		t.Errorf("Stale() = %v, want none", stale)
+	}
+}
+
+func TestCassette_Stale(t *testing.T) {
+	backend, _ := newBackend()
+	defer backend.Close()
+	path := filepath.Join(t.TempDir(), "TestOrder.json")
+	recording, _ := Open(path, Record)
+	session(httpclient.NewCustomClient(httpclient.WithTransport(recording.Transport)), backend.URL, "order-1", "2024-06-06")
+	recording.Save()
+
+	// The code now sends "to" instead of "from" and no longer queries the order.
+	replaying, _ := Open(path, Replay, WithMaxAge(time.Hour))
+	replaying.now = func() time.Time { return time.Now().Add(48 * time.Hour) }
+	c := httpclient.NewCustomClient(httpclient.WithTransport(replaying.Transport))
+	c.SendRequest("POST", backend.URL+"/api/v1/users/login", map[string]string{"username": "fdse", "password": "111111"})
+	_, err := c.SendRequest("POST", backend.URL+"/api/v1/orderservice/order", map[string]string{"id": "order-1", "travelDate": "2024-06-06", "to": "shanghai"})
+	if !errors.Is(err, ErrNoInteraction) {
+		t.Errorf("changed request err = %v, want ErrNoInteraction", err)
+	}
+
+	stale := replaying.Stale()
+	want := []string{
+		`request not in cassette: POST /api/v1/orderservice/order {"id":"order-1","to":"shanghai","travelDate":"2024-06-06"}`,
+		"interaction 1 never requested: POST /api/v1/orderservice/order",
+		"interaction 2 never requested: GET /api/v1/orderservice/order/order-1?date=2024-06-06",
+		"recorded 48h0m0s ago",
+	}
+	if len(stale) != len(want) {
+		t.Fatalf("Stale() = %q, want %d problems", stale, len(want))
+	}
+	for i := range want {
+		if !strings.HasPrefix(stale[i], want[i]) {
+			t.Errorf("Stale()[%d] = %q, want prefix %q", i, stale[i], want[i])
+		}
+	}
+}
+
+func TestCassette_OpenMissing(t *testing.T) {
+	_, err := Open(filepath.Join(t.TempDir(), "missing.json"), Replay)
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("err = %v, want fs.ErrNotExist", err)
+	}
+}
+
+func TestCassette_Matching(t *testing.T) {
+	c := &Cassette{ignore: map[string]bool{"name": true}}
+	tests := []struct {
+		name   string
+		a, b   [3]string
+		equals bool
+	}{
+		{"uuid in path", [3]string{"GET", "/a/4bd6e5a2-2b1a-4fa5-b0b8-0e6f8c9a4d11", ""}, [3]string{"GET", "/a/9C3E1F70-55AA-4C1E-8D2B-1F2E3D4C5B6A", ""}, true},
+		{"hex id in path", [3]string{"GET", "/a/8a80811d9031564e0190366bc1950000", ""}, [3]string{"GET", "/a/ff80811d9031564e0190366bc1950001", ""}, true},
+		{"query order and date", [3]string{"GET", "/a?x=1&d=2024-06-06", ""}, [3]string{"GET", "/a?d=2030-01-01&x=1", ""}, true},
+		{"field order, datetime, timestamp and token", [3]string{"POST", "/a", `{"at":"2024-06-06 09:00:00","ms":1717635600000,"t":"eyJa.eyJb.c"}`}, [3]string{"POST", "/a", `{"t":"eyJx.eyJy.z","ms":1893456000000,"at":"2030-01-01T10:11:12Z"}`}, true},
+		{"ignored field", [3]string{"POST", "/a", `{"name":"Ann","age":1}`}, [3]string{"POST", "/a", `{"name":"Bob","age":1}`}, true},
+		{"other value", [3]string{"POST", "/a", `{"from":"shanghai"}`}, [3]string{"POST", "/a", `{"from":"nanjing"}`}, false},
+		{"other method", [3]string{"GET", "/a", ""}, [3]string{"DELETE", "/a", ""}, false},
+		{"other path", [3]string{"GET", "/trips/G1234", ""}, [3]string{"GET", "/trips/D1345", ""}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := c.key(tt.a[0], tt.a[1], tt.a[2]), c.key(tt.b[0], tt.b[1], tt.b[2])
+			if (a == b) != tt.equals {
+				t.Errorf("keys %q and %q, want equal = %v", a, b, tt.equals)
+			}
+		})
+	}
+}
+
+func TestCassette_Shape(t *testing.T) {
+	tests := []struct {
+		name   string
+		a, b   [3]string
+		equals bool
+	}{
+		{"random values", [3]string{"POST", "/a", `{"name":"Ms. Breana Berge","seat":2}`}, [3]string{"POST", "/a", `{"name":"Lord Erling Berge","seat":5}`}, true},
+		{"value in path", [3]string{"GET", "/users/Prof.%20Shyanne%20Windler", ""}, [3]string{"GET", "/users/King%20Keshawn", ""}, true},
+		{"trip id in path", [3]string{"GET", "/trips/G1234", ""}, [3]string{"GET", "/trips/D1345", ""}, true},
+		{"query values", [3]string{"GET", "/a?name=x", ""}, [3]string{"GET", "/a?name=y", ""}, true},
+		{"other field", [3]string{"POST", "/a", `{"from":"shanghai"}`}, [3]string{"POST", "/a", `{"to":"shanghai"}`}, false},
+		{"other type", [3]string{"POST", "/a", `{"seat":"2"}`}, [3]string{"POST", "/a", `{"seat":2}`}, false},
+		{"other endpoint", [3]string{"GET", "/users/id/x1", ""}, [3]string{"GET", "/users/name/x1", ""}, false},
+		{"other query", [3]string{"GET", "/a?name=x", ""}, [3]string{"GET", "/a?id=x", ""}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := shape(tt.a[0], tt.a[1], tt.a[2]), shape(tt.b[0], tt.b[1], tt.b[2])
+			if (a == b) != tt.equals {
+				t.Errorf("shapes %q and %q, want equal = %v", a, b, tt.equals)
+			}
+		})
+	}
+}
+
+func TestCassette_ReplaysCookies(t *testing.T) {
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/verifycode/generate" {
+			http.SetCookie(w, &http.Cookie{Name: "YsbCaptcha", Value: "session-1", Path: "/"})
+			return
+		}
+		cookie, err := r.Cookie("YsbCaptcha")
+		fmt.Fprint(w, err == nil && cookie.Value == "session-1")
+	}))
+	defer backend.Close()
+	path := filepath.Join(t.TempDir(), "TestVerifyCode.json")
+	verify := func(c *httpclient.HttpClient, base string) string {
+		c.SendRequest("GET", base+"/api/v1/verifycode/generate", nil)
+		resp, err := c.SendRequest("GET", base+"/api/v1/verifycode/verify/1234", nil)
+		if err != nil {
+			return err.Error()
+		}
+		body, _ := httpclient.ReadResponseBody(resp)
+		return string(body)
+	}
+
+	recording, _ := Open(path, Record)
+	if got := verify(httpclient.NewCustomClient(httpclient.WithCookieJar(), httpclient.WithTransport(recording.Transport)), backend.URL); got != "true" {
+		t.Fatalf("recorded verify = %s, want true", got)
+	}
+	recording.Save()
+
+	// The recorded Set-Cookie reaches the client's jar as it did while recording.
+	replaying, _ := Open(path, Replay)
+	c := httpclient.NewCustomClient(httpclient.WithCookieJar(), httpclient.WithTransport(replaying.Transport))
+	if got := verify(c, "http://127.0.0.1:1"); got != "true" {
+		t.Errorf("replayed verify = %s, want true", got)
+	}
+	if cookies := c.Cookies("http://127.0.0.1:1/"); len(cookies) != 1 || cookies[0].Value != "session-1" {
+		t.Errorf("Cookies() after replay = %v, want YsbCaptcha=session-1", cookies)
+	}
+}
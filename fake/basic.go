@@ -0,0 +1,373 @@
+package fake
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+func (s *Server) registerBasic() {
+	for _, prefix := range []string{"/api/v1/stationservice/stations", "/api/v1/adminbasicservice/adminbasic/stations"} {
+		s.handle("GET", prefix, func(w http.ResponseWriter, r *http.Request, _ params) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			ok(w, "Find all content", s.store.stations)
+		})
+		s.handle("POST", prefix, s.createStation)
+		s.handle("PUT", prefix, s.updateStation)
+		s.handle("DELETE", prefix+"/{stationId}", s.deleteStation)
+	}
+	s.handle("POST", "/api/v1/stationservice/stations/idlist", func(w http.ResponseWriter, r *http.Request, _ params) {
+		var names []string
+		if !decode(w, r, &names) {
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		ids := make(map[string]string, len(names))
+		for _, name := range names {
+			if station := s.stationByName(name); station != nil {
+				ids[name] = station.Id
+			}
+		}
+		ok(w, "Success", ids)
+	})
+	s.handle("POST", "/api/v1/stationservice/stations/namelist", func(w http.ResponseWriter, r *http.Request, _ params) {
+		var ids []string
+		if !decode(w, r, &ids) {
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		names := []string{}
+		for _, id := range ids {
+			if station := find(s.store.stations, func(st *Station) bool { return st.Id == id }); station != nil {
+				names = append(names, station.Name)
+			}
+		}
+		ok(w, "Success", names)
+	})
+	s.handle("GET", "/api/v1/stationservice/stations/id/{stationName}", func(w http.ResponseWriter, r *http.Request, p params) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		station := s.stationByName(p["stationName"])
+		if station == nil {
+			fail(w, "Not exists")
+			return
+		}
+		ok(w, "Success", station.Id)
+	})
+	s.handle("GET", "/api/v1/stationservice/stations/name/{stationId}", func(w http.ResponseWriter, r *http.Request, p params) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		station := find(s.store.stations, func(st *Station) bool { return st.Id == p["stationId"] })
+		if station == nil {
+			fail(w, "No that stationId")
+			return
+		}
+		ok(w, "Success", station.Name)
+	})
+
+	for _, prefix := range []string{"/api/v1/trainservice/trains", "/api/v1/adminbasicservice/adminbasic/trains"} {
+		s.handle("GET", prefix, func(w http.ResponseWriter, r *http.Request, _ params) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			ok(w, "success", s.store.trains)
+		})
+		s.handle("POST", prefix, s.createTrain)
+		s.handle("PUT", prefix, s.updateTrain)
+		s.handle("DELETE", prefix+"/{id}", s.deleteTrain)
+	}
+	s.handle("POST", "/api/v1/trainservice/trains/byNames", func(w http.ResponseWriter, r *http.Request, _ params) {
+		var names []string
+		if !decode(w, r, &names) {
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		ok(w, "success", filter(s.store.trains, func(t *TrainType) bool {
+			for _, name := range names {
+				if t.Name == name {
+					return true
+				}
+			}
+			return false
+		}))
+	})
+	s.handle("GET", "/api/v1/trainservice/trains/byName/{name}", func(w http.ResponseWriter, r *http.Request, p params) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if train := s.trainByName(p["name"]); train != nil {
+			ok(w, "success", train)
+			return
+		}
+		fail(w, "here is no TrainType with the trainType name: "+p["name"])
+	})
+	s.handle("GET", "/api/v1/trainservice/trains/{id}", func(w http.ResponseWriter, r *http.Request, p params) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if train := find(s.store.trains, func(t *TrainType) bool { return t.Id == p["id"] }); train != nil {
+			ok(w, "success", train)
+			return
+		}
+		fail(w, "here is no TrainType with the trainType id: "+p["id"])
+	})
+
+	s.handle("GET", "/api/v1/routeservice/routes", func(w http.ResponseWriter, r *http.Request, _ params) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		ok(w, "Success", s.store.routes)
+	})
+	s.handle("POST", "/api/v1/routeservice/routes", func(w http.ResponseWriter, r *http.Request, _ params) {
+		var req routeInfo
+		if !decode(w, r, &req) {
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		route, msg := s.saveRoute(req)
+		if route == nil {
+			fail(w, msg)
+			return
+		}
+		ok(w, msg, route)
+	})
+	s.handle("POST", "/api/v1/routeservice/routes/byIds", func(w http.ResponseWriter, r *http.Request, _ params) {
+		var ids []string
+		if !decode(w, r, &ids) {
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		routes := filter(s.store.routes, func(route *Route) bool {
+			for _, id := range ids {
+				if route.Id == id {
+					return true
+				}
+			}
+			return false
+		})
+		if len(routes) == 0 {
+			fail(w, "No content with the routeIds")
+			return
+		}
+		ok(w, "Success", routes)
+	})
+	s.handle("DELETE", "/api/v1/routeservice/routes/{routeId}", func(w http.ResponseWriter, r *http.Request, p params) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if !remove(&s.store.routes, func(route *Route) bool { return route.Id == p["routeId"] }) {
+			fail(w, "Delete failed, Reason unKnown with this routeId")
+			return
+		}
+		ok(w, "Delete Success", p["routeId"])
+	})
+	s.handle("GET", "/api/v1/routeservice/routes/{routeId}", func(w http.ResponseWriter, r *http.Request, p params) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if route := s.routeById(p["routeId"]); route != nil {
+			ok(w, "Success", route)
+			return
+		}
+		fail(w, "No content with the routeId")
+	})
+	s.handle("GET", "/api/v1/routeservice/routes/{start}/{end}", func(w http.ResponseWriter, r *http.Request, p params) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		routes := filter(s.store.routes, func(route *Route) bool {
+			_, ok := route.distance(p["start"], p["end"])
+			return ok
+		})
+		if len(routes) == 0 {
+			fail(w, "No routes with the startId and terminalId")
+			return
+		}
+		ok(w, "Success", routes)
+	})
+
+	s.handle("GET", "/api/v1/adminrouteservice/adminroute", func(w http.ResponseWriter, r *http.Request, _ params) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		ok(w, "Success", s.store.routes)
+	})
+	s.handle("POST", "/api/v1/adminrouteservice/adminroute", func(w http.ResponseWriter, r *http.Request, _ params) {
+		var req routeInfo
+		if !decode(w, r, &req) {
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if route, msg := s.saveRoute(req); route == nil {
+			fail(w, msg)
+			return
+		}
+		ok(w, "Success", nil)
+	})
+	s.handle("DELETE", "/api/v1/adminrouteservice/adminroute/{routeId}", func(w http.ResponseWriter, r *http.Request, p params) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if !remove(&s.store.routes, func(route *Route) bool { return route.Id == p["routeId"] }) {
+			fail(w, "Delete failed, Reason unKnown with this routeId")
+			return
+		}
+		ok(w, "Delete Success", p["routeId"])
+	})
+
+	s.handle("GET", "/api/v1/basicservice/basic/{stationName}", func(w http.ResponseWriter, r *http.Request, p params) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		station := s.stationByName(p["stationName"])
+		if station == nil {
+			fail(w, "Not exists")
+			return
+		}
+		ok(w, "Success", station.Id)
+	})
+}
+
+func (s *Server) stationByName(name string) *Station {
+	return find(s.store.stations, func(st *Station) bool { return st.Name == name })
+}
+
+func (s *Server) trainByName(name string) *TrainType {
+	return find(s.store.trains, func(t *TrainType) bool { return t.Name == name })
+}
+
+func (s *Server) routeById(id string) *Route {
+	return find(s.store.routes, func(r *Route) bool { return r.Id == id })
+}
+
+// stationName normalizes a station name the way the station and travel services store it.
+func stationName(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, " ", ""))
+}
+
+func (s *Server) createStation(w http.ResponseWriter, r *http.Request, _ params) {
+	var req Station
+	if !decode(w, r, &req) {
+		return
+	}
+	req.Name = stationName(req.Name)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stationByName(req.Name) != nil {
+		fail(w, "Already exists")
+		return
+	}
+	if req.Id == "" {
+		req.Id = uuid.NewString()
+	}
+	s.store.stations = append(s.store.stations, &req)
+	ok(w, "Create success", &req)
+}
+
+func (s *Server) updateStation(w http.ResponseWriter, r *http.Request, _ params) {
+	var req Station
+	if !decode(w, r, &req) {
+		return
+	}
+	req.Name = stationName(req.Name)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	station := find(s.store.stations, func(st *Station) bool { return st.Id == req.Id })
+	if station == nil {
+		fail(w, "Station not exist")
+		return
+	}
+	*station = req
+	ok(w, "Update success", station)
+}
+
+func (s *Server) deleteStation(w http.ResponseWriter, r *http.Request, p params) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	station := find(s.store.stations, func(st *Station) bool { return st.Id == p["stationId"] })
+	if station == nil {
+		fail(w, "Station not exist")
+		return
+	}
+	remove(&s.store.stations, func(st *Station) bool { return st == station })
+	ok(w, "Delete success", station)
+}
+
+func (s *Server) createTrain(w http.ResponseWriter, r *http.Request, _ params) {
+	var req TrainType
+	if !decode(w, r, &req) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.trainByName(req.Name) != nil {
+		fail(w, "train type already exist")
+		return
+	}
+	if req.Id == "" {
+		req.Id = uuid.NewString()
+	}
+	s.store.trains = append(s.store.trains, &req)
+	ok(w, "create success", &req)
+}
+
+func (s *Server) updateTrain(w http.ResponseWriter, r *http.Request, _ params) {
+	var req TrainType
+	if !decode(w, r, &req) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	train := find(s.store.trains, func(t *TrainType) bool { return t.Id == req.Id })
+	if train == nil {
+		fail(w, "there is no trainType with the trainType id")
+		return
+	}
+	*train = req
+	ok(w, "update success", true)
+}
+
+func (s *Server) deleteTrain(w http.ResponseWriter, r *http.Request, p params) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !remove(&s.store.trains, func(t *TrainType) bool { return t.Id == p["id"] }) {
+		fail(w, "there is no train according to id")
+		return
+	}
+	ok(w, "delete success", true)
+}
+
+// routeInfo is the request body of route creation: stations and distances as comma-separated lists.
+type routeInfo struct {
+	Id           string `json:"id"`
+	StartStation string `json:"startStation"`
+	EndStation   string `json:"endStation"`
+	StationList  string `json:"stationList"`
+	DistanceList string `json:"distanceList"`
+}
+
+// saveRoute creates a route, or replaces the one with the same id, and returns it with the response msg.
+func (s *Server) saveRoute(req routeInfo) (*Route, string) {
+	stations := strings.Split(req.StationList, ",")
+	distances := strings.Split(req.DistanceList, ",")
+	if len(stations) != len(distances) {
+		return nil, "Station Number Not Equal To Distance Number"
+	}
+	route := &Route{Id: req.Id, StartStation: req.StartStation, EndStation: req.EndStation}
+	for i := range stations {
+		d, err := strconv.Atoi(strings.TrimSpace(distances[i]))
+		if err != nil {
+			return nil, "Invalid distance " + distances[i]
+		}
+		route.Stations = append(route.Stations, strings.TrimSpace(stations[i]))
+		route.Distances = append(route.Distances, d)
+	}
+	if existing := s.routeById(req.Id); existing != nil && req.Id != "" {
+		*existing = *route
+		return existing, "Modify success"
+	}
+	if route.Id == "" {
+		route.Id = uuid.NewString()
+	}
+	s.store.routes = append(s.store.routes, route)
+	return route, "Save Success"
+}
@@ -0,0 +1,369 @@
+package fake
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Security config names checked by the security service.
+const (
+	maxOrderOneHour = "max_order_1_hour"
+	maxOrderNotUse  = "max_order_not_use"
+)
+
+func (s *Server) registerConfigs() {
+	for _, prefix := range []string{"/api/v1/configservice/configs", "/api/v1/adminbasicservice/adminbasic/configs"} {
+		s.handle("GET", prefix, func(w http.ResponseWriter, r *http.Request, _ params) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			ok(w, "Find all  config success", all(s.store.configs))
+		})
+		s.handle("POST", prefix, func(w http.ResponseWriter, r *http.Request, _ params) {
+			var req Config
+			if !decode(w, r, &req) {
+				return
+			}
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			if s.config(req.Name) != nil {
+				fail(w, "Config "+req.Name+" already exists.")
+				return
+			}
+			s.store.configs = append(s.store.configs, &req)
+			ok(w, "Create success", &req)
+		})
+		s.handle("PUT", prefix, func(w http.ResponseWriter, r *http.Request, _ params) {
+			var req Config
+			if !decode(w, r, &req) {
+				return
+			}
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			config := s.config(req.Name)
+			if config == nil {
+				fail(w, "Config "+req.Name+" doesn't exist.")
+				return
+			}
+			*config = req
+			ok(w, "Update success", config)
+		})
+		s.handle("DELETE", prefix+"/{configName}", func(w http.ResponseWriter, r *http.Request, p params) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			config := s.config(p["configName"])
+			if config == nil {
+				fail(w, "Config "+p["configName"]+" doesn't exist.")
+				return
+			}
+			remove(&s.store.configs, func(c *Config) bool { return c == config })
+			ok(w, "Delete success", config)
+		})
+	}
+	s.handle("GET", "/api/v1/configservice/configs/{configName}", func(w http.ResponseWriter, r *http.Request, p params) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		config := s.config(p["configName"])
+		if config == nil {
+			fail(w, "No content")
+			return
+		}
+		ok(w, "Success", config)
+	})
+
+	const security = "/api/v1/securityservice/securityConfigs"
+	s.handle("GET", security, func(w http.ResponseWriter, r *http.Request, _ params) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		ok(w, "Success", all(s.store.securityConfigs))
+	})
+	s.handle("POST", security, func(w http.ResponseWriter, r *http.Request, _ params) {
+		var req SecurityConfig
+		if !decode(w, r, &req) {
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if s.securityConfig(req.Name) != nil {
+			fail(w, "Security Config Already Exist")
+			return
+		}
+		req.Id = uuid.NewString()
+		s.store.securityConfigs = append(s.store.securityConfigs, &req)
+		ok(w, "Success", &req)
+	})
+	s.handle("PUT", security, func(w http.ResponseWriter, r *http.Request, _ params) {
+		var req SecurityConfig
+		if !decode(w, r, &req) {
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		config := find(s.store.securityConfigs, func(c *SecurityConfig) bool { return c.Id == req.Id })
+		if config == nil {
+			fail(w, "Security Config Not Exist")
+			return
+		}
+		*config = req
+		ok(w, "Success", config)
+	})
+	s.handle("DELETE", security+"/{id}", func(w http.ResponseWriter, r *http.Request, p params) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if !remove(&s.store.securityConfigs, func(c *SecurityConfig) bool { return c.Id == p["id"] }) {
+			fail(w, "Security Config Not Exist")
+			return
+		}
+		ok(w, "Success", p["id"])
+	})
+	// TrainTicket answers the check with the account id; the client decodes a SecurityConfig, so the fake sends no data.
+	s.handle("GET", security+"/{accountId}", func(w http.ResponseWriter, r *http.Request, p params) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		lastHour, notUsed := 0, 0
+		for _, order := range s.ordersOf("") {
+			if order.AccountId != p["accountId"] {
+				continue
+			}
+			if bought, err := time.Parse(time.DateTime, order.BoughtDate); err == nil && time.Since(bought) < time.Hour {
+				lastHour++
+			}
+			if order.Status == OrderNotPaid || order.Status == OrderPaid || order.Status == OrderCollected {
+				notUsed++
+			}
+		}
+		if lastHour > s.securityLimit(maxOrderOneHour) {
+			fail(w, "Too much order in last one hour or too much valid order")
+			return
+		}
+		if notUsed > s.securityLimit(maxOrderNotUse) {
+			fail(w, "Too much order in last one hour or too much valid order")
+			return
+		}
+		ok(w, "Success.r", nil)
+	})
+
+	for _, prefix := range []string{"/api/v1/priceservice/prices", "/api/v1/adminbasicservice/adminbasic/prices"} {
+		s.handle("GET", prefix, func(w http.ResponseWriter, r *http.Request, _ params) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			ok(w, "Success", all(s.store.prices))
+		})
+		s.handle("POST", prefix, func(w http.ResponseWriter, r *http.Request, _ params) {
+			var req Price
+			if !decode(w, r, &req) {
+				return
+			}
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			if s.price(req.RouteId, req.TrainType) != nil {
+				fail(w, "Already exists")
+				return
+			}
+			if req.Id == "" {
+				req.Id = uuid.NewString()
+			}
+			s.store.prices = append(s.store.prices, &req)
+			ok(w, "Create success", &req)
+		})
+		s.handle("PUT", prefix, func(w http.ResponseWriter, r *http.Request, _ params) {
+			var req Price
+			if !decode(w, r, &req) {
+				return
+			}
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			price := find(s.store.prices, func(p *Price) bool { return p.Id == req.Id })
+			if price == nil {
+				fail(w, "No that config")
+				return
+			}
+			*price = req
+			ok(w, "Update success", price)
+		})
+		s.handle("DELETE", prefix+"/{pricesId}", func(w http.ResponseWriter, r *http.Request, p params) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			price := find(s.store.prices, func(pc *Price) bool { return pc.Id == p["pricesId"] })
+			if price == nil {
+				fail(w, "No that config")
+				return
+			}
+			remove(&s.store.prices, func(pc *Price) bool { return pc == price })
+			ok(w, "Delete success", price)
+		})
+	}
+	// TrainTicket answers with a map keyed "routeId:trainType"; the client decodes a list.
+	s.handle("POST", "/api/v1/priceservice/prices/byRouteIdsAndTrainTypes", func(w http.ResponseWriter, r *http.Request, _ params) {
+		var req []string
+		if !decode(w, r, &req) {
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		result := []*Price{}
+		for _, key := range req {
+			routeId, trainType, _ := strings.Cut(key, ":")
+			if price := s.price(routeId, trainType); price != nil {
+				result = append(result, price)
+			}
+		}
+		ok(w, "Success", result)
+	})
+	s.handle("GET", "/api/v1/priceservice/prices/{routeId}/{trainType}", func(w http.ResponseWriter, r *http.Request, p params) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		price := s.price(p["routeId"], p["trainType"])
+		if price == nil {
+			fail(w, "No that config")
+			return
+		}
+		ok(w, "Success", price)
+	})
+
+	const consigns = "/api/v1/consignservice/consigns"
+	s.handle("POST", consigns, func(w http.ResponseWriter, r *http.Request, _ params) {
+		var req Consign
+		if !decode(w, r, &req) {
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.insertConsign(w, &req)
+	})
+	s.handle("PUT", consigns, func(w http.ResponseWriter, r *http.Request, _ params) {
+		var req Consign
+		if !decode(w, r, &req) {
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		consign := find(s.store.consigns, func(c *Consign) bool { return c.Id == req.Id })
+		if consign == nil {
+			s.insertConsign(w, &req)
+			return
+		}
+		*consign = req
+		consign.Price = s.consignPrice(consign.Weight, consign.IsWithin)
+		ok(w, "Update consign success", consign)
+	})
+	s.handle("GET", consigns+"/account/{accountId}", func(w http.ResponseWriter, r *http.Request, p params) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		result := filter(s.store.consigns, func(c *Consign) bool { return c.AccountId == p["accountId"] })
+		if len(result) == 0 {
+			fail(w, "No Content according to accountId")
+			return
+		}
+		ok(w, "Find consign by account id success", result)
+	})
+	s.handle("GET", consigns+"/order/{orderId}", func(w http.ResponseWriter, r *http.Request, p params) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		consign := find(s.store.consigns, func(c *Consign) bool { return c.OrderId == p["orderId"] })
+		if consign == nil {
+			fail(w, "No Content according to order id")
+			return
+		}
+		ok(w, "Find consign by order id success", consign)
+	})
+	s.handle("GET", consigns+"/{consignee}", func(w http.ResponseWriter, r *http.Request, p params) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		result := filter(s.store.consigns, func(c *Consign) bool { return c.Consignee == p["consignee"] })
+		if len(result) == 0 {
+			fail(w, "No Content according to consignee")
+			return
+		}
+		ok(w, "Find consign by consignee success", result)
+	})
+
+	const consignPrice = "/api/v1/consignpriceservice/consignprice"
+	s.handle("GET", consignPrice+"/price", func(w http.ResponseWriter, r *http.Request, _ params) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		c := s.store.consignPrice
+		ok(w, "Success", fmt.Sprintf("The price of weight within %s is %s. The price of extra weight within the region is %s and beyond the region is %s",
+			formatPrice(c.InitialWeight), formatPrice(c.InitialPrice), formatPrice(c.WithinPrice), formatPrice(c.BeyondPrice)))
+	})
+	s.handle("GET", consignPrice+"/config", func(w http.ResponseWriter, r *http.Request, _ params) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		ok(w, "Success", s.store.consignPrice)
+	})
+	s.handle("GET", consignPrice+"/{weight}/{isWithinRegion}", func(w http.ResponseWriter, r *http.Request, p params) {
+		weight, err := strconv.ParseFloat(p["weight"], 64)
+		if err != nil {
+			fail(w, "Invalid weight "+p["weight"])
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		ok(w, "Success", s.consignPrice(weight, p["isWithinRegion"] == "true"))
+	})
+	s.handle("POST", consignPrice, func(w http.ResponseWriter, r *http.Request, _ params) {
+		var req ConsignPrice
+		if !decode(w, r, &req) {
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.store.consignPrice = req
+		ok(w, "Success", req)
+	})
+}
+
+func (s *Server) config(name string) *Config {
+	return find(s.store.configs, func(c *Config) bool { return c.Name == name })
+}
+
+func (s *Server) securityConfig(name string) *SecurityConfig {
+	return find(s.store.securityConfigs, func(c *SecurityConfig) bool { return c.Name == name })
+}
+
+// securityLimit returns the value of a security config, unlimited when it is missing or invalid.
+func (s *Server) securityLimit(name string) int {
+	if config := s.securityConfig(name); config != nil {
+		if n, err := strconv.Atoi(config.Value); err == nil {
+			return n
+		}
+	}
+	return int(^uint(0) >> 1)
+}
+
+func (s *Server) price(routeId, trainType string) *Price {
+	return find(s.store.prices, func(p *Price) bool { return p.RouteId == routeId && p.TrainType == trainType })
+}
+
+// priceRates returns the economy and first class price rates of a train type on a route, the
+// TrainTicket defaults when no price config exists.
+func (s *Server) priceRates(routeId, trainType string) (float64, float64) {
+	if price := s.price(routeId, trainType); price != nil {
+		return price.BasicPriceRate, price.FirstClassPriceRate
+	}
+	return economyPriceRate, confortPriceRate
+}
+
+// insertConsign stores a consignment under a new id, priced by the consign price config.
+func (s *Server) insertConsign(w http.ResponseWriter, c *Consign) {
+	c.Id = uuid.NewString()
+	c.Price = s.consignPrice(c.Weight, c.IsWithin)
+	s.store.consigns = append(s.store.consigns, c)
+	ok(w, "You have consigned successfully! The price is "+formatPrice(c.Price), c)
+}
+
+func (s *Server) consignPrice(weight float64, within bool) float64 {
+	c := s.store.consignPrice
+	if weight <= c.InitialWeight {
+		return c.InitialPrice
+	}
+	extra := c.BeyondPrice
+	if within {
+		extra = c.WithinPrice
+	}
+	return c.InitialPrice + (weight-c.InitialWeight)*extra
+}
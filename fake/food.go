@@ -0,0 +1,386 @@
+package fake
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// foodView is the menu of a trip leg: the trip's own food and the stores of the stations on the way.
+type foodView struct {
+	TrainFoodList    []Food                         `json:"trainFoodList"`
+	FoodStoreListMap map[string][]*StationFoodStore `json:"foodStoreListMap"`
+}
+
+func (s *Server) registerFood() {
+	const orders = "/api/v1/foodservice/orders"
+	s.handle("GET", orders, func(w http.ResponseWriter, r *http.Request, _ params) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		ok(w, "Find all food order success", all(s.store.foodOrders))
+	})
+	s.handle("POST", orders, func(w http.ResponseWriter, r *http.Request, _ params) {
+		var req FoodOrder
+		if !decode(w, r, &req) {
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if s.foodOrder(req.OrderId) != nil {
+			fail(w, "Order Id Has Existed.")
+			return
+		}
+		s.addFoodOrder(&req)
+		ok(w, "Success.", &req)
+	})
+	s.handle("POST", "/api/v1/foodservice/createOrderBatch", func(w http.ResponseWriter, r *http.Request, _ params) {
+		var req []FoodOrder
+		if !decode(w, r, &req) {
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		for _, order := range req {
+			if s.foodOrder(order.OrderId) != nil {
+				fail(w, "Order Id "+order.OrderId+"Existed")
+				return
+			}
+		}
+		for _, order := range clone(req) {
+			s.addFoodOrder(order)
+		}
+		ok(w, "Success", nil)
+	})
+	s.handle("PUT", orders, func(w http.ResponseWriter, r *http.Request, _ params) {
+		var req FoodOrder
+		if !decode(w, r, &req) {
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		order := find(s.store.foodOrders, func(o *FoodOrder) bool { return o.Id == req.Id })
+		if order == nil {
+			fail(w, "Order Id Is Non-Existent.")
+			return
+		}
+		*order = req
+		ok(w, "Success", order)
+	})
+	s.handle("DELETE", orders+"/{orderId}", func(w http.ResponseWriter, r *http.Request, p params) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if !remove(&s.store.foodOrders, func(o *FoodOrder) bool { return o.OrderId == p["orderId"] }) {
+			fail(w, "Order Id Is Non-Existent.")
+			return
+		}
+		ok(w, "Success", nil)
+	})
+	s.handle("GET", orders+"/{orderId}", func(w http.ResponseWriter, r *http.Request, p params) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		order := s.foodOrder(p["orderId"])
+		if order == nil {
+			fail(w, "Order Id Is Non-Existent.")
+			return
+		}
+		ok(w, "Success.", order)
+	})
+	s.handle("GET", "/api/v1/foodservice/foods/{date}/{startStation}/{endStation}/{tripId}", func(w http.ResponseWriter, r *http.Request, p params) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		ok(w, "Get All Food Success", s.foods(p["tripId"], stationName(p["startStation"]), stationName(p["endStation"])))
+	})
+
+	const stores = "/api/v1/stationfoodservice/stationfoodstores"
+	s.handle("GET", stores, func(w http.ResponseWriter, r *http.Request, _ params) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		ok(w, "Success", all(s.store.stationFoodStores))
+	})
+	s.handle("POST", stores, func(w http.ResponseWriter, r *http.Request, _ params) {
+		var req []string
+		if !decode(w, r, &req) {
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		result := []*StationFoodStore{}
+		for _, name := range req {
+			result = append(result, s.foodStores(name)...)
+		}
+		ok(w, "Success", result)
+	})
+	s.handle("GET", stores+"/bystoreid/{stationFoodStoreId}", func(w http.ResponseWriter, r *http.Request, p params) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		store := s.foodStore(p["stationFoodStoreId"])
+		if store == nil {
+			fail(w, "No content")
+			return
+		}
+		ok(w, "Success", store)
+	})
+	s.handle("GET", stores+"/{stationName}", func(w http.ResponseWriter, r *http.Request, p params) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		result := s.foodStores(p["stationName"])
+		if len(result) == 0 {
+			fail(w, "Food store is empty")
+			return
+		}
+		ok(w, "Success", result)
+	})
+
+	const trainFoods = "/api/v1/trainfoodservice/trainfoods"
+	s.handle("GET", trainFoods, func(w http.ResponseWriter, r *http.Request, _ params) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		ok(w, "Success", all(s.store.trainFoods))
+	})
+	s.handle("GET", trainFoods+"/{tripId}", func(w http.ResponseWriter, r *http.Request, p params) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		food := s.trainFood(p["tripId"])
+		if food == nil {
+			fail(w, "No content")
+			return
+		}
+		ok(w, "Success", food.FoodList)
+	})
+
+	const deliveryOrders = "/api/v1/fooddeliveryservice/orders"
+	s.handle("POST", deliveryOrders, func(w http.ResponseWriter, r *http.Request, _ params) {
+		var req FoodDeliveryOrder
+		if !decode(w, r, &req) {
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		store := s.foodStore(req.StationFoodStoreId)
+		if store == nil {
+			fail(w, "Station food store not found")
+			return
+		}
+		if req.Id == "" {
+			req.Id = uuid.NewString()
+		}
+		if req.CreatedTime == "" {
+			req.CreatedTime = time.Now().Format(time.DateTime)
+		}
+		req.DeliveryFee = store.DeliveryFee
+		s.store.foodDeliveryOrders = append(s.store.foodDeliveryOrders, &req)
+		s.store.deliver(&req)
+		ok(w, "Save success", &req)
+	})
+	s.handle("GET", deliveryOrders+"/all", func(w http.ResponseWriter, r *http.Request, _ params) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		ok(w, "Success", all(s.store.foodDeliveryOrders))
+	})
+	s.handle("GET", deliveryOrders+"/store/{storeId}", func(w http.ResponseWriter, r *http.Request, p params) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		ok(w, "Success", filter(s.store.foodDeliveryOrders, func(o *FoodDeliveryOrder) bool { return o.StationFoodStoreId == p["storeId"] }))
+	})
+	s.handle("GET", deliveryOrders+"/{orderId}", func(w http.ResponseWriter, r *http.Request, p params) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		order := s.foodDeliveryOrder(p["orderId"])
+		if order == nil {
+			fail(w, "Order not found")
+			return
+		}
+		ok(w, "Success", order)
+	})
+	s.handle("DELETE", deliveryOrders+"/d/{orderId}", func(w http.ResponseWriter, r *http.Request, p params) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if !remove(&s.store.foodDeliveryOrders, func(o *FoodDeliveryOrder) bool { return o.Id == p["orderId"] }) {
+			fail(w, "Order not found")
+			return
+		}
+		for _, delivery := range s.store.deliveries {
+			if delivery.OrderId == p["orderId"] && delivery.Status != DeliveryDelivered {
+				delivery.Status = DeliveryCancelled
+			}
+		}
+		ok(w, "Delete success", nil)
+	})
+	s.handle("PUT", deliveryOrders+"/dtime", func(w http.ResponseWriter, r *http.Request, _ params) {
+		var req struct {
+			OrderId      string `json:"orderId"`
+			DeliveryTime string `json:"deliveryTime"`
+		}
+		if !decode(w, r, &req) {
+			return
+		}
+		s.updateFoodDeliveryOrder(w, req.OrderId, func(o *FoodDeliveryOrder) { o.DeliveryTime = req.DeliveryTime })
+	})
+	s.handle("PUT", deliveryOrders+"/seatno", func(w http.ResponseWriter, r *http.Request, _ params) {
+		var req struct {
+			OrderId string `json:"orderId"`
+			SeatNo  int    `json:"seatNo"`
+		}
+		if !decode(w, r, &req) {
+			return
+		}
+		s.updateFoodDeliveryOrder(w, req.OrderId, func(o *FoodDeliveryOrder) { o.SeatNo = req.SeatNo })
+	})
+	s.handle("PUT", deliveryOrders+"/tripid", func(w http.ResponseWriter, r *http.Request, _ params) {
+		var req struct {
+			OrderId string `json:"orderId"`
+			TripId  string `json:"tripId"`
+		}
+		if !decode(w, r, &req) {
+			return
+		}
+		s.updateFoodDeliveryOrder(w, req.OrderId, func(o *FoodDeliveryOrder) { o.TripId = req.TripId })
+	})
+
+	const deliveries = "/api/v1/deliveryservice/deliveries"
+	s.handle("GET", deliveries+"/order/{orderId}", func(w http.ResponseWriter, r *http.Request, p params) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		ok(w, "Success", filter(s.store.deliveries, func(d *Delivery) bool { return d.OrderId == p["orderId"] }))
+	})
+	s.handle("GET", deliveries+"/station/{stationName}", func(w http.ResponseWriter, r *http.Request, p params) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		ok(w, "Success", filter(s.store.deliveries, func(d *Delivery) bool { return d.StationName == p["stationName"] }))
+	})
+	s.handle("PUT", deliveries+"/status", func(w http.ResponseWriter, r *http.Request, _ params) {
+		var req struct {
+			Id     string `json:"id"`
+			Status int    `json:"status"`
+		}
+		if !decode(w, r, &req) {
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		delivery := find(s.store.deliveries, func(d *Delivery) bool { return d.Id == req.Id })
+		if delivery == nil {
+			fail(w, "Delivery not found")
+			return
+		}
+		if !deliveryTransition(delivery.Status, req.Status) {
+			fail(w, "Invalid delivery status transition")
+			return
+		}
+		delivery.Status = req.Status
+		ok(w, "Update success", delivery)
+	})
+}
+
+func (s *Server) foodOrder(orderId string) *FoodOrder {
+	return find(s.store.foodOrders, func(o *FoodOrder) bool { return o.OrderId == orderId })
+}
+
+// addFoodOrder stores a food order, keeping its id when the client chose one.
+func (s *Server) addFoodOrder(order *FoodOrder) {
+	if order.Id == "" {
+		order.Id = uuid.NewString()
+	}
+	s.store.foodOrders = append(s.store.foodOrders, order)
+}
+
+func (s *Server) foodStore(id string) *StationFoodStore {
+	return find(s.store.stationFoodStores, func(f *StationFoodStore) bool { return f.Id == id })
+}
+
+func (s *Server) foodStores(station string) []*StationFoodStore {
+	return filter(s.store.stationFoodStores, func(f *StationFoodStore) bool { return f.StationName == station })
+}
+
+func (s *Server) trainFood(tripId string) *TrainFood {
+	return find(s.store.trainFoods, func(f *TrainFood) bool { return f.TripId == tripId })
+}
+
+// foods returns the food of a trip between two stations: its train food and the stores of the
+// stations it calls at on the way, or of both ends when the trip is unknown.
+func (s *Server) foods(tripId, from, to string) foodView {
+	view := foodView{TrainFoodList: []Food{}, FoodStoreListMap: map[string][]*StationFoodStore{}}
+	if food := s.trainFood(tripId); food != nil {
+		view.TrainFoodList = food.FoodList
+	}
+	stations := []string{from, to}
+	if trip := s.tripById(tripId); trip != nil {
+		if route := s.routeById(trip.RouteId); route != nil {
+			stations = stationsBetween(route, from, to)
+		}
+	}
+	for _, station := range stations {
+		if stores := s.foodStores(station); len(stores) > 0 {
+			view.FoodStoreListMap[station] = stores
+		}
+	}
+	return view
+}
+
+// stationsBetween returns the stations of route from from to to, both included.
+func stationsBetween(route *Route, from, to string) []string {
+	var result []string
+	in := false
+	for _, station := range route.Stations {
+		if station == from {
+			in = true
+		}
+		if in {
+			result = append(result, station)
+		}
+		if station == to {
+			break
+		}
+	}
+	return result
+}
+
+func (s *Server) foodDeliveryOrder(id string) *FoodDeliveryOrder {
+	return find(s.store.foodDeliveryOrders, func(o *FoodDeliveryOrder) bool { return o.Id == id })
+}
+
+func (s *Server) updateFoodDeliveryOrder(w http.ResponseWriter, id string, update func(*FoodDeliveryOrder)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	order := s.foodDeliveryOrder(id)
+	if order == nil {
+		fail(w, "Order not found")
+		return
+	}
+	update(order)
+	ok(w, "Update success", order)
+}
+
+// deliver creates a delivery for each dish of a food delivery order, as the delivery service does
+// when the order is published to it.
+func (st *store) deliver(order *FoodDeliveryOrder) {
+	var storeName, station string
+	if store := find(st.stationFoodStores, func(f *StationFoodStore) bool { return f.Id == order.StationFoodStoreId }); store != nil {
+		storeName, station = store.StoreName, store.StationName
+	}
+	for _, food := range order.FoodList {
+		st.deliveries = append(st.deliveries, &Delivery{
+			Id:          uuid.NewString(),
+			OrderId:     order.Id,
+			FoodName:    food.FoodName,
+			StoreName:   storeName,
+			StationName: station,
+			Status:      DeliveryCreated,
+		})
+	}
+}
+
+// deliveryTransition reports whether a delivery may go from one status to another: forward through
+// created, delivering and delivered, or cancelled before it is delivered.
+func deliveryTransition(from, to int) bool {
+	switch to {
+	case DeliveryDelivering:
+		return from == DeliveryCreated
+	case DeliveryDelivered:
+		return from == DeliveryDelivering
+	case DeliveryCancelled:
+		return from == DeliveryCreated || from == DeliveryDelivering
+	}
+	return false
+}
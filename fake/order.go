@@ -0,0 +1,519 @@
+package fake
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	orderService      = "orderservice"
+	orderOtherService = "orderOtherService"
+)
+
+// orderServiceOf returns the order service that stores orders of a trip.
+func orderServiceOf(tripId string) string {
+	if highSpeed(tripId) {
+		return orderService
+	}
+	return orderOtherService
+}
+
+// orderQuery is the Qi filter of the query and refresh endpoints.
+type orderQuery struct {
+	LoginId               string `json:"loginId"`
+	EnableStateQuery      bool   `json:"enableStateQuery"`
+	State                 int    `json:"state"`
+	EnableTravelDateQuery bool   `json:"enableTravelQuery"`
+	TravelDateStart       string `json:"travelDateStart"`
+	TravelDateEnd         string `json:"travelDateEnd"`
+	EnableBoughtDateQuery bool   `json:"enableBoughtDateQuery"`
+	BoughtDateStart       string `json:"boughtDateStart"`
+	BoughtDateEnd         string `json:"boughtDateEnd"`
+}
+
+func (q orderQuery) match(o *Order) bool {
+	if o.AccountId != q.LoginId {
+		return false
+	}
+	if q.EnableStateQuery && o.Status != q.State {
+		return false
+	}
+	if q.EnableTravelDateQuery && !between(o.TravelDate, q.TravelDateStart, q.TravelDateEnd) {
+		return false
+	}
+	if q.EnableBoughtDateQuery && !between(o.BoughtDate, q.BoughtDateStart, q.BoughtDateEnd) {
+		return false
+	}
+	return true
+}
+
+// between reports whether the date of value is within [start, end]; empty bounds are open.
+func between(value, start, end string) bool {
+	t := travelDate(value)
+	return (start == "" || !t.Before(travelDate(start))) && (end == "" || !t.After(travelDate(end)))
+}
+
+// orderTickets is the request body of preserve and preserveOther.
+type orderTickets struct {
+	AccountId  string `json:"accountId"`
+	ContactsId string `json:"contactsId"`
+	TripId     string `json:"tripId"`
+	SeatType   int    `json:"seatType"`
+	Date       string `json:"date"`
+	From       string `json:"from"`
+	To         string `json:"to"`
+	Assurance  int    `json:"assurance"`
+}
+
+type rebookInfo struct {
+	LoginId   string `json:"loginId"`
+	OrderId   string `json:"orderId"`
+	OldTripId string `json:"oldTripId"`
+	TripId    string `json:"tripId"`
+	SeatType  int    `json:"seatType"`
+	Date      string `json:"date"`
+}
+
+func (s *Server) registerOrders() {
+	for _, svc := range []string{orderService, orderOtherService} {
+		svc := svc
+		prefix := "/api/v1/" + svc + "/order"
+		if svc == orderOtherService {
+			prefix = "/api/v1/" + svc + "/orderOther"
+		}
+		s.handle("GET", prefix, func(w http.ResponseWriter, r *http.Request, _ params) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			ok(w, "Success", s.ordersOf(svc))
+		})
+		s.handle("POST", prefix, func(w http.ResponseWriter, r *http.Request, _ params) {
+			s.createOrder(w, r, svc)
+		})
+		s.handle("POST", prefix+"/admin", func(w http.ResponseWriter, r *http.Request, _ params) {
+			s.createOrder(w, r, svc)
+		})
+		s.handle("PUT", prefix, func(w http.ResponseWriter, r *http.Request, _ params) {
+			s.updateOrder(w, r, svc)
+		})
+		s.handle("PUT", prefix+"/admin", func(w http.ResponseWriter, r *http.Request, _ params) {
+			s.updateOrder(w, r, svc)
+		})
+		s.handle("POST", prefix+"/query", func(w http.ResponseWriter, r *http.Request, _ params) {
+			s.queryOrders(w, r, svc)
+		})
+		s.handle("POST", prefix+"/refresh", func(w http.ResponseWriter, r *http.Request, _ params) {
+			s.queryOrders(w, r, svc)
+		})
+		s.handle("POST", prefix+"/tickets", func(w http.ResponseWriter, r *http.Request, _ params) {
+			var req struct {
+				TrainNumber string `json:"trainNumber"`
+				TravelDate  string `json:"travelDate"`
+			}
+			if !decode(w, r, &req) {
+				return
+			}
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			sold := []map[string]interface{}{}
+			for _, order := range s.ordersOf(svc) {
+				if order.TrainNumber == req.TrainNumber && sameDay(order.TravelDate, req.TravelDate) && order.Status != OrderCancel {
+					sold = append(sold, map[string]interface{}{"seatNo": order.SeatNumber, "startStation": order.From, "destStation": order.To})
+				}
+			}
+			ok(w, "Success", map[string]interface{}{"soldTickets": sold})
+		})
+		s.handle("GET", prefix+"/orderpay/{orderId}", func(w http.ResponseWriter, r *http.Request, p params) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			order := s.orderById(svc, p["orderId"])
+			if order == nil {
+				fail(w, "Order Not Found")
+				return
+			}
+			order.Status = OrderPaid
+			ok(w, "Pay Order Success.", order)
+		})
+		s.handle("GET", prefix+"/price/{orderId}", func(w http.ResponseWriter, r *http.Request, p params) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			order := s.orderById(svc, p["orderId"])
+			if order == nil {
+				fail(w, "Order Not Found")
+				return
+			}
+			ok(w, "Success", order.Price)
+		})
+		s.handle("GET", prefix+"/security/{checkDate}/{accountId}", func(w http.ResponseWriter, r *http.Request, p params) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			var lastHour, valid int
+			for _, order := range s.ordersOf(svc) {
+				if order.AccountId != p["accountId"] {
+					continue
+				}
+				if bought, err := time.Parse(time.DateTime, order.BoughtDate); err == nil && time.Since(bought) < time.Hour {
+					lastHour++
+				}
+				if order.Status == OrderNotPaid || order.Status == OrderPaid || order.Status == OrderCollected {
+					valid++
+				}
+			}
+			ok(w, "Check Security Success . ", map[string]int{"orderNumInLastOneHour": lastHour, "orderNumOfValidOrder": valid})
+		})
+		s.handle("GET", prefix+"/status/{orderId}/{status}", func(w http.ResponseWriter, r *http.Request, p params) {
+			status, err := strconv.Atoi(p["status"])
+			if err != nil {
+				fail(w, "Invalid status "+p["status"])
+				return
+			}
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			order := s.orderById(svc, p["orderId"])
+			if order == nil {
+				fail(w, "Order Not Found")
+				return
+			}
+			order.Status = status
+			ok(w, "Modify Order Success", order)
+		})
+		s.handle("GET", prefix+"/{travelDate}/{trainNumber}", func(w http.ResponseWriter, r *http.Request, p params) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			sold := map[string]interface{}{"travelDate": p["travelDate"], "trainNumber": p["trainNumber"]}
+			counts := map[int]int{}
+			for _, order := range s.ordersOf(svc) {
+				if order.TrainNumber == p["trainNumber"] && sameDay(order.TravelDate, p["travelDate"]) {
+					counts[order.SeatClass]++
+				}
+			}
+			sold["firstClassSeat"] = counts[SeatFirstClass]
+			sold["secondClassSeat"] = counts[SeatSecondClass]
+			ok(w, "Success", sold)
+		})
+		s.handle("GET", prefix+"/{orderId}", func(w http.ResponseWriter, r *http.Request, p params) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			if order := s.orderById(svc, p["orderId"]); order != nil {
+				ok(w, "Success", order)
+				return
+			}
+			fail(w, "Order Not Found")
+		})
+		s.handle("DELETE", prefix+"/{orderId}", func(w http.ResponseWriter, r *http.Request, p params) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			order := s.orderById(svc, p["orderId"])
+			if order == nil {
+				fail(w, "Order Not Exist.")
+				return
+			}
+			orders := s.store.orders[svc]
+			remove(&orders, func(o *Order) bool { return o == order })
+			s.store.orders[svc] = orders
+			ok(w, "Delete Order Success", order)
+		})
+	}
+
+	s.handle("GET", "/api/v1/adminorderservice/adminorder", func(w http.ResponseWriter, r *http.Request, _ params) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		ok(w, "Get the orders successfully!", append(s.ordersOf(orderService), s.ordersOf(orderOtherService)...))
+	})
+	s.handle("POST", "/api/v1/adminorderservice/adminorder", func(w http.ResponseWriter, r *http.Request, _ params) {
+		s.createOrder(w, r, "")
+	})
+	s.handle("PUT", "/api/v1/adminorderservice/adminorder", func(w http.ResponseWriter, r *http.Request, _ params) {
+		s.updateOrder(w, r, "")
+	})
+	s.handle("DELETE", "/api/v1/adminorderservice/adminorder/{orderId}/{trainNumber}", func(w http.ResponseWriter, r *http.Request, p params) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		svc := orderServiceOf(p["trainNumber"])
+		orders := s.store.orders[svc]
+		if !remove(&orders, func(o *Order) bool { return o.Id == p["orderId"] }) {
+			fail(w, "Order Not Exist.")
+			return
+		}
+		s.store.orders[svc] = orders
+		ok(w, "Delete Order Success", nil)
+	})
+
+	s.handle("POST", "/api/v1/preserveservice/preserve", func(w http.ResponseWriter, r *http.Request, _ params) {
+		s.preserve(w, r, travelService)
+	})
+	s.handle("POST", "/api/v1/preserveotherservice/preserveOther", func(w http.ResponseWriter, r *http.Request, _ params) {
+		s.preserve(w, r, travel2Service)
+	})
+
+	s.handle("POST", "/api/v1/rebookservice/rebook", func(w http.ResponseWriter, r *http.Request, _ params) {
+		s.rebook(w, r, false)
+	})
+	s.handle("POST", "/api/v1/rebookservice/rebook/difference", func(w http.ResponseWriter, r *http.Request, _ params) {
+		s.rebook(w, r, true)
+	})
+
+	s.handle("GET", "/api/v1/cancelservice/cancel/refound/{orderId}", func(w http.ResponseWriter, r *http.Request, p params) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		order := s.findOrder(p["orderId"])
+		if order == nil {
+			fail(w, "Order Not Found")
+			return
+		}
+		if order.Status != OrderNotPaid && order.Status != OrderPaid {
+			fail(w, "Order Status Cancel Not Permitted, Refound error")
+			return
+		}
+		ok(w, "Success. ", formatPrice(refund(order)))
+	})
+	s.handle("GET", "/api/v1/cancelservice/cancel/{orderId}/{loginId}", func(w http.ResponseWriter, r *http.Request, p params) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		order := s.findOrder(p["orderId"])
+		if order == nil {
+			fail(w, "Order Not Found.")
+			return
+		}
+		if order.Status != OrderNotPaid && order.Status != OrderPaid && order.Status != OrderChange {
+			fail(w, "Order Status Cancel Not Permitted")
+			return
+		}
+		if money := refund(order); money > 0 {
+			s.addMoney(p["loginId"], money)
+			s.store.moneys = append(s.store.moneys, &Money{Id: uuid.NewString(), UserId: p["loginId"], Money: formatPrice(money), Type: MoneyDrawBack})
+		}
+		order.Status = OrderCancel
+		ok(w, "Success.", "test not null")
+	})
+
+	s.handle("GET", "/api/v1/executeservice/execute/collected/{orderId}", func(w http.ResponseWriter, r *http.Request, p params) {
+		s.advanceOrder(w, p["orderId"], OrderPaid, OrderCollected, "Success.")
+	})
+	s.handle("GET", "/api/v1/executeservice/execute/execute/{orderId}", func(w http.ResponseWriter, r *http.Request, p params) {
+		s.advanceOrder(w, p["orderId"], OrderCollected, OrderUsed, "Success.")
+	})
+}
+
+// ordersOf returns the orders of an order service, or of both when svc is empty.
+func (s *Server) ordersOf(svc string) []*Order {
+	if svc == "" {
+		return append(append([]*Order{}, s.store.orders[orderService]...), s.store.orders[orderOtherService]...)
+	}
+	return append([]*Order{}, s.store.orders[svc]...)
+}
+
+func (s *Server) orderById(svc, id string) *Order {
+	return find(s.ordersOf(svc), func(o *Order) bool { return o.Id == id })
+}
+
+// findOrder looks an order up in both order services.
+func (s *Server) findOrder(id string) *Order {
+	return s.orderById("", id)
+}
+
+// createOrder saves an order in svc, or in the service of its train number when svc is empty.
+func (s *Server) createOrder(w http.ResponseWriter, r *http.Request, svc string) {
+	var req Order
+	if !decode(w, r, &req) {
+		return
+	}
+	if svc == "" {
+		svc = orderServiceOf(req.TrainNumber)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// Like TrainTicket, an order is a duplicate when all its fields but the id match, and a new order
+	// always gets a new id.
+	exists := find(s.store.orders[svc], func(o *Order) bool {
+		dup := *o
+		dup.Id = req.Id
+		return dup == req
+	})
+	if exists != nil && req.AccountId != "" {
+		fail(w, "Order already exist")
+		return
+	}
+	req.Id = uuid.NewString()
+	if req.BoughtDate == "" {
+		req.BoughtDate = time.Now().Format(time.DateTime)
+	}
+	s.store.orders[svc] = append(s.store.orders[svc], &req)
+	ok(w, "Success", &req)
+}
+
+func (s *Server) updateOrder(w http.ResponseWriter, r *http.Request, svc string) {
+	var req Order
+	if !decode(w, r, &req) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	order := s.orderById(svc, req.Id)
+	if order == nil {
+		fail(w, "Order Not Found, Can't update")
+		return
+	}
+	*order = req
+	ok(w, "Success", order)
+}
+
+func (s *Server) queryOrders(w http.ResponseWriter, r *http.Request, svc string) {
+	var req orderQuery
+	if !decode(w, r, &req) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ok(w, "Get order list success", filter(s.store.orders[svc], req.match))
+}
+
+// preserve books a ticket on a trip of travelSvc: it checks the contact and the trip leg, then
+// creates an unpaid order and the requested assurance.
+func (s *Server) preserve(w http.ResponseWriter, r *http.Request, travelSvc string) {
+	var req orderTickets
+	if !decode(w, r, &req) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	contact := find(s.store.contacts, func(c *Contact) bool { return c.Id == req.ContactsId })
+	if contact == nil {
+		fail(w, "Contacts Not Exist")
+		return
+	}
+	trip := find(s.tripsOf(travelSvc), func(t *Trip) bool { return t.TripId.String() == req.TripId })
+	if trip == nil {
+		fail(w, "Trip "+req.TripId+" does not exist")
+		return
+	}
+	leg := s.tripResponse(trip, req.From, req.To, req.Date)
+	if leg == nil {
+		fail(w, "Trip "+req.TripId+" does not go from "+req.From+" to "+req.To)
+		return
+	}
+	price := leg.PriceForEconomyClass
+	if req.SeatType == SeatFirstClass {
+		price = leg.PriceForConfortClass
+	}
+	svc := orderServiceOf(req.TripId)
+	order := &Order{
+		Id:                     uuid.NewString(),
+		BoughtDate:             time.Now().Format(time.DateTime),
+		TravelDate:             travelDate(req.Date).Format(time.DateOnly),
+		TravelTime:             leg.StartTime,
+		AccountId:              req.AccountId,
+		ContactsName:           contact.Name,
+		DocumentType:           contact.DocumentType,
+		ContactsDocumentNumber: contact.DocumentNumber,
+		TrainNumber:            req.TripId,
+		CoachNumber:            5,
+		SeatClass:              req.SeatType,
+		SeatNumber:             len(s.store.orders[svc]) + 1,
+		From:                   req.From,
+		To:                     req.To,
+		Status:                 OrderNotPaid,
+		Price:                  price,
+		DifferenceMoney:        "0.0",
+	}
+	s.store.orders[svc] = append(s.store.orders[svc], order)
+	if req.Assurance != 0 {
+		s.store.assurances = append(s.store.assurances, &Assurance{Id: uuid.NewString(), OrderId: order.Id, TypeIndex: req.Assurance})
+	}
+	ok(w, "Success.", "Success")
+}
+
+// rebook moves a paid order to another trip. A more expensive trip fails with status 2 unless
+// payDifference is set, in which case the difference is charged to the inside-payment account.
+func (s *Server) rebook(w http.ResponseWriter, r *http.Request, payDifference bool) {
+	var req rebookInfo
+	if !decode(w, r, &req) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	oldSvc := orderServiceOf(req.OldTripId)
+	order := s.orderById(oldSvc, req.OrderId)
+	if order == nil {
+		if order = s.findOrder(req.OrderId); order == nil {
+			fail(w, "Order Not Found")
+			return
+		}
+		oldSvc = orderServiceOf(order.TrainNumber)
+	}
+	if order.Status != OrderPaid {
+		fail(w, "you order not suitable to rebook!")
+		return
+	}
+	trip := s.tripById(req.TripId)
+	if trip == nil {
+		fail(w, "Trip "+req.TripId+" does not exist")
+		return
+	}
+	leg := s.tripResponse(trip, order.From, order.To, req.Date)
+	if leg == nil {
+		fail(w, "Trip "+req.TripId+" does not go from "+order.From+" to "+order.To)
+		return
+	}
+	price := leg.PriceForEconomyClass
+	if req.SeatType == SeatFirstClass {
+		price = leg.PriceForConfortClass
+	}
+	difference := parsePrice(price) - parsePrice(order.Price)
+	if difference > 0 {
+		if !payDifference {
+			writeJSON(w, http.StatusOK, response{Status: 2, Msg: "Please pay the different money!"})
+			return
+		}
+		s.addMoney(req.LoginId, -difference)
+		s.store.payments = append(s.store.payments, &Payment{Id: uuid.NewString(), OrderId: order.Id, TripId: req.TripId, UserId: req.LoginId, Price: formatPrice(difference)})
+	} else if difference < 0 {
+		s.addMoney(req.LoginId, -difference)
+	}
+
+	order.TrainNumber = req.TripId
+	order.TravelDate = travelDate(req.Date).Format(time.DateOnly)
+	order.TravelTime = leg.StartTime
+	order.SeatClass = req.SeatType
+	order.Price = price
+	order.DifferenceMoney = formatPrice(difference)
+	order.Status = OrderChange
+	if newSvc := orderServiceOf(req.TripId); newSvc != oldSvc {
+		orders := s.store.orders[oldSvc]
+		remove(&orders, func(o *Order) bool { return o == order })
+		s.store.orders[oldSvc] = orders
+		s.store.orders[newSvc] = append(s.store.orders[newSvc], order)
+	}
+	ok(w, "Success!", order)
+}
+
+func (s *Server) advanceOrder(w http.ResponseWriter, orderId string, from, to int, msg string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	order := s.findOrder(orderId)
+	if order == nil {
+		fail(w, "Order Not Found")
+		return
+	}
+	if order.Status != from {
+		fail(w, "Order Status Wrong")
+		return
+	}
+	order.Status = to
+	ok(w, msg, nil)
+}
+
+// refund is 80% of the price of a paid order, 0 for an unpaid one.
+func refund(order *Order) float64 {
+	if order.Status == OrderNotPaid {
+		return 0
+	}
+	return parsePrice(order.Price) * 0.8
+}
+
+func parsePrice(price string) float64 {
+	v, _ := strconv.ParseFloat(price, 64)
+	return v
+}
@@ -0,0 +1,266 @@
+package fake
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+)
+
+// tripPayment is the request body of inside-payment pay and difference.
+type tripPayment struct {
+	TripId  string `json:"tripId"`
+	OrderId string `json:"orderId"`
+	Price   string `json:"price"`
+	UserId  string `json:"userId"`
+}
+
+// assuranceView is an assurance as returned by the single-assurance endpoints.
+type assuranceView struct {
+	Id      string `json:"id"`
+	OrderId string `json:"orderId"`
+	Type    string `json:"type"`
+}
+
+func (s *Server) registerPayments() {
+	const inside = "/api/v1/inside_pay_service/inside_payment"
+	s.handle("POST", inside, func(w http.ResponseWriter, r *http.Request, _ params) {
+		var req tripPayment
+		if !decode(w, r, &req) {
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		order := s.findOrder(req.OrderId)
+		if order == nil {
+			fail(w, "Payment Failed, Order Not Exists")
+			return
+		}
+		if order.Status != OrderNotPaid {
+			fail(w, "Payment Failed, Order Already Paid")
+			return
+		}
+		s.addMoney(req.UserId, -parsePrice(order.Price))
+		s.store.payments = append(s.store.payments, &Payment{Id: uuid.NewString(), OrderId: order.Id, TripId: req.TripId, UserId: req.UserId, Price: order.Price})
+		order.Status = OrderPaid
+		ok(w, "Payment Success", nil)
+	})
+	s.handle("POST", inside+"/account", func(w http.ResponseWriter, r *http.Request, _ params) {
+		var req Account
+		if !decode(w, r, &req) {
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if s.account(req.UserId) != nil {
+			fail(w, "Create Account Failed, Account already Exists")
+			return
+		}
+		s.store.accounts = append(s.store.accounts, &req)
+		ok(w, "Create Account Success", nil)
+	})
+	s.handle("POST", inside+"/difference", func(w http.ResponseWriter, r *http.Request, _ params) {
+		var req tripPayment
+		if !decode(w, r, &req) {
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.addMoney(req.UserId, -parsePrice(req.Price))
+		s.store.payments = append(s.store.payments, &Payment{Id: uuid.NewString(), OrderId: req.OrderId, TripId: req.TripId, UserId: req.UserId, Price: req.Price})
+		ok(w, "Pay Difference Success", nil)
+	})
+	s.handle("GET", inside+"/account", func(w http.ResponseWriter, r *http.Request, _ params) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		balances := []map[string]string{}
+		for _, account := range s.store.accounts {
+			balances = append(balances, map[string]string{"userId": account.UserId, "balance": account.Money})
+		}
+		ok(w, "Success", balances)
+	})
+	// TrainTicket returns every money record; the client decodes a single one, so the fake returns the latest.
+	s.handle("GET", inside+"/money", func(w http.ResponseWriter, r *http.Request, _ params) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		latest := Money{}
+		if n := len(s.store.moneys); n > 0 {
+			latest = *s.store.moneys[n-1]
+		}
+		ok(w, "Query Money Success", latest)
+	})
+	s.handle("GET", inside+"/payment", func(w http.ResponseWriter, r *http.Request, _ params) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		ok(w, "Query Payment Success", s.store.payments)
+	})
+	s.handle("GET", inside+"/drawback/{userId}/{money}", func(w http.ResponseWriter, r *http.Request, p params) {
+		s.changeMoney(w, p, MoneyDrawBack, "Draw Back Money Success")
+	})
+	s.handle("GET", inside+"/{userId}/{money}", func(w http.ResponseWriter, r *http.Request, p params) {
+		s.changeMoney(w, p, MoneyAdd, "Add Money Success")
+	})
+
+	const payment = "/api/v1/paymentservice/payment"
+	s.handle("POST", payment, func(w http.ResponseWriter, r *http.Request, _ params) {
+		var req Payment
+		if !decode(w, r, &req) {
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if find(s.store.payments, func(p *Payment) bool { return p.OrderId == req.OrderId }) != nil {
+			fail(w, "Pay Failed, order not found with order id"+req.OrderId)
+			return
+		}
+		if req.Id == "" {
+			req.Id = uuid.NewString()
+		}
+		s.store.payments = append(s.store.payments, &req)
+		ok(w, "Pay Success", nil)
+	})
+	s.handle("POST", payment+"/money", func(w http.ResponseWriter, r *http.Request, _ params) {
+		var req Payment
+		if !decode(w, r, &req) {
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.addMoney(req.UserId, parsePrice(req.Price))
+		ok(w, "Add Money Success", nil)
+	})
+	s.handle("GET", payment, func(w http.ResponseWriter, r *http.Request, _ params) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		ok(w, "Query Success", s.store.payments)
+	})
+
+	const assurances = "/api/v1/assuranceservice/assurances"
+	s.handle("GET", assurances, func(w http.ResponseWriter, r *http.Request, _ params) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		result := []map[string]interface{}{}
+		for _, a := range s.store.assurances {
+			t := s.assuranceType(a.TypeIndex)
+			result = append(result, map[string]interface{}{
+				"id": a.Id, "orderId": a.OrderId, "typeIndex": t.Index, "typeName": t.Name, "typePrice": t.Price,
+			})
+		}
+		ok(w, "Success", result)
+	})
+	s.handle("GET", assurances+"/types", func(w http.ResponseWriter, r *http.Request, _ params) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		ok(w, "Find All Assurance", s.store.assuranceTypes)
+	})
+	s.handle("GET", assurances+"/assuranceid/{assuranceId}", func(w http.ResponseWriter, r *http.Request, p params) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.writeAssurance(w, find(s.store.assurances, func(a *Assurance) bool { return a.Id == p["assuranceId"] }))
+	})
+	s.handle("GET", assurances+"/orderid/{orderId}", func(w http.ResponseWriter, r *http.Request, p params) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.writeAssurance(w, find(s.store.assurances, func(a *Assurance) bool { return a.OrderId == p["orderId"] }))
+	})
+	s.handle("DELETE", assurances+"/assuranceid/{assuranceId}", func(w http.ResponseWriter, r *http.Request, p params) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if !remove(&s.store.assurances, func(a *Assurance) bool { return a.Id == p["assuranceId"] }) {
+			fail(w, "Fail.Assurance not clear")
+			return
+		}
+		ok(w, "Delete Success with Assurance id", nil)
+	})
+	s.handle("DELETE", assurances+"/orderid/{orderId}", func(w http.ResponseWriter, r *http.Request, p params) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if !remove(&s.store.assurances, func(a *Assurance) bool { return a.OrderId == p["orderId"] }) {
+			fail(w, "Fail.Assurance not clear")
+			return
+		}
+		ok(w, "Delete Success with Order Id", nil)
+	})
+	s.handle("PATCH", assurances+"/{assuranceId}/{orderId}/{typeIndex}", func(w http.ResponseWriter, r *http.Request, p params) {
+		typeIndex, _ := strconv.Atoi(p["typeIndex"])
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		assurance := find(s.store.assurances, func(a *Assurance) bool { return a.Id == p["assuranceId"] && a.OrderId == p["orderId"] })
+		if assurance == nil {
+			fail(w, "Fail.Assurance not found.")
+			return
+		}
+		if s.assuranceType(typeIndex).Type == "" {
+			fail(w, "Assurance Type not exist")
+			return
+		}
+		assurance.TypeIndex = typeIndex
+		ok(w, "Modify Success", s.assuranceView(assurance))
+	})
+	s.handle("GET", assurances+"/{typeIndex}/{orderId}", func(w http.ResponseWriter, r *http.Request, p params) {
+		typeIndex, _ := strconv.Atoi(p["typeIndex"])
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if find(s.store.assurances, func(a *Assurance) bool { return a.OrderId == p["orderId"] }) != nil {
+			fail(w, "Already exists")
+			return
+		}
+		if s.assuranceType(typeIndex).Type == "" {
+			fail(w, "Assurance type doesn't exist")
+			return
+		}
+		assurance := &Assurance{Id: uuid.NewString(), OrderId: p["orderId"], TypeIndex: typeIndex}
+		s.store.assurances = append(s.store.assurances, assurance)
+		ok(w, "Success", s.assuranceView(assurance))
+	})
+}
+
+func (s *Server) account(userId string) *Account {
+	return find(s.store.accounts, func(a *Account) bool { return a.UserId == userId })
+}
+
+// addMoney changes the balance of an inside-payment account, creating it when missing. Balances
+// may go negative: the fake does not fall back to an external payment like TrainTicket does.
+func (s *Server) addMoney(userId string, amount float64) {
+	account := s.account(userId)
+	if account == nil {
+		account = &Account{UserId: userId, Money: "0"}
+		s.store.accounts = append(s.store.accounts, account)
+	}
+	account.Money = formatPrice(parsePrice(account.Money) + amount)
+}
+
+// changeMoney adds money to an account and records it.
+func (s *Server) changeMoney(w http.ResponseWriter, p params, moneyType, msg string) {
+	amount, err := strconv.ParseFloat(p["money"], 64)
+	if err != nil {
+		fail(w, "Invalid money "+p["money"])
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.addMoney(p["userId"], amount)
+	s.store.moneys = append(s.store.moneys, &Money{Id: uuid.NewString(), UserId: p["userId"], Money: p["money"], Type: moneyType})
+	ok(w, msg, nil)
+}
+
+func (s *Server) assuranceType(index int) AssuranceType {
+	for _, t := range s.store.assuranceTypes {
+		if t.Index == index {
+			return t
+		}
+	}
+	return AssuranceType{}
+}
+
+func (s *Server) assuranceView(a *Assurance) assuranceView {
+	return assuranceView{Id: a.Id, OrderId: a.OrderId, Type: s.assuranceType(a.TypeIndex).Type}
+}
+
+func (s *Server) writeAssurance(w http.ResponseWriter, a *Assurance) {
+	if a == nil {
+		fail(w, "No Content by this id")
+		return
+	}
+	ok(w, "Find Assurance Success", s.assuranceView(a))
+}
@@ -0,0 +1,306 @@
+package fake
+
+import (
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// routePlanQuery is the request body of the route plan service.
+type routePlanQuery struct {
+	StartStation string `json:"startStation"`
+	EndStation   string `json:"endStation"`
+	Num          int    `json:"num"`
+	TravelDate   string `json:"travelDate"`
+}
+
+// routePlanUnit is a trip leg as returned by the route plan service.
+type routePlanUnit struct {
+	TripId                  string   `json:"tripId"`
+	TrainTypeName           string   `json:"trainTypeName"`
+	FromStationName         string   `json:"fromStationName"`
+	ToStationName           string   `json:"toStationName"`
+	StopStations            []string `json:"stopStations"`
+	PriceForSecondClassSeat string   `json:"priceForSecondClassSeat"`
+	PriceForFirstClassSeat  string   `json:"priceForFirstClassSeat"`
+	StartTime               string   `json:"startTime"`
+	EndTime                 string   `json:"endTime"`
+}
+
+// travelPlanUnit is a trip leg as returned by the travel plan service.
+type travelPlanUnit struct {
+	TripId                        string   `json:"tripId"`
+	TrainTypeId                   string   `json:"trainTypeId"`
+	StartStation                  string   `json:"startStation"`
+	EndStation                    string   `json:"endStation"`
+	StopStations                  []string `json:"stopStations"`
+	PriceForSecondClassSeat       string   `json:"priceForSecondClassSeat"`
+	NumberOfRestTicketSecondClass int      `json:"numberOfRestTicketSecondClass"`
+	PriceForFirstClassSeat        string   `json:"priceForFirstClassSeat"`
+	NumberOfRestTicketFirstClass  int      `json:"numberOfRestTicketFirstClass"`
+	StartTime                     string   `json:"startTime"`
+	EndTime                       string   `json:"endTime"`
+}
+
+// transferQuery is the request body of the travel plan transfer search. TrainType 1 only takes
+// G/D trips, 2 only Z/T/K trips, anything else both.
+type transferQuery struct {
+	StartStation string `json:"startStation"`
+	ViaStation   string `json:"viaStation"`
+	EndStation   string `json:"endStation"`
+	TravelDate   string `json:"travelDate"`
+	TrainType    string `json:"trainType"`
+}
+
+// seatRequest is the request body of the seat service.
+type seatRequest struct {
+	TravelDate  string   `json:"travelDate"`
+	TrainNumber string   `json:"trainNumber"`
+	DestStation string   `json:"destStation"`
+	SeatType    int      `json:"seatType"`
+	TotalNum    int      `json:"totalNum"`
+	Stations    []string `json:"stations"`
+}
+
+// waitOrderRequest is the request body of the wait order service.
+type waitOrderRequest struct {
+	AccountId  string `json:"accountId"`
+	ContactsId string `json:"contactsId"`
+	TripId     string `json:"tripId"`
+	SeatType   int    `json:"seatType"`
+	Date       string `json:"date"`
+	From       string `json:"from"`
+	To         string `json:"to"`
+	Price      string `json:"price"`
+}
+
+// leg is a trip between two stations on a date.
+type leg struct {
+	*tripResponse
+	stops []string
+}
+
+func (l leg) duration() time.Duration {
+	start, _ := time.Parse(time.DateTime, l.StartTime)
+	end, _ := time.Parse(time.DateTime, l.EndTime)
+	return end.Sub(start)
+}
+
+func (s *Server) registerPlans() {
+	const routePlan = "/api/v1/routeplanservice/routePlan"
+	for path, less := range map[string]func(a, b leg) bool{
+		"/cheapestRoute":   cheaper,
+		"/quickestRoute":   quicker,
+		"/minStopStations": fewerStops,
+	} {
+		less := less
+		s.handle("POST", routePlan+path, func(w http.ResponseWriter, r *http.Request, _ params) {
+			var req routePlanQuery
+			if !decode(w, r, &req) {
+				return
+			}
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			result := []routePlanUnit{}
+			for _, l := range s.plan(req.StartStation, req.EndStation, req.TravelDate, "", less, req.Num) {
+				result = append(result, routePlanUnit{
+					TripId:                  l.TripId.String(),
+					TrainTypeName:           l.TrainTypeName,
+					FromStationName:         l.StartStation,
+					ToStationName:           l.TerminalStation,
+					StopStations:            l.stops,
+					PriceForSecondClassSeat: l.PriceForEconomyClass,
+					PriceForFirstClassSeat:  l.PriceForConfortClass,
+					StartTime:               l.StartTime,
+					EndTime:                 l.EndTime,
+				})
+			}
+			ok(w, "Success", result)
+		})
+	}
+
+	const travelPlan = "/api/v1/travelplanservice/travelPlan"
+	for path, less := range map[string]func(a, b leg) bool{
+		"/cheapest":   cheaper,
+		"/quickest":   quicker,
+		"/minStation": fewerStops,
+	} {
+		less := less
+		s.handle("POST", travelPlan+path, func(w http.ResponseWriter, r *http.Request, _ params) {
+			var req tripQuery
+			if !decode(w, r, &req) {
+				return
+			}
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			result := []travelPlanUnit{}
+			for _, l := range s.plan(req.StartPlace, req.EndPlace, req.DepartureTime, "", less, 5) {
+				result = append(result, planUnit(l))
+			}
+			ok(w, "Success", result)
+		})
+	}
+	// TrainTicket answers with the legs of both sections; the client decodes a single unit, so the
+	// fake returns the quickest first section, failing when either section has no trip.
+	s.handle("POST", travelPlan+"/transferResult", func(w http.ResponseWriter, r *http.Request, _ params) {
+		var req transferQuery
+		if !decode(w, r, &req) {
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		first := s.plan(req.StartStation, req.ViaStation, req.TravelDate, req.TrainType, quicker, 1)
+		second := s.plan(req.ViaStation, req.EndStation, req.TravelDate, req.TrainType, quicker, 1)
+		if len(first) == 0 || len(second) == 0 {
+			fail(w, "Cannot Find")
+			return
+		}
+		ok(w, "Success.", planUnit(first[0]))
+	})
+
+	const seats = "/api/v1/seatservice/seats"
+	s.handle("POST", seats, func(w http.ResponseWriter, r *http.Request, _ params) {
+		var req seatRequest
+		if !decode(w, r, &req) {
+			return
+		}
+		if len(req.Stations) == 0 {
+			fail(w, "Stations is empty")
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		ok(w, "Use a new seat number success", map[string]interface{}{
+			"seatNo":       s.soldSeats(req.TrainNumber, req.TravelDate, req.SeatType) + 1,
+			"startStation": req.Stations[0],
+			"destStation":  req.DestStation,
+		})
+	})
+	s.handle("POST", seats+"/left_tickets", func(w http.ResponseWriter, r *http.Request, _ params) {
+		var req seatRequest
+		if !decode(w, r, &req) {
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		total := req.TotalNum
+		if trip := s.tripById(req.TrainNumber); trip != nil && total == 0 {
+			if train := s.trainByName(trip.TrainTypeName); train != nil {
+				total = train.EconomyClass
+				if req.SeatType == SeatFirstClass {
+					total = train.ConfortClass
+				}
+			}
+		}
+		ok(w, "Get Left Ticket of Internal Success", max(total-s.soldSeats(req.TrainNumber, req.TravelDate, req.SeatType), 0))
+	})
+
+	const waitOrder = "/api/v1/waitorderservice"
+	s.handle("POST", waitOrder+"/order", func(w http.ResponseWriter, r *http.Request, _ params) {
+		var req waitOrderRequest
+		if !decode(w, r, &req) {
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		order := &Order{
+			Id:          uuid.NewString(),
+			BoughtDate:  time.Now().Format(time.DateTime),
+			TravelDate:  travelDate(req.Date).Format(time.DateOnly),
+			AccountId:   req.AccountId,
+			TrainNumber: req.TripId,
+			SeatClass:   req.SeatType,
+			From:        req.From,
+			To:          req.To,
+			Status:      OrderNotPaid,
+			Price:       req.Price,
+		}
+		if contact := find(s.store.contacts, func(c *Contact) bool { return c.Id == req.ContactsId }); contact != nil {
+			order.ContactsName = contact.Name
+			order.DocumentType = contact.DocumentType
+			order.ContactsDocumentNumber = contact.DocumentNumber
+		}
+		s.store.waitOrders = append(s.store.waitOrders, order)
+		ok(w, "Success", order)
+	})
+	s.handle("GET", waitOrder+"/orders", func(w http.ResponseWriter, r *http.Request, _ params) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		ok(w, "Success", all(s.store.waitOrders))
+	})
+	s.handle("GET", waitOrder+"/waitlistorders", func(w http.ResponseWriter, r *http.Request, _ params) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		ok(w, "Success", filter(s.store.waitOrders, func(o *Order) bool { return o.Status == OrderNotPaid }))
+	})
+
+	// The notify service sends mail and answers with a bare true.
+	const notify = "/api/v1/notifyservice"
+	for _, path := range []string{
+		"/notification/order_cancel_success", "/notification/order_changed_success",
+		"/notification/order_create_success", "/notification/preserve_success",
+	} {
+		s.handle("POST", notify+path, func(w http.ResponseWriter, r *http.Request, _ params) {
+			writeJSON(w, http.StatusOK, true)
+		})
+	}
+	for _, path := range []string{"/test_send_mail", "/test_send_mq"} {
+		s.handle("GET", notify+path, func(w http.ResponseWriter, r *http.Request, _ params) {
+			writeJSON(w, http.StatusOK, true)
+		})
+	}
+}
+
+// plan returns the n best legs from..to on date of all trips of trainType ("1" G/D, "2" Z/T/K, else all) by less.
+func (s *Server) plan(from, to, date, trainType string, less func(a, b leg) bool, n int) []leg {
+	var legs []leg
+	for _, trip := range s.store.trips {
+		if (trainType == "1" && !highSpeed(trip.TripId.String())) || (trainType == "2" && highSpeed(trip.TripId.String())) {
+			continue
+		}
+		resp := s.tripResponse(trip, from, to, date)
+		if resp == nil {
+			continue
+		}
+		l := leg{tripResponse: resp}
+		if route := s.routeById(trip.RouteId); route != nil {
+			l.stops = stationsBetween(route, from, to)
+		}
+		legs = append(legs, l)
+	}
+	sort.SliceStable(legs, func(i, j int) bool { return less(legs[i], legs[j]) })
+	if n > 0 && len(legs) > n {
+		legs = legs[:n]
+	}
+	return legs
+}
+
+func cheaper(a, b leg) bool {
+	return parsePrice(a.PriceForEconomyClass) < parsePrice(b.PriceForEconomyClass)
+}
+
+func quicker(a, b leg) bool {
+	return a.duration() < b.duration()
+}
+
+func fewerStops(a, b leg) bool {
+	return len(a.stops) < len(b.stops)
+}
+
+func planUnit(l leg) travelPlanUnit {
+	return travelPlanUnit{
+		TripId:                        l.TripId.String(),
+		TrainTypeId:                   l.TrainTypeName,
+		StartStation:                  l.StartStation,
+		EndStation:                    l.TerminalStation,
+		StopStations:                  l.stops,
+		PriceForSecondClassSeat:       l.PriceForEconomyClass,
+		NumberOfRestTicketSecondClass: l.EconomyClass,
+		PriceForFirstClassSeat:        l.PriceForConfortClass,
+		NumberOfRestTicketFirstClass:  l.ConfortClass,
+		StartTime:                     l.StartTime,
+		EndTime:                       l.EndTime,
+	}
+}
@@ -0,0 +1,300 @@
+// Package fake is an in-process TrainTicket backend for running service tests and behaviour chains
+// without a cluster. It serves the endpoints used by the service package from in-memory stores and
+// can inject latency and failures.
+package fake
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Lincyaw/loadgenerator/httpclient"
+)
+
+// Fault injects latency or a failure into matching requests.
+type Fault struct {
+	// Service, Method and Path select the requests. Service is the <service> in /api/v1/<service>/...,
+	// Path is matched with path.Match against the URL path. Empty fields match every request.
+	Service string
+	Method  string
+	Path    string
+	// Rate is the fraction of matching requests affected, <= 0 means every matching request.
+	Rate float64
+	// Latency is added before the request is handled.
+	Latency time.Duration
+	// HTTPStatus, when set, answers with this status and {"status":0,"msg":Msg} instead of handling the request.
+	HTTPStatus int
+	// Msg, when set without HTTPStatus, answers with a business failure {"status":0,"msg":Msg} and HTTP 200.
+	Msg string
+	// Drop closes the connection without a response, which the client sees as a transport error.
+	Drop bool
+}
+
+func (f Fault) match(r *http.Request) bool {
+	if f.Service != "" && f.Service != httpclient.ServiceName(r.URL.Path) {
+		return false
+	}
+	if f.Method != "" && f.Method != r.Method {
+		return false
+	}
+	if f.Path != "" {
+		if ok, err := path.Match(f.Path, r.URL.Path); err != nil || !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithLatency delays every request by latency plus a random duration in [0, jitter).
+func WithLatency(latency, jitter time.Duration) Option {
+	return func(s *Server) {
+		s.latency = latency
+		s.jitter = jitter
+	}
+}
+
+// WithFault registers a fault. Faults are checked in registration order and the first matching one applies.
+func WithFault(f Fault) Option {
+	return func(s *Server) {
+		s.faults = append(s.faults, f)
+	}
+}
+
+// WithErrorRate answers the given fraction of all requests with HTTP 500.
+func WithErrorRate(rate float64) Option {
+	return WithFault(Fault{Rate: rate, HTTPStatus: http.StatusInternalServerError, Msg: "injected error"})
+}
+
+// WithRandSeed makes latency jitter and fault sampling deterministic.
+func WithRandSeed(seed int64) Option {
+	return func(s *Server) {
+		s.rand = rand.New(rand.NewSource(seed))
+	}
+}
+
+// WithSeed replaces the data the stores start with, DefaultSeed by default.
+func WithSeed(seed Seed) Option {
+	return func(s *Server) {
+		s.seed = seed
+	}
+}
+
+// WithCaptcha makes the verify code service check codes like the real one: generate keeps a random code
+// for the YsbCaptcha cookie it sets and answers with the code in plain text as the image, verify and login
+// only accept that code. Without it any code passes.
+func WithCaptcha() Option {
+	return func(s *Server) {
+		s.captchas = make(map[string]string)
+	}
+}
+
+// Server is a running fake backend. Use its URL as BASE_URL.
+type Server struct {
+	*httptest.Server
+
+	latency time.Duration
+	jitter  time.Duration
+	seed    Seed
+	routes  []route
+
+	faultMu sync.Mutex
+	faults  []Fault
+	rand    *rand.Rand
+
+	requests atomic.Int64
+
+	// mu guards store and captchas.
+	mu    sync.Mutex
+	store *store
+	// captchas maps a YsbCaptcha cookie to its code, nil unless WithCaptcha is set.
+	captchas map[string]string
+}
+
+// NewServer starts a fake backend. Close it when done.
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		seed: DefaultSeed(),
+		rand: rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.store = newStore(s.seed)
+	s.registerUsers()
+	s.registerBasic()
+	s.registerTravel()
+	s.registerOrders()
+	s.registerPayments()
+	s.registerConfigs()
+	s.registerFood()
+	s.registerPlans()
+	s.Server = httptest.NewServer(s)
+	return s
+}
+
+// AddFault registers a fault on a running server.
+func (s *Server) AddFault(f Fault) {
+	s.faultMu.Lock()
+	defer s.faultMu.Unlock()
+	s.faults = append(s.faults, f)
+}
+
+// ClearFaults removes all faults.
+func (s *Server) ClearFaults() {
+	s.faultMu.Lock()
+	defer s.faultMu.Unlock()
+	s.faults = nil
+}
+
+// Requests returns the number of requests received, including failed ones.
+func (s *Server) Requests() int64 {
+	return s.requests.Load()
+}
+
+// delay returns the latency of a request and the fault to apply to it, if any.
+func (s *Server) delay(r *http.Request) (time.Duration, *Fault) {
+	s.faultMu.Lock()
+	defer s.faultMu.Unlock()
+	d := s.latency
+	if s.jitter > 0 {
+		d += time.Duration(s.rand.Int63n(int64(s.jitter)))
+	}
+	for i := range s.faults {
+		f := s.faults[i]
+		if !f.match(r) {
+			continue
+		}
+		if f.Rate > 0 && s.rand.Float64() >= f.Rate {
+			continue
+		}
+		return d + f.Latency, &f
+	}
+	return d, nil
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.requests.Add(1)
+	d, fault := s.delay(r)
+	if d > 0 {
+		timer := time.NewTimer(d)
+		select {
+		case <-r.Context().Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+	if fault != nil {
+		switch {
+		case fault.Drop:
+			if hijacker, ok := w.(http.Hijacker); ok {
+				if conn, _, err := hijacker.Hijack(); err == nil {
+					conn.Close()
+					return
+				}
+			}
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		case fault.HTTPStatus != 0:
+			writeJSON(w, fault.HTTPStatus, response{Status: 0, Msg: fault.Msg})
+			return
+		case fault.Msg != "":
+			fail(w, fault.Msg)
+			return
+		}
+	}
+
+	// Split before unescaping, so an escaped "/" stays inside its {name} value.
+	segments := splitPath(r.URL.EscapedPath())
+	for i, segment := range segments {
+		if unescaped, err := url.PathUnescape(segment); err == nil {
+			segments[i] = unescaped
+		}
+	}
+	for _, rt := range s.routes {
+		if p, ok := rt.match(r.Method, segments); ok {
+			rt.handler(w, r, p)
+			return
+		}
+	}
+	writeJSON(w, http.StatusNotFound, response{Status: 0, Msg: fmt.Sprintf("fake: no handler for %s %s", r.Method, r.URL.Path)})
+}
+
+// params holds the values of the {name} segments of a route.
+type params map[string]string
+
+type handlerFunc func(w http.ResponseWriter, r *http.Request, p params)
+
+type route struct {
+	method   string
+	segments []string
+	handler  handlerFunc
+}
+
+// handle registers a handler for a pattern such as "/api/v1/orderservice/order/{orderId}".
+// Routes are matched in registration order, so literal routes go before overlapping {name} routes.
+func (s *Server) handle(method, pattern string, h handlerFunc) {
+	s.routes = append(s.routes, route{method: method, segments: splitPath(pattern), handler: h})
+}
+
+func (rt route) match(method string, segments []string) (params, bool) {
+	if rt.method != method || len(rt.segments) != len(segments) {
+		return nil, false
+	}
+	p := params{}
+	for i, segment := range rt.segments {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			p[segment[1:len(segment)-1]] = segments[i]
+			continue
+		}
+		if segment != segments[i] {
+			return nil, false
+		}
+	}
+	return p, true
+}
+
+func splitPath(p string) []string {
+	return strings.Split(strings.Trim(p, "/"), "/")
+}
+
+// response is the envelope of every TrainTicket response.
+type response struct {
+	Status int         `json:"status"`
+	Msg    string      `json:"msg"`
+	Data   interface{} `json:"data"`
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
+
+func ok(w http.ResponseWriter, msg string, data interface{}) {
+	writeJSON(w, http.StatusOK, response{Status: 1, Msg: msg, Data: data})
+}
+
+func fail(w http.ResponseWriter, msg string) {
+	writeJSON(w, http.StatusOK, response{Status: 0, Msg: msg})
+}
+
+// decode reads a JSON request body, answering 400 when it is invalid.
+func decode(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeJSON(w, http.StatusBadRequest, response{Status: 0, Msg: fmt.Sprintf("invalid body: %v", err)})
+		return false
+	}
+	return true
+}
@@ -0,0 +1,233 @@
+package fake
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+type result struct {
+	Status int             `json:"status"`
+	Msg    string          `json:"msg"`
+	Data   json.RawMessage `json:"data"`
+}
+
+// call sends a JSON request to the server and decodes the TrainTicket response envelope.
+func call(t *testing.T, s *Server, method, path string, body interface{}) (int, result) {
+	t.Helper()
+	var payload bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&payload).Encode(body); err != nil {
+			t.Fatal(err)
+		}
+	}
+	req, err := http.NewRequest(method, s.URL+path, &payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := s.Client().Do(req)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, path, err)
+	}
+	defer resp.Body.Close()
+	var res result
+	json.NewDecoder(resp.Body).Decode(&res)
+	return resp.StatusCode, res
+}
+
+func TestServer_Routing(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	code, res := call(t, s, "GET", "/api/v1/stationservice/stations", nil)
+	if code != http.StatusOK || res.Status != 1 {
+		t.Fatalf("GET stations = %d %+v", code, res)
+	}
+	var stations []Station
+	json.Unmarshal(res.Data, &stations)
+	if len(stations) != len(DefaultSeed().Stations) {
+		t.Errorf("got %d stations, want %d", len(stations), len(DefaultSeed().Stations))
+	}
+
+	_, res = call(t, s, "GET", "/api/v1/travelservice/trips/G1234", nil)
+	var trip Trip
+	json.Unmarshal(res.Data, &trip)
+	if res.Status != 1 || trip.TripId.String() != "G1234" {
+		t.Errorf("GET trips/G1234 = %+v", res)
+	}
+
+	code, res = call(t, s, "GET", "/api/v1/nosuchservice/things", nil)
+	if code != http.StatusNotFound || res.Msg != "fake: no handler for GET /api/v1/nosuchservice/things" {
+		t.Errorf("unknown route = %d %+v", code, res)
+	}
+	if s.Requests() != 3 {
+		t.Errorf("Requests() = %d, want 3", s.Requests())
+	}
+}
+
+func TestServer_Latency(t *testing.T) {
+	s := NewServer(WithLatency(50*time.Millisecond, 10*time.Millisecond), WithRandSeed(1))
+	defer s.Close()
+
+	start := time.Now()
+	call(t, s, "GET", "/api/v1/trainservice/trains", nil)
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("request took %v, want at least 50ms", elapsed)
+	}
+}
+
+func TestServer_Faults(t *testing.T) {
+	tests := []struct {
+		name       string
+		fault      Fault
+		path       string
+		wantCode   int
+		wantStatus int
+		wantMsg    string
+	}{
+		{"http status", Fault{Service: "trainservice", HTTPStatus: http.StatusServiceUnavailable, Msg: "down"}, "/api/v1/trainservice/trains", http.StatusServiceUnavailable, 0, "down"},
+		{"business failure", Fault{Method: "GET", Path: "/api/v1/trainservice/*", Msg: "busy"}, "/api/v1/trainservice/trains", http.StatusOK, 0, "busy"},
+		{"other service", Fault{Service: "stationservice", Msg: "busy"}, "/api/v1/trainservice/trains", http.StatusOK, 1, "success"},
+		{"never", Fault{Rate: 1e-9, Msg: "busy"}, "/api/v1/trainservice/trains", http.StatusOK, 1, "success"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewServer(WithFault(tt.fault), WithRandSeed(1))
+			defer s.Close()
+			code, res := call(t, s, "GET", tt.path, nil)
+			if code != tt.wantCode || res.Status != tt.wantStatus || res.Msg != tt.wantMsg {
+				t.Errorf("got %d %d %q, want %d %d %q", code, res.Status, res.Msg, tt.wantCode, tt.wantStatus, tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestServer_ErrorRate(t *testing.T) {
+	s := NewServer(WithErrorRate(0.5), WithRandSeed(7))
+	defer s.Close()
+
+	failed := 0
+	for i := 0; i < 200; i++ {
+		if code, _ := call(t, s, "GET", "/api/v1/trainservice/trains", nil); code == http.StatusInternalServerError {
+			failed++
+		}
+	}
+	if failed < 70 || failed > 130 {
+		t.Errorf("%d of 200 requests failed, want about 100", failed)
+	}
+
+	s.ClearFaults()
+	if code, _ := call(t, s, "GET", "/api/v1/trainservice/trains", nil); code != http.StatusOK {
+		t.Errorf("after ClearFaults got %d", code)
+	}
+}
+
+func TestServer_Drop(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	s.AddFault(Fault{Path: "/api/v1/users/login", Drop: true})
+
+	resp, err := s.Client().Post(s.URL+"/api/v1/users/login", "application/json", bytes.NewBufferString(`{}`))
+	if err == nil {
+		resp.Body.Close()
+		t.Fatal("dropped request succeeded")
+	}
+}
+
+// TestServer_PreserveAndPay books, pays and cancels a ticket and checks the balance along the way.
+func TestServer_PreserveAndPay(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	_, res := call(t, s, "POST", "/api/v1/users/login", map[string]string{"username": "fdse_microservice", "password": "111111"})
+	var login struct {
+		UserId string `json:"userId"`
+		Token  string `json:"token"`
+	}
+	json.Unmarshal(res.Data, &login)
+	if res.Status != 1 || login.UserId != BasicUserId || login.Token == "" {
+		t.Fatalf("login = %+v", res)
+	}
+
+	_, res = call(t, s, "GET", "/api/v1/contactservice/contacts/account/"+BasicUserId, nil)
+	var contacts []Contact
+	json.Unmarshal(res.Data, &contacts)
+	if len(contacts) == 0 {
+		t.Fatalf("no contacts: %+v", res)
+	}
+
+	date := time.Now().AddDate(0, 0, 1).Format(time.DateOnly)
+	_, res = call(t, s, "POST", "/api/v1/preserveservice/preserve", orderTickets{
+		AccountId: BasicUserId, ContactsId: contacts[0].Id, TripId: "G1234", SeatType: SeatSecondClass,
+		Date: date, From: "nanjing", To: "shanghai", Assurance: 1,
+	})
+	if res.Status != 1 {
+		t.Fatalf("preserve = %+v", res)
+	}
+
+	_, res = call(t, s, "POST", "/api/v1/orderservice/order/refresh", orderQuery{LoginId: BasicUserId, EnableStateQuery: true, State: OrderNotPaid})
+	var orders []Order
+	json.Unmarshal(res.Data, &orders)
+	var order *Order
+	for i := range orders {
+		if orders[i].TravelDate == date {
+			order = &orders[i]
+		}
+	}
+	if order == nil || order.Price != "95" {
+		t.Fatalf("preserved order not found or mispriced: %+v", orders)
+	}
+
+	_, res = call(t, s, "POST", "/api/v1/inside_pay_service/inside_payment", tripPayment{TripId: "G1234", OrderId: order.Id, UserId: BasicUserId})
+	if res.Status != 1 {
+		t.Fatalf("pay = %+v", res)
+	}
+	_, res = call(t, s, "POST", "/api/v1/inside_pay_service/inside_payment", tripPayment{TripId: "G1234", OrderId: order.Id, UserId: BasicUserId})
+	if res.Status != 0 {
+		t.Errorf("paying twice = %+v", res)
+	}
+	if got := balance(t, s, BasicUserId); got != "9905" {
+		t.Errorf("balance after paying = %s, want 9905", got)
+	}
+
+	_, res = call(t, s, "GET", "/api/v1/cancelservice/cancel/"+order.Id+"/"+BasicUserId, nil)
+	if res.Status != 1 {
+		t.Fatalf("cancel = %+v", res)
+	}
+	if got := balance(t, s, BasicUserId); got != "9981" {
+		t.Errorf("balance after refund = %s, want 9981", got)
+	}
+}
+
+func balance(t *testing.T, s *Server, userId string) string {
+	_, res := call(t, s, "GET", "/api/v1/inside_pay_service/inside_payment/account", nil)
+	var balances []map[string]string
+	json.Unmarshal(res.Data, &balances)
+	for _, b := range balances {
+		if b["userId"] == userId {
+			return b["balance"]
+		}
+	}
+	return ""
+}
+
+func TestServer_SeedIsolation(t *testing.T) {
+	seed := DefaultSeed()
+	a, b := NewServer(WithSeed(seed)), NewServer(WithSeed(seed))
+	defer a.Close()
+	defer b.Close()
+
+	_, res := call(t, a, "DELETE", "/api/v1/stationservice/stations/"+seed.Stations[0].Id, nil)
+	if res.Status != 1 {
+		t.Fatalf("delete station = %+v", res)
+	}
+	_, res = call(t, b, "GET", "/api/v1/stationservice/stations", nil)
+	var stations []Station
+	json.Unmarshal(res.Data, &stations)
+	if len(stations) != len(seed.Stations) {
+		t.Errorf("deleting on one server changed the other: %d stations", len(stations))
+	}
+}
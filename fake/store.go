@@ -0,0 +1,610 @@
+package fake
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// User is an account of the auth and user services.
+type User struct {
+	UserId       string `json:"userId"`
+	UserName     string `json:"userName"`
+	Password     string `json:"password"`
+	Gender       int    `json:"gender"`
+	DocumentType int    `json:"documentType"`
+	DocumentNum  string `json:"documentNum"`
+	Email        string `json:"email"`
+}
+
+// Contact is a passenger of an account.
+type Contact struct {
+	Id             string `json:"id"`
+	AccountId      string `json:"accountId"`
+	Name           string `json:"name"`
+	DocumentType   int    `json:"documentType"`
+	DocumentNumber string `json:"documentNumber"`
+	PhoneNumber    string `json:"phoneNumber"`
+}
+
+// Station is a station of the station service. Names are lower case without spaces.
+type Station struct {
+	Id       string `json:"id"`
+	Name     string `json:"name"`
+	StayTime int    `json:"stayTime"`
+}
+
+// TrainType holds the seat capacity of a train. ConfortClass is spelled as in TrainTicket.
+type TrainType struct {
+	Id           string `json:"id"`
+	Name         string `json:"name"`
+	EconomyClass int    `json:"economyClass"`
+	ConfortClass int    `json:"confortClass"`
+	AverageSpeed int    `json:"averageSpeed"`
+}
+
+// Route is an ordered list of stations with their distance from the first one.
+type Route struct {
+	Id           string   `json:"id"`
+	Stations     []string `json:"stations"`
+	Distances    []int    `json:"distances"`
+	StartStation string   `json:"startStation"`
+	EndStation   string   `json:"endStation"`
+}
+
+// distance returns the distance between two stations of the route, false when to is not after from.
+func (r *Route) distance(from, to string) (int, bool) {
+	start, end := -1, -1
+	for i, station := range r.Stations {
+		if station == from {
+			start = i
+		}
+		if station == to {
+			end = i
+		}
+	}
+	if start < 0 || end <= start || end >= len(r.Distances) {
+		return 0, false
+	}
+	return r.Distances[end] - r.Distances[start], true
+}
+
+type TripId struct {
+	Type   string `json:"type"`
+	Number string `json:"number"`
+}
+
+func (t TripId) String() string {
+	return t.Type + t.Number
+}
+
+func parseTripId(s string) TripId {
+	if s == "" {
+		return TripId{}
+	}
+	return TripId{Type: strings.ToUpper(s[:1]), Number: s[1:]}
+}
+
+// highSpeed reports whether a trip is served by travel-service and order-service (G/D) rather than
+// travel2-service and order-other-service (Z/T/K).
+func highSpeed(tripId string) bool {
+	t := parseTripId(tripId).Type
+	return t == "G" || t == "D"
+}
+
+// Trip is a scheduled train. StartTime and EndTime only matter for their time of day.
+type Trip struct {
+	Id                  string `json:"id"`
+	TripId              TripId `json:"tripId"`
+	TrainTypeName       string `json:"trainTypeName"`
+	RouteId             string `json:"routeId"`
+	StartStationName    string `json:"startStationName"`
+	StationsName        string `json:"stationsName"`
+	TerminalStationName string `json:"terminalStationName"`
+	StartTime           string `json:"startTime"`
+	EndTime             string `json:"endTime"`
+}
+
+// Order statuses
+const (
+	OrderNotPaid = iota
+	OrderPaid
+	OrderCollected
+	OrderChange
+	OrderCancel
+	OrderRefunds
+	OrderUsed
+)
+
+// Seat classes
+const (
+	SeatFirstClass  = 2
+	SeatSecondClass = 3
+)
+
+// Order is a ticket of the order or order-other service.
+type Order struct {
+	Id                     string `json:"id"`
+	BoughtDate             string `json:"boughtDate"`
+	TravelDate             string `json:"travelDate"`
+	TravelTime             string `json:"travelTime"`
+	AccountId              string `json:"accountId"`
+	ContactsName           string `json:"contactsName"`
+	DocumentType           int    `json:"documentType"`
+	ContactsDocumentNumber string `json:"contactsDocumentNumber"`
+	TrainNumber            string `json:"trainNumber"`
+	CoachNumber            int    `json:"coachNumber"`
+	SeatClass              int    `json:"seatClass"`
+	SeatNumber             int    `json:"seatNumber"`
+	From                   string `json:"from"`
+	To                     string `json:"to"`
+	Status                 int    `json:"status"`
+	Price                  string `json:"price"`
+	DifferenceMoney        string `json:"differenceMoney"`
+}
+
+// Payment is a payment of an order through inside-payment or payment service.
+type Payment struct {
+	Id      string `json:"id"`
+	OrderId string `json:"orderId"`
+	TripId  string `json:"tripId,omitempty"`
+	UserId  string `json:"userId"`
+	Price   string `json:"price"`
+}
+
+// Money types
+const (
+	MoneyAdd      = "A"
+	MoneyDrawBack = "D"
+)
+
+// Money is a change of an inside-payment balance other than a ticket payment.
+type Money struct {
+	Id     string `json:"id"`
+	UserId string `json:"userId"`
+	Money  string `json:"money"`
+	Type   string `json:"type"`
+}
+
+// Account is an inside-payment balance.
+type Account struct {
+	UserId string `json:"userId"`
+	Money  string `json:"money"`
+}
+
+// AssuranceType is an insurance that can be bought with a ticket.
+type AssuranceType struct {
+	Index int     `json:"index"`
+	Name  string  `json:"name"`
+	Price float64 `json:"price"`
+	// Type is the enum name TrainTicket returns for a single assurance, e.g. "TRAFFIC_ACCIDENT".
+	Type string `json:"-"`
+}
+
+// Assurance is an insurance bought for an order.
+type Assurance struct {
+	Id        string `json:"id"`
+	OrderId   string `json:"orderId"`
+	TypeIndex int    `json:"typeIndex"`
+}
+
+// Config is a name/value setting of the config service.
+type Config struct {
+	Name        string `json:"name"`
+	Value       string `json:"value"`
+	Description string `json:"description"`
+}
+
+// SecurityConfig is a limit checked by the security service before an order is preserved.
+type SecurityConfig struct {
+	Id          string `json:"id"`
+	Name        string `json:"name"`
+	Value       string `json:"value"`
+	Description string `json:"description"`
+}
+
+// Price is the price rate of a train type on a route.
+type Price struct {
+	Id                  string  `json:"id"`
+	TrainType           string  `json:"trainType"`
+	RouteId             string  `json:"routeId"`
+	BasicPriceRate      float64 `json:"basicPriceRate"`
+	FirstClassPriceRate float64 `json:"firstClassPriceRate"`
+}
+
+// Consign is a luggage consignment attached to an order.
+type Consign struct {
+	Id         string  `json:"id"`
+	OrderId    string  `json:"orderId"`
+	AccountId  string  `json:"accountId"`
+	HandleDate string  `json:"handleDate"`
+	TargetDate string  `json:"targetDate"`
+	From       string  `json:"from"`
+	To         string  `json:"to"`
+	Consignee  string  `json:"consignee"`
+	Phone      string  `json:"phone"`
+	Weight     float64 `json:"weight"`
+	IsWithin   bool    `json:"isWithin"`
+	Price      float64 `json:"price"`
+}
+
+// ConsignPrice is the pricing of consignments: InitialPrice up to InitialWeight, then a price per
+// extra unit of weight that depends on whether the consignment stays within the region.
+type ConsignPrice struct {
+	Id            string  `json:"id"`
+	Index         int     `json:"index"`
+	InitialWeight float64 `json:"initialWeight"`
+	InitialPrice  float64 `json:"initialPrice"`
+	WithinPrice   float64 `json:"withinPrice"`
+	BeyondPrice   float64 `json:"beyondPrice"`
+}
+
+// Food is a dish of a station store or a train.
+type Food struct {
+	FoodName string  `json:"foodName"`
+	Price    float64 `json:"price"`
+}
+
+// StationFoodStore is a food store in a station.
+type StationFoodStore struct {
+	Id           string  `json:"id"`
+	StationName  string  `json:"stationName"`
+	StoreName    string  `json:"storeName"`
+	Telephone    string  `json:"telephone"`
+	BusinessTime string  `json:"businessTime"`
+	DeliveryFee  float64 `json:"deliveryFee"`
+	FoodList     []Food  `json:"foodList"`
+}
+
+// TrainFood is the menu served on a trip.
+type TrainFood struct {
+	Id       string `json:"id"`
+	TripId   string `json:"tripId"`
+	FoodList []Food `json:"foodList"`
+}
+
+// Food order types
+const (
+	FoodTrain = 1
+	FoodStore = 2
+)
+
+// FoodOrder is the meal booked with a ticket.
+type FoodOrder struct {
+	Id          string  `json:"id"`
+	OrderId     string  `json:"orderId"`
+	FoodType    int     `json:"foodType"`
+	StationName string  `json:"stationName"`
+	StoreName   string  `json:"storeName"`
+	FoodName    string  `json:"foodName"`
+	Price       float64 `json:"price"`
+}
+
+// FoodDeliveryOrder is a meal delivered from a station store to a seat.
+type FoodDeliveryOrder struct {
+	Id                 string  `json:"id"`
+	StationFoodStoreId string  `json:"stationFoodStoreId"`
+	FoodList           []Food  `json:"foodList"`
+	TripId             string  `json:"tripId"`
+	SeatNo             int     `json:"seatNo"`
+	CreatedTime        string  `json:"createdTime"`
+	DeliveryTime       string  `json:"deliveryTime"`
+	DeliveryFee        float64 `json:"deliveryFee"`
+}
+
+// Delivery statuses
+const (
+	DeliveryCreated = iota
+	DeliveryDelivering
+	DeliveryDelivered
+	DeliveryCancelled
+)
+
+// Delivery tracks one dish of a food delivery order to the seat.
+type Delivery struct {
+	Id          string `json:"id"`
+	OrderId     string `json:"orderId"`
+	FoodName    string `json:"foodName"`
+	StoreName   string `json:"storeName"`
+	StationName string `json:"stationName"`
+	Status      int    `json:"status"`
+}
+
+// Seed is the data the stores start with.
+type Seed struct {
+	Users          []User
+	Contacts       []Contact
+	Stations       []Station
+	Trains         []TrainType
+	Routes         []Route
+	Trips          []Trip
+	Orders         []Order
+	Accounts       []Account
+	AssuranceTypes []AssuranceType
+
+	Configs            []Config
+	SecurityConfigs    []SecurityConfig
+	Prices             []Price
+	ConsignPrice       ConsignPrice
+	StationFoodStores  []StationFoodStore
+	TrainFoods         []TrainFood
+	FoodDeliveryOrders []FoodDeliveryOrder
+}
+
+// Well-known ids of DefaultSeed.
+const (
+	AdminUserId = "d0b9a6b8-5f4e-4a0a-9a3c-6a3f4c2e7d11"
+	BasicUserId = "4d2a46c7-71cb-4cf1-b5bb-b68406d9da6f"
+
+	ConsignPriceId      = "39f89515-2d68-4ffb-9214-3c25a73da65f"
+	StationFoodStoreId  = "fc212d9b-4215-40ab-bc66-a02710fd387b"
+	FoodDeliveryOrderId = "8a80811d9031564e0190366bc1950000"
+)
+
+// DefaultSeed returns a small copy of the TrainTicket initial data: the admin and fdse_microservice
+// accounts, the stations, trains and routes between Shanghai, Nanjing, Suzhou and Taiyuan, G/D/Z/T/K
+// trips on them with one unpaid order each for G1234 and Z1234, and the configs, prices and food stores.
+func DefaultSeed() Seed {
+	stations := []Station{
+		{Name: "shanghai", StayTime: 10}, {Name: "shanghaihongqiao", StayTime: 10}, {Name: "taiyuan", StayTime: 5},
+		{Name: "beijing", StayTime: 10}, {Name: "nanjing", StayTime: 8}, {Name: "shijiazhuang", StayTime: 8},
+		{Name: "xuzhou", StayTime: 7}, {Name: "jinan", StayTime: 5}, {Name: "hangzhou", StayTime: 9},
+		{Name: "jiaxingnan", StayTime: 2}, {Name: "zhenjiang", StayTime: 2}, {Name: "wuxi", StayTime: 3},
+		{Name: "suzhou", StayTime: 3},
+	}
+	for i := range stations {
+		stations[i].Id = uuid.NewString()
+	}
+	return Seed{
+		Users: []User{
+			{UserId: AdminUserId, UserName: "admin", Password: "222222", Gender: 1, DocumentType: 1, DocumentNum: "2135488099312X", Email: "admin@163.com"},
+			{UserId: BasicUserId, UserName: "fdse_microservice", Password: "111111", Gender: 1, DocumentType: 1, DocumentNum: "2135488099312X", Email: "trainticket_notify@163.com"},
+		},
+		Contacts: []Contact{
+			{Id: uuid.NewString(), AccountId: BasicUserId, Name: "Contacts_One", DocumentType: 1, DocumentNumber: "DocumentNumber_One", PhoneNumber: "ContactsPhoneNum_One"},
+			{Id: uuid.NewString(), AccountId: BasicUserId, Name: "Contacts_Two", DocumentType: 1, DocumentNumber: "DocumentNumber_Two", PhoneNumber: "ContactsPhoneNum_Two"},
+		},
+		Stations: stations,
+		Trains: []TrainType{
+			{Id: "GaoTieOne", Name: "GaoTieOne", EconomyClass: 2147483647, ConfortClass: 2147483647, AverageSpeed: 250},
+			{Id: "GaoTieTwo", Name: "GaoTieTwo", EconomyClass: 2147483647, ConfortClass: 2147483647, AverageSpeed: 200},
+			{Id: "DongCheOne", Name: "DongCheOne", EconomyClass: 2147483647, ConfortClass: 2147483647, AverageSpeed: 180},
+			{Id: "ZhiDa", Name: "ZhiDa", EconomyClass: 2147483647, ConfortClass: 2147483647, AverageSpeed: 120},
+			{Id: "TeKuai", Name: "TeKuai", EconomyClass: 2147483647, ConfortClass: 2147483647, AverageSpeed: 120},
+			{Id: "KuaiSu", Name: "KuaiSu", EconomyClass: 2147483647, ConfortClass: 2147483647, AverageSpeed: 90},
+		},
+		Routes: []Route{
+			newRoute("0b23bd3e-876a-4af3-b920-c50a90c90b04", "shanghai,nanjing,shijiazhuang,taiyuan", "0,350,1000,1300"),
+			newRoute("9fc9c261-3263-4bfa-82f8-bb44e06b2f52", "nanjing,xuzhou,jinan,beijing", "0,500,700,1200"),
+			newRoute("d693a2c5-ef87-4a3c-bef8-600b43f62c68", "taiyuan,shijiazhuang,nanjing,shanghai", "0,300,950,1300"),
+			newRoute("20eb7122-3a11-423f-b10a-be0dc5bce7db", "shanghai,taiyuan", "0,1300"),
+			newRoute("1367db1f-461e-4ab7-87ad-2bcc05fd9cb7", "shanghaihongqiao,jiaxingnan,hangzhou", "0,150,300"),
+			newRoute("92708982-77af-4318-be25-57ccb0ff69ad", "nanjing,zhenjiang,wuxi,suzhou,shanghai", "0,100,150,200,250"),
+			newRoute("aefcef3f-3f42-46e8-afd7-6cb2a928bd3d", "nanjing,shanghai", "0,250"),
+			newRoute("a3f256c1-0e43-4f7d-9c21-121bf258101f", "nanjing,suzhou", "0,200"),
+			newRoute("084837bb-53c8-4438-87c8-0321a4d09917", "suzhou,shanghai", "0,50"),
+			newRoute("f3d4d4ef-693b-4456-8eed-59c0d717dd08", "shanghai,suzhou", "0,50"),
+		},
+		Trips: []Trip{
+			newTrip("G1234", "GaoTieOne", "92708982-77af-4318-be25-57ccb0ff69ad", "nanjing,zhenjiang,wuxi,suzhou,shanghai", "09:00:00", "15:00:00"),
+			newTrip("G1235", "GaoTieOne", "aefcef3f-3f42-46e8-afd7-6cb2a928bd3d", "nanjing,shanghai", "12:00:00", "17:00:00"),
+			newTrip("G1236", "GaoTieOne", "a3f256c1-0e43-4f7d-9c21-121bf258101f", "nanjing,suzhou", "14:00:00", "20:00:00"),
+			newTrip("G1237", "GaoTieTwo", "084837bb-53c8-4438-87c8-0321a4d09917", "suzhou,shanghai", "08:00:00", "17:30:00"),
+			newTrip("D1345", "DongCheOne", "f3d4d4ef-693b-4456-8eed-59c0d717dd08", "shanghai,suzhou", "07:00:00", "19:00:00"),
+			newTrip("Z1234", "ZhiDa", "0b23bd3e-876a-4af3-b920-c50a90c90b04", "shanghai,nanjing,shijiazhuang,taiyuan", "09:51:52", "15:51:52"),
+			newTrip("Z1235", "ZhiDa", "9fc9c261-3263-4bfa-82f8-bb44e06b2f52", "nanjing,xuzhou,jinan,beijing", "11:31:52", "17:51:52"),
+			newTrip("T1235", "TeKuai", "d693a2c5-ef87-4a3c-bef8-600b43f62c68", "taiyuan,shijiazhuang,nanjing,shanghai", "08:31:52", "17:21:52"),
+			newTrip("K1345", "KuaiSu", "20eb7122-3a11-423f-b10a-be0dc5bce7db", "shanghai,taiyuan", "07:51:52", "19:59:52"),
+		},
+		Accounts: []Account{
+			{UserId: AdminUserId, Money: "10000"},
+			{UserId: BasicUserId, Money: "10000"},
+		},
+		Orders: []Order{
+			newOrder("G1234", "nanjing", "shanghai", "2024-06-06", "09:00:00", "95"),
+			newOrder("Z1234", "shanghai", "taiyuan", "2024-06-06", "09:51:52", "494"),
+		},
+		AssuranceTypes: []AssuranceType{
+			{Index: 1, Name: "Traffic Accident Assurance", Price: 3.0, Type: "TRAFFIC_ACCIDENT"},
+		},
+		Configs: []Config{
+			{Name: "DirectTicketAllocationProportion", Value: "0.5", Description: "Allocation Proportion Of The Direct Ticket - From Start To End"},
+		},
+		SecurityConfigs: []SecurityConfig{
+			{Id: uuid.NewString(), Name: "max_order_1_hour", Value: "2147483647", Description: "Max in 1 hour"},
+			{Id: uuid.NewString(), Name: "max_order_not_use", Value: "2147483647", Description: "Max not used"},
+		},
+		Prices: []Price{
+			{Id: uuid.NewString(), TrainType: "GaoTieOne", RouteId: "92708982-77af-4318-be25-57ccb0ff69ad", BasicPriceRate: economyPriceRate, FirstClassPriceRate: confortPriceRate},
+			{Id: uuid.NewString(), TrainType: "ZhiDa", RouteId: "0b23bd3e-876a-4af3-b920-c50a90c90b04", BasicPriceRate: economyPriceRate, FirstClassPriceRate: confortPriceRate},
+		},
+		ConsignPrice: ConsignPrice{Id: ConsignPriceId, InitialWeight: 1, InitialPrice: 8, WithinPrice: 2, BeyondPrice: 4},
+		StationFoodStores: []StationFoodStore{
+			{
+				Id: StationFoodStoreId, StationName: "shanghai", StoreName: "Roman Holiday", Telephone: "3769464",
+				BusinessTime: "08:00-23:00", DeliveryFee: 15,
+				FoodList: []Food{{FoodName: "Big Burger", Price: 1.2}, {FoodName: "Bone Soup", Price: 2.5}},
+			},
+			{
+				Id: uuid.NewString(), StationName: "nanjing", StoreName: "KFC", Telephone: "01-234567",
+				BusinessTime: "10:00-20:00", DeliveryFee: 20,
+				FoodList: []Food{{FoodName: "Hamburger", Price: 5.0}, {FoodName: "Cola", Price: 2.0}},
+			},
+		},
+		TrainFoods: []TrainFood{
+			{Id: uuid.NewString(), TripId: "G1234", FoodList: []Food{{FoodName: "Pork Chop with rice", Price: 9.5}, {FoodName: "Egg Soup", Price: 3.2}}},
+			{Id: uuid.NewString(), TripId: "Z1234", FoodList: []Food{{FoodName: "Soup", Price: 3.7}, {FoodName: "Spicy hot noodles", Price: 5}}},
+		},
+		FoodDeliveryOrders: []FoodDeliveryOrder{
+			{
+				Id: FoodDeliveryOrderId, StationFoodStoreId: StationFoodStoreId, FoodList: []Food{{FoodName: "Big Burger", Price: 1.2}},
+				TripId: "G1234", SeatNo: 1, CreatedTime: "2024-06-06 08:00:00", DeliveryTime: "2024-06-06 10:00:00", DeliveryFee: 15,
+			},
+		},
+	}
+}
+
+// newOrder returns an unpaid second class order of the fdse_microservice account.
+func newOrder(tripId, from, to, date, clock, price string) Order {
+	return Order{
+		Id:                     uuid.NewString(),
+		BoughtDate:             "2024-06-01 12:00:00",
+		TravelDate:             date,
+		TravelTime:             date + " " + clock,
+		AccountId:              BasicUserId,
+		ContactsName:           "Contacts_One",
+		DocumentType:           1,
+		ContactsDocumentNumber: "DocumentNumber_One",
+		TrainNumber:            tripId,
+		CoachNumber:            5,
+		SeatClass:              SeatSecondClass,
+		SeatNumber:             1,
+		From:                   from,
+		To:                     to,
+		Status:                 OrderNotPaid,
+		Price:                  price,
+		DifferenceMoney:        "0",
+	}
+}
+
+func newRoute(id, stations, distances string) Route {
+	r := Route{Id: id, Stations: strings.Split(stations, ",")}
+	for _, d := range strings.Split(distances, ",") {
+		n, _ := strconv.Atoi(d)
+		r.Distances = append(r.Distances, n)
+	}
+	r.StartStation = r.Stations[0]
+	r.EndStation = r.Stations[len(r.Stations)-1]
+	return r
+}
+
+func newTrip(tripId, trainType, routeId, stations, start, end string) Trip {
+	names := strings.Split(stations, ",")
+	return Trip{
+		Id:                  uuid.NewString(),
+		TripId:              parseTripId(tripId),
+		TrainTypeName:       trainType,
+		RouteId:             routeId,
+		StartStationName:    names[0],
+		StationsName:        stations,
+		TerminalStationName: names[len(names)-1],
+		StartTime:           "2013-05-04 " + start,
+		EndTime:             "2013-05-04 " + end,
+	}
+}
+
+// Default price rates per distance unit, used for trips whose route and train have no price config.
+const (
+	economyPriceRate = 0.38
+	confortPriceRate = 1.0
+)
+
+// store holds the state of the fake backend. Server.mu guards it.
+type store struct {
+	users          []*User
+	contacts       []*Contact
+	stations       []*Station
+	trains         []*TrainType
+	routes         []*Route
+	trips          []*Trip
+	orders         map[string][]*Order // by service, "orderservice" or "orderOtherService"
+	payments       []*Payment
+	moneys         []*Money
+	accounts       []*Account
+	assurances     []*Assurance
+	assuranceTypes []AssuranceType
+
+	configs            []*Config
+	securityConfigs    []*SecurityConfig
+	prices             []*Price
+	consigns           []*Consign
+	consignPrice       ConsignPrice
+	stationFoodStores  []*StationFoodStore
+	trainFoods         []*TrainFood
+	foodOrders         []*FoodOrder
+	foodDeliveryOrders []*FoodDeliveryOrder
+	deliveries         []*Delivery
+	waitOrders         []*Order
+}
+
+func newStore(seed Seed) *store {
+	st := &store{
+		users:              clone(seed.Users),
+		contacts:           clone(seed.Contacts),
+		stations:           clone(seed.Stations),
+		trains:             clone(seed.Trains),
+		routes:             clone(seed.Routes),
+		trips:              clone(seed.Trips),
+		orders:             map[string][]*Order{orderService: nil, orderOtherService: nil},
+		accounts:           clone(seed.Accounts),
+		assuranceTypes:     seed.AssuranceTypes,
+		configs:            clone(seed.Configs),
+		securityConfigs:    clone(seed.SecurityConfigs),
+		prices:             clone(seed.Prices),
+		consignPrice:       seed.ConsignPrice,
+		stationFoodStores:  clone(seed.StationFoodStores),
+		trainFoods:         clone(seed.TrainFoods),
+		foodDeliveryOrders: clone(seed.FoodDeliveryOrders),
+	}
+	for _, order := range clone(seed.Orders) {
+		svc := orderServiceOf(order.TrainNumber)
+		st.orders[svc] = append(st.orders[svc], order)
+	}
+	for _, order := range st.foodDeliveryOrders {
+		st.deliver(order)
+	}
+	return st
+}
+
+// clone returns pointers to copies of items, so the server never changes a Seed.
+func clone[T any](items []T) []*T {
+	result := make([]*T, len(items))
+	for i := range items {
+		item := items[i]
+		result[i] = &item
+	}
+	return result
+}
+
+// find returns the first item for which match is true.
+func find[T any](items []*T, match func(*T) bool) *T {
+	for _, item := range items {
+		if match(item) {
+			return item
+		}
+	}
+	return nil
+}
+
+// all returns items, never nil so it encodes as [].
+func all[T any](items []*T) []*T {
+	if items == nil {
+		return []*T{}
+	}
+	return items
+}
+
+// filter returns the items for which match is true, never nil so it encodes as [].
+func filter[T any](items []*T, match func(*T) bool) []*T {
+	result := []*T{}
+	for _, item := range items {
+		if match(item) {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// remove deletes the items for which match is true and reports whether any was deleted.
+func remove[T any](items *[]*T, match func(*T) bool) bool {
+	kept := (*items)[:0]
+	for _, item := range *items {
+		if !match(item) {
+			kept = append(kept, item)
+		}
+	}
+	removed := len(kept) != len(*items)
+	*items = kept
+	return removed
+}
@@ -0,0 +1,428 @@
+package fake
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	travelService  = "travelservice"
+	travel2Service = "travel2service"
+)
+
+// travelInfo is the request body of trip creation and the element of the admintravel list.
+type travelInfo struct {
+	LoginId             string `json:"loginId"`
+	TripId              string `json:"tripId"`
+	TrainTypeName       string `json:"trainTypeName"`
+	RouteId             string `json:"routeId"`
+	StartStationName    string `json:"startStationName"`
+	StationsName        string `json:"stationsName"`
+	TerminalStationName string `json:"terminalStationName"`
+	StartTime           string `json:"startTime"`
+	EndTime             string `json:"endTime"`
+}
+
+func (t travelInfo) trip(id string) Trip {
+	return Trip{
+		Id:                  id,
+		TripId:              parseTripId(t.TripId),
+		TrainTypeName:       t.TrainTypeName,
+		RouteId:             t.RouteId,
+		StartStationName:    stationName(t.StartStationName),
+		StationsName:        stationName(t.StationsName),
+		TerminalStationName: stationName(t.TerminalStationName),
+		StartTime:           t.StartTime,
+		EndTime:             t.EndTime,
+	}
+}
+
+// tripResponse is a trip between two stations of its route on a given date, with seats left and prices.
+type tripResponse struct {
+	TripId               TripId `json:"tripId"`
+	TrainTypeName        string `json:"trainTypeName"`
+	StartStation         string `json:"startStation"`
+	TerminalStation      string `json:"terminalStation"`
+	StartTime            string `json:"startTime"`
+	EndTime              string `json:"endTime"`
+	EconomyClass         int    `json:"economyClass"`
+	ConfortClass         int    `json:"confortClass"`
+	PriceForEconomyClass string `json:"priceForEconomyClass"`
+	PriceForConfortClass string `json:"priceForConfortClass"`
+}
+
+type tripQuery struct {
+	StartPlace    string `json:"startPlace"`
+	EndPlace      string `json:"endPlace"`
+	DepartureTime string `json:"departureTime"`
+}
+
+type tripDetailQuery struct {
+	TripId     string `json:"tripId"`
+	TravelDate string `json:"travelDate"`
+	From       string `json:"from"`
+	To         string `json:"to"`
+}
+
+func (s *Server) registerTravel() {
+	for _, svc := range []string{travelService, travel2Service} {
+		svc := svc
+		prefix := "/api/v1/" + svc
+		s.handle("GET", prefix+"/trips", func(w http.ResponseWriter, r *http.Request, _ params) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			ok(w, "Success", s.tripsOf(svc))
+		})
+		s.handle("POST", prefix+"/trips", func(w http.ResponseWriter, r *http.Request, _ params) {
+			var req travelInfo
+			if !decode(w, r, &req) {
+				return
+			}
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			if s.tripById(req.TripId) != nil {
+				fail(w, "Already exists")
+				return
+			}
+			trip := req.trip(uuid.NewString())
+			s.store.trips = append(s.store.trips, &trip)
+			ok(w, "Create trip:"+req.TripId+".", &trip)
+		})
+		s.handle("PUT", prefix+"/trips", func(w http.ResponseWriter, r *http.Request, _ params) {
+			var req travelInfo
+			if !decode(w, r, &req) {
+				return
+			}
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			trip := s.tripById(req.TripId)
+			if trip == nil {
+				fail(w, "Trip "+req.TripId+" doesn't exists")
+				return
+			}
+			*trip = req.trip(trip.Id)
+			ok(w, "Update trip:"+req.TripId, trip)
+		})
+		s.handle("POST", prefix+"/trips/left", func(w http.ResponseWriter, r *http.Request, _ params) {
+			s.queryTrips(w, r, svc)
+		})
+		s.handle("POST", prefix+"/trips/left_parallel", func(w http.ResponseWriter, r *http.Request, _ params) {
+			s.queryTrips(w, r, svc)
+		})
+		s.handle("POST", prefix+"/trips/routes", func(w http.ResponseWriter, r *http.Request, _ params) {
+			var routeIds []string
+			if !decode(w, r, &routeIds) {
+				return
+			}
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			result := [][]*Trip{}
+			for _, routeId := range routeIds {
+				result = append(result, filter(s.tripsOf(svc), func(t *Trip) bool { return t.RouteId == routeId }))
+			}
+			ok(w, "Success", result)
+		})
+		s.handle("GET", prefix+"/trips/{tripId}", func(w http.ResponseWriter, r *http.Request, p params) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			if trip := s.tripById(p["tripId"]); trip != nil {
+				ok(w, "Search Trip Success by Trip Id "+p["tripId"], trip)
+				return
+			}
+			fail(w, "No Content according to tripId"+p["tripId"])
+		})
+		s.handle("DELETE", prefix+"/trips/{tripId}", func(w http.ResponseWriter, r *http.Request, p params) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			if !remove(&s.store.trips, func(t *Trip) bool { return t.TripId.String() == p["tripId"] }) {
+				fail(w, "Trip "+p["tripId"]+" doesn't exist.")
+				return
+			}
+			ok(w, "Delete trip:"+p["tripId"]+".", p["tripId"])
+		})
+		s.handle("POST", prefix+"/trip_detail", func(w http.ResponseWriter, r *http.Request, _ params) {
+			var req tripDetailQuery
+			if !decode(w, r, &req) {
+				return
+			}
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			trip := s.tripById(req.TripId)
+			if trip == nil {
+				fail(w, "Trip "+req.TripId+" doesn't exists")
+				return
+			}
+			resp := s.tripResponse(trip, req.From, req.To, req.TravelDate)
+			if resp == nil {
+				fail(w, "Trip "+req.TripId+" doesn't go from "+req.From+" to "+req.To)
+				return
+			}
+			ok(w, "Success", map[string]interface{}{"status": true, "tripResponse": resp, "trip": trip})
+		})
+		s.handle("GET", prefix+"/train_types/{tripId}", func(w http.ResponseWriter, r *http.Request, p params) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			if trip := s.tripById(p["tripId"]); trip != nil {
+				if train := s.trainByName(trip.TrainTypeName); train != nil {
+					ok(w, "Success query Train by trip id", train)
+					return
+				}
+			}
+			fail(w, "No Content")
+		})
+		s.handle("GET", prefix+"/routes/{tripId}", func(w http.ResponseWriter, r *http.Request, p params) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			if trip := s.tripById(p["tripId"]); trip != nil {
+				if route := s.routeById(trip.RouteId); route != nil {
+					ok(w, "Success", route)
+					return
+				}
+			}
+			fail(w, "No Content")
+		})
+		s.handle("GET", prefix+"/admin_trip", func(w http.ResponseWriter, r *http.Request, _ params) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			result := []map[string]interface{}{}
+			for _, trip := range s.tripsOf(svc) {
+				result = append(result, map[string]interface{}{
+					"trip":      trip,
+					"trainType": s.trainByName(trip.TrainTypeName),
+					"route":     s.routeById(trip.RouteId),
+				})
+			}
+			ok(w, "Travel Service Admin Query All Travel Success", result)
+		})
+	}
+
+	// admintravel forwards to travel or travel2 service by trip type and lists plain travel infos.
+	s.handle("GET", "/api/v1/admintravelservice/admintravel", func(w http.ResponseWriter, r *http.Request, _ params) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		result := []travelInfo{}
+		for _, trip := range s.store.trips {
+			result = append(result, travelInfo{
+				TripId:              trip.TripId.String(),
+				TrainTypeName:       trip.TrainTypeName,
+				RouteId:             trip.RouteId,
+				StartStationName:    trip.StartStationName,
+				StationsName:        trip.StationsName,
+				TerminalStationName: trip.TerminalStationName,
+				StartTime:           trip.StartTime,
+				EndTime:             trip.EndTime,
+			})
+		}
+		writeJSON(w, http.StatusOK, result)
+	})
+	s.handle("POST", "/api/v1/admintravelservice/admintravel", func(w http.ResponseWriter, r *http.Request, _ params) {
+		var req travelInfo
+		if !decode(w, r, &req) {
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if s.tripById(req.TripId) != nil {
+			fail(w, "Already exists")
+			return
+		}
+		trip := req.trip(uuid.NewString())
+		s.store.trips = append(s.store.trips, &trip)
+		ok(w, "[Admin add new travel]", req)
+	})
+	s.handle("PUT", "/api/v1/admintravelservice/admintravel", func(w http.ResponseWriter, r *http.Request, _ params) {
+		var req travelInfo
+		if !decode(w, r, &req) {
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		trip := s.tripById(req.TripId)
+		if trip == nil {
+			fail(w, "Trip "+req.TripId+" doesn't exists")
+			return
+		}
+		*trip = req.trip(trip.Id)
+		ok(w, "Update trip:"+req.TripId, req)
+	})
+	s.handle("DELETE", "/api/v1/admintravelservice/admintravel/{tripId}", func(w http.ResponseWriter, r *http.Request, p params) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if !remove(&s.store.trips, func(t *Trip) bool { return t.TripId.String() == p["tripId"] }) {
+			fail(w, "Trip "+p["tripId"]+" doesn't exist.")
+			return
+		}
+		ok(w, "Delete trip:"+p["tripId"]+".", nil)
+	})
+
+	s.handle("POST", "/api/v1/basicservice/basic/travel", func(w http.ResponseWriter, r *http.Request, _ params) {
+		var req basicTravel
+		if !decode(w, r, &req) {
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		result, msg := s.basicTravel(req)
+		if result == nil {
+			fail(w, msg)
+			return
+		}
+		ok(w, msg, result)
+	})
+	s.handle("POST", "/api/v1/basicservice/basic/travels", func(w http.ResponseWriter, r *http.Request, _ params) {
+		var req []basicTravel
+		if !decode(w, r, &req) {
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		results := map[string]interface{}{}
+		for _, travel := range req {
+			if result, _ := s.basicTravel(travel); result != nil {
+				results[travel.Trip.TripId.String()] = result
+			}
+		}
+		ok(w, "Success", results)
+	})
+}
+
+func (s *Server) tripsOf(svc string) []*Trip {
+	return filter(s.store.trips, func(t *Trip) bool { return highSpeed(t.TripId.String()) == (svc == travelService) })
+}
+
+func (s *Server) tripById(tripId string) *Trip {
+	return find(s.store.trips, func(t *Trip) bool { return t.TripId.String() == tripId })
+}
+
+func (s *Server) queryTrips(w http.ResponseWriter, r *http.Request, svc string) {
+	var req tripQuery
+	if !decode(w, r, &req) {
+		return
+	}
+	if req.StartPlace == "" || req.EndPlace == "" {
+		fail(w, "Start place or end place is empty")
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	result := []*tripResponse{}
+	for _, trip := range s.tripsOf(svc) {
+		if resp := s.tripResponse(trip, req.StartPlace, req.EndPlace, req.DepartureTime); resp != nil {
+			result = append(result, resp)
+		}
+	}
+	ok(w, "Success Query", result)
+}
+
+// tripResponse returns trip's leg from..to on date, nil when the trip does not serve it. The
+// departure is the trip's start time plus the time needed to reach from at the train's average speed,
+// and prices are the distance times the price rates of the route and train.
+func (s *Server) tripResponse(trip *Trip, from, to, date string) *tripResponse {
+	route := s.routeById(trip.RouteId)
+	if route == nil {
+		return nil
+	}
+	distance, ok := route.distance(from, to)
+	if !ok {
+		return nil
+	}
+	offset, _ := route.distance(route.Stations[0], from)
+	speed := 1
+	resp := &tripResponse{
+		TripId:          trip.TripId,
+		TrainTypeName:   trip.TrainTypeName,
+		StartStation:    from,
+		TerminalStation: to,
+	}
+	if train := s.trainByName(trip.TrainTypeName); train != nil {
+		speed = max(train.AverageSpeed, 1)
+		resp.EconomyClass = train.EconomyClass - s.soldSeats(trip.TripId.String(), date, SeatSecondClass)
+		resp.ConfortClass = train.ConfortClass - s.soldSeats(trip.TripId.String(), date, SeatFirstClass)
+	}
+	start := travelDate(date).Add(clock(trip.StartTime) + hours(offset, speed))
+	resp.StartTime = start.Format(time.DateTime)
+	resp.EndTime = start.Add(hours(distance, speed)).Format(time.DateTime)
+	economy, confort := s.priceRates(trip.RouteId, trip.TrainTypeName)
+	resp.PriceForEconomyClass = formatPrice(float64(distance) * economy)
+	resp.PriceForConfortClass = formatPrice(float64(distance) * confort)
+	return resp
+}
+
+// soldSeats counts the orders holding a seat of the class on the trip and date.
+func (s *Server) soldSeats(tripId, date string, seatClass int) int {
+	n := 0
+	for _, order := range s.store.orders[orderServiceOf(tripId)] {
+		if order.TrainNumber == tripId && sameDay(order.TravelDate, date) && order.SeatClass == seatClass &&
+			order.Status != OrderCancel && order.Status != OrderRefunds {
+			n++
+		}
+	}
+	return n
+}
+
+type basicTravel struct {
+	Trip          Trip   `json:"trip"`
+	StartPlace    string `json:"startPlace"`
+	EndPlace      string `json:"endPlace"`
+	DepartureTime string `json:"departureTime"`
+}
+
+func (s *Server) basicTravel(req basicTravel) (map[string]interface{}, string) {
+	train := s.trainByName(req.Trip.TrainTypeName)
+	if train == nil {
+		return nil, "Train type doesn't exist"
+	}
+	route := s.routeById(req.Trip.RouteId)
+	if route == nil {
+		return nil, "Route doesn't exist"
+	}
+	distance, ok := route.distance(req.StartPlace, req.EndPlace)
+	if !ok {
+		return nil, "Start place or end place not exist"
+	}
+	economy, confort := s.priceRates(route.Id, train.Name)
+	return map[string]interface{}{
+		"status":    true,
+		"percent":   1.0,
+		"trainType": train,
+		"route":     route,
+		"prices": map[string]string{
+			"economyClass": formatPrice(float64(distance) * economy),
+			"confortClass": formatPrice(float64(distance) * confort),
+		},
+	}, "Success"
+}
+
+// travelDate parses the date part of a "2006-01-02" or "2006-01-02 15:04:05" value, today when it is invalid.
+func travelDate(value string) time.Time {
+	if len(value) >= len(time.DateOnly) {
+		if t, err := time.Parse(time.DateOnly, value[:len(time.DateOnly)]); err == nil {
+			return t
+		}
+	}
+	return time.Now().Truncate(24 * time.Hour)
+}
+
+func sameDay(a, b string) bool {
+	return travelDate(a).Equal(travelDate(b))
+}
+
+// clock returns the time of day of a "2006-01-02 15:04:05" value.
+func clock(value string) time.Duration {
+	t, err := time.Parse(time.DateTime, value)
+	if err != nil {
+		return 0
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second
+}
+
+func hours(distance, speed int) time.Duration {
+	return time.Duration(distance) * time.Hour / time.Duration(speed)
+}
+
+func formatPrice(price float64) string {
+	return strconv.FormatFloat(price, 'f', -1, 64)
+}
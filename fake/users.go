@@ -0,0 +1,277 @@
+package fake
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TokenTTL is the lifetime of the tokens issued by the fake login endpoint.
+const TokenTTL = time.Hour
+
+// token returns an unsigned JWT whose payload carries the user and an exp claim, enough for
+// httpclient.TokenManager to schedule refreshes.
+func token(user *User) string {
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none","typ":"JWT"}`))
+	payload, _ := json.Marshal(map[string]interface{}{
+		"sub": user.UserName,
+		"id":  user.UserId,
+		"exp": time.Now().Add(TokenTTL).Unix(),
+	})
+	return header + "." + base64.RawURLEncoding.EncodeToString(payload) + ".fake"
+}
+
+func (s *Server) registerUsers() {
+	s.handle("POST", "/api/v1/users/login", s.login)
+	s.handle("DELETE", "/api/v1/users/{userId}", s.deleteUser)
+	s.handle("POST", "/api/v1/auth", s.createAuthUser)
+
+	s.handle("GET", "/api/v1/verifycode/generate", s.generateVerifyCode)
+	s.handle("GET", "/api/v1/verifycode/verify/{code}", func(w http.ResponseWriter, r *http.Request, p params) {
+		writeJSON(w, http.StatusOK, s.checkCaptcha(r, p["code"]))
+	})
+
+	s.handle("GET", "/api/v1/userservice/users", s.allUsers)
+	s.handle("POST", "/api/v1/userservice/users/register", s.addUser)
+	s.handle("PUT", "/api/v1/userservice/users", s.updateUser)
+	s.handle("GET", "/api/v1/userservice/users/id/{userId}", func(w http.ResponseWriter, r *http.Request, p params) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		user := find(s.store.users, func(u *User) bool { return u.UserId == p["userId"] })
+		if user == nil {
+			fail(w, "No User")
+			return
+		}
+		ok(w, "Find User Success", user)
+	})
+	s.handle("GET", "/api/v1/userservice/users/{userName}", func(w http.ResponseWriter, r *http.Request, p params) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		user := find(s.store.users, func(u *User) bool { return u.UserName == p["userName"] })
+		if user == nil {
+			fail(w, "No User")
+			return
+		}
+		ok(w, "Find User Success", user)
+	})
+	s.handle("DELETE", "/api/v1/userservice/users/{userId}", s.deleteUser)
+
+	s.handle("GET", "/api/v1/adminuserservice/users", s.allUsers)
+	s.handle("POST", "/api/v1/adminuserservice/users", s.addUser)
+	s.handle("PUT", "/api/v1/adminuserservice/users", s.updateUser)
+	s.handle("DELETE", "/api/v1/adminuserservice/users/{userId}", s.deleteUser)
+
+	for _, prefix := range []string{"/api/v1/contactservice/contacts", "/api/v1/adminbasicservice/adminbasic/contacts"} {
+		s.handle("GET", prefix, s.allContacts)
+		s.handle("POST", prefix, s.addContact)
+		s.handle("PUT", prefix, s.modifyContact)
+		s.handle("DELETE", prefix+"/{contactsId}", s.deleteContact)
+	}
+	s.handle("POST", "/api/v1/contactservice/contacts/admin", s.addContact)
+	s.handle("GET", "/api/v1/contactservice/contacts/account/{accountId}", func(w http.ResponseWriter, r *http.Request, p params) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		ok(w, "Success", filter(s.store.contacts, func(c *Contact) bool { return c.AccountId == p["accountId"] }))
+	})
+	s.handle("GET", "/api/v1/contactservice/contacts/{contactsId}", func(w http.ResponseWriter, r *http.Request, p params) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		contact := find(s.store.contacts, func(c *Contact) bool { return c.Id == p["contactsId"] })
+		if contact == nil {
+			fail(w, "No contacts according to contacts id")
+			return
+		}
+		ok(w, "Success", contact)
+	})
+}
+
+// login checks the verification code like the real service, which without WithCaptcha accepts any code.
+func (s *Server) login(w http.ResponseWriter, r *http.Request, _ params) {
+	var req struct {
+		UserName string `json:"username"`
+		Password string `json:"password"`
+		Code     string `json:"verificationCode"`
+	}
+	if !decode(w, r, &req) {
+		return
+	}
+	if !s.checkCaptcha(r, req.Code) {
+		fail(w, "Verification failed.")
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	user := find(s.store.users, func(u *User) bool { return u.UserName == req.UserName })
+	if user == nil || user.Password != req.Password {
+		fail(w, "Incorrect username or password.")
+		return
+	}
+	ok(w, "login success", map[string]string{
+		"userId":   user.UserId,
+		"username": user.UserName,
+		"token":    token(user),
+	})
+}
+
+func (s *Server) createAuthUser(w http.ResponseWriter, r *http.Request, _ params) {
+	var req User
+	if !decode(w, r, &req) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if find(s.store.users, func(u *User) bool { return u.UserName == req.UserName }) != nil {
+		fail(w, "User already exists")
+		return
+	}
+	if req.UserId == "" {
+		req.UserId = uuid.NewString()
+	}
+	s.store.users = append(s.store.users, &req)
+	ok(w, "SUCCESS", map[string]string{"userId": req.UserId, "userName": req.UserName, "password": req.Password})
+}
+
+func (s *Server) deleteUser(w http.ResponseWriter, r *http.Request, p params) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !remove(&s.store.users, func(u *User) bool { return u.UserId == p["userId"] }) {
+		fail(w, "USER NOT EXISTS")
+		return
+	}
+	ok(w, "DELETE SUCCESS", nil)
+}
+
+func (s *Server) allUsers(w http.ResponseWriter, r *http.Request, _ params) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ok(w, "Success", s.store.users)
+}
+
+func (s *Server) addUser(w http.ResponseWriter, r *http.Request, _ params) {
+	var req User
+	if !decode(w, r, &req) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if find(s.store.users, func(u *User) bool { return u.UserName == req.UserName }) != nil {
+		fail(w, "User Has Already Exists")
+		return
+	}
+	if req.UserId == "" {
+		req.UserId = uuid.NewString()
+	}
+	s.store.users = append(s.store.users, &req)
+	ok(w, "REGISTER USER SUCCESS", &req)
+}
+
+func (s *Server) updateUser(w http.ResponseWriter, r *http.Request, _ params) {
+	var req User
+	if !decode(w, r, &req) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	user := find(s.store.users, func(u *User) bool { return u.UserName == req.UserName || u.UserId == req.UserId })
+	if user == nil {
+		fail(w, "USER NOT EXISTS")
+		return
+	}
+	if req.UserId == "" {
+		req.UserId = user.UserId
+	}
+	*user = req
+	ok(w, "SAVE USER SUCCESS", user)
+}
+
+// generateVerifyCode sets the YsbCaptcha cookie like the real service. It answers with a placeholder
+// image, any code passes verification, unless WithCaptcha is set; then the image is the code itself.
+func (s *Server) generateVerifyCode(w http.ResponseWriter, r *http.Request, _ params) {
+	id := uuid.NewString()
+	http.SetCookie(w, &http.Cookie{Name: "YsbCaptcha", Value: id, Path: "/"})
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.captchas == nil {
+		w.Header().Set("Content-Type", "image/jpeg")
+		w.Write([]byte("fake captcha " + id))
+		return
+	}
+	code := strings.ToUpper(strings.ReplaceAll(uuid.NewString(), "-", "")[:6])
+	s.captchas[id] = code
+	w.Header().Set("Content-Type", "text/plain")
+	w.Write([]byte(code))
+}
+
+// checkCaptcha reports whether code matches the one generated for the request's YsbCaptcha cookie.
+// The real service compares case-insensitively.
+func (s *Server) checkCaptcha(r *http.Request, code string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.captchas == nil {
+		return true
+	}
+	cookie, err := r.Cookie("YsbCaptcha")
+	if err != nil {
+		return false
+	}
+	want, ok := s.captchas[cookie.Value]
+	return ok && strings.EqualFold(want, code)
+}
+
+func (s *Server) allContacts(w http.ResponseWriter, r *http.Request, _ params) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ok(w, "Success", s.store.contacts)
+}
+
+func (s *Server) addContact(w http.ResponseWriter, r *http.Request, _ params) {
+	var req Contact
+	if !decode(w, r, &req) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	exists := find(s.store.contacts, func(c *Contact) bool {
+		return c.AccountId == req.AccountId && c.DocumentType == req.DocumentType && c.DocumentNumber == req.DocumentNumber && c.Name == req.Name
+	})
+	if exists != nil {
+		fail(w, "Contacts already exists")
+		return
+	}
+	if req.Id == "" {
+		req.Id = uuid.NewString()
+	}
+	s.store.contacts = append(s.store.contacts, &req)
+	ok(w, "Create contacts success", &req)
+}
+
+func (s *Server) modifyContact(w http.ResponseWriter, r *http.Request, _ params) {
+	var req Contact
+	if !decode(w, r, &req) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	contact := find(s.store.contacts, func(c *Contact) bool { return c.Id == req.Id })
+	if contact == nil {
+		fail(w, "Contacts not found")
+		return
+	}
+	*contact = req
+	ok(w, "Modify success", contact)
+}
+
+func (s *Server) deleteContact(w http.ResponseWriter, r *http.Request, p params) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !remove(&s.store.contacts, func(c *Contact) bool { return c.Id == p["contactsId"] }) {
+		fail(w, fmt.Sprintf("Contacts %s not found", p["contactsId"]))
+		return
+	}
+	ok(w, "Delete success", p["contactsId"])
+}
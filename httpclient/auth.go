@@ -0,0 +1,206 @@
+package httpclient
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// LoginFunc 使用记住的凭据重新登录并返回新的 token。
+type LoginFunc func() (string, error)
+
+// AuthConfig 配置 TokenManager。
+type AuthConfig struct {
+	// RefreshBefore 是 token 过期前多久主动刷新，<= 0 时不主动刷新。
+	RefreshBefore time.Duration
+	// Skip 判断请求是否不需要认证，例如登录接口本身。LoginFunc 发出的所有请求都必须被跳过，
+	// 否则它们会等待正在进行的刷新而死锁。
+	Skip func(req *http.Request) bool
+	// MeterProvider 用于记录刷新次数，为 nil 时使用 otel 全局 MeterProvider。
+	MeterProvider metric.MeterProvider
+}
+
+// DefaultAuthConfig 返回默认配置：过期前 1 分钟主动刷新。
+func DefaultAuthConfig() AuthConfig {
+	return AuthConfig{RefreshBefore: time.Minute}
+}
+
+// TokenManager 为请求设置 Bearer token，在 JWT 过期前主动刷新，
+// 收到 401/403 时重新登录并重发一次请求。
+type TokenManager struct {
+	config    AuthConfig
+	refreshes metric.Int64Counter
+	now       func() time.Time
+
+	mu     sync.Mutex
+	token  string
+	expiry time.Time
+	login  LoginFunc
+	count  int
+
+	// refreshMu 保证同一时间只有一个刷新在进行，登录请求本身不持有 mu。
+	refreshMu sync.Mutex
+}
+
+// NewTokenManager 创建 TokenManager。
+func NewTokenManager(config AuthConfig) *TokenManager {
+	mp := config.MeterProvider
+	if mp == nil {
+		mp = otel.GetMeterProvider()
+	}
+	refreshes, _ := mp.Meter(meterName).Int64Counter("loadgen.auth.refreshes",
+		metric.WithDescription("Token refreshes and re-logins performed by the load generator, by reason and result."))
+	return &TokenManager{
+		config:    config,
+		refreshes: refreshes,
+		now:       time.Now,
+	}
+}
+
+// WithTokenManager 注册认证中间件。认证中间件应最先注册，这样重新登录后重发的请求仍经过其他中间件。
+func WithTokenManager(m *TokenManager) Option {
+	return func(c *HttpClient) {
+		c.middlewares = append(c.middlewares, m.Middleware)
+	}
+}
+
+// SetToken 设置当前 token，并从 JWT 的 exp 字段解析过期时间；无法解析时不主动刷新。
+func (m *TokenManager) SetToken(token string) {
+	expiry, _ := JWTExpiry(token)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.token = token
+	m.expiry = expiry
+}
+
+// SetLogin 设置重新登录使用的函数，通常在首次登录成功后以闭包记住凭据。
+func (m *TokenManager) SetLogin(login LoginFunc) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.login = login
+}
+
+// Token 返回当前 token。
+func (m *TokenManager) Token() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.token
+}
+
+// RefreshCount 返回成功刷新的次数。
+func (m *TokenManager) RefreshCount() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.count
+}
+
+// Middleware 为请求设置 Authorization 头，必要时刷新 token。
+func (m *TokenManager) Middleware(next RoundTripFunc) RoundTripFunc {
+	return func(req *http.Request) (*http.Response, error) {
+		if m.config.Skip != nil && m.config.Skip(req) {
+			return next(req)
+		}
+		token, expiry, login := m.state()
+		if login != nil && m.config.RefreshBefore > 0 && !expiry.IsZero() &&
+			m.now().Add(m.config.RefreshBefore).After(expiry) {
+			// 主动刷新失败时仍使用旧 token 发送，由服务端决定是否拒绝。
+			if refreshed, err := m.refresh(req.Context(), token, "expiry"); err == nil {
+				token = refreshed
+			}
+		}
+
+		resp, err := next(withToken(req, token))
+		if err != nil || login == nil || (resp.StatusCode != http.StatusUnauthorized && resp.StatusCode != http.StatusForbidden) {
+			return resp, err
+		}
+		refreshed, refreshErr := m.refresh(req.Context(), token, "unauthorized")
+		if refreshErr != nil {
+			return resp, nil
+		}
+		retry := withToken(req, refreshed)
+		if req.GetBody != nil {
+			if retry.Body, err = req.GetBody(); err != nil {
+				return nil, err
+			}
+		}
+		return next(retry)
+	}
+}
+
+func (m *TokenManager) state() (string, time.Time, LoginFunc) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.token, m.expiry, m.login
+}
+
+// refresh 重新登录。如果等待期间其他请求已经刷新了 token（当前 token 不再是 stale），直接使用新 token。
+func (m *TokenManager) refresh(ctx context.Context, stale, reason string) (string, error) {
+	m.refreshMu.Lock()
+	defer m.refreshMu.Unlock()
+	token, _, login := m.state()
+	if token != stale {
+		return token, nil
+	}
+
+	token, err := login()
+	result := "success"
+	if err == nil && token == "" {
+		err = errors.New("login returned empty token")
+	}
+	if err != nil {
+		result = "failure"
+	}
+	m.refreshes.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("reason", reason),
+		attribute.String("result", result),
+	))
+	if err != nil {
+		return "", fmt.Errorf("refresh token: %w", err)
+	}
+	m.SetToken(token)
+	m.mu.Lock()
+	m.count++
+	m.mu.Unlock()
+	return token, nil
+}
+
+func withToken(req *http.Request, token string) *http.Request {
+	if token == "" {
+		return req
+	}
+	r := req.Clone(req.Context())
+	r.Header.Set("Authorization", "Bearer "+token)
+	return r
+}
+
+// JWTExpiry 解析 JWT 载荷中的 exp 字段，不校验签名。
+func JWTExpiry(token string) (time.Time, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}, errors.New("token is not a JWT")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return time.Time{}, err
+	}
+	var claims struct {
+		Exp *float64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return time.Time{}, err
+	}
+	if claims.Exp == nil {
+		return time.Time{}, errors.New("JWT has no exp claim")
+	}
+	return time.Unix(int64(*claims.Exp), 0), nil
+}
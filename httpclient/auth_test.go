@@ -0,0 +1,136 @@
+package httpclient
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func makeJWT(exp time.Time, id int) string {
+	enc := base64.RawURLEncoding
+	return enc.EncodeToString([]byte(`{"alg":"HS256"}`)) + "." +
+		enc.EncodeToString([]byte(fmt.Sprintf(`{"sub":"fdse","id":%d,"exp":%d}`, id, exp.Unix()))) + ".sig"
+}
+
+func TestJWTExpiry(t *testing.T) {
+	exp := time.Unix(1718000000, 0)
+	got, err := JWTExpiry(makeJWT(exp, 1))
+	if err != nil || !got.Equal(exp) {
+		t.Errorf("JWTExpiry = %v, %v, want %v", got, err, exp)
+	}
+	if _, err := JWTExpiry("opaque-token"); err == nil {
+		t.Error("Expected error for non-JWT token")
+	}
+}
+
+func TestTokenManager_ReloginOnUnauthorized(t *testing.T) {
+	valid := makeJWT(time.Now().Add(time.Hour), 2)
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+valid {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		w.Write([]byte(`{"status":1}`))
+	}))
+	defer server.Close()
+
+	reader := sdkmetric.NewManualReader()
+	m := NewTokenManager(AuthConfig{MeterProvider: sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))})
+	m.SetToken(makeJWT(time.Now().Add(time.Hour), 1))
+	logins := 0
+	m.SetLogin(func() (string, error) {
+		logins++
+		return valid, nil
+	})
+	c := NewCustomClient(WithTokenManager(m))
+
+	resp, err := c.SendRequest("POST", server.URL+"/api/v1/orderservice/order", map[string]string{"id": "1"})
+	if err != nil {
+		t.Fatalf("SendRequest failed: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("StatusCode = %d, want 200 after re-login", resp.StatusCode)
+	}
+	if logins != 1 || m.RefreshCount() != 1 || m.Token() != valid {
+		t.Errorf("logins = %d, refreshes = %d", logins, m.RefreshCount())
+	}
+	if len(bodies) != 1 || bodies[0] != `{"id":"1"}` {
+		t.Errorf("Retried request body = %v", bodies)
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("Collect failed: %v", err)
+	}
+	data := rm.ScopeMetrics[0].Metrics[0].Data.(metricdata.Sum[int64])
+	reason, _ := data.DataPoints[0].Attributes.Value("reason")
+	if data.DataPoints[0].Value != 1 || reason.AsString() != "unauthorized" {
+		t.Errorf("Unexpected refresh metric: %+v", data.DataPoints)
+	}
+}
+
+func TestTokenManager_ReloginOnlyOnce(t *testing.T) {
+	hits := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	m := NewTokenManager(DefaultAuthConfig())
+	m.SetToken("t0")
+	logins := 0
+	m.SetLogin(func() (string, error) {
+		logins++
+		return fmt.Sprintf("t%d", logins), nil
+	})
+	c := NewCustomClient(WithTokenManager(m))
+	resp, err := c.SendRequest("GET", server.URL+"/api/v1/users", nil)
+	if err != nil {
+		t.Fatalf("SendRequest failed: %v", err)
+	}
+	if resp.StatusCode != http.StatusUnauthorized || hits != 2 || logins != 1 {
+		t.Errorf("status = %d, hits = %d, logins = %d", resp.StatusCode, hits, logins)
+	}
+}
+
+func TestTokenManager_ProactiveRefresh(t *testing.T) {
+	var seen []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = append(seen, r.Header.Get("Authorization"))
+	}))
+	defer server.Close()
+
+	fresh := makeJWT(time.Now().Add(time.Hour), 2)
+	m := NewTokenManager(AuthConfig{
+		RefreshBefore: time.Minute,
+		Skip:          func(req *http.Request) bool { return req.URL.Path == "/api/v1/users/login" },
+	})
+	m.SetToken(makeJWT(time.Now().Add(30*time.Second), 1))
+	m.SetLogin(func() (string, error) { return fresh, nil })
+	c := NewCustomClient(WithTokenManager(m))
+
+	c.SendRequest("GET", server.URL+"/api/v1/users", nil)
+	c.SendRequest("GET", server.URL+"/api/v1/users", nil)
+	c.SendRequest("POST", server.URL+"/api/v1/users/login", nil)
+	if m.RefreshCount() != 1 {
+		t.Errorf("RefreshCount = %d, want 1", m.RefreshCount())
+	}
+	if seen[0] != "Bearer "+fresh || seen[1] != "Bearer "+fresh {
+		t.Errorf("Requests were not sent with the refreshed token: %v", seen)
+	}
+	if seen[2] != "" {
+		t.Errorf("Skipped request got Authorization %q", seen[2])
+	}
+}
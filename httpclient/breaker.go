@@ -0,0 +1,287 @@
+package httpclient
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen 表示请求因熔断器打开而被直接拒绝，没有发送到服务端。
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// CircuitOpenError 是熔断时返回的错误，可以用 errors.Is(err, ErrCircuitOpen) 判断。
+type CircuitOpenError struct {
+	Key   string
+	State BreakerState
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("%v: %s (%s)", ErrCircuitOpen, e.Key, e.State)
+}
+
+func (e *CircuitOpenError) Unwrap() error {
+	return ErrCircuitOpen
+}
+
+// BreakerState 是熔断器的状态。
+type BreakerState int
+
+const (
+	BreakerClosed BreakerState = iota
+	BreakerOpen
+	BreakerHalfOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	}
+	return "unknown"
+}
+
+// BreakerConfig 配置熔断器。
+type BreakerConfig struct {
+	// Key 决定熔断的粒度，默认为 ServiceKey（按服务），也可以使用 EndpointKey（按接口）。
+	Key func(req *http.Request) string
+	// WindowSize 是计算失败率时使用的最近请求数。
+	WindowSize int
+	// MinRequests 是窗口内至少需要的请求数，达到后才会根据失败率打开熔断器。
+	MinRequests int
+	// FailureRateThreshold 是打开熔断器的失败率阈值（0~1）。
+	FailureRateThreshold float64
+	// CoolDown 是熔断器打开后进入半开状态前的等待时间。
+	CoolDown time.Duration
+	// HalfOpenRequests 是半开状态下允许通过的探测请求数，全部成功后关闭熔断器。
+	HalfOpenRequests int
+	// IsFailure 判断一次请求是否计为失败，默认传输层错误和 5xx 计为失败，业务失败不计入。
+	IsFailure func(resp *http.Response, err error) bool
+	// OnStateChange 在状态变化时调用，默认只记录日志。
+	OnStateChange func(key string, from, to BreakerState)
+}
+
+// DefaultBreakerConfig 返回按服务熔断的默认配置。
+func DefaultBreakerConfig() BreakerConfig {
+	return BreakerConfig{
+		Key:                  ServiceKey,
+		WindowSize:           20,
+		MinRequests:          10,
+		FailureRateThreshold: 0.5,
+		CoolDown:             10 * time.Second,
+		HalfOpenRequests:     3,
+		IsFailure:            isOutageFailure,
+	}
+}
+
+// ServiceKey 以 TrainTicket 路径 /api/v1/<service>/... 中的服务名作为熔断键。
+func ServiceKey(req *http.Request) string {
+	if service := ServiceName(req.URL.Path); service != "" {
+		return req.URL.Host + "/" + service
+	}
+	return req.URL.Host
+}
+
+// EndpointKey 以方法和接口模板作为熔断键。
+func EndpointKey(req *http.Request) string {
+	return req.Method + " " + req.URL.Host + RouteTemplate(req.URL.Path)
+}
+
+func isOutageFailure(resp *http.Response, err error) bool {
+	return err != nil || resp.StatusCode >= 500
+}
+
+// WithCircuitBreaker 为客户端创建独立的熔断器并注册熔断中间件。熔断中间件位于统计中间件之内、重试之外，
+// 熔断时不会重试，统计中计为 ShortCircuited。
+func WithCircuitBreaker(config BreakerConfig) Option {
+	return WithSharedCircuitBreaker(NewCircuitBreaker(config))
+}
+
+// WithSharedCircuitBreaker 注册 cb 的熔断中间件。多个客户端共享同一个 CircuitBreaker 时，
+// 所有客户端的请求结果都计入同一组熔断器，例如一次压测的全部 VU。
+func WithSharedCircuitBreaker(cb *CircuitBreaker) Option {
+	return func(c *HttpClient) {
+		c.middlewares = append(c.middlewares, cb.Middleware)
+	}
+}
+
+// CircuitBreaker 按键维护多个熔断器。
+type CircuitBreaker struct {
+	config   BreakerConfig
+	mu       sync.Mutex
+	breakers map[string]*breaker
+	now      func() time.Time
+}
+
+// NewCircuitBreaker 创建熔断器，未设置的配置项使用默认值。
+func NewCircuitBreaker(config BreakerConfig) *CircuitBreaker {
+	defaults := DefaultBreakerConfig()
+	if config.Key == nil {
+		config.Key = defaults.Key
+	}
+	if config.WindowSize <= 0 {
+		config.WindowSize = defaults.WindowSize
+	}
+	if config.MinRequests <= 0 || config.MinRequests > config.WindowSize {
+		config.MinRequests = config.WindowSize
+	}
+	if config.FailureRateThreshold <= 0 {
+		config.FailureRateThreshold = defaults.FailureRateThreshold
+	}
+	if config.CoolDown <= 0 {
+		config.CoolDown = defaults.CoolDown
+	}
+	if config.HalfOpenRequests <= 0 {
+		config.HalfOpenRequests = defaults.HalfOpenRequests
+	}
+	if config.IsFailure == nil {
+		config.IsFailure = defaults.IsFailure
+	}
+	return &CircuitBreaker{
+		config:   config,
+		breakers: make(map[string]*breaker),
+		now:      time.Now,
+	}
+}
+
+// breaker 是单个键的熔断器状态，window 是最近请求结果的环形缓冲区。
+type breaker struct {
+	state    BreakerState
+	window   []bool
+	next     int
+	filled   int
+	failures int
+	openedAt time.Time
+	inflight int
+	probes   int
+	// generation 在每次状态切换时加一，用于识别在之前的状态下放行的请求。
+	generation uint64
+}
+
+// admission 记录一个请求被放行时的情况：probe 表示它是半开状态下的探测请求，
+// generation 是放行时熔断器的状态代数。
+type admission struct {
+	probe      bool
+	generation uint64
+}
+
+// State 返回某个键当前的状态。
+func (cb *CircuitBreaker) State(key string) BreakerState {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	if b, ok := cb.breakers[key]; ok {
+		return b.state
+	}
+	return BreakerClosed
+}
+
+// Middleware 在熔断器打开时直接返回 CircuitOpenError。
+func (cb *CircuitBreaker) Middleware(next RoundTripFunc) RoundTripFunc {
+	return func(req *http.Request) (*http.Response, error) {
+		key := cb.config.Key(req)
+		adm, err := cb.allow(key)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := next(req)
+		cb.record(key, adm, cb.config.IsFailure(resp, err))
+		return resp, err
+	}
+}
+
+func (cb *CircuitBreaker) get(key string) *breaker {
+	b, ok := cb.breakers[key]
+	if !ok {
+		b = &breaker{window: make([]bool, cb.config.WindowSize)}
+		cb.breakers[key] = b
+	}
+	return b
+}
+
+func (cb *CircuitBreaker) allow(key string) (admission, error) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	b := cb.get(key)
+	switch b.state {
+	case BreakerOpen:
+		if cb.now().Sub(b.openedAt) < cb.config.CoolDown {
+			return admission{}, &CircuitOpenError{Key: key, State: b.state}
+		}
+		cb.transition(key, b, BreakerHalfOpen)
+		fallthrough
+	case BreakerHalfOpen:
+		if b.inflight+b.probes >= cb.config.HalfOpenRequests {
+			return admission{}, &CircuitOpenError{Key: key, State: b.state}
+		}
+		b.inflight++
+		return admission{probe: true, generation: b.generation}, nil
+	}
+	return admission{generation: b.generation}, nil
+}
+
+// record 记录请求结果。在之前的状态下放行、状态切换后才完成的请求不再计入，
+// 例如关闭状态下发出、熔断器半开后才返回的请求不算作探测请求。
+func (cb *CircuitBreaker) record(key string, adm admission, failed bool) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	b := cb.get(key)
+	if adm.generation != b.generation {
+		return
+	}
+	switch b.state {
+	case BreakerHalfOpen:
+		b.inflight--
+		if failed {
+			cb.transition(key, b, BreakerOpen)
+			return
+		}
+		b.probes++
+		if b.probes >= cb.config.HalfOpenRequests {
+			cb.transition(key, b, BreakerClosed)
+		}
+	case BreakerClosed:
+		if b.filled == len(b.window) && b.window[b.next] {
+			b.failures--
+		}
+		b.window[b.next] = failed
+		if failed {
+			b.failures++
+		}
+		b.next = (b.next + 1) % len(b.window)
+		if b.filled < len(b.window) {
+			b.filled++
+		}
+		if b.filled >= cb.config.MinRequests &&
+			float64(b.failures)/float64(b.filled) >= cb.config.FailureRateThreshold {
+			cb.transition(key, b, BreakerOpen)
+		}
+	}
+}
+
+// transition 切换状态并重置计数，调用方需持有 cb.mu。
+func (cb *CircuitBreaker) transition(key string, b *breaker, to BreakerState) {
+	from := b.state
+	b.state = to
+	b.generation++
+	b.inflight, b.probes = 0, 0
+	switch to {
+	case BreakerOpen:
+		b.openedAt = cb.now()
+	case BreakerClosed:
+		b.next, b.filled, b.failures = 0, 0, 0
+		for i := range b.window {
+			b.window[i] = false
+		}
+	}
+	if cb.config.OnStateChange != nil {
+		cb.config.OnStateChange(key, from, to)
+		return
+	}
+	log.Printf("circuit breaker %s: %s -> %s", key, from, to)
+}
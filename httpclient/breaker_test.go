@@ -0,0 +1,180 @@
+package httpclient
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker_Transitions(t *testing.T) {
+	var failing atomic.Bool
+	var hits atomic.Int32
+	failing.Store(true)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		if failing.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte(`{"status":1}`))
+	}))
+	defer server.Close()
+
+	var transitions []string
+	cb := NewCircuitBreaker(BreakerConfig{
+		WindowSize:           4,
+		MinRequests:          4,
+		FailureRateThreshold: 0.5,
+		CoolDown:             time.Minute,
+		HalfOpenRequests:     1,
+		OnStateChange: func(key string, from, to BreakerState) {
+			transitions = append(transitions, from.String()+"->"+to.String())
+		},
+	})
+	now := time.Now()
+	cb.now = func() time.Time { return now }
+	c := NewCustomClient(WithMiddleware(cb.Middleware))
+	// 每个请求的订单 id 不同，统计仍按路由模板聚合
+	orderURL := func(i int) string { return fmt.Sprintf("%s/api/v1/orderservice/order/%d", server.URL, 1000+i) }
+
+	for i := 0; i < 4; i++ {
+		if _, err := c.SendRequest("POST", orderURL(i), nil); err != nil {
+			t.Fatalf("Unexpected error before opening: %v", err)
+		}
+	}
+	if _, err := c.SendRequest("POST", orderURL(4), nil); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("Expected ErrCircuitOpen, got %v", err)
+	}
+	if hits.Load() != 4 {
+		t.Errorf("Short-circuited request reached the server, hits = %d", hits.Load())
+	}
+
+	// 冷却结束后半开，探测请求成功则关闭。
+	failing.Store(false)
+	now = now.Add(time.Minute)
+	if _, err := c.SendRequest("POST", orderURL(5), nil); err != nil {
+		t.Fatalf("Probe request failed: %v", err)
+	}
+	want := []string{"closed->open", "open->half-open", "half-open->closed"}
+	if len(transitions) != len(want) {
+		t.Fatalf("Transitions = %v, want %v", transitions, want)
+	}
+	for i := range want {
+		if transitions[i] != want[i] {
+			t.Errorf("Transitions = %v, want %v", transitions, want)
+		}
+	}
+
+	stats := c.GetRequestStats()[RequestStatsKey{URL: server.URL + "/api/v1/orderservice/order/{id}", Method: "POST"}]
+	if stats.ShortCircuited != 1 || stats.HTTPFailed != 4 || stats.Failed != 5 || stats.Success != 1 {
+		t.Errorf("Unexpected stats: %+v", stats)
+	}
+	if stats.RequestSize.Count != 5 {
+		t.Errorf("Short-circuited request counted as sent: %d", stats.RequestSize.Count)
+	}
+}
+
+func TestCircuitBreaker_HalfOpenFailureReopens(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	cb := NewCircuitBreaker(BreakerConfig{WindowSize: 2, CoolDown: time.Second, HalfOpenRequests: 1})
+	now := time.Now()
+	cb.now = func() time.Time { return now }
+	c := NewCustomClient(WithMiddleware(cb.Middleware))
+	url := server.URL + "/api/v1/travelservice/trips"
+	key := server.Listener.Addr().String() + "/travelservice"
+
+	c.SendRequest("GET", url, nil)
+	c.SendRequest("GET", url, nil)
+	if cb.State(key) != BreakerOpen {
+		t.Fatalf("State = %s, want open", cb.State(key))
+	}
+	now = now.Add(time.Second)
+	if _, err := c.SendRequest("GET", url, nil); err != nil {
+		t.Fatalf("Probe request failed: %v", err)
+	}
+	if cb.State(key) != BreakerOpen {
+		t.Errorf("State after failed probe = %s, want open", cb.State(key))
+	}
+	if _, err := c.SendRequest("GET", url, nil); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Expected ErrCircuitOpen after failed probe, got %v", err)
+	}
+}
+
+func TestCircuitBreaker_Shared(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	// 每个客户端各发一个失败请求，共享的熔断器累计后打开，之后所有客户端都被熔断。
+	cb := NewCircuitBreaker(BreakerConfig{WindowSize: 4, CoolDown: time.Minute})
+	url := server.URL + "/api/v1/travelservice/trips"
+	for i := 0; i < 4; i++ {
+		c := NewCustomClient(WithSharedCircuitBreaker(cb))
+		if _, err := c.SendRequest("GET", url, nil); err != nil {
+			t.Fatalf("Request %d failed before opening: %v", i, err)
+		}
+	}
+	c := NewCustomClient(WithSharedCircuitBreaker(cb))
+	if _, err := c.SendRequest("GET", url, nil); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Expected ErrCircuitOpen from a new client, got %v", err)
+	}
+	if hits.Load() != 4 {
+		t.Errorf("Short-circuited request reached the server, hits = %d", hits.Load())
+	}
+}
+
+func TestCircuitBreaker_Keys(t *testing.T) {
+	req, _ := http.NewRequest("GET", "http://gw:8080/api/v1/orderservice/order/790bcfd5-82d2-4717-aa9f-e00bef992268", nil)
+	if got := ServiceKey(req); got != "gw:8080/orderservice" {
+		t.Errorf("ServiceKey = %s", got)
+	}
+	if got := EndpointKey(req); got != "GET gw:8080/api/v1/orderservice/order/{id}" {
+		t.Errorf("EndpointKey = %s", got)
+	}
+}
+
+func TestCircuitBreaker_StaleResultInHalfOpen(t *testing.T) {
+	cb := NewCircuitBreaker(BreakerConfig{WindowSize: 2, CoolDown: time.Minute, HalfOpenRequests: 1})
+	now := time.Now()
+	cb.now = func() time.Time { return now }
+	const key = "gw/orderservice"
+
+	// 关闭状态下放行一个慢请求，之后两个失败请求打开熔断器。
+	slow, err := cb.allow(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		adm, _ := cb.allow(key)
+		cb.record(key, adm, true)
+	}
+	now = now.Add(time.Minute)
+	probe, err := cb.allow(key)
+	if err != nil {
+		t.Fatalf("Probe rejected: %v", err)
+	}
+
+	// 慢请求在半开后才返回，不计为探测请求，也不能多放行一个探测请求。
+	cb.record(key, slow, false)
+	if state := cb.State(key); state != BreakerHalfOpen {
+		t.Errorf("State after the stale result = %v, want half-open", state)
+	}
+	if _, err := cb.allow(key); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Second probe admitted with HalfOpenRequests 1, err = %v", err)
+	}
+	cb.record(key, probe, false)
+	if state := cb.State(key); state != BreakerClosed {
+		t.Errorf("State after the probe succeeded = %v, want closed", state)
+	}
+}
@@ -0,0 +1,156 @@
+package httpclient
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"path"
+	"sort"
+)
+
+// Outcome 表示一次请求结果的分类。
+type Outcome int
+
+const (
+	// OutcomeSuccess 表示 HTTP 状态和业务状态都成功。
+	OutcomeSuccess Outcome = iota
+	// OutcomeTransportFailure 表示连接失败、超时等传输层错误，没有拿到响应。
+	OutcomeTransportFailure
+	// OutcomeHTTPFailure 表示 HTTP 状态码不是 2xx。
+	OutcomeHTTPFailure
+	// OutcomeBusinessFailure 表示 HTTP 成功但业务状态失败，例如 TrainTicket 返回的 {"status":0}。
+	OutcomeBusinessFailure
+	// OutcomeShortCircuited 表示熔断器打开，请求没有发送到服务端。
+	OutcomeShortCircuited
+)
+
+func (o Outcome) String() string {
+	switch o {
+	case OutcomeSuccess:
+		return "success"
+	case OutcomeTransportFailure:
+		return "transport_failure"
+	case OutcomeHTTPFailure:
+		return "http_failure"
+	case OutcomeBusinessFailure:
+		return "business_failure"
+	case OutcomeShortCircuited:
+		return "short_circuited"
+	}
+	return "unknown"
+}
+
+// Classification 是分类器的结果，Msg 为失败时的业务消息或错误描述。
+type Classification struct {
+	Outcome Outcome
+	Msg     string
+}
+
+// Classifier 根据请求、响应和错误对一次请求的结果进行分类。
+type Classifier interface {
+	Classify(req *http.Request, resp *http.Response, respBody []byte, err error) Classification
+}
+
+// ClassifierFunc 将普通函数适配为 Classifier。
+type ClassifierFunc func(req *http.Request, resp *http.Response, respBody []byte, err error) Classification
+
+func (f ClassifierFunc) Classify(req *http.Request, resp *http.Response, respBody []byte, err error) Classification {
+	return f(req, resp, respBody, err)
+}
+
+// EndpointRule 是针对某个接口的分类规则，Pattern 使用 path.Match 语法匹配 URL 路径。
+type EndpointRule struct {
+	Method     string
+	Pattern    string
+	Classifier Classifier
+}
+
+func (r EndpointRule) match(req *http.Request) bool {
+	if r.Method != "" && r.Method != req.Method {
+		return false
+	}
+	ok, err := path.Match(r.Pattern, req.URL.Path)
+	return err == nil && ok
+}
+
+// StatusClassifier 依次检查熔断、传输错误、HTTP 状态码和 JSON 中的 status 字段，
+// 匹配到的接口规则优先于默认的业务状态判断。
+type StatusClassifier struct {
+	rules []EndpointRule
+}
+
+// NewStatusClassifier 创建一个默认的分类器。
+func NewStatusClassifier() *StatusClassifier {
+	return &StatusClassifier{}
+}
+
+// AddRule 为匹配 method 和 pattern 的接口注册分类规则，method 为空表示匹配所有方法。
+func (s *StatusClassifier) AddRule(method, pattern string, classifier Classifier) *StatusClassifier {
+	s.rules = append(s.rules, EndpointRule{Method: method, Pattern: pattern, Classifier: classifier})
+	return s
+}
+
+func (s *StatusClassifier) Classify(req *http.Request, resp *http.Response, respBody []byte, err error) Classification {
+	if errors.Is(err, ErrCircuitOpen) {
+		return Classification{Outcome: OutcomeShortCircuited, Msg: err.Error()}
+	}
+	if err != nil {
+		return Classification{Outcome: OutcomeTransportFailure, Msg: err.Error()}
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return Classification{Outcome: OutcomeHTTPFailure, Msg: resp.Status}
+	}
+	for _, rule := range s.rules {
+		if rule.match(req) {
+			return rule.Classifier.Classify(req, resp, respBody, err)
+		}
+	}
+	return ClassifyBusinessStatus(respBody)
+}
+
+// ClassifyBusinessStatus 解析 TrainTicket 的 {"status":1,"msg":"..."} 响应，
+// status 不为 1 时视为业务失败；没有 status 字段的响应视为成功。
+func ClassifyBusinessStatus(respBody []byte) Classification {
+	var payload struct {
+		Status *int   `json:"status"`
+		Msg    string `json:"msg"`
+	}
+	if err := json.Unmarshal(respBody, &payload); err != nil || payload.Status == nil {
+		return Classification{Outcome: OutcomeSuccess}
+	}
+	if *payload.Status != 1 {
+		return Classification{Outcome: OutcomeBusinessFailure, Msg: payload.Msg}
+	}
+	return Classification{Outcome: OutcomeSuccess}
+}
+
+// WithClassifier 设置 HttpClient 使用的结果分类器。
+func WithClassifier(classifier Classifier) Option {
+	return func(c *HttpClient) {
+		c.classifier = classifier
+	}
+}
+
+// MsgCount 是某个失败消息出现的次数。
+type MsgCount struct {
+	Msg   string
+	Count int
+}
+
+// TopMsgs 返回出现次数最多的 n 个失败消息，n <= 0 时返回全部。
+func (s RequestStats) TopMsgs(n int) []MsgCount {
+	msgs := make([]MsgCount, 0, len(s.FailureMsgs))
+	for msg, count := range s.FailureMsgs {
+		msgs = append(msgs, MsgCount{Msg: msg, Count: count})
+	}
+	sort.Slice(msgs, func(i, j int) bool {
+		if msgs[i].Count == msgs[j].Count {
+			return msgs[i].Msg < msgs[j].Msg
+		}
+		return msgs[i].Count > msgs[j].Count
+	})
+	if n > 0 && len(msgs) > n {
+		msgs = msgs[:n]
+	}
+	return msgs
+}
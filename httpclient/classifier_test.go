@@ -0,0 +1,98 @@
+package httpclient
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSendRequest_ClassifyOutcomes(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status":1,"msg":"Success","data":null}`))
+	})
+	mux.HandleFunc("/business", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status":0,"msg":"Already exists","data":null}`))
+	})
+	mux.HandleFunc("/http", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	mux.HandleFunc("/verify/", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`false`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	classifier := NewStatusClassifier().AddRule("GET", "/verify/*", ClassifierFunc(
+		func(req *http.Request, resp *http.Response, respBody []byte, err error) Classification {
+			if bytes.Equal(respBody, []byte("true")) {
+				return Classification{Outcome: OutcomeSuccess}
+			}
+			return Classification{Outcome: OutcomeBusinessFailure, Msg: "verify failed"}
+		}))
+	c := NewCustomClient(WithClassifier(classifier))
+	for _, path := range []string{"/ok?page=1", "/business", "/business", "/http", "/verify/1234", "/verify/5678"} {
+		if _, err := c.SendRequest("GET", server.URL+path, nil); err != nil {
+			t.Fatalf("SendRequest %s failed: %v", path, err)
+		}
+	}
+
+	stats := c.GetRequestStats()
+	ok := stats[RequestStatsKey{URL: server.URL + "/ok", Method: "GET"}]
+	if ok.Success != 1 || ok.Failed != 0 {
+		t.Errorf("Unexpected stats for /ok: %+v", ok)
+	}
+	business := stats[RequestStatsKey{URL: server.URL + "/business", Method: "GET"}]
+	if business.Success != 0 || business.Failed != 2 || business.BusinessFailed != 2 {
+		t.Errorf("Unexpected stats for /business: %+v", business)
+	}
+	if top := business.TopMsgs(1); len(top) != 1 || top[0].Msg != "Already exists" || top[0].Count != 2 {
+		t.Errorf("Unexpected top msgs: %v", top)
+	}
+	httpFailed := stats[RequestStatsKey{URL: server.URL + "/http", Method: "GET"}]
+	if httpFailed.HTTPFailed != 1 || httpFailed.Failed != 1 {
+		t.Errorf("Unexpected stats for /http: %+v", httpFailed)
+	}
+	// 不同 id 和查询参数的请求按路由模板聚合
+	verify := stats[RequestStatsKey{URL: server.URL + "/verify/{id}", Method: "GET"}]
+	if verify.BusinessFailed != 2 || verify.FailureMsgs["verify failed"] != 2 {
+		t.Errorf("Unexpected stats for /verify/{id}: %+v", verify)
+	}
+	if len(stats) != 4 {
+		t.Errorf("Expected stats for 4 endpoints, got %d", len(stats))
+	}
+}
+
+func TestSendRequest_TransportFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	url := server.URL
+	server.Close()
+
+	c := NewCustomClient()
+	if _, err := c.SendRequest("GET", url, nil); err == nil {
+		t.Fatalf("Expected error for closed server")
+	}
+	stats := c.GetRequestStats()[RequestStatsKey{URL: url, Method: "GET"}]
+	if stats.TransportFailed != 1 || stats.Failed != 1 {
+		t.Errorf("Unexpected stats: %+v", stats)
+	}
+}
+
+func TestClassifyBusinessStatus(t *testing.T) {
+	cases := []struct {
+		body string
+		want Outcome
+	}{
+		{`{"status":1,"msg":"ok"}`, OutcomeSuccess},
+		{`{"status":0,"msg":"fail"}`, OutcomeBusinessFailure},
+		{`{"data":[]}`, OutcomeSuccess},
+		{`true`, OutcomeSuccess},
+		{`not json`, OutcomeSuccess},
+	}
+	for _, tc := range cases {
+		if got := ClassifyBusinessStatus([]byte(tc.body)).Outcome; got != tc.want {
+			t.Errorf("%s: expected %v, got %v", tc.body, tc.want, got)
+		}
+	}
+}
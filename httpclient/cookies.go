@@ -0,0 +1,71 @@
+package httpclient
+
+import (
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+)
+
+// WithCookieJar 为 HttpClient 启用独立的 cookie jar，服务端设置的 cookie 会在后续请求中自动带上。
+// 每个 VU 使用自己的 HttpClient，因此 cookie 按 VU 隔离。
+// cookie 由中间件按路由前的地址（BASE_URL 中的逻辑地址）保存，使用多个网关副本时，某个副本设置的 cookie
+// 也会发送到其他副本，例如验证码 cookie 和随后的登录请求落在不同副本上。因此 WithCookieJar 需要在
+// WithRouter 之前注册。
+func WithCookieJar() Option {
+	return func(c *HttpClient) {
+		c.jar = newCookieJar()
+		c.middlewares = append(c.middlewares, c.cookieMiddleware)
+	}
+}
+
+func newCookieJar() http.CookieJar {
+	// cookiejar.New 只有在 PublicSuffixList 出错时才会返回错误，传入 nil 不会出错。
+	jar, _ := cookiejar.New(nil)
+	return jar
+}
+
+// cookieMiddleware 为请求带上 jar 中的 cookie，并保存响应设置的 cookie。
+func (c *HttpClient) cookieMiddleware(next RoundTripFunc) RoundTripFunc {
+	return func(req *http.Request) (*http.Response, error) {
+		c.mu.Lock()
+		jar := c.jar
+		c.mu.Unlock()
+		if cookies := jar.Cookies(req.URL); len(cookies) > 0 {
+			req = req.Clone(req.Context())
+			for _, cookie := range cookies {
+				req.AddCookie(cookie)
+			}
+		}
+		resp, err := next(req)
+		if resp != nil {
+			if cookies := resp.Cookies(); len(cookies) > 0 {
+				jar.SetCookies(req.URL, cookies)
+			}
+		}
+		return resp, err
+	}
+}
+
+// Cookies 返回 cookie jar 中会随 rawURL 请求发送的 cookie，未启用 cookie jar 时返回 nil。
+func (c *HttpClient) Cookies(rawURL string) []*http.Cookie {
+	c.mu.Lock()
+	jar := c.jar
+	c.mu.Unlock()
+	if jar == nil {
+		return nil
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil
+	}
+	return jar.Cookies(u)
+}
+
+// ClearCookies 丢弃所有 cookie，例如 VU 开始新的会话时。
+func (c *HttpClient) ClearCookies() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.jar != nil {
+		c.jar = newCookieJar()
+	}
+}
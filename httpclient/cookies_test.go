@@ -0,0 +1,86 @@
+package httpclient
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWithCookieJar(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/verifycode/generate" {
+			http.SetCookie(w, &http.Cookie{Name: "YsbCaptcha", Value: "abc", Path: "/"})
+			return
+		}
+		cookie, err := r.Cookie("YsbCaptcha")
+		if err != nil || cookie.Value != "abc" {
+			w.Write([]byte("false"))
+			return
+		}
+		w.Write([]byte("true"))
+	}))
+	defer server.Close()
+
+	c := NewCustomClient(WithCookieJar())
+	if _, err := c.SendRequest("GET", server.URL+"/api/v1/verifycode/generate", nil); err != nil {
+		t.Fatalf("SendRequest failed: %v", err)
+	}
+	if cookies := c.Cookies(server.URL); len(cookies) != 1 || cookies[0].Value != "abc" {
+		t.Errorf("Unexpected cookies: %v", cookies)
+	}
+	resp, err := c.SendRequest("GET", server.URL+"/api/v1/verifycode/verify/123", nil)
+	if err != nil {
+		t.Fatalf("SendRequest failed: %v", err)
+	}
+	if body, _ := ReadResponseBody(resp); string(body) != "true" {
+		t.Errorf("Cookie was not sent back, body = %s", body)
+	}
+
+	c.ClearCookies()
+	if cookies := c.Cookies(server.URL); len(cookies) != 0 {
+		t.Errorf("Cookies after ClearCookies: %v", cookies)
+	}
+	if NewCustomClient().Cookies(server.URL) != nil {
+		t.Error("Expected no cookies without a jar")
+	}
+}
+
+func TestWithCookieJar_AcrossReplicas(t *testing.T) {
+	// 两个副本共享同一个会话，只接受另一个副本设置的 cookie 时才返回 true。
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/verifycode/generate" {
+			http.SetCookie(w, &http.Cookie{Name: "YsbCaptcha", Value: "abc", Path: "/"})
+			return
+		}
+		if cookie, err := r.Cookie("YsbCaptcha"); err != nil || cookie.Value != "abc" {
+			w.Write([]byte("false"))
+			return
+		}
+		w.Write([]byte("true"))
+	})
+	gw1 := httptest.NewServer(handler)
+	defer gw1.Close()
+	gw2 := httptest.NewServer(handler)
+	defer gw2.Close()
+	router, err := NewRouter(RoutingConfig{Default: []string{gw1.URL, gw2.URL}})
+	if err != nil {
+		t.Fatalf("NewRouter failed: %v", err)
+	}
+
+	// 轮询时两个请求分别落在两个副本上，cookie 按路由前的地址保存。
+	c := NewCustomClient(WithCookieJar(), WithRouter(router))
+	base := "http://gateway.invalid"
+	if _, err := c.SendRequest("GET", base+"/api/v1/verifycode/generate", nil); err != nil {
+		t.Fatalf("SendRequest failed: %v", err)
+	}
+	resp, err := c.SendRequest("GET", base+"/api/v1/verifycode/verify/123", nil)
+	if err != nil {
+		t.Fatalf("SendRequest failed: %v", err)
+	}
+	if body, _ := ReadResponseBody(resp); string(body) != "true" {
+		t.Errorf("Cookie was not sent to the other replica, body = %s", body)
+	}
+	if cookies := c.Cookies(base); len(cookies) != 1 {
+		t.Errorf("Unexpected cookies for the base URL: %v", cookies)
+	}
+}
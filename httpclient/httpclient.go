@@ -2,44 +2,98 @@ package httpclient
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
-	"math/rand"
 	"net/http"
 	"strings"
 	"sync"
+	"time"
 )
 
 // RequestStats 保存每个请求的统计信息。
 type RequestStats struct {
-	Success      int
-	Failed       int
-	RequestBody  []string
-	ResponseBody []string
+	Success int
+	// Failed 是失败总数，等于 TransportFailed + HTTPFailed + BusinessFailed + ShortCircuited。
+	Failed          int
+	TransportFailed int
+	HTTPFailed      int
+	BusinessFailed  int
+	// ShortCircuited 是熔断器打开时被直接拒绝、没有发送的请求数。
+	ShortCircuited int
+	// FailureMsgs 统计失败时的业务消息或错误描述出现的次数。
+	FailureMsgs map[string]int
+	// Retries 是重试次数，不计入 Success/Failed，避免掩盖真实的失败率。
+	Retries int
+	// Timings 是各连接阶段（DNS、建连、TLS、TTFB、响应体传输）的耗时分布和连接复用次数。
+	Timings PhaseTimings
+	// RequestSize 和 ResponseSize 是请求体和响应体（解压后）的大小分布，Total 为累计字节数。
+	RequestSize  SizeDistribution
+	ResponseSize SizeDistribution
+	// Samples 是按接口和结果蓄水池采样得到的请求/响应对，按时间排序。
+	Samples []Sample
 }
 
+// RequestStatsKey 标识一个接口。URL 是 scheme://host 加上路由模板（见 RouteTemplate），不含 id 和查询参数，
+// 这样统计和样本的数量只随接口数量增长，而不随运行时间增长。
 type RequestStatsKey struct {
 	URL    string
 	Method string
 }
 
+func statsKey(req *http.Request) RequestStatsKey {
+	return RequestStatsKey{
+		URL:    req.URL.Scheme + "://" + req.URL.Host + RouteTemplate(req.URL.Path),
+		Method: req.Method,
+	}
+}
+
 // HttpClient 是自定义的 HTTP 客户端，包含请求计数器和头信息。
 type HttpClient struct {
 	client       *http.Client
 	headers      map[string]string
 	reqCount     int
+	retryCount   int
 	mu           sync.Mutex
 	requestStats map[RequestStatsKey]RequestStats
+	retry        RetryPolicy
+	sleep        func(ctx context.Context, d time.Duration) error
+	classifier   Classifier
+	sampling     SamplingConfig
+	samples      map[RequestStatsKey]map[Outcome]*reservoir
+	middlewares  []Middleware
+	jar          http.CookieJar
+	traffic      *throughputSeries
+	ctx          context.Context
 }
 
+// maxFailureMsgs 是每个接口最多记录的不同失败消息数量，超出部分计入 otherFailureMsg。
+const maxFailureMsgs = 50
+
+const otherFailureMsg = "(other)"
+
+// Option 用于配置 HttpClient。
+type Option func(*HttpClient)
+
 // NewCustomClient 创建并返回一个新的 HttpClient 实例。
-func NewCustomClient() *HttpClient {
-	return &HttpClient{
+func NewCustomClient(opts ...Option) *HttpClient {
+	c := &HttpClient{
 		client:       &http.Client{},
 		headers:      make(map[string]string),
 		requestStats: make(map[RequestStatsKey]RequestStats),
+		sleep:        sleepContext,
+		classifier:   NewStatusClassifier(),
+		sampling:     DefaultSamplingConfig(),
+		samples:      make(map[RequestStatsKey]map[Outcome]*reservoir),
+		traffic:      newThroughputSeries(DefaultTrafficConfig()),
 	}
+	// 统计中间件总是位于最外层，记录经过其他中间件处理后的最终结果
+	c.middlewares = []Middleware{c.statsMiddleware}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
 // AddHeader 向 HttpClient 添加一个头信息。
@@ -49,10 +103,31 @@ func (c *HttpClient) AddHeader(key, value string) {
 	c.headers[key] = value
 }
 
-// SendRequest 发送 HTTP 请求并统计请求数量和详细信息。
+// SetContext 设置 SendRequest 使用的默认上下文，例如当前行为节点的 span。
+// HttpClient 通常只被一个 VU 顺序使用，因此在节点之间切换上下文是安全的。
+func (c *HttpClient) SetContext(ctx context.Context) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ctx = ctx
+}
+
+// SendRequest 使用 SetContext 设置的上下文发送 HTTP 请求并统计请求数量和详细信息。
 func (c *HttpClient) SendRequest(method, url string, body interface{}) (*http.Response, error) {
+	c.mu.Lock()
+	ctx := c.ctx
+	c.mu.Unlock()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return c.SendRequestWithContext(ctx, method, url, body)
+}
+
+// SendRequestWithContext 与 SendRequest 相同，但请求携带 ctx，中间件可以通过 req.Context() 读取。
+func (c *HttpClient) SendRequestWithContext(ctx context.Context, method, url string, body interface{}) (*http.Response, error) {
 	c.mu.Lock()
 	c.reqCount++
+	middlewares := make([]Middleware, len(c.middlewares))
+	copy(middlewares, c.middlewares)
 	c.mu.Unlock()
 
 	// 将 body 转换为 JSON
@@ -62,79 +137,171 @@ func (c *HttpClient) SendRequest(method, url string, body interface{}) (*http.Re
 	}
 
 	// 创建新的 HTTP 请求
-	req, err := http.NewRequest(method, url, bytes.NewBuffer(jsonData))
+	info := &RequestInfo{RequestBody: jsonData}
+	req, err := c.newRequest(context.WithValue(ctx, requestInfoKey{}, info), method, url, jsonData)
 	if err != nil {
 		return nil, err
 	}
 
-	// 添加头信息
-	for key, value := range c.headers {
-		req.Header.Set(key, value)
+	// 按注册顺序组装中间件链，第一个注册的中间件位于最外层
+	next := c.roundTrip
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		next = middlewares[i](next)
 	}
+	return next(req)
+}
 
-	// 发送请求并记录请求和响应信息
-	resp, err := c.client.Do(req)
+// roundTrip 是中间件链的末端，负责重试、发送请求并完整读取响应体。
+func (c *HttpClient) roundTrip(req *http.Request) (*http.Response, error) {
+	info := RequestInfoFromContext(req.Context())
+	if info == nil {
+		info = &RequestInfo{}
+	}
+
+	var (
+		resp     *http.Response
+		respBody []byte
+		err      error
+	)
+	for attempt := 1; ; attempt++ {
+		// 每次尝试都需要新的请求体
+		attemptReq := req
+		if attempt > 1 && req.GetBody != nil {
+			attemptReq = req.Clone(req.Context())
+			if attemptReq.Body, err = req.GetBody(); err != nil {
+				return nil, err
+			}
+		}
+
+		// 发送请求并读取响应体，同时通过 httptrace 记录各连接阶段的耗时
+		tracer := newConnTracer()
+		start := time.Now()
+		resp, err = c.client.Do(tracer.withTrace(attemptReq))
+		if err == nil {
+			respBody, err = io.ReadAll(resp.Body)
+			resp.Body.Close()
+		}
+		info.Latency = time.Since(start)
+		info.Timing = tracer.done()
+
+		if attempt >= c.retry.MaxAttempts || !c.retry.canRetry(req.Method) || !c.retry.shouldRetry(resp, err) {
+			break
+		}
+		info.Retries++
+		// 请求被取消时不再等待退避时间
+		if sleepErr := c.sleep(req.Context(), c.retry.backoff(attempt, resp)); sleepErr != nil {
+			return nil, sleepErr
+		}
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	// 读取响应体
-	respBody, err := io.ReadAll(resp.Body)
+	// 重新创建响应体以便后续处理
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+	return resp, nil
+}
+
+// newRequest 创建一个带有公共头信息的 HTTP 请求。
+func (c *HttpClient) newRequest(ctx context.Context, method, url string, body []byte) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
 	if err != nil {
 		return nil, err
 	}
-	// 关闭响应体
-	resp.Body.Close()
 
-	// 重新创建响应体以便后续处理
-	resp.Body = io.NopCloser(bytes.NewBuffer(respBody))
+	// 添加头信息，上下文中的头信息优先
+	c.mu.Lock()
+	for key, value := range c.headers {
+		req.Header.Set(key, value)
+	}
+	c.mu.Unlock()
+	for key, values := range HeadersFromContext(ctx) {
+		req.Header[key] = values
+	}
+	return req, nil
+}
 
-	// 记录请求和响应信息
-	c.logRequestResponse(req, resp, jsonData, respBody)
+type headersKey struct{}
 
-	return resp, nil
+// ContextWithHeaders 返回带有请求头的上下文，使用该上下文发送的请求会带上这些头，
+// 覆盖 AddHeader 设置的同名头，适合同一个 HttpClient 代表多个会话发送请求。
+func ContextWithHeaders(ctx context.Context, header http.Header) context.Context {
+	return context.WithValue(ctx, headersKey{}, header)
+}
+
+// HeadersFromContext 返回上下文中的请求头，不存在时返回 nil。
+func HeadersFromContext(ctx context.Context) http.Header {
+	header, _ := ctx.Value(headersKey{}).(http.Header)
+	return header
 }
 
-// logRequestResponse 记录请求和响应信息。
-func (c *HttpClient) logRequestResponse(req *http.Request, resp *http.Response, reqBody, respBody []byte) {
+// logRetries 记录一次请求产生的重试次数。
+func (c *HttpClient) logRetries(req *http.Request, retries int) {
+	if retries == 0 {
+		return
+	}
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	//stats := RequestStats{
-	//	URL:             req.URL.String(),
-	//	Method:          req.Method,
-	//	RequestHeaders:  req.Header,
-	//	RequestBody:     string(reqBody),
-	//	ResponseStatus:  resp.Status,
-	//	ResponseHeaders: resp.Header,
-	//	ResponseBody:    string(respBody),
-	//}
-	key := RequestStatsKey{
-		URL:    req.URL.String(),
-		Method: req.Method,
+	c.retryCount += retries
+	key := statsKey(req)
+	value := c.requestStats[key]
+	value.Retries += retries
+	c.requestStats[key] = value
+}
+
+// logRequestResponse 对请求结果分类并记录请求和响应信息，err 不为 nil 时表示传输层失败。
+func (c *HttpClient) logRequestResponse(req *http.Request, resp *http.Response, respBody []byte, info *RequestInfo, err error) {
+	result := c.classifier.Classify(req, resp, respBody, err)
+	sample := Sample{
+		Timestamp:    time.Now(),
+		Outcome:      result.Outcome,
+		Latency:      info.Latency,
+		TraceID:      info.TraceID,
+		RequestBody:  truncateBody(info.RequestBody, c.sampling.MaxBodyBytes),
+		ResponseBody: truncateBody(respBody, c.sampling.MaxBodyBytes),
 	}
-	if _, ok := c.requestStats[key]; !ok {
-		c.requestStats[key] = RequestStats{
-			RequestBody:  make([]string, 0),
-			ResponseBody: make([]string, 0),
-		}
+	if resp != nil {
+		sample.StatusCode = resp.StatusCode
 	}
-	if resp.StatusCode == 200 || resp.StatusCode == 201 {
-		value := c.requestStats[key]
-		value.Success += 1
 
-		if rand.Int()%10 == 0 {
-			value.RequestBody = append(value.RequestBody, string(reqBody))
-			value.ResponseBody = append(value.RequestBody, string(respBody))
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	key := statsKey(req)
+	c.addSample(key, sample)
+	value := c.requestStats[key]
+	if result.Outcome != OutcomeShortCircuited {
+		value.RequestSize.observe(int64(len(info.RequestBody)))
+		if resp != nil {
+			value.Timings.observe(info.Timing)
+			value.ResponseSize.observe(int64(len(respBody)))
 		}
+		c.traffic.add(sample.Timestamp, int64(len(info.RequestBody)), int64(len(respBody)))
+	}
+	if result.Outcome == OutcomeSuccess {
+		value.Success += 1
 		c.requestStats[key] = value
 		return
 	}
-	value := c.requestStats[key]
+
 	value.Failed += 1
-	if rand.Int()%5 == 0 {
-		value.RequestBody = append(value.RequestBody, string(reqBody))
-		value.ResponseBody = append(value.RequestBody, string(respBody))
+	switch result.Outcome {
+	case OutcomeTransportFailure:
+		value.TransportFailed += 1
+	case OutcomeHTTPFailure:
+		value.HTTPFailed += 1
+	case OutcomeBusinessFailure:
+		value.BusinessFailed += 1
+	case OutcomeShortCircuited:
+		value.ShortCircuited += 1
+	}
+	if value.FailureMsgs == nil {
+		value.FailureMsgs = make(map[string]int)
+	}
+	msg := result.Msg
+	if _, ok := value.FailureMsgs[msg]; !ok && len(value.FailureMsgs) >= maxFailureMsgs {
+		msg = otherFailureMsg
 	}
+	value.FailureMsgs[msg] += 1
 	c.requestStats[key] = value
 }
 
@@ -145,6 +312,13 @@ func (c *HttpClient) GetRequestCount() int {
 	return c.reqCount
 }
 
+// GetRetryCount 返回所有请求累计的重试次数。
+func (c *HttpClient) GetRetryCount() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.retryCount
+}
+
 // GetRequestStats 返回所有请求的统计信息。
 func (c *HttpClient) GetRequestStats() map[RequestStatsKey]RequestStats {
 	c.mu.Lock()
@@ -156,10 +330,19 @@ func (c *HttpClient) GetRequestStats() map[RequestStatsKey]RequestStats {
 		newv := RequestStats{}
 		newv.Success = value.Success
 		newv.Failed = value.Failed
-		newv.RequestBody = make([]string, len(value.RequestBody))
-		newv.ResponseBody = make([]string, len(value.RequestBody))
-		copy(newv.RequestBody, value.RequestBody)
-		copy(newv.ResponseBody, value.ResponseBody)
+		newv.Retries = value.Retries
+		newv.TransportFailed = value.TransportFailed
+		newv.HTTPFailed = value.HTTPFailed
+		newv.BusinessFailed = value.BusinessFailed
+		newv.ShortCircuited = value.ShortCircuited
+		newv.FailureMsgs = make(map[string]int, len(value.FailureMsgs))
+		for msg, count := range value.FailureMsgs {
+			newv.FailureMsgs[msg] = count
+		}
+		newv.Timings = value.Timings
+		newv.RequestSize = value.RequestSize
+		newv.ResponseSize = value.ResponseSize
+		newv.Samples = c.collectSamples(key)
 		statsCopy[key] = newv
 	}
 	return statsCopy
@@ -168,16 +351,25 @@ func GenerateMarkdownTable(data map[RequestStatsKey]RequestStats) string {
 	var sb strings.Builder
 
 	// 表头
-	sb.WriteString("| URL | Method | Success | Failed | Request Body | Response Body |\n")
-	sb.WriteString("| --- | ------ | ------- | ------ | ------------ | ------------- |\n")
+	sb.WriteString("| URL | Method | Success | Failed | Transport | HTTP | Business | Short-circuited | Retries | Top Msgs | Phases (mean/p50/p99) | Sent (mean/max) | Received (mean/max) | Samples |\n")
+	sb.WriteString("| --- | ------ | ------- | ------ | --------- | ---- | -------- | --------------- | ------- | -------- | --------------------- | --------------- | ------------------- | ------- |\n")
 
 	// 遍历 map 并生成表格行
 	for key, stats := range data {
-		requestBody := strings.Join(stats.RequestBody, "<br>")
-		responseBody := strings.Join(stats.ResponseBody, "<br>")
-		sb.WriteString(fmt.Sprintf("| %s | %s | %d | %d | %s | %s |\n",
-			key.URL, key.Method, stats.Success, stats.Failed, requestBody, responseBody))
+		sb.WriteString(fmt.Sprintf("| %s | %s | %d | %d | %d | %d | %d | %d | %d | %s | %s | %s | %s | %s |\n",
+			key.URL, key.Method, stats.Success, stats.Failed, stats.TransportFailed, stats.HTTPFailed, stats.BusinessFailed,
+			stats.ShortCircuited, stats.Retries, FormatTopMsgs(stats.TopMsgs(3), "<br>"), stats.Timings, stats.RequestSize, stats.ResponseSize,
+			FormatSamples(stats.Samples, "<br>")))
 	}
 
 	return sb.String()
 }
+
+// FormatTopMsgs 将失败消息格式化为 "msg (count)" 并用 sep 连接。
+func FormatTopMsgs(msgs []MsgCount, sep string) string {
+	parts := make([]string, 0, len(msgs))
+	for _, m := range msgs {
+		parts = append(parts, fmt.Sprintf("%s (%d)", m.Msg, m.Count))
+	}
+	return strings.Join(parts, sep)
+}
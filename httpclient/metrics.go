@@ -0,0 +1,94 @@
+package httpclient
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const meterName = "github.com/Lincyaw/loadgenerator/httpclient"
+
+// WithMetrics 注册 MetricsMiddleware，结果分类使用客户端自己的 Classifier（见 WithClassifier），
+// 与请求统计的成功/失败判定一致。
+func WithMetrics(mp metric.MeterProvider) Option {
+	return func(c *HttpClient) {
+		classify := ClassifierFunc(func(req *http.Request, resp *http.Response, respBody []byte, err error) Classification {
+			return c.classifier.Classify(req, resp, respBody, err)
+		})
+		c.middlewares = append(c.middlewares, MetricsMiddleware(mp, classify))
+	}
+}
+
+// MetricsMiddleware 为每次 HTTP 调用记录延迟直方图和失败计数，按方法、接口模板和结果分类。
+// mp 为 nil 时使用 otel 全局 MeterProvider，classifier 为 nil 时使用 NewStatusClassifier。
+func MetricsMiddleware(mp metric.MeterProvider, classifier Classifier) Middleware {
+	if mp == nil {
+		mp = otel.GetMeterProvider()
+	}
+	if classifier == nil {
+		classifier = NewStatusClassifier()
+	}
+	meter := mp.Meter(meterName)
+	duration, _ := meter.Float64Histogram("http.client.request.duration",
+		metric.WithUnit("s"),
+		metric.WithDescription("Latency of HTTP requests sent by the load generator."))
+	requests, _ := meter.Int64Counter("loadgen.http.client.requests",
+		metric.WithDescription("HTTP requests sent by the load generator, by outcome."))
+	failures, _ := meter.Int64Counter("loadgen.http.client.failures",
+		metric.WithDescription("HTTP requests that failed at transport, HTTP or business level."))
+	requestSize, _ := meter.Int64Histogram("http.client.request.body.size",
+		metric.WithUnit("By"),
+		metric.WithDescription("Size of HTTP request bodies."))
+	responseSize, _ := meter.Int64Histogram("http.client.response.body.size",
+		metric.WithUnit("By"),
+		metric.WithDescription("Size of HTTP response bodies after decompression."))
+	retries, _ := meter.Int64Counter("loadgen.http.client.retries",
+		metric.WithDescription("Retries performed by the HTTP client."))
+
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			resp, err := next(req)
+			info := RequestInfoFromContext(req.Context())
+			if info == nil {
+				info = &RequestInfo{}
+			}
+
+			var respBody []byte
+			if err == nil {
+				if respBody, err = ReadResponseBody(resp); err != nil {
+					return nil, err
+				}
+			}
+			result := classifier.Classify(req, resp, respBody, err)
+
+			attrs := []attribute.KeyValue{
+				attribute.String("http.request.method", req.Method),
+				attribute.String("server.address", req.URL.Host),
+				attribute.String("url.template", RouteTemplate(req.URL.Path)),
+				attribute.String("outcome", result.Outcome.String()),
+			}
+			if resp != nil {
+				attrs = append(attrs, attribute.Int("http.response.status_code", resp.StatusCode))
+			}
+			ctx := req.Context()
+			requests.Add(ctx, 1, metric.WithAttributes(attrs...))
+			duration.Record(ctx, info.Latency.Seconds(), metric.WithAttributes(attrs...))
+			requestSize.Record(ctx, int64(len(info.RequestBody)), metric.WithAttributes(attrs[:3]...))
+			if resp != nil {
+				responseSize.Record(ctx, int64(len(respBody)), metric.WithAttributes(attrs[:3]...))
+			}
+			if result.Outcome != OutcomeSuccess {
+				failures.Add(ctx, 1, metric.WithAttributes(attrs...))
+			}
+			if info.Retries > 0 {
+				retries.Add(ctx, int64(info.Retries), metric.WithAttributes(attrs[:3]...))
+			}
+			if err != nil {
+				return nil, err
+			}
+			return resp, nil
+		}
+	}
+}
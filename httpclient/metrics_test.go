@@ -0,0 +1,115 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestRouteTemplate(t *testing.T) {
+	cases := map[string]string{
+		"/api/v1/cancelservice/cancel/790bcfd5-82d2-4717-aa9f-e00bef992268/test1": "/api/v1/cancelservice/cancel/{id}/test1",
+		"/api/v1/travelservice/trips/G1234":                                       "/api/v1/travelservice/trips/G1234",
+		"/api/v1/orderservice/order/2024-06-01":                                   "/api/v1/orderservice/order/{id}",
+		"/api/v1/assuranceservice/assurances/1/abc":                               "/api/v1/assuranceservice/assurances/{id}/abc",
+		"/api/v1/users/login":                                                     "/api/v1/users/login",
+		"/api/v1/travel2service/train_types/Z1234":                                "/api/v1/travel2service/train_types/Z1234",
+		"/api/v1/travel2service/trips/left":                                       "/api/v1/travel2service/trips/left",
+		"/api/v1/consignservice/consigns/order/ab12cd34ef":                        "/api/v1/consignservice/consigns/order/ab12cd34ef",
+		"/api/v1/1234/orders":                                                     "/api/v1/1234/orders",
+	}
+	for path, want := range cases {
+		if got := RouteTemplate(path); got != want {
+			t.Errorf("RouteTemplate(%s) = %s, want %s", path, got, want)
+		}
+	}
+}
+
+func TestMetricsMiddleware(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status":0,"msg":"fail"}`))
+	}))
+	defer server.Close()
+
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	c := NewCustomClient(WithMiddleware(MetricsMiddleware(mp, nil)))
+	for i := 0; i < 2; i++ {
+		if _, err := c.SendRequest("GET", server.URL+"/api/v1/orders/12345678", nil); err != nil {
+			t.Fatalf("SendRequest failed: %v", err)
+		}
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("Collect failed: %v", err)
+	}
+	found := make(map[string]bool)
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			found[m.Name] = true
+			switch data := m.Data.(type) {
+			case metricdata.Histogram[float64]:
+				if len(data.DataPoints) != 1 || data.DataPoints[0].Count != 2 {
+					t.Errorf("Unexpected histogram data: %+v", data.DataPoints)
+				}
+				route, _ := data.DataPoints[0].Attributes.Value("url.template")
+				if route.AsString() != "/api/v1/orders/{id}" {
+					t.Errorf("Unexpected route attribute: %s", route.AsString())
+				}
+			case metricdata.Sum[int64]:
+				if len(data.DataPoints) != 1 || data.DataPoints[0].Value != 2 {
+					t.Errorf("Unexpected counter %s: %+v", m.Name, data.DataPoints)
+				}
+			}
+		}
+	}
+	if !found["http.client.request.duration"] || !found["loadgen.http.client.requests"] || !found["loadgen.http.client.failures"] {
+		t.Errorf("Missing metrics, got %v", found)
+	}
+}
+
+func TestWithMetrics_UsesClientClassifier(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status":0,"msg":"no order"}`))
+	}))
+	defer server.Close()
+
+	// 规则把该接口的业务失败视为成功，指标应与请求统计一致。
+	classifier := NewStatusClassifier().AddRule("GET", "/api/v1/orders/*", ClassifierFunc(
+		func(req *http.Request, resp *http.Response, respBody []byte, err error) Classification {
+			return Classification{Outcome: OutcomeSuccess}
+		}))
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	c := NewCustomClient(WithMetrics(mp), WithClassifier(classifier))
+	if _, err := c.SendRequest("GET", server.URL+"/api/v1/orders/12345678", nil); err != nil {
+		t.Fatalf("SendRequest failed: %v", err)
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("Collect failed: %v", err)
+	}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name == "loadgen.http.client.failures" {
+				t.Errorf("Request classified as success by the client recorded a failure: %+v", m.Data)
+			}
+			if m.Name != "loadgen.http.client.requests" {
+				continue
+			}
+			outcome, _ := m.Data.(metricdata.Sum[int64]).DataPoints[0].Attributes.Value("outcome")
+			if outcome.AsString() != "success" {
+				t.Errorf("outcome = %s, want success", outcome.AsString())
+			}
+		}
+	}
+	if stats := c.GetRequestStats()[RequestStatsKey{URL: server.URL + "/api/v1/orders/{id}", Method: "GET"}]; stats.Success != 1 {
+		t.Errorf("Unexpected stats: %+v", stats)
+	}
+}
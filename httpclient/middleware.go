@@ -0,0 +1,99 @@
+package httpclient
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"time"
+)
+
+// RoundTripFunc 执行一次请求。返回的响应体已被完整读取，可以通过 ReadResponseBody 重复读取。
+type RoundTripFunc func(req *http.Request) (*http.Response, error)
+
+// Middleware 包装 RoundTripFunc。中间件可以通过 req.Context() 读取请求上下文，
+// 修改请求或响应，也可以不调用 next 直接返回响应或错误（短路）。
+type Middleware func(next RoundTripFunc) RoundTripFunc
+
+// RequestInfo 保存一次 SendRequest 调用的元信息，由中间件链末端填充。
+type RequestInfo struct {
+	// RequestBody 是序列化后的请求体。
+	RequestBody []byte
+	// Retries 是本次调用的重试次数。
+	Retries int
+	// Latency 是最后一次尝试的耗时。
+	Latency time.Duration
+	// Timing 是最后一次尝试各连接阶段的耗时。
+	Timing ConnTiming
+	// TraceID 和 SpanID 是 HTTP 调用对应的客户端 span，未启用追踪时为空。
+	TraceID string
+	SpanID  string
+}
+
+type requestInfoKey struct{}
+
+// RequestInfoFromContext 返回 SendRequest 放入请求上下文中的 RequestInfo，不存在时返回 nil。
+func RequestInfoFromContext(ctx context.Context) *RequestInfo {
+	info, _ := ctx.Value(requestInfoKey{}).(*RequestInfo)
+	return info
+}
+
+// Use 按顺序注册中间件，先注册的中间件位于外层。
+func (c *HttpClient) Use(middlewares ...Middleware) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.middlewares = append(c.middlewares, middlewares...)
+}
+
+// WithMiddleware 在创建 HttpClient 时注册中间件。
+func WithMiddleware(middlewares ...Middleware) Option {
+	return func(c *HttpClient) {
+		c.middlewares = append(c.middlewares, middlewares...)
+	}
+}
+
+// WithTransport 用 wrap 包装底层 http.Client 的 Transport。Transport 位于所有中间件（包括 cookie jar）和重试之下，
+// 能看到每一次尝试，它返回的响应也会经过 cookie jar。
+func WithTransport(wrap func(http.RoundTripper) http.RoundTripper) Option {
+	return func(c *HttpClient) {
+		next := c.client.Transport
+		if next == nil {
+			next = http.DefaultTransport
+		}
+		c.client.Transport = wrap(next)
+	}
+}
+
+// ReadResponseBody 读取响应体并将其恢复，以便后续中间件和调用方再次读取。
+func ReadResponseBody(resp *http.Response) ([]byte, error) {
+	if resp == nil || resp.Body == nil {
+		return nil, nil
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return body, err
+}
+
+// statsMiddleware 记录请求的重试次数、结果分类和样本。
+func (c *HttpClient) statsMiddleware(next RoundTripFunc) RoundTripFunc {
+	return func(req *http.Request) (*http.Response, error) {
+		resp, err := next(req)
+		info := RequestInfoFromContext(req.Context())
+		if info == nil {
+			info = &RequestInfo{}
+		}
+		c.logRetries(req, info.Retries)
+		if err != nil {
+			c.logRequestResponse(req, nil, nil, info, err)
+			return nil, err
+		}
+
+		respBody, err := ReadResponseBody(resp)
+		if err != nil {
+			return nil, err
+		}
+		c.logRequestResponse(req, resp, respBody, info, nil)
+		return resp, nil
+	}
+}
@@ -0,0 +1,112 @@
+package httpclient
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type testCtxKey struct{}
+
+func TestMiddleware_OrderAndContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Header.Get("X-Order")))
+	}))
+	defer server.Close()
+
+	var order []string
+	tag := func(name string) Middleware {
+		return func(next RoundTripFunc) RoundTripFunc {
+			return func(req *http.Request) (*http.Response, error) {
+				order = append(order, name)
+				req.Header.Set("X-Order", req.Header.Get("X-Order")+name)
+				return next(req)
+			}
+		}
+	}
+	var ctxValue interface{}
+	capture := func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			ctxValue = req.Context().Value(testCtxKey{})
+			return next(req)
+		}
+	}
+
+	c := NewCustomClient(WithMiddleware(tag("a")))
+	c.Use(tag("b"), capture)
+	ctx := context.WithValue(context.Background(), testCtxKey{}, "vu-1")
+	resp, err := c.SendRequestWithContext(ctx, "GET", server.URL, nil)
+	if err != nil {
+		t.Fatalf("SendRequest failed: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	if string(body) != "ab" {
+		t.Errorf("Expected header built in order 'ab', got %q", body)
+	}
+	if len(order) != 2 || order[0] != "a" || order[1] != "b" {
+		t.Errorf("Unexpected middleware order: %v", order)
+	}
+	if ctxValue != "vu-1" {
+		t.Errorf("Expected context value 'vu-1', got %v", ctxValue)
+	}
+}
+
+func TestMiddleware_ShortCircuitIsCounted(t *testing.T) {
+	shortCircuit := func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Status:     "200 OK",
+				Header:     make(http.Header),
+				Body:       io.NopCloser(bytes.NewReader([]byte(`{"status":0,"msg":"injected"}`))),
+				Request:    req,
+			}, nil
+		}
+	}
+	c := NewCustomClient(WithMiddleware(shortCircuit))
+	url := "http://fault.invalid/api/v1/test"
+	resp, err := c.SendRequest("GET", url, nil)
+	if err != nil {
+		t.Fatalf("SendRequest failed: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	if string(body) != `{"status":0,"msg":"injected"}` {
+		t.Errorf("Unexpected body: %s", body)
+	}
+	stats := c.GetRequestStats()[RequestStatsKey{URL: url, Method: "GET"}]
+	if stats.BusinessFailed != 1 || stats.FailureMsgs["injected"] != 1 {
+		t.Errorf("Short-circuited response not counted: %+v", stats)
+	}
+}
+
+func TestMiddleware_MutateResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status":1}`))
+	}))
+	defer server.Close()
+
+	rewrite := func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			resp, err := next(req)
+			if err != nil {
+				return nil, err
+			}
+			if _, err := ReadResponseBody(resp); err != nil {
+				return nil, err
+			}
+			resp.Body = io.NopCloser(bytes.NewReader([]byte(`{"status":0,"msg":"rewritten"}`)))
+			return resp, nil
+		}
+	}
+	c := NewCustomClient(WithMiddleware(rewrite))
+	if _, err := c.SendRequest("GET", server.URL, nil); err != nil {
+		t.Fatalf("SendRequest failed: %v", err)
+	}
+	stats := c.GetRequestStats()[RequestStatsKey{URL: server.URL, Method: "GET"}]
+	if stats.BusinessFailed != 1 || stats.FailureMsgs["rewritten"] != 1 {
+		t.Errorf("Mutated response not seen by stats: %+v", stats)
+	}
+}
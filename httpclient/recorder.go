@@ -0,0 +1,252 @@
+package httpclient
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Labels 标识发出请求的 VU、迭代、行为链和节点，由 behaviors 放入请求上下文。
+type Labels struct {
+	VU        int
+	Iteration int
+	Chain     string
+	Node      string
+}
+
+type labelsKey struct{}
+
+// ContextWithLabels 返回带有 labels 的上下文。
+func ContextWithLabels(ctx context.Context, labels Labels) context.Context {
+	return context.WithValue(ctx, labelsKey{}, labels)
+}
+
+// LabelsFromContext 返回上下文中的 labels，不存在时返回零值。
+func LabelsFromContext(ctx context.Context) Labels {
+	labels, _ := ctx.Value(labelsKey{}).(Labels)
+	return labels
+}
+
+// Record 是录制文件中的一行，对应一次 HTTP 调用（重试只记录最后一次尝试），Timestamp 为调用开始时间。
+type Record struct {
+	Timestamp      time.Time         `json:"timestamp"`
+	RunID          string            `json:"run_id,omitempty"`
+	VU             int               `json:"vu"`
+	Iteration      int               `json:"iteration"`
+	Chain          string            `json:"chain,omitempty"`
+	Node           string            `json:"node,omitempty"`
+	Method         string            `json:"method"`
+	Route          string            `json:"route"`
+	URL            string            `json:"url"`
+	RequestHeaders map[string]string `json:"request_headers,omitempty"`
+	RequestBody    string            `json:"request_body,omitempty"`
+	StatusCode     int               `json:"status_code,omitempty"`
+	ResponseBody   string            `json:"response_body,omitempty"`
+	LatencyMs      float64           `json:"latency_ms"`
+	TraceID        string            `json:"trace_id,omitempty"`
+	Error          string            `json:"error,omitempty"`
+}
+
+// RecorderConfig 配置请求录制。
+type RecorderConfig struct {
+	// RunID 写入每一条记录，用于区分不同的压测。
+	RunID string
+	// RedactHeaders 中的请求头（不区分大小写）的值会被替换为 "[REDACTED]"。
+	RedactHeaders []string
+	// MaxResponseBytes 是记录的响应体最大长度，<= 0 表示不截断。请求体总是完整记录，以便回放。
+	MaxResponseBytes int
+	// BufferSize 是等待写入的记录数上限，缓冲区满时丢弃新记录，不阻塞请求。
+	BufferSize int
+	// FlushInterval 是写入文件的最长间隔。
+	FlushInterval time.Duration
+}
+
+// DefaultRecorderConfig 返回默认配置。
+func DefaultRecorderConfig() RecorderConfig {
+	return RecorderConfig{
+		RedactHeaders:    []string{"Authorization", "Cookie"},
+		MaxResponseBytes: 4096,
+		BufferSize:       8192,
+		FlushInterval:    time.Second,
+	}
+}
+
+const redacted = "[REDACTED]"
+
+// Recorder 异步地将每次 HTTP 调用写成一行 JSON。多个 HttpClient 可以共享同一个 Recorder。
+type Recorder struct {
+	config  RecorderConfig
+	redact  map[string]bool
+	records chan Record
+	done    chan struct{}
+	closer  io.Closer
+	dropped atomic.Int64
+
+	mu        sync.RWMutex
+	closed    bool
+	closeOnce sync.Once
+	// err 只由写入 goroutine 和 Close 修改。
+	err error
+}
+
+// NewRecorder 创建写入 w 的 Recorder，调用 Close 后才能保证所有记录都已写入。
+func NewRecorder(w io.Writer, config RecorderConfig) *Recorder {
+	defaults := DefaultRecorderConfig()
+	if config.BufferSize <= 0 {
+		config.BufferSize = defaults.BufferSize
+	}
+	if config.FlushInterval <= 0 {
+		config.FlushInterval = defaults.FlushInterval
+	}
+	r := &Recorder{
+		config:  config,
+		redact:  make(map[string]bool, len(config.RedactHeaders)),
+		records: make(chan Record, config.BufferSize),
+		done:    make(chan struct{}),
+	}
+	for _, header := range config.RedactHeaders {
+		r.redact[http.CanonicalHeaderKey(header)] = true
+	}
+	go r.run(w)
+	return r
+}
+
+// NewFileRecorder 创建追加写入 path 的 Recorder，Close 时关闭文件。
+func NewFileRecorder(path string, config RecorderConfig) (*Recorder, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+	r := NewRecorder(f, config)
+	r.closer = f
+	return r, nil
+}
+
+// WithRecorder 注册录制中间件。录制中间件应最后注册，这样记录的请求头包含认证和 trace 头。
+func WithRecorder(r *Recorder) Option {
+	return func(c *HttpClient) {
+		c.middlewares = append(c.middlewares, r.Middleware)
+	}
+}
+
+// Middleware 在请求完成后生成记录并放入缓冲区。
+func (r *Recorder) Middleware(next RoundTripFunc) RoundTripFunc {
+	return func(req *http.Request) (*http.Response, error) {
+		start := time.Now()
+		resp, err := next(req)
+		info := RequestInfoFromContext(req.Context())
+		if info == nil {
+			info = &RequestInfo{}
+		}
+		labels := LabelsFromContext(req.Context())
+		record := Record{
+			Timestamp:      start,
+			RunID:          r.config.RunID,
+			VU:             labels.VU,
+			Iteration:      labels.Iteration,
+			Chain:          labels.Chain,
+			Node:           labels.Node,
+			Method:         req.Method,
+			Route:          RouteTemplate(req.URL.Path),
+			URL:            req.URL.String(),
+			RequestHeaders: r.headers(req.Header),
+			RequestBody:    string(info.RequestBody),
+			LatencyMs:      float64(info.Latency) / float64(time.Millisecond),
+			TraceID:        info.TraceID,
+		}
+		if err != nil {
+			record.Error = err.Error()
+		} else {
+			body, readErr := ReadResponseBody(resp)
+			if readErr != nil {
+				return nil, readErr
+			}
+			record.StatusCode = resp.StatusCode
+			record.ResponseBody = truncateBody(body, r.config.MaxResponseBytes)
+		}
+		r.Record(record)
+		return resp, err
+	}
+}
+
+func (r *Recorder) headers(header http.Header) map[string]string {
+	headers := make(map[string]string, len(header))
+	for key, values := range header {
+		if r.redact[key] {
+			headers[key] = redacted
+			continue
+		}
+		headers[key] = strings.Join(values, ", ")
+	}
+	return headers
+}
+
+// Record 将记录放入缓冲区，缓冲区已满或 Recorder 已关闭时丢弃。
+func (r *Recorder) Record(record Record) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.closed {
+		r.dropped.Add(1)
+		return
+	}
+	select {
+	case r.records <- record:
+	default:
+		r.dropped.Add(1)
+	}
+}
+
+// Dropped 返回因缓冲区已满或 Recorder 已关闭而丢弃的记录数。
+func (r *Recorder) Dropped() int64 {
+	return r.dropped.Load()
+}
+
+// Close 写入缓冲区中剩余的记录并关闭底层文件，返回写入过程中遇到的第一个错误。
+func (r *Recorder) Close() error {
+	r.closeOnce.Do(func() {
+		r.mu.Lock()
+		r.closed = true
+		close(r.records)
+		r.mu.Unlock()
+
+		<-r.done
+		if r.closer != nil {
+			if err := r.closer.Close(); err != nil && r.err == nil {
+				r.err = err
+			}
+		}
+	})
+	return r.err
+}
+
+func (r *Recorder) run(w io.Writer) {
+	defer close(r.done)
+	buf := bufio.NewWriter(w)
+	encoder := json.NewEncoder(buf)
+	ticker := time.NewTicker(r.config.FlushInterval)
+	defer ticker.Stop()
+	setErr := func(err error) {
+		if err != nil && r.err == nil {
+			r.err = err
+		}
+	}
+	for {
+		select {
+		case record, ok := <-r.records:
+			if !ok {
+				setErr(buf.Flush())
+				return
+			}
+			setErr(encoder.Encode(record))
+		case <-ticker.C:
+			setErr(buf.Flush())
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package httpclient
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRecorder_WritesJSONLines(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status":1,"msg":"` + strings.Repeat("x", 100) + `"}`))
+	}))
+	defer server.Close()
+
+	var out bytes.Buffer
+	config := DefaultRecorderConfig()
+	config.RunID = "run-1"
+	config.MaxResponseBytes = 16
+	r := NewRecorder(&out, config)
+	c := NewCustomClient(WithRecorder(r))
+	c.AddHeader("Authorization", "Bearer secret")
+	c.AddHeader("X-Requested-With", "XMLHttpRequest")
+	c.SetContext(ContextWithLabels(context.Background(), Labels{VU: 2, Iteration: 5, Chain: "login", Node: "LoginAdmin"}))
+
+	url := server.URL + "/api/v1/orderservice/order/12345678"
+	if _, err := c.SendRequest("POST", url, map[string]string{"id": "1"}); err != nil {
+		t.Fatalf("SendRequest failed: %v", err)
+	}
+	if err := r.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	var records []Record
+	scanner := bufio.NewScanner(&out)
+	for scanner.Scan() {
+		var record Record
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatalf("Invalid JSON line %q: %v", scanner.Text(), err)
+		}
+		records = append(records, record)
+	}
+	if len(records) != 1 {
+		t.Fatalf("Expected 1 record, got %d", len(records))
+	}
+	record := records[0]
+	if record.RunID != "run-1" || record.VU != 2 || record.Iteration != 5 || record.Chain != "login" || record.Node != "LoginAdmin" {
+		t.Errorf("Unexpected ids: %+v", record)
+	}
+	if record.Method != "POST" || record.URL != url || record.Route != "/api/v1/orderservice/order/{id}" {
+		t.Errorf("Unexpected request: %+v", record)
+	}
+	if record.RequestHeaders["Authorization"] != redacted || record.RequestHeaders["X-Requested-With"] != "XMLHttpRequest" {
+		t.Errorf("Unexpected headers: %v", record.RequestHeaders)
+	}
+	if record.RequestBody != `{"id":"1"}` || record.StatusCode != 200 || record.LatencyMs <= 0 {
+		t.Errorf("Unexpected record: %+v", record)
+	}
+	if !strings.HasSuffix(record.ResponseBody, "(truncated, 121 bytes)") {
+		t.Errorf("Response body not truncated: %s", record.ResponseBody)
+	}
+}
+
+// blockingWriter 在 release 关闭前阻塞写入，模拟慢磁盘。
+type blockingWriter struct {
+	release chan struct{}
+	buf     bytes.Buffer
+}
+
+func (w *blockingWriter) Write(p []byte) (int, error) {
+	<-w.release
+	return w.buf.Write(p)
+}
+
+func TestRecorder_DropsWhenBufferFull(t *testing.T) {
+	w := &blockingWriter{release: make(chan struct{})}
+	r := NewRecorder(w, RecorderConfig{BufferSize: 2})
+	for i := 0; i < 10; i++ {
+		r.Record(Record{Method: "GET"})
+	}
+	// 写入 goroutine 最多取走一条记录，其余最多 2 条留在缓冲区。
+	if dropped := r.Dropped(); dropped < 7 {
+		t.Errorf("Dropped = %d, want at least 7", dropped)
+	}
+	close(w.release)
+	if err := r.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	lines := strings.Count(w.buf.String(), "\n")
+	if int64(lines)+r.Dropped() != 10 {
+		t.Errorf("Written %d + dropped %d != 10", lines, r.Dropped())
+	}
+	r.Record(Record{})
+	if int64(lines)+r.Dropped() != 11 {
+		t.Errorf("Record after Close was not dropped")
+	}
+}
@@ -0,0 +1,165 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy 描述 SendRequest 在遇到瞬时错误时的重试策略。
+type RetryPolicy struct {
+	// MaxAttempts 是包含第一次请求在内的最大尝试次数，<= 1 表示不重试。
+	MaxAttempts int
+	// BaseBackoff 是第一次重试前的等待时间，之后按指数增长。
+	BaseBackoff time.Duration
+	// MaxBackoff 是单次等待时间的上限。
+	MaxBackoff time.Duration
+	// Jitter 是随机抖动比例（0~1），实际等待时间在 [d*(1-Jitter), d] 之间。
+	Jitter float64
+	// RetryableStatus 是需要重试的 HTTP 状态码。
+	RetryableStatus map[int]bool
+	// RetryableError 判断一个传输层错误是否可以重试，为 nil 时使用 IsRetryableError。
+	RetryableError func(error) bool
+	// RetryNonIdempotent 为 true 时 POST/PATCH 等非幂等请求也会重试。
+	RetryNonIdempotent bool
+	// RespectRetryAfter 为 true 时 429/503 响应中的 Retry-After 头会覆盖退避时间。
+	RespectRetryAfter bool
+	// MaxRetryAfter 是 Retry-After 允许的最长等待时间，0 表示不限制。
+	MaxRetryAfter time.Duration
+}
+
+// DefaultRetryPolicy 返回一个适用于 TrainTicket 网关的默认重试策略。
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		BaseBackoff: 100 * time.Millisecond,
+		MaxBackoff:  5 * time.Second,
+		Jitter:      0.5,
+		RetryableStatus: map[int]bool{
+			http.StatusTooManyRequests:    true,
+			http.StatusBadGateway:         true,
+			http.StatusServiceUnavailable: true,
+			http.StatusGatewayTimeout:     true,
+		},
+		RespectRetryAfter: true,
+		MaxRetryAfter:     30 * time.Second,
+	}
+}
+
+// sleepContext 等待 d，ctx 被取消时提前返回 ctx.Err()。
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// WithRetryPolicy 为 HttpClient 设置重试策略。
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *HttpClient) {
+		c.retry = policy
+	}
+}
+
+// IsIdempotentMethod 判断 HTTP 方法是否幂等。
+func IsIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// IsRetryableError 判断传输层错误是否为连接重置、拒绝连接、超时等瞬时错误。
+func IsRetryableError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.EPIPE) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return false
+}
+
+// canRetry 判断给定方法的请求是否允许重试。
+func (p RetryPolicy) canRetry(method string) bool {
+	if p.MaxAttempts <= 1 {
+		return false
+	}
+	return p.RetryNonIdempotent || IsIdempotentMethod(method)
+}
+
+// shouldRetry 根据响应或错误判断本次尝试是否需要重试。
+func (p RetryPolicy) shouldRetry(resp *http.Response, err error) bool {
+	if err != nil {
+		if p.RetryableError != nil {
+			return p.RetryableError(err)
+		}
+		return IsRetryableError(err)
+	}
+	return p.RetryableStatus[resp.StatusCode]
+}
+
+// backoff 计算第 attempt 次重试（从 1 开始）前的等待时间。
+func (p RetryPolicy) backoff(attempt int, resp *http.Response) time.Duration {
+	if p.RespectRetryAfter && resp != nil &&
+		(resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable) {
+		if d, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+			if p.MaxRetryAfter > 0 && d > p.MaxRetryAfter {
+				d = p.MaxRetryAfter
+			}
+			return d
+		}
+	}
+
+	d := float64(p.BaseBackoff) * math.Pow(2, float64(attempt-1))
+	if p.MaxBackoff > 0 && d > float64(p.MaxBackoff) {
+		d = float64(p.MaxBackoff)
+	}
+	if p.Jitter > 0 {
+		jitter := math.Min(p.Jitter, 1)
+		d -= d * jitter * rand.Float64()
+	}
+	return time.Duration(d)
+}
+
+// parseRetryAfter 解析 Retry-After 头，支持秒数和 HTTP 日期两种格式。
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		d := t.Sub(now)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
@@ -0,0 +1,182 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newRetryTestClient(policy RetryPolicy, slept *[]time.Duration) *HttpClient {
+	c := NewCustomClient(WithRetryPolicy(policy))
+	c.sleep = func(ctx context.Context, d time.Duration) error {
+		*slept = append(*slept, d)
+		return nil
+	}
+	return c
+}
+
+func TestSendRequest_RetryOnStatus(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"status":1}`))
+	}))
+	defer server.Close()
+
+	var slept []time.Duration
+	c := newRetryTestClient(DefaultRetryPolicy(), &slept)
+	resp, err := c.SendRequest("GET", server.URL, nil)
+	if err != nil {
+		t.Fatalf("SendRequest failed: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", resp.StatusCode)
+	}
+	if calls != 3 {
+		t.Errorf("Expected 3 attempts, got %d", calls)
+	}
+	if len(slept) != 2 {
+		t.Errorf("Expected 2 backoffs, got %d", len(slept))
+	}
+
+	stats := c.GetRequestStats()[RequestStatsKey{URL: server.URL, Method: "GET"}]
+	if stats.Success != 1 || stats.Failed != 0 || stats.Retries != 2 {
+		t.Errorf("Unexpected stats: %+v", stats)
+	}
+	if c.GetRetryCount() != 2 {
+		t.Errorf("Expected retry count 2, got %d", c.GetRetryCount())
+	}
+}
+
+func TestSendRequest_NoRetryForPost(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	var slept []time.Duration
+	c := newRetryTestClient(DefaultRetryPolicy(), &slept)
+	if _, err := c.SendRequest("POST", server.URL, map[string]string{"a": "b"}); err != nil {
+		t.Fatalf("SendRequest failed: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("Expected 1 attempt for POST, got %d", calls)
+	}
+
+	policy := DefaultRetryPolicy()
+	policy.RetryNonIdempotent = true
+	calls = 0
+	c = newRetryTestClient(policy, &slept)
+	if _, err := c.SendRequest("POST", server.URL, map[string]string{"a": "b"}); err != nil {
+		t.Fatalf("SendRequest failed: %v", err)
+	}
+	if calls != int32(policy.MaxAttempts) {
+		t.Errorf("Expected %d attempts, got %d", policy.MaxAttempts, calls)
+	}
+	stats := c.GetRequestStats()[RequestStatsKey{URL: server.URL, Method: "POST"}]
+	if stats.Failed != 1 || stats.Retries != policy.MaxAttempts-1 {
+		t.Errorf("Unexpected stats: %+v", stats)
+	}
+}
+
+func TestSendRequest_RetryAfter(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "2")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	var slept []time.Duration
+	c := newRetryTestClient(DefaultRetryPolicy(), &slept)
+	if _, err := c.SendRequest("GET", server.URL, nil); err != nil {
+		t.Fatalf("SendRequest failed: %v", err)
+	}
+	if len(slept) != 1 || slept[0] != 2*time.Second {
+		t.Errorf("Expected to sleep 2s from Retry-After, got %v", slept)
+	}
+}
+
+func TestSendRequest_RetryAfterCancelled(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	c := NewCustomClient(WithRetryPolicy(DefaultRetryPolicy()))
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := c.SendRequestWithContext(ctx, "GET", server.URL, nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Cancelled request waited %v for Retry-After", elapsed)
+	}
+	if calls != 1 {
+		t.Errorf("Expected 1 attempt, got %d", calls)
+	}
+}
+
+func TestSendRequest_RetryOnConnectionError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	url := server.URL
+	server.Close()
+
+	var slept []time.Duration
+	c := newRetryTestClient(DefaultRetryPolicy(), &slept)
+	if _, err := c.SendRequest("GET", url, nil); err == nil {
+		t.Fatalf("Expected error for closed server")
+	}
+	if len(slept) != DefaultRetryPolicy().MaxAttempts-1 {
+		t.Errorf("Expected %d backoffs, got %d", DefaultRetryPolicy().MaxAttempts-1, len(slept))
+	}
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := RetryPolicy{BaseBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+	expected := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, time.Second}
+	for i, want := range expected {
+		if got := policy.backoff(i+1, nil); got != want {
+			t.Errorf("attempt %d: expected %v, got %v", i+1, want, got)
+		}
+	}
+
+	policy.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		got := policy.backoff(1, nil)
+		if got < 50*time.Millisecond || got > 100*time.Millisecond {
+			t.Fatalf("Jittered backoff out of range: %v", got)
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	if d, ok := parseRetryAfter("5", now); !ok || d != 5*time.Second {
+		t.Errorf("Expected 5s, got %v %v", d, ok)
+	}
+	if d, ok := parseRetryAfter(now.Add(3*time.Second).Format(http.TimeFormat), now); !ok || d != 3*time.Second {
+		t.Errorf("Expected 3s, got %v %v", d, ok)
+	}
+	if _, ok := parseRetryAfter("soon", now); ok {
+		t.Errorf("Expected invalid Retry-After to be rejected")
+	}
+}
@@ -0,0 +1,57 @@
+package httpclient
+
+import (
+	"strings"
+	"unicode"
+)
+
+// RouteTemplate 将 URL 路径中的 id 类片段（UUID、纯数字或日期这类只含数字和分隔符的片段）替换为 {id}，
+// 用于以较低的基数聚合同一接口的指标。/api/v1/<service> 中的服务名即使含数字（例如 travel2service）也保持原样。
+func RouteTemplate(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if i == 3 && segments[1] == "api" {
+			continue
+		}
+		if isIDSegment(segment) {
+			segments[i] = "{id}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+func isIDSegment(segment string) bool {
+	if isUUID(segment) {
+		return true
+	}
+	digits := 0
+	for _, r := range segment {
+		switch {
+		case unicode.IsDigit(r):
+			digits++
+		case !strings.ContainsRune("-_.:", r):
+			return false
+		}
+	}
+	return digits > 0
+}
+
+// isUUID 判断片段是否为 8-4-4-4-12 格式的十六进制 UUID。
+func isUUID(segment string) bool {
+	if len(segment) != 36 {
+		return false
+	}
+	for i, r := range segment {
+		switch i {
+		case 8, 13, 18, 23:
+			if r != '-' {
+				return false
+			}
+		default:
+			if !unicode.Is(unicode.ASCII_Hex_Digit, r) {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,188 @@
+package httpclient
+
+import (
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// Strategy 是在多个目标地址之间选择的负载均衡策略。
+type Strategy string
+
+const (
+	RoundRobin    Strategy = "round-robin"
+	Random        Strategy = "random"
+	LeastInflight Strategy = "least-inflight"
+)
+
+// RoutingConfig 配置按服务路由和多网关负载均衡。
+type RoutingConfig struct {
+	// Default 是默认的目标地址（例如多个网关副本），没有单独配置的服务使用这些地址。
+	// 为空时不改写这些服务的请求，继续使用 BaseUrl。
+	Default []string
+	// Services 是服务名到目标地址的路由表，服务名为路径 /api/v1/<service>/... 中的 <service>，
+	// 例如 orderservice、travelservice、users。
+	Services map[string][]string
+	// Strategy 是同一服务有多个目标地址时的选择策略，默认 RoundRobin。
+	Strategy Strategy
+}
+
+// ParseServiceURLs 解析 "orderservice=http://a:8080,travelservice=http://b:8080|http://c:8080"
+// 形式的路由表，同一服务的多个地址用 | 分隔。
+func ParseServiceURLs(value string) (map[string][]string, error) {
+	services := make(map[string][]string)
+	for _, entry := range strings.Split(value, ",") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		name, targets, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid service url entry %q", entry)
+		}
+		name = strings.TrimSpace(name)
+		for _, target := range strings.Split(targets, "|") {
+			services[name] = append(services[name], strings.TrimSpace(target))
+		}
+	}
+	return services, nil
+}
+
+// target 是一个目标地址及其正在处理的请求数。
+type target struct {
+	url      *url.URL
+	inflight int
+}
+
+// pool 是同一服务的一组目标地址。
+type pool struct {
+	targets []*target
+	next    int
+}
+
+// Router 根据请求路径中的服务名选择目标地址并改写请求的 scheme 和 host。
+type Router struct {
+	strategy Strategy
+	mu       sync.Mutex
+	fallback *pool
+	services map[string]*pool
+	rand     *rand.Rand
+}
+
+// NewRouter 创建路由器，目标地址必须是 scheme://host[:port] 形式。
+func NewRouter(config RoutingConfig) (*Router, error) {
+	switch config.Strategy {
+	case "":
+		config.Strategy = RoundRobin
+	case RoundRobin, Random, LeastInflight:
+	default:
+		return nil, fmt.Errorf("unsupported load balancing strategy %q", config.Strategy)
+	}
+	r := &Router{
+		strategy: config.Strategy,
+		services: make(map[string]*pool, len(config.Services)),
+		rand:     rand.New(rand.NewSource(rand.Int63())),
+	}
+	var err error
+	if len(config.Default) > 0 {
+		if r.fallback, err = newPool(config.Default); err != nil {
+			return nil, err
+		}
+	}
+	for name, targets := range config.Services {
+		if r.services[name], err = newPool(targets); err != nil {
+			return nil, fmt.Errorf("service %s: %w", name, err)
+		}
+	}
+	return r, nil
+}
+
+func newPool(urls []string) (*pool, error) {
+	p := &pool{}
+	for _, raw := range urls {
+		u, err := url.Parse(raw)
+		if err != nil {
+			return nil, err
+		}
+		if u.Scheme == "" || u.Host == "" || strings.Trim(u.Path, "/") != "" {
+			return nil, fmt.Errorf("invalid target %q, expected scheme://host[:port]", raw)
+		}
+		p.targets = append(p.targets, &target{url: u})
+	}
+	if len(p.targets) == 0 {
+		return nil, fmt.Errorf("no targets")
+	}
+	return p, nil
+}
+
+// WithRouter 注册路由中间件。路由中间件应在指标、链路追踪和熔断中间件之前注册，
+// 这样它们记录的是实际的目标地址；统计仍按原始 URL 聚合。
+func WithRouter(router *Router) Option {
+	return func(c *HttpClient) {
+		c.middlewares = append(c.middlewares, router.Middleware)
+	}
+}
+
+// Middleware 改写请求的目标地址，请求完成后释放 inflight 计数。
+func (r *Router) Middleware(next RoundTripFunc) RoundTripFunc {
+	return func(req *http.Request) (*http.Response, error) {
+		t := r.pick(ServiceName(req.URL.Path))
+		if t == nil {
+			return next(req)
+		}
+		defer r.release(t)
+		routed := req.Clone(req.Context())
+		routed.URL.Scheme = t.url.Scheme
+		routed.URL.Host = t.url.Host
+		routed.Host = ""
+		return next(routed)
+	}
+}
+
+func (r *Router) pick(service string) *target {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	p, ok := r.services[service]
+	if !ok {
+		p = r.fallback
+	}
+	if p == nil {
+		return nil
+	}
+	var t *target
+	switch r.strategy {
+	case Random:
+		t = p.targets[r.rand.Intn(len(p.targets))]
+	case LeastInflight:
+		// 从轮询位置开始查找，inflight 相同时在目标之间轮流选择。
+		for i := range p.targets {
+			candidate := p.targets[(p.next+i)%len(p.targets)]
+			if t == nil || candidate.inflight < t.inflight {
+				t = candidate
+			}
+		}
+		p.next = (p.next + 1) % len(p.targets)
+	default:
+		t = p.targets[p.next]
+		p.next = (p.next + 1) % len(p.targets)
+	}
+	t.inflight++
+	return t
+}
+
+func (r *Router) release(t *target) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	t.inflight--
+}
+
+// ServiceName 返回 TrainTicket 路径 /api/v1/<service>/... 中的服务名，不符合该形式时返回空字符串。
+func ServiceName(path string) string {
+	segments := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	if len(segments) >= 3 && segments[0] == "api" {
+		return segments[2]
+	}
+	return ""
+}
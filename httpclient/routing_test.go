@@ -0,0 +1,98 @@
+package httpclient
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func newNamedServer(name string, hits map[string]int, mu *sync.Mutex) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		hits[name]++
+		mu.Unlock()
+		w.Write([]byte(`{"status":1}`))
+	}))
+}
+
+func TestRouter_ServiceRoutingAndFallback(t *testing.T) {
+	var mu sync.Mutex
+	hits := make(map[string]int)
+	gw1 := newNamedServer("gw1", hits, &mu)
+	defer gw1.Close()
+	gw2 := newNamedServer("gw2", hits, &mu)
+	defer gw2.Close()
+	order := newNamedServer("order", hits, &mu)
+	defer order.Close()
+
+	router, err := NewRouter(RoutingConfig{
+		Default:  []string{gw1.URL, gw2.URL},
+		Services: map[string][]string{"orderservice": {order.URL}},
+	})
+	if err != nil {
+		t.Fatalf("NewRouter failed: %v", err)
+	}
+	c := NewCustomClient(WithRouter(router))
+	base := "http://gateway.invalid"
+	for i := 0; i < 4; i++ {
+		if _, err := c.SendRequest("GET", base+"/api/v1/travelservice/trips", nil); err != nil {
+			t.Fatalf("SendRequest failed: %v", err)
+		}
+	}
+	if _, err := c.SendRequest("GET", base+"/api/v1/orderservice/order/1", nil); err != nil {
+		t.Fatalf("SendRequest failed: %v", err)
+	}
+	if hits["gw1"] != 2 || hits["gw2"] != 2 || hits["order"] != 1 {
+		t.Errorf("Unexpected distribution: %v", hits)
+	}
+
+	// 统计按原始 URL 聚合，不按副本拆分。
+	stats := c.GetRequestStats()
+	if got := stats[RequestStatsKey{URL: base + "/api/v1/travelservice/trips", Method: "GET"}].Success; got != 4 {
+		t.Errorf("Success = %d, want 4, stats: %v", got, stats)
+	}
+}
+
+func TestRouter_LeastInflight(t *testing.T) {
+	router, err := NewRouter(RoutingConfig{
+		Default:  []string{"http://a:1", "http://b:1", "http://c:1"},
+		Strategy: LeastInflight,
+	})
+	if err != nil {
+		t.Fatalf("NewRouter failed: %v", err)
+	}
+	first := router.pick("")
+	second := router.pick("")
+	router.release(first)
+	// first 已释放，c 和 first 都没有请求，second 仍在处理中，不应被选中。
+	for i := 0; i < 4; i++ {
+		next := router.pick("")
+		if next == second {
+			t.Fatalf("Picked busy target %s", next.url)
+		}
+		router.release(next)
+	}
+}
+
+func TestNewRouter_Invalid(t *testing.T) {
+	if _, err := NewRouter(RoutingConfig{Default: []string{"gateway:8080"}}); err == nil {
+		t.Error("Expected error for target without scheme")
+	}
+	if _, err := NewRouter(RoutingConfig{Default: []string{"http://a:1"}, Strategy: "weighted"}); err == nil {
+		t.Error("Expected error for unknown strategy")
+	}
+}
+
+func TestParseServiceURLs(t *testing.T) {
+	services, err := ParseServiceURLs("orderservice=http://a:8080, travelservice=http://b:8080|http://c:8080")
+	if err != nil {
+		t.Fatalf("ParseServiceURLs failed: %v", err)
+	}
+	if len(services["orderservice"]) != 1 || len(services["travelservice"]) != 2 || services["travelservice"][1] != "http://c:8080" {
+		t.Errorf("Unexpected services: %v", services)
+	}
+	if _, err := ParseServiceURLs("orderservice"); err == nil {
+		t.Error("Expected error for entry without '='")
+	}
+}
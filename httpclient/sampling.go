@@ -0,0 +1,130 @@
+package httpclient
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Sample 是一次请求和响应的采样，请求体和响应体总是成对保存。
+type Sample struct {
+	Timestamp  time.Time
+	Outcome    Outcome
+	StatusCode int
+	Latency    time.Duration
+	// TraceID 是客户端 span 的 trace id，可用于与服务端链路关联。
+	TraceID      string
+	RequestBody  string
+	ResponseBody string
+}
+
+// SamplingConfig 配置每个接口、每种结果的蓄水池采样。
+type SamplingConfig struct {
+	// ReservoirSize 是每个接口、每种结果最多保留的样本数，<= 0 表示不采样。
+	ReservoirSize int
+	// MaxBodyBytes 是样本中请求体和响应体的最大长度，超出部分会被截断，<= 0 表示不截断。
+	MaxBodyBytes int
+}
+
+// DefaultSamplingConfig 返回默认的采样配置。
+func DefaultSamplingConfig() SamplingConfig {
+	return SamplingConfig{
+		ReservoirSize: 10,
+		MaxBodyBytes:  2048,
+	}
+}
+
+// WithSampling 设置 HttpClient 的采样配置。
+func WithSampling(config SamplingConfig) Option {
+	return func(c *HttpClient) {
+		c.sampling = config
+	}
+}
+
+// reservoir 使用 Algorithm R 在固定大小的内存中保留均匀分布的样本。
+type reservoir struct {
+	size    int
+	seen    int
+	samples []Sample
+}
+
+func newReservoir(size int) *reservoir {
+	return &reservoir{size: size, samples: make([]Sample, 0, size)}
+}
+
+func (r *reservoir) add(s Sample) {
+	r.seen++
+	if len(r.samples) < r.size {
+		r.samples = append(r.samples, s)
+		return
+	}
+	if j := rand.Intn(r.seen); j < r.size {
+		r.samples[j] = s
+	}
+}
+
+// truncateBody 将 body 截断到 max 字节并标注原始长度。
+func truncateBody(body []byte, max int) string {
+	if max <= 0 || len(body) <= max {
+		return string(body)
+	}
+	return fmt.Sprintf("%s...(truncated, %d bytes)", body[:max], len(body))
+}
+
+// addSample 将样本加入对应接口和结果的蓄水池，调用方需持有 c.mu。
+func (c *HttpClient) addSample(key RequestStatsKey, s Sample) {
+	if c.sampling.ReservoirSize <= 0 {
+		return
+	}
+	byOutcome, ok := c.samples[key]
+	if !ok {
+		byOutcome = make(map[Outcome]*reservoir)
+		c.samples[key] = byOutcome
+	}
+	r, ok := byOutcome[s.Outcome]
+	if !ok {
+		r = newReservoir(c.sampling.ReservoirSize)
+		byOutcome[s.Outcome] = r
+	}
+	r.add(s)
+}
+
+// collectSamples 返回某个接口所有结果的样本副本，按时间排序，调用方需持有 c.mu。
+func (c *HttpClient) collectSamples(key RequestStatsKey) []Sample {
+	samples := make([]Sample, 0)
+	for _, r := range c.samples[key] {
+		samples = append(samples, r.samples...)
+	}
+	sort.Slice(samples, func(i, j int) bool {
+		return samples[i].Timestamp.Before(samples[j].Timestamp)
+	})
+	return samples
+}
+
+// GetSamples 返回某个接口指定结果的样本。
+func (c *HttpClient) GetSamples(key RequestStatsKey, outcome Outcome) []Sample {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	r, ok := c.samples[key][outcome]
+	if !ok {
+		return nil
+	}
+	samples := make([]Sample, len(r.samples))
+	copy(samples, r.samples)
+	return samples
+}
+
+// FormatSamples 将样本格式化为 "[outcome status latency] request -> response" 并用 sep 连接。
+func FormatSamples(samples []Sample, sep string) string {
+	parts := make([]string, 0, len(samples))
+	for _, s := range samples {
+		prefix := fmt.Sprintf("%s %d %v", s.Outcome, s.StatusCode, s.Latency)
+		if s.TraceID != "" {
+			prefix += " trace=" + s.TraceID
+		}
+		parts = append(parts, fmt.Sprintf("[%s] %s -> %s", prefix, s.RequestBody, s.ResponseBody))
+	}
+	return strings.Join(parts, sep)
+}
@@ -0,0 +1,76 @@
+package httpclient
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestReservoir_Bounded(t *testing.T) {
+	r := newReservoir(5)
+	for i := 0; i < 1000; i++ {
+		r.add(Sample{StatusCode: i})
+	}
+	if len(r.samples) != 5 {
+		t.Errorf("Expected 5 samples, got %d", len(r.samples))
+	}
+	if r.seen != 1000 {
+		t.Errorf("Expected 1000 seen, got %d", r.seen)
+	}
+}
+
+func TestTruncateBody(t *testing.T) {
+	if got := truncateBody([]byte("short"), 10); got != "short" {
+		t.Errorf("Expected untouched body, got %s", got)
+	}
+	got := truncateBody([]byte(strings.Repeat("a", 20)), 10)
+	if !strings.HasPrefix(got, strings.Repeat("a", 10)+"...") || !strings.Contains(got, "20 bytes") {
+		t.Errorf("Unexpected truncated body: %s", got)
+	}
+}
+
+func TestSendRequest_SamplesPairedByOutcome(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status":1,"msg":"` + strings.Repeat("x", 100) + `"}`))
+	})
+	mux.HandleFunc("/fail", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status":0,"msg":"fail"}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	c := NewCustomClient(WithSampling(SamplingConfig{ReservoirSize: 3, MaxBodyBytes: 32}))
+	for i := 0; i < 20; i++ {
+		if _, err := c.SendRequest("POST", server.URL+"/ok", map[string]int{"i": i}); err != nil {
+			t.Fatalf("SendRequest failed: %v", err)
+		}
+	}
+	if _, err := c.SendRequest("POST", server.URL+"/fail", map[string]string{"req": "fail"}); err != nil {
+		t.Fatalf("SendRequest failed: %v", err)
+	}
+
+	okKey := RequestStatsKey{URL: server.URL + "/ok", Method: "POST"}
+	samples := c.GetSamples(okKey, OutcomeSuccess)
+	if len(samples) != 3 {
+		t.Fatalf("Expected 3 success samples, got %d", len(samples))
+	}
+	for _, s := range samples {
+		if !strings.HasPrefix(s.RequestBody, `{"i":`) {
+			t.Errorf("Request body not paired: %s", s.RequestBody)
+		}
+		if !strings.Contains(s.ResponseBody, "truncated") {
+			t.Errorf("Expected truncated response body, got %s", s.ResponseBody)
+		}
+		if s.StatusCode != http.StatusOK || s.Timestamp.IsZero() {
+			t.Errorf("Unexpected sample metadata: %+v", s)
+		}
+	}
+
+	failStats := c.GetRequestStats()[RequestStatsKey{URL: server.URL + "/fail", Method: "POST"}]
+	if len(failStats.Samples) != 1 || failStats.Samples[0].Outcome != OutcomeBusinessFailure ||
+		failStats.Samples[0].RequestBody != `{"req":"fail"}` {
+		t.Errorf("Unexpected failure samples: %+v", failStats.Samples)
+	}
+}
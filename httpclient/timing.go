@@ -0,0 +1,187 @@
+package httpclient
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/http/httptrace"
+	"time"
+)
+
+// ConnTiming 是一次 HTTP 尝试各连接阶段的耗时，没有发生的阶段为 0（例如复用连接时没有 DNS 和建连）。
+type ConnTiming struct {
+	DNS          time.Duration
+	Connect      time.Duration
+	TLSHandshake time.Duration
+	// WaitConn 是从发起请求到拿到连接（新建或空闲连接）的耗时，包含排队等待空闲连接的时间。
+	WaitConn time.Duration
+	// TTFB 是从请求写完到收到响应第一个字节的耗时，主要反映服务端处理时间。
+	TTFB time.Duration
+	// BodyTransfer 是从收到第一个字节到响应体读取完毕的耗时。
+	BodyTransfer time.Duration
+	Reused       bool
+}
+
+// timingBuckets 是 Distribution 的桶上界，最后一个桶收集所有更大的值。
+var timingBuckets = [...]time.Duration{
+	100 * time.Microsecond, 250 * time.Microsecond, 500 * time.Microsecond,
+	time.Millisecond, 2500 * time.Microsecond, 5 * time.Millisecond,
+	10 * time.Millisecond, 25 * time.Millisecond, 50 * time.Millisecond,
+	100 * time.Millisecond, 250 * time.Millisecond, 500 * time.Millisecond,
+	time.Second, 2500 * time.Millisecond, 5 * time.Second, 10 * time.Second,
+}
+
+// Distribution 是耗时的固定桶直方图，可以按值复制。
+type Distribution struct {
+	Count   int
+	Sum     time.Duration
+	Min     time.Duration
+	Max     time.Duration
+	Buckets [len(timingBuckets) + 1]int
+}
+
+func (d *Distribution) observe(v time.Duration) {
+	if d.Count == 0 || v < d.Min {
+		d.Min = v
+	}
+	if v > d.Max {
+		d.Max = v
+	}
+	d.Count++
+	d.Sum += v
+	i := 0
+	for i < len(timingBuckets) && v > timingBuckets[i] {
+		i++
+	}
+	d.Buckets[i]++
+}
+
+// Mean 返回平均耗时。
+func (d Distribution) Mean() time.Duration {
+	if d.Count == 0 {
+		return 0
+	}
+	return d.Sum / time.Duration(d.Count)
+}
+
+// Quantile 返回 q 分位数所在桶的上界（不超过 Max），q 取值 0~1。
+func (d Distribution) Quantile(q float64) time.Duration {
+	if d.Count == 0 {
+		return 0
+	}
+	rank := int(q*float64(d.Count) + 0.5)
+	if rank < 1 {
+		rank = 1
+	}
+	cumulative := 0
+	for i, n := range d.Buckets {
+		cumulative += n
+		if cumulative >= rank {
+			if i < len(timingBuckets) && timingBuckets[i] < d.Max {
+				return timingBuckets[i]
+			}
+			return d.Max
+		}
+	}
+	return d.Max
+}
+
+// String 返回 "mean/p50/p99" 形式的摘要。
+func (d Distribution) String() string {
+	if d.Count == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%v/%v/%v", d.Mean().Round(time.Microsecond),
+		d.Quantile(0.5).Round(time.Microsecond), d.Quantile(0.99).Round(time.Microsecond))
+}
+
+// PhaseTimings 是某个接口各连接阶段的耗时分布以及连接复用情况。
+type PhaseTimings struct {
+	DNS          Distribution
+	Connect      Distribution
+	TLSHandshake Distribution
+	WaitConn     Distribution
+	TTFB         Distribution
+	BodyTransfer Distribution
+	ReusedConns  int
+	NewConns     int
+}
+
+func (p *PhaseTimings) observe(t ConnTiming) {
+	if t.DNS > 0 {
+		p.DNS.observe(t.DNS)
+	}
+	if t.Connect > 0 {
+		p.Connect.observe(t.Connect)
+	}
+	if t.TLSHandshake > 0 {
+		p.TLSHandshake.observe(t.TLSHandshake)
+	}
+	p.WaitConn.observe(t.WaitConn)
+	p.TTFB.observe(t.TTFB)
+	p.BodyTransfer.observe(t.BodyTransfer)
+	if t.Reused {
+		p.ReusedConns++
+	} else {
+		p.NewConns++
+	}
+}
+
+// String 返回各阶段 "mean/p50/p99" 摘要和连接复用情况。
+func (p PhaseTimings) String() string {
+	return fmt.Sprintf("dns %s, connect %s, tls %s, wait %s, ttfb %s, body %s, reused %d/new %d",
+		p.DNS, p.Connect, p.TLSHandshake, p.WaitConn, p.TTFB, p.BodyTransfer, p.ReusedConns, p.NewConns)
+}
+
+// connTracer 使用 httptrace 记录一次尝试的连接阶段时间点。
+type connTracer struct {
+	start, dnsStart, connectStart, tlsStart time.Time
+	gotConn, wroteRequest, firstByte        time.Time
+	timing                                  ConnTiming
+}
+
+func newConnTracer() *connTracer {
+	return &connTracer{start: time.Now()}
+}
+
+func (t *connTracer) clientTrace() *httptrace.ClientTrace {
+	return &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) { t.dnsStart = time.Now() },
+		DNSDone: func(httptrace.DNSDoneInfo) {
+			t.timing.DNS = time.Since(t.dnsStart)
+		},
+		ConnectStart: func(string, string) { t.connectStart = time.Now() },
+		ConnectDone: func(string, string, error) {
+			t.timing.Connect = time.Since(t.connectStart)
+		},
+		TLSHandshakeStart: func() { t.tlsStart = time.Now() },
+		TLSHandshakeDone: func(tls.ConnectionState, error) {
+			t.timing.TLSHandshake = time.Since(t.tlsStart)
+		},
+		GotConn: func(info httptrace.GotConnInfo) {
+			t.gotConn = time.Now()
+			t.timing.WaitConn = t.gotConn.Sub(t.start)
+			t.timing.Reused = info.Reused
+		},
+		WroteRequest: func(httptrace.WroteRequestInfo) { t.wroteRequest = time.Now() },
+		GotFirstResponseByte: func() {
+			t.firstByte = time.Now()
+			if !t.wroteRequest.IsZero() {
+				t.timing.TTFB = t.firstByte.Sub(t.wroteRequest)
+			}
+		},
+	}
+}
+
+// withTrace 返回挂载了 httptrace 的请求。
+func (t *connTracer) withTrace(req *http.Request) *http.Request {
+	return req.WithContext(httptrace.WithClientTrace(req.Context(), t.clientTrace()))
+}
+
+// done 在响应体读取完毕后调用，返回本次尝试的阶段耗时。
+func (t *connTracer) done() ConnTiming {
+	if !t.firstByte.IsZero() {
+		t.timing.BodyTransfer = time.Since(t.firstByte)
+	}
+	return t.timing
+}
@@ -0,0 +1,60 @@
+package httpclient
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestDistribution(t *testing.T) {
+	var d Distribution
+	for i := 1; i <= 100; i++ {
+		d.observe(time.Duration(i) * time.Millisecond)
+	}
+	if d.Count != 100 || d.Min != time.Millisecond || d.Max != 100*time.Millisecond {
+		t.Errorf("Unexpected distribution: %+v", d)
+	}
+	if d.Mean() != 50500*time.Microsecond {
+		t.Errorf("Unexpected mean: %v", d.Mean())
+	}
+	if q := d.Quantile(0.5); q != 50*time.Millisecond {
+		t.Errorf("Unexpected p50: %v", q)
+	}
+	if q := d.Quantile(0.99); q != 100*time.Millisecond {
+		t.Errorf("Unexpected p99: %v", q)
+	}
+	var empty Distribution
+	if empty.Quantile(0.5) != 0 || empty.String() != "-" {
+		t.Errorf("Unexpected empty distribution summary")
+	}
+}
+
+func TestSendRequest_PhaseTimings(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(20 * time.Millisecond)
+		w.Write([]byte(`{"status":1}`))
+	}))
+	defer server.Close()
+
+	c := NewCustomClient()
+	for i := 0; i < 3; i++ {
+		if _, err := c.SendRequest("GET", server.URL, nil); err != nil {
+			t.Fatalf("SendRequest failed: %v", err)
+		}
+	}
+
+	timings := c.GetRequestStats()[RequestStatsKey{URL: server.URL, Method: "GET"}].Timings
+	if timings.NewConns != 1 || timings.ReusedConns != 2 {
+		t.Errorf("Expected 1 new and 2 reused connections, got %d/%d", timings.NewConns, timings.ReusedConns)
+	}
+	if timings.Connect.Count != 1 {
+		t.Errorf("Expected 1 connect observation, got %d", timings.Connect.Count)
+	}
+	if timings.TTFB.Count != 3 || timings.TTFB.Min < 20*time.Millisecond {
+		t.Errorf("Unexpected TTFB distribution: %+v", timings.TTFB)
+	}
+	if timings.BodyTransfer.Count != 3 {
+		t.Errorf("Unexpected body transfer distribution: %+v", timings.BodyTransfer)
+	}
+}
@@ -0,0 +1,55 @@
+package httpclient
+
+import (
+	"fmt"
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/Lincyaw/loadgenerator/httpclient"
+
+// TracingMiddleware 为每次 HTTP 调用创建一个客户端 span（父 span 取自请求上下文），
+// 并注入 W3C traceparent/tracestate 头。tp 为 nil 时使用 otel 全局 TracerProvider。
+func TracingMiddleware(tp trace.TracerProvider) Middleware {
+	propagator := propagation.TraceContext{}
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			provider := tp
+			if provider == nil {
+				provider = otel.GetTracerProvider()
+			}
+			ctx, span := provider.Tracer(tracerName).Start(req.Context(), fmt.Sprintf("HTTP %s", req.Method),
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(
+					attribute.String("http.request.method", req.Method),
+					attribute.String("url.full", req.URL.String()),
+					attribute.String("server.address", req.URL.Host),
+				))
+			defer span.End()
+
+			req = req.WithContext(ctx)
+			propagator.Inject(ctx, propagation.HeaderCarrier(req.Header))
+			if info := RequestInfoFromContext(ctx); info != nil && span.SpanContext().IsValid() {
+				info.TraceID = span.SpanContext().TraceID().String()
+				info.SpanID = span.SpanContext().SpanID().String()
+			}
+
+			resp, err := next(req)
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+				return nil, err
+			}
+			span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+			if resp.StatusCode >= 400 {
+				span.SetStatus(codes.Error, resp.Status)
+			}
+			return resp, nil
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTracingMiddleware_InjectsTraceparent(t *testing.T) {
+	var traceparent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		w.Write([]byte(`{"status":1}`))
+	}))
+	defer server.Close()
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "node")
+
+	c := NewCustomClient(WithMiddleware(TracingMiddleware(tp)))
+	c.SetContext(ctx)
+	if _, err := c.SendRequest("GET", server.URL, nil); err != nil {
+		t.Fatalf("SendRequest failed: %v", err)
+	}
+	parent.End()
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("Expected 2 spans, got %d", len(spans))
+	}
+	httpSpan := spans[0]
+	if httpSpan.SpanKind() != trace.SpanKindClient {
+		t.Errorf("Expected client span, got %v", httpSpan.SpanKind())
+	}
+	if httpSpan.Parent().SpanID() != parent.SpanContext().SpanID() {
+		t.Errorf("HTTP span is not a child of the node span")
+	}
+
+	traceID := httpSpan.SpanContext().TraceID().String()
+	spanID := httpSpan.SpanContext().SpanID().String()
+	if !strings.HasPrefix(traceparent, "00-"+traceID+"-"+spanID) {
+		t.Errorf("Unexpected traceparent %q, trace %s span %s", traceparent, traceID, spanID)
+	}
+
+	stats := c.GetRequestStats()[RequestStatsKey{URL: server.URL, Method: "GET"}]
+	if len(stats.Samples) != 1 || stats.Samples[0].TraceID != traceID {
+		t.Errorf("Expected sample with trace id %s, got %+v", traceID, stats.Samples)
+	}
+}
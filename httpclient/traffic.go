@@ -0,0 +1,159 @@
+package httpclient
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// sizeBuckets 是 SizeDistribution 的桶上界（字节），最后一个桶收集所有更大的值。
+var sizeBuckets = [...]int64{
+	256, 1 << 10, 4 << 10, 16 << 10, 64 << 10, 256 << 10, 1 << 20, 4 << 20, 16 << 20,
+}
+
+// SizeDistribution 是请求体或响应体大小的固定桶直方图，可以按值复制。
+type SizeDistribution struct {
+	Count   int
+	Total   int64
+	Min     int64
+	Max     int64
+	Buckets [len(sizeBuckets) + 1]int
+}
+
+func (d *SizeDistribution) observe(size int64) {
+	if d.Count == 0 || size < d.Min {
+		d.Min = size
+	}
+	if size > d.Max {
+		d.Max = size
+	}
+	d.Count++
+	d.Total += size
+	i := 0
+	for i < len(sizeBuckets) && size > sizeBuckets[i] {
+		i++
+	}
+	d.Buckets[i]++
+}
+
+// Mean 返回平均大小（字节）。
+func (d SizeDistribution) Mean() int64 {
+	if d.Count == 0 {
+		return 0
+	}
+	return d.Total / int64(d.Count)
+}
+
+// String 返回 "total (mean/max)" 形式的摘要。
+func (d SizeDistribution) String() string {
+	if d.Count == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%s (%s/%s)", FormatBytes(d.Total), FormatBytes(d.Mean()), FormatBytes(d.Max))
+}
+
+// FormatBytes 将字节数格式化为 B/KiB/MiB/GiB。
+func FormatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%dB", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit && exp < 2; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%ciB", float64(n)/float64(div), "KMG"[exp])
+}
+
+// ThroughputPoint 是一个时间窗口内发送和接收的字节数。
+type ThroughputPoint struct {
+	Start         time.Time
+	Interval      time.Duration
+	RequestBytes  int64
+	ResponseBytes int64
+}
+
+// RequestBytesPerSecond 返回窗口内的发送速率。
+func (p ThroughputPoint) RequestBytesPerSecond() float64 {
+	return float64(p.RequestBytes) / p.Interval.Seconds()
+}
+
+// ResponseBytesPerSecond 返回窗口内的接收速率。
+func (p ThroughputPoint) ResponseBytesPerSecond() float64 {
+	return float64(p.ResponseBytes) / p.Interval.Seconds()
+}
+
+// TrafficConfig 配置吞吐量时间序列。
+type TrafficConfig struct {
+	// Interval 是每个时间窗口的长度。
+	Interval time.Duration
+	// MaxPoints 是最多保留的窗口数量，超出后丢弃最早的窗口。
+	MaxPoints int
+}
+
+// DefaultTrafficConfig 返回默认配置：10 秒一个窗口，保留 24 小时。
+func DefaultTrafficConfig() TrafficConfig {
+	return TrafficConfig{
+		Interval:  10 * time.Second,
+		MaxPoints: 8640,
+	}
+}
+
+// WithTraffic 设置吞吐量时间序列的配置。
+func WithTraffic(config TrafficConfig) Option {
+	return func(c *HttpClient) {
+		c.traffic = newThroughputSeries(config)
+	}
+}
+
+// throughputSeries 是按固定窗口聚合的吞吐量环形缓冲区。
+type throughputSeries struct {
+	config TrafficConfig
+	points []ThroughputPoint
+}
+
+func newThroughputSeries(config TrafficConfig) *throughputSeries {
+	if config.Interval <= 0 {
+		config.Interval = DefaultTrafficConfig().Interval
+	}
+	if config.MaxPoints <= 0 {
+		config.MaxPoints = DefaultTrafficConfig().MaxPoints
+	}
+	return &throughputSeries{config: config}
+}
+
+func (s *throughputSeries) add(now time.Time, requestBytes, responseBytes int64) {
+	start := now.Truncate(s.config.Interval)
+	if n := len(s.points); n == 0 || !s.points[n-1].Start.Equal(start) {
+		s.points = append(s.points, ThroughputPoint{Start: start, Interval: s.config.Interval})
+		if len(s.points) > s.config.MaxPoints {
+			s.points = s.points[len(s.points)-s.config.MaxPoints:]
+		}
+	}
+	last := &s.points[len(s.points)-1]
+	last.RequestBytes += requestBytes
+	last.ResponseBytes += responseBytes
+}
+
+// GetThroughput 返回吞吐量时间序列，按时间排序。没有流量的窗口不会出现在结果中。
+func (c *HttpClient) GetThroughput() []ThroughputPoint {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	points := make([]ThroughputPoint, len(c.traffic.points))
+	copy(points, c.traffic.points)
+	return points
+}
+
+// GenerateThroughputTable 生成吞吐量时间序列的 Markdown 表格。
+func GenerateThroughputTable(points []ThroughputPoint) string {
+	var sb strings.Builder
+	sb.WriteString("| Start | Sent | Received | Sent/s | Received/s |\n")
+	sb.WriteString("| ----- | ---- | -------- | ------ | ---------- |\n")
+	for _, p := range points {
+		sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s |\n", p.Start.Format(time.RFC3339),
+			FormatBytes(p.RequestBytes), FormatBytes(p.ResponseBytes),
+			FormatBytes(int64(p.RequestBytesPerSecond())), FormatBytes(int64(p.ResponseBytesPerSecond()))))
+	}
+	return sb.String()
+}
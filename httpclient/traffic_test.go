@@ -0,0 +1,70 @@
+package httpclient
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSendRequest_TrafficAccounting(t *testing.T) {
+	payload := strings.Repeat("x", 5000)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(payload))
+	}))
+	defer server.Close()
+
+	c := NewCustomClient()
+	for i := 0; i < 4; i++ {
+		if _, err := c.SendRequest("POST", server.URL, map[string]string{"k": "v"}); err != nil {
+			t.Fatalf("SendRequest failed: %v", err)
+		}
+	}
+
+	stats := c.GetRequestStats()[RequestStatsKey{URL: server.URL, Method: "POST"}]
+	if stats.RequestSize.Count != 4 || stats.RequestSize.Total != 4*int64(len(`{"k":"v"}`)) {
+		t.Errorf("Unexpected request sizes: %+v", stats.RequestSize)
+	}
+	if stats.ResponseSize.Total != 4*5000 || stats.ResponseSize.Max != 5000 || stats.ResponseSize.Buckets[3] != 4 {
+		t.Errorf("Unexpected response sizes: %+v", stats.ResponseSize)
+	}
+
+	var received int64
+	for _, p := range c.GetThroughput() {
+		received += p.ResponseBytes
+	}
+	if received != 4*5000 {
+		t.Errorf("Expected 20000 received bytes in throughput series, got %d", received)
+	}
+}
+
+func TestThroughputSeries(t *testing.T) {
+	s := newThroughputSeries(TrafficConfig{Interval: time.Second, MaxPoints: 2})
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	s.add(base, 10, 100)
+	s.add(base.Add(500*time.Millisecond), 10, 100)
+	s.add(base.Add(time.Second), 1, 1)
+	s.add(base.Add(2*time.Second), 2, 2)
+	if len(s.points) != 2 {
+		t.Fatalf("Expected 2 points, got %d", len(s.points))
+	}
+	if !s.points[0].Start.Equal(base.Add(time.Second)) || s.points[1].ResponseBytes != 2 {
+		t.Errorf("Unexpected points: %+v", s.points)
+	}
+
+	s = newThroughputSeries(TrafficConfig{Interval: 10 * time.Second})
+	s.add(base, 0, 2000)
+	if rate := s.points[0].ResponseBytesPerSecond(); rate != 200 {
+		t.Errorf("Expected 200 B/s, got %v", rate)
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	cases := map[int64]string{512: "512B", 2048: "2.0KiB", 5 << 20: "5.0MiB", 3 << 30: "3.0GiB"}
+	for n, want := range cases {
+		if got := FormatBytes(n); got != want {
+			t.Errorf("FormatBytes(%d) = %s, want %s", n, got, want)
+		}
+	}
+}
@@ -1,12 +1,250 @@
 package main
 
 import (
+	"context"
+	"fmt"
 	"github.com/Lincyaw/loadgenerator/behaviors"
+	"github.com/Lincyaw/loadgenerator/httpclient"
+	"github.com/Lincyaw/loadgenerator/replay"
+	"github.com/Lincyaw/loadgenerator/seed"
+	"github.com/Lincyaw/loadgenerator/service"
+	"github.com/Lincyaw/loadgenerator/telemetry"
 	"log"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
 )
 
 func main() {
 	log.SetFlags(log.LstdFlags | log.Lshortfile)
+	// exitCode is applied by the first deferred call, so it runs after the others have flushed
+	// telemetry and closed the record file.
+	exitCode := 0
+	defer func() {
+		if exitCode != 0 {
+			os.Exit(exitCode)
+		}
+	}()
+	telemetryConfig, err := telemetry.ConfigFromEnv()
+	if err != nil {
+		log.Fatalf("Invalid telemetry config: %v", err)
+	}
+	tel, err := telemetry.Setup(context.Background(), telemetryConfig)
+	if err != nil {
+		log.Fatalf("Setup telemetry failed: %v", err)
+	}
+	defer func() {
+		if err := tel.Shutdown(context.Background()); err != nil {
+			log.Printf("Shutdown telemetry failed: %v", err)
+		}
+	}()
+
+	if path := os.Getenv("REPLAY_FILE"); path != "" {
+		runReplay(path)
+		return
+	}
+
+	if behaviors.VerifyCodeSolver, err = service.VerifyCodeSolverFromEnv(); err != nil {
+		log.Fatalf("Invalid verify code solver config: %v", err)
+	}
+	if behaviors.VerifyCodeSolver == nil {
+		log.Fatalf("Logins need the code of the captcha: %v", service.ErrNoVerifyCodeSolver)
+	}
+
+	var clientOpts []service.ClientOption
+	breaker, err := service.NewCircuitBreakerFromEnv()
+	if err != nil {
+		log.Fatalf("Invalid circuit breaker config: %v", err)
+	}
+	if breaker != nil {
+		clientOpts = append(clientOpts, service.WithCircuitBreaker(breaker))
+	}
+	if path := os.Getenv("RECORD_FILE"); path != "" {
+		recorderConfig := httpclient.DefaultRecorderConfig()
+		recorderConfig.RunID = telemetryConfig.RunID
+		recorder, err := httpclient.NewFileRecorder(path, recorderConfig)
+		if err != nil {
+			log.Fatalf("Open record file failed: %v", err)
+		}
+		clientOpts = append(clientOpts, service.WithRecorder(recorder))
+		defer func() {
+			if err := recorder.Close(); err != nil {
+				log.Printf("Close record file failed: %v", err)
+			}
+			if dropped := recorder.Dropped(); dropped > 0 {
+				log.Printf("Dropped %d records because the record buffer was full", dropped)
+			}
+		}()
+	}
+
+	if path := os.Getenv("SEED_SPEC"); path != "" {
+		if err := runSeed(path, clientOpts); err != nil {
+			log.Printf("Seed failed: %v", err)
+			exitCode = 1
+		}
+		return
+	}
+	if path := os.Getenv("SEED_CLEANUP"); path != "" {
+		if err := runSeedCleanup(path, clientOpts); err != nil {
+			log.Printf("Seed cleanup failed: %v", err)
+			exitCode = 1
+		}
+		return
+	}
+
+	var catalogRefresh time.Duration
+	if v := os.Getenv("CATALOG_REFRESH"); v != "" {
+		if catalogRefresh, err = time.ParseDuration(v); err != nil {
+			log.Fatalf("Invalid CATALOG_REFRESH %q: %v", v, err)
+		}
+	}
+
+	// RATE is the target number of iterations per second of all VUs, 0 sleeps between iterations instead
+	var rate float64
+	if v := os.Getenv("RATE"); v != "" {
+		if rate, err = strconv.ParseFloat(v, 64); err != nil || !(rate >= 0 && rate <= behaviors.MaxRate) {
+			log.Fatalf("Invalid RATE %q: must be a number between 0 and %g", v, float64(behaviors.MaxRate))
+		}
+	}
+
+	// STATION_POPULARITY weights the stations picked as origin and destination, e.g. shanghai=5,nanjing=2
+	var popularity map[string]float64
+	if v := os.Getenv("STATION_POPULARITY"); v != "" {
+		popularity = make(map[string]float64)
+		for _, pair := range strings.Split(v, ",") {
+			station, weight, ok := strings.Cut(pair, "=")
+			if !ok {
+				log.Fatalf("Invalid STATION_POPULARITY entry %q", pair)
+			}
+			if popularity[strings.TrimSpace(station)], err = strconv.ParseFloat(strings.TrimSpace(weight), 64); err != nil {
+				log.Fatalf("Invalid STATION_POPULARITY entry %q: %v", pair, err)
+			}
+		}
+	}
+
 	lg := &behaviors.LoadGenerator{}
-	lg.Start(behaviors.WithThread(1), behaviors.WithSleep(1000), behaviors.WithChain(behaviors.LoginChain))
+	lg.Start(behaviors.WithThread(1), behaviors.WithSleep(1000), behaviors.WithChain(behaviors.LoginChain),
+		behaviors.WithTracerProvider(tel.TracerProvider), behaviors.WithMeterProvider(tel.MeterProvider),
+		behaviors.WithRate(rate), behaviors.WithCatalogRefresh(catalogRefresh), behaviors.WithStationPopularity(popularity),
+		behaviors.WithClientOptions(clientOpts...))
+}
+
+// runReplay replays a recorded JSONL file against BASE_URL. REPLAY_SPEED divides the recorded
+// inter-arrival times, REPLAY_RATE sends a fixed number of requests per second instead, and
+// REPLAY_RULES is a JSON file of extraction rules (default: the login token).
+func runReplay(path string) {
+	config := replay.Config{BaseURL: os.Getenv("BASE_URL"), Rules: replay.DefaultRules()}
+	var err error
+	if v := os.Getenv("REPLAY_SPEED"); v != "" {
+		if config.Speed, err = strconv.ParseFloat(v, 64); err != nil {
+			log.Fatalf("Invalid REPLAY_SPEED %q: %v", v, err)
+		}
+	}
+	if v := os.Getenv("REPLAY_RATE"); v != "" {
+		if config.Rate, err = strconv.ParseFloat(v, 64); err != nil {
+			log.Fatalf("Invalid REPLAY_RATE %q: %v", v, err)
+		}
+	}
+	if v := os.Getenv("REPLAY_RULES"); v != "" {
+		if config.Rules, err = replay.LoadRules(v); err != nil {
+			log.Fatalf("Load replay rules failed: %v", err)
+		}
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		log.Fatalf("Open replay file failed: %v", err)
+	}
+	records, err := replay.ReadRecords(f)
+	f.Close()
+	if err != nil {
+		log.Fatalf("Read replay file failed: %v", err)
+	}
+	replayer, err := replay.New(config)
+	if err != nil {
+		log.Fatalf("Create replayer failed: %v", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	result, err := replayer.Run(ctx, records)
+	log.Printf("Replayed %d of %d requests, %d errors, max lag %v", result.Sent, len(records), result.Errors, result.MaxLag)
+	if err != nil {
+		log.Printf("Replay stopped: %v", err)
+	}
+	fmt.Println(httpclient.GenerateMarkdownTable(replayer.Client().GetRequestStats()))
+}
+
+// seedClient logs in as admin for seeding.
+func seedClient(opts []service.ClientOption) (*service.SvcImpl, error) {
+	cli := service.NewSvcClients(opts...)
+	if _, err := cli.LoginWithVerifyCode("admin", "222222", behaviors.VerifyCodeSolver); err != nil {
+		return nil, fmt.Errorf("admin login: %w", err)
+	}
+	return cli, nil
+}
+
+// runSeed creates the dataset of a JSON spec file. The created records are added to the manifest
+// at SEED_MANIFEST (default seed-manifest.json), which SEED_CLEANUP removes again.
+func runSeed(path string, opts []service.ClientOption) error {
+	spec, err := seed.LoadSpec(path)
+	if err != nil {
+		return fmt.Errorf("load seed spec: %w", err)
+	}
+	manifestPath := os.Getenv("SEED_MANIFEST")
+	if manifestPath == "" {
+		manifestPath = "seed-manifest.json"
+	}
+	manifest, err := seed.LoadManifest(manifestPath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("load seed manifest: %w", err)
+	}
+	cli, err := seedClient(opts)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	created, seedErr := seed.Seed(ctx, cli, spec)
+	if manifest == nil {
+		manifest = created
+	} else {
+		manifest.Merge(created)
+	}
+	if err := manifest.Save(manifestPath); err != nil {
+		return fmt.Errorf("save seed manifest: %w", err)
+	}
+	log.Printf("Seeded %d records, manifest %s", created.Len(), manifestPath)
+	return seedErr
+}
+
+// runSeedCleanup deletes the records of a seed manifest and rewrites it with the records it failed
+// to delete, or removes it when all were deleted.
+func runSeedCleanup(path string, opts []service.ClientOption) error {
+	manifest, err := seed.LoadManifest(path)
+	if err != nil {
+		return fmt.Errorf("load seed manifest: %w", err)
+	}
+	cli, err := seedClient(opts)
+	if err != nil {
+		return err
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	left, cleanupErr := seed.Cleanup(ctx, cli, manifest, 0)
+	log.Printf("Deleted %d of %d seeded records", manifest.Len()-left.Len(), manifest.Len())
+	if left.Len() == 0 {
+		if err := os.Remove(path); err != nil {
+			log.Printf("Remove seed manifest failed: %v", err)
+		}
+		return nil
+	}
+	if err := left.Save(path); err != nil {
+		return fmt.Errorf("save seed manifest: %w", err)
+	}
+	return cleanupErr
 }
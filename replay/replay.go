@@ -0,0 +1,337 @@
+// Package replay replays HTTP calls recorded by httpclient.Recorder against another deployment.
+package replay
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Lincyaw/loadgenerator/httpclient"
+)
+
+// Rule extracts a session-specific value from responses so later requests of the same session use
+// the value issued by the target instead of the recorded one.
+type Rule struct {
+	// Method and Route select the calls to extract from. Route is matched with path.Match against
+	// the route template, e.g. "/api/v1/users/login". An empty Method matches every method.
+	Method string `json:"method"`
+	Route  string `json:"route"`
+	// Path is a dotted path into the JSON response body, e.g. "data.token" or "data.0.id".
+	Path string `json:"path"`
+	// Header, when set, sends the extracted value in this header on later requests of the session,
+	// formatted with Format ("%s" when empty). Otherwise the value found in the recorded response is
+	// replaced by the value found in the live response in later URLs and bodies of the session.
+	Header string `json:"header,omitempty"`
+	Format string `json:"format,omitempty"`
+}
+
+func (r Rule) match(record httpclient.Record) bool {
+	if r.Method != "" && r.Method != record.Method {
+		return false
+	}
+	ok, err := path.Match(r.Route, record.Route)
+	return err == nil && ok
+}
+
+// DefaultRules sends the token issued by the target's login endpoint as the Bearer token of the session.
+func DefaultRules() []Rule {
+	return []Rule{
+		{Method: "POST", Route: "/api/v1/users/login", Path: "data.token", Header: "Authorization", Format: "Bearer %s"},
+	}
+}
+
+// LoadRules reads a JSON array of rules from a file.
+func LoadRules(file string) ([]Rule, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var rules []Rule
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("parse rules %s: %w", file, err)
+	}
+	return rules, nil
+}
+
+// Config configures a replay.
+type Config struct {
+	// BaseURL replaces the scheme and host of every recorded URL.
+	BaseURL string
+	// Speed divides the recorded inter-arrival times, 2 replays twice as fast. Values <= 0 mean 1.
+	Speed float64
+	// Rate, when > 0, ignores the recorded timestamps and sends Rate requests per second in recorded order.
+	Rate float64
+	// Rules extract tokens, order ids and other session-specific values.
+	Rules []Rule
+	// Client sends the requests, defaults to a client without retries so the recorded traffic is reproduced exactly.
+	Client *httpclient.HttpClient
+}
+
+// Result summarises a replay.
+type Result struct {
+	Sent int
+	// Errors counts calls that got no response. HTTP and business failures are in the client's statistics.
+	Errors int
+	// MaxLag is the largest delay between a request's scheduled and actual send time. A large lag means
+	// the target (or a session's previous requests) could not keep up with the recorded pace.
+	MaxLag time.Duration
+}
+
+// ReadRecords reads a recorded JSONL file and returns its records ordered by timestamp.
+func ReadRecords(r io.Reader) ([]httpclient.Record, error) {
+	var records []httpclient.Record
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		var record httpclient.Record
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Timestamp.Before(records[j].Timestamp)
+	})
+	return records, nil
+}
+
+// Replayer replays records. Calls of the same session (run, VU and iteration) are sent sequentially
+// in recorded order, different sessions run concurrently.
+type Replayer struct {
+	config Config
+	base   *url.URL
+}
+
+// New creates a Replayer.
+func New(config Config) (*Replayer, error) {
+	base, err := url.Parse(config.BaseURL)
+	if err != nil || base.Scheme == "" || base.Host == "" {
+		return nil, fmt.Errorf("invalid base url %q", config.BaseURL)
+	}
+	if config.Speed <= 0 {
+		config.Speed = 1
+	}
+	if config.Client == nil {
+		config.Client = httpclient.NewCustomClient()
+	}
+	return &Replayer{config: config, base: base}, nil
+}
+
+// Client returns the client used for the replay, e.g. to report its statistics.
+func (r *Replayer) Client() *httpclient.HttpClient {
+	return r.config.Client
+}
+
+// session holds the values extracted for one recorded session.
+type session struct {
+	records      []httpclient.Record
+	offsets      []time.Duration
+	header       http.Header
+	replacements [][2]string
+}
+
+// Run replays records, which must be ordered by timestamp as returned by ReadRecords,
+// and blocks until all of them are sent or ctx is done.
+func (r *Replayer) Run(ctx context.Context, records []httpclient.Record) (Result, error) {
+	sessions := make(map[string]*session)
+	var order []*session
+	for i, record := range records {
+		key := fmt.Sprintf("%s/%d/%d", record.RunID, record.VU, record.Iteration)
+		s, ok := sessions[key]
+		if !ok {
+			s = &session{header: make(http.Header)}
+			sessions[key] = s
+			order = append(order, s)
+		}
+		s.records = append(s.records, record)
+		s.offsets = append(s.offsets, r.offset(records, i))
+	}
+
+	var (
+		mu     sync.Mutex
+		result Result
+		wg     sync.WaitGroup
+	)
+	start := time.Now()
+	for _, s := range order {
+		wg.Add(1)
+		go func(s *session) {
+			defer wg.Done()
+			for i, record := range s.records {
+				if err := sleepContext(ctx, time.Until(start.Add(s.offsets[i]))); err != nil {
+					return
+				}
+				lag := time.Since(start.Add(s.offsets[i]))
+				err := r.send(ctx, s, record)
+				mu.Lock()
+				result.Sent++
+				if err != nil {
+					result.Errors++
+				}
+				if lag > result.MaxLag {
+					result.MaxLag = lag
+				}
+				mu.Unlock()
+			}
+		}(s)
+	}
+	wg.Wait()
+	return result, ctx.Err()
+}
+
+// offset returns when records[i] is scheduled relative to the start of the replay.
+func (r *Replayer) offset(records []httpclient.Record, i int) time.Duration {
+	if r.config.Rate > 0 {
+		return time.Duration(float64(i) / r.config.Rate * float64(time.Second))
+	}
+	return time.Duration(float64(records[i].Timestamp.Sub(records[0].Timestamp)) / r.config.Speed)
+}
+
+func (r *Replayer) send(ctx context.Context, s *session, record httpclient.Record) error {
+	target, err := r.rewrite(s.substitute(record.URL))
+	if err != nil {
+		return err
+	}
+	var body interface{}
+	if record.RequestBody != "" && record.RequestBody != "null" {
+		body = json.RawMessage(s.substitute(record.RequestBody))
+	}
+
+	header := make(http.Header)
+	for key, value := range record.RequestHeaders {
+		if skipHeader(key, value) {
+			continue
+		}
+		header.Set(key, value)
+	}
+	for key, values := range s.header {
+		header[key] = values
+	}
+
+	resp, err := r.config.Client.SendRequestWithContext(httpclient.ContextWithHeaders(ctx, header), record.Method, target, body)
+	if err != nil {
+		return err
+	}
+	respBody, err := httpclient.ReadResponseBody(resp)
+	if err != nil {
+		return err
+	}
+	s.extract(r.config.Rules, record, respBody)
+	return nil
+}
+
+// rewrite replaces the scheme and host of a recorded URL with the base URL.
+func (r *Replayer) rewrite(raw string) (string, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", err
+	}
+	u.Scheme = r.base.Scheme
+	u.Host = r.base.Host
+	return u.String(), nil
+}
+
+// skipHeader drops redacted values and headers that belong to the original call rather than the request.
+func skipHeader(key, value string) bool {
+	switch http.CanonicalHeaderKey(key) {
+	case "Traceparent", "Tracestate", "Content-Length", "Cookie", "Authorization":
+		return true
+	}
+	return value == "[REDACTED]"
+}
+
+func (s *session) substitute(value string) string {
+	for _, pair := range s.replacements {
+		value = strings.ReplaceAll(value, pair[0], pair[1])
+	}
+	return value
+}
+
+func (s *session) extract(rules []Rule, record httpclient.Record, respBody []byte) {
+	for _, rule := range rules {
+		if !rule.match(record) {
+			continue
+		}
+		live, ok := lookup(respBody, rule.Path)
+		if !ok {
+			continue
+		}
+		if rule.Header != "" {
+			format := rule.Format
+			if format == "" {
+				format = "%s"
+			}
+			s.header.Set(rule.Header, fmt.Sprintf(format, live))
+			continue
+		}
+		// The recorded response may be truncated, then the old value is unknown and nothing is replaced.
+		recorded, ok := lookup([]byte(record.ResponseBody), rule.Path)
+		if !ok || recorded == live || len(recorded) < minReplacementLength {
+			continue
+		}
+		s.replacements = append(s.replacements, [2]string{recorded, live})
+	}
+}
+
+// minReplacementLength avoids replacing short values such as "1" that would match unrelated text.
+const minReplacementLength = 4
+
+// lookup returns the string form of the value at a dotted path in a JSON document.
+func lookup(body []byte, dotted string) (string, bool) {
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return "", false
+	}
+	for _, key := range strings.Split(dotted, ".") {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			value = v[key]
+		case []interface{}:
+			i, err := strconv.Atoi(key)
+			if err != nil || i < 0 || i >= len(v) {
+				return "", false
+			}
+			value = v[i]
+		default:
+			return "", false
+		}
+	}
+	switch v := value.(type) {
+	case string:
+		return v, v != ""
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	}
+	return "", false
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
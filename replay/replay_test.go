@@ -0,0 +1,141 @@
+package replay
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Lincyaw/loadgenerator/httpclient"
+)
+
+// newBackend returns a server that issues the given token and order id and records the calls it receives.
+func newBackend(token, orderId string) (*httptest.Server, *[]string) {
+	var mu sync.Mutex
+	calls := &[]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		*calls = append(*calls, fmt.Sprintf("%s %s %s", r.Method, r.URL.Path, r.Header.Get("Authorization")))
+		mu.Unlock()
+		switch r.URL.Path {
+		case "/api/v1/users/login":
+			fmt.Fprintf(w, `{"status":1,"data":{"token":"%s"}}`, token)
+		case "/api/v1/orderservice/order":
+			fmt.Fprintf(w, `{"status":1,"data":{"id":"%s"}}`, orderId)
+		default:
+			w.Write([]byte(`{"status":1}`))
+		}
+	}))
+	return server, calls
+}
+
+func TestReplayer_RecordAndReplay(t *testing.T) {
+	original, _ := newBackend("old-token", "old-order-0001")
+	defer original.Close()
+
+	// Record one session: login, create an order and query it.
+	var recording bytes.Buffer
+	recorder := httpclient.NewRecorder(&recording, httpclient.DefaultRecorderConfig())
+	c := httpclient.NewCustomClient(httpclient.WithRecorder(recorder))
+	c.SetContext(httpclient.ContextWithLabels(context.Background(), httpclient.Labels{VU: 1, Iteration: 1}))
+	c.SendRequest("POST", original.URL+"/api/v1/users/login", map[string]string{"username": "fdse"})
+	c.AddHeader("Authorization", "Bearer old-token")
+	c.SendRequest("POST", original.URL+"/api/v1/orderservice/order", map[string]string{"from": "shanghai"})
+	c.SendRequest("GET", original.URL+"/api/v1/orderservice/order/old-order-0001", nil)
+	if err := recorder.Close(); err != nil {
+		t.Fatalf("Close recorder failed: %v", err)
+	}
+
+	records, err := ReadRecords(&recording)
+	if err != nil {
+		t.Fatalf("ReadRecords failed: %v", err)
+	}
+	target, calls := newBackend("new-token", "new-order-0002")
+	defer target.Close()
+	rules := append(DefaultRules(), Rule{Method: "POST", Route: "/api/v1/orderservice/order", Path: "data.id"})
+	replayer, err := New(Config{BaseURL: target.URL, Rate: 1000, Rules: rules})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	result, err := replayer.Run(context.Background(), records)
+	if err != nil || result.Sent != 3 || result.Errors != 0 {
+		t.Fatalf("Run = %+v, %v", result, err)
+	}
+
+	want := []string{
+		"POST /api/v1/users/login ",
+		"POST /api/v1/orderservice/order Bearer new-token",
+		"GET /api/v1/orderservice/order/new-order-0002 Bearer new-token",
+	}
+	if strings.Join(*calls, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Replayed calls:\n%s\nwant:\n%s", strings.Join(*calls, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestReplayer_Timing(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	start := time.Now()
+	records := []httpclient.Record{
+		{Timestamp: start, Method: "GET", URL: "http://recorded/a", VU: 1},
+		{Timestamp: start.Add(200 * time.Millisecond), Method: "GET", URL: "http://recorded/b", VU: 2},
+	}
+	cases := []struct {
+		config   Config
+		min, max time.Duration
+	}{
+		{Config{Speed: 1}, 200 * time.Millisecond, time.Second},
+		{Config{Speed: 4}, 50 * time.Millisecond, 150 * time.Millisecond},
+		{Config{Rate: 10}, 100 * time.Millisecond, 190 * time.Millisecond},
+	}
+	for _, tc := range cases {
+		tc.config.BaseURL = server.URL
+		replayer, err := New(tc.config)
+		if err != nil {
+			t.Fatalf("New failed: %v", err)
+		}
+		begin := time.Now()
+		if _, err := replayer.Run(context.Background(), records); err != nil {
+			t.Fatalf("Run failed: %v", err)
+		}
+		if elapsed := time.Since(begin); elapsed < tc.min || elapsed > tc.max {
+			t.Errorf("%+v took %v, want between %v and %v", tc.config, elapsed, tc.min, tc.max)
+		}
+	}
+}
+
+func TestReadRecords_SortsByTimestamp(t *testing.T) {
+	input := `{"timestamp":"2024-06-01T10:00:02Z","method":"GET","url":"http://a/2"}
+
+{"timestamp":"2024-06-01T10:00:01Z","method":"GET","url":"http://a/1"}
+`
+	records, err := ReadRecords(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ReadRecords failed: %v", err)
+	}
+	if len(records) != 2 || records[0].URL != "http://a/1" {
+		t.Errorf("Unexpected records: %+v", records)
+	}
+	if _, err := ReadRecords(strings.NewReader("{")); err == nil {
+		t.Error("Expected error for invalid line")
+	}
+}
+
+func TestLookup(t *testing.T) {
+	body := []byte(`{"data":[{"id":"abc","price":12.5}]}`)
+	if v, ok := lookup(body, "data.0.id"); !ok || v != "abc" {
+		t.Errorf("lookup data.0.id = %q, %v", v, ok)
+	}
+	if v, ok := lookup(body, "data.0.price"); !ok || v != "12.5" {
+		t.Errorf("lookup data.0.price = %q, %v", v, ok)
+	}
+	if _, ok := lookup(body, "data.1.id"); ok {
+		t.Error("Expected lookup out of range to fail")
+	}
+}
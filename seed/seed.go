@@ -0,0 +1,575 @@
+// Package seed populates a TrainTicket deployment through its admin services: stations, train types,
+// routes, trips, price configs, users and their contacts. Seeding is idempotent and records what it
+// created in a Manifest, which Cleanup removes again.
+package seed
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Lincyaw/loadgenerator/service"
+	"github.com/google/uuid"
+)
+
+// Client is the part of the TrainTicket API used for seeding. It must be logged in as admin.
+type Client interface {
+	service.AdminBasicInfoService
+	service.AdminRouteService
+	service.AdminTravelService
+	service.AdminUserService
+	service.PriceService
+}
+
+// Spec declares a dataset. Every generated name and id is derived from Prefix and the record's
+// index, so seeding the same Spec again finds the records of the previous run instead of
+// creating new ones.
+type Spec struct {
+	// Prefix starts the names of the generated stations, trains and users: lowercase letters and digits
+	Prefix string `json:"prefix"`
+
+	Stations int `json:"stations"`
+	Trains   int `json:"trains"`
+	Routes   int `json:"routes"`
+	// StopsPerRoute is the number of stations on a route, default 4
+	StopsPerRoute int `json:"stopsPerRoute"`
+	// TripsPerRoute is the number of trips on each route, default 2. A trip gets a price config for
+	// its route and train type. TrainTicket runs every trip daily, so trips starting today can be
+	// booked for any later day.
+	TripsPerRoute int `json:"tripsPerRoute"`
+	// TripNumberStart is the number of the first trip, default 5000. Trip ids are a G, D, Z, T or K
+	// followed by four digits, so they can't carry the prefix; use distinct ranges for datasets
+	// seeded into the same deployment.
+	TripNumberStart int `json:"tripNumberStart"`
+
+	Users           int `json:"users"`
+	ContactsPerUser int `json:"contactsPerUser"`
+	// Password of the generated users, default 111111
+	Password string `json:"password"`
+
+	// Concurrency is the number of records created at the same time, default 8
+	Concurrency int `json:"concurrency"`
+}
+
+var validPrefix = regexp.MustCompile(`^[a-z][a-z0-9]*$`)
+
+// LoadSpec reads a JSON Spec from a file.
+func LoadSpec(file string) (Spec, error) {
+	var spec Spec
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return spec, err
+	}
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return spec, fmt.Errorf("parse spec %s: %w", file, err)
+	}
+	return spec, nil
+}
+
+func (s Spec) withDefaults() Spec {
+	if s.StopsPerRoute == 0 {
+		s.StopsPerRoute = 4
+	}
+	if s.TripsPerRoute == 0 {
+		s.TripsPerRoute = 2
+	}
+	if s.TripNumberStart == 0 {
+		s.TripNumberStart = 5000
+	}
+	if s.Password == "" {
+		s.Password = "111111"
+	}
+	if s.Concurrency <= 0 {
+		s.Concurrency = 8
+	}
+	return s
+}
+
+func (s Spec) validate() error {
+	switch {
+	case !validPrefix.MatchString(s.Prefix):
+		return fmt.Errorf("prefix %q must be lowercase letters and digits starting with a letter", s.Prefix)
+	case s.Routes > 0 && (s.StopsPerRoute < 2 || s.Stations < s.StopsPerRoute):
+		return fmt.Errorf("routes need at least 2 stops and %d stations, got %d stops and %d stations",
+			s.StopsPerRoute, s.StopsPerRoute, s.Stations)
+	case s.Routes > 0 && s.Trains == 0:
+		return errors.New("trips need at least one train type")
+	case s.TripNumberStart < 1000 || s.TripNumberStart+s.Routes*s.TripsPerRoute > 10000:
+		return fmt.Errorf("trip numbers %d to %d don't have four digits", s.TripNumberStart, s.TripNumberStart+s.Routes*s.TripsPerRoute-1)
+	}
+	return nil
+}
+
+// Manifest lists the records created by seeding, by id (trip id for trips).
+type Manifest struct {
+	Prefix   string    `json:"prefix"`
+	SeededAt time.Time `json:"seeded_at"`
+	Stations []string  `json:"stations,omitempty"`
+	Trains   []string  `json:"trains,omitempty"`
+	Routes   []string  `json:"routes,omitempty"`
+	Trips    []string  `json:"trips,omitempty"`
+	Prices   []string  `json:"prices,omitempty"`
+	Users    []string  `json:"users,omitempty"`
+	Contacts []string  `json:"contacts,omitempty"`
+
+	mu sync.Mutex
+}
+
+// LoadManifest reads a Manifest written by Save.
+func LoadManifest(file string) (*Manifest, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("parse manifest %s: %w", file, err)
+	}
+	return &m, nil
+}
+
+// Save writes the Manifest as indented JSON.
+func (m *Manifest) Save(file string) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(file, append(data, '\n'), 0644)
+}
+
+// Merge adds the records of other, e.g. to keep one manifest across several seeding runs.
+func (m *Manifest) Merge(other *Manifest) {
+	m.Stations = append(m.Stations, other.Stations...)
+	m.Trains = append(m.Trains, other.Trains...)
+	m.Routes = append(m.Routes, other.Routes...)
+	m.Trips = append(m.Trips, other.Trips...)
+	m.Prices = append(m.Prices, other.Prices...)
+	m.Users = append(m.Users, other.Users...)
+	m.Contacts = append(m.Contacts, other.Contacts...)
+}
+
+// Len returns the number of records in the Manifest.
+func (m *Manifest) Len() int {
+	return len(m.Stations) + len(m.Trains) + len(m.Routes) + len(m.Trips) + len(m.Prices) + len(m.Users) + len(m.Contacts)
+}
+
+func (m *Manifest) add(ids *[]string, id string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	*ids = append(*ids, id)
+}
+
+// dataset is the records generated from a Spec.
+type dataset struct {
+	stations []service.AdminStation
+	trains   []service.AdminTrainType
+	routes   []service.AdminRouteInfo
+	trips    []service.AdminTravelInfo
+	prices   []service.PriceConfig
+	users    []service.AdminUserDto
+	contacts []service.AdminContacts
+}
+
+// generate derives the dataset from the spec. The random source is seeded from the prefix, so the
+// same spec always generates the same records; only the trips' start date is today's.
+func generate(spec Spec, today time.Time) dataset {
+	h := fnv.New64a()
+	h.Write([]byte(spec.Prefix))
+	rng := rand.New(rand.NewSource(int64(h.Sum64())))
+	id := func(kind string, i ...int) string {
+		name := spec.Prefix + "/" + kind
+		for _, n := range i {
+			name += "/" + strconv.Itoa(n)
+		}
+		return uuid.NewSHA1(uuid.NameSpaceOID, []byte(name)).String()
+	}
+
+	var d dataset
+	for i := 0; i < spec.Stations; i++ {
+		d.stations = append(d.stations, service.AdminStation{
+			ID:       id("station", i),
+			Name:     fmt.Sprintf("%sstation%d", spec.Prefix, i),
+			StayTime: 2 + rng.Intn(9),
+		})
+	}
+	for i := 0; i < spec.Trains; i++ {
+		d.trains = append(d.trains, service.AdminTrainType{
+			ID:           fmt.Sprintf("%strain%d", spec.Prefix, i),
+			Name:         fmt.Sprintf("%strain%d", spec.Prefix, i),
+			EconomyClass: 2147483647,
+			ConfortClass: 2147483647,
+			AverageSpeed: 80 + 20*rng.Intn(12),
+		})
+	}
+
+	prices := map[[2]string]bool{}
+	for i := 0; i < spec.Routes; i++ {
+		stops := rng.Perm(spec.Stations)[:spec.StopsPerRoute]
+		stations := make([]string, len(stops))
+		distances := make([]string, len(stops))
+		distance := 0
+		for j, stop := range stops {
+			if j > 0 {
+				distance += 50 + rng.Intn(300)
+			}
+			stations[j] = d.stations[stop].Name
+			distances[j] = strconv.Itoa(distance)
+		}
+		route := service.AdminRouteInfo{
+			ID:           id("route", i),
+			StartStation: stations[0],
+			EndStation:   stations[len(stations)-1],
+			StationList:  strings.Join(stations, ","),
+			DistanceList: strings.Join(distances, ","),
+		}
+		d.routes = append(d.routes, route)
+
+		for j := 0; j < spec.TripsPerRoute; j++ {
+			n := i*spec.TripsPerRoute + j
+			train := d.trains[n%len(d.trains)]
+			start := time.Date(today.Year(), today.Month(), today.Day(), 6, 0, 0, 0, today.Location()).
+				Add(time.Duration(rng.Intn(14*60)) * time.Minute)
+			end := start.Add(time.Duration(float64(distance) / float64(train.AverageSpeed) * float64(time.Hour))).Truncate(time.Minute)
+			d.trips = append(d.trips, service.AdminTravelInfo{
+				TripID:              fmt.Sprintf("%c%d", "GDZTK"[n%5], spec.TripNumberStart+n),
+				TrainTypeName:       train.Name,
+				RouteID:             route.ID,
+				StartStationName:    route.StartStation,
+				StationsName:        route.StationList,
+				TerminalStationName: route.EndStation,
+				StartTime:           start.Format(time.DateTime),
+				EndTime:             end.Format(time.DateTime),
+			})
+			if key := [2]string{route.ID, train.Name}; !prices[key] {
+				prices[key] = true
+				d.prices = append(d.prices, service.PriceConfig{
+					ID:                  id("price", i, n%len(d.trains)),
+					RouteID:             route.ID,
+					TrainType:           train.Name,
+					BasicPriceRate:      float64(20+rng.Intn(30)) / 100,
+					FirstClassPriceRate: float64(80+rng.Intn(40)) / 100,
+				})
+			}
+		}
+	}
+
+	for i := 0; i < spec.Users; i++ {
+		user := service.AdminUserDto{
+			UserID:       id("user", i),
+			UserName:     fmt.Sprintf("%suser%d", spec.Prefix, i),
+			Password:     spec.Password,
+			Gender:       rng.Intn(2),
+			DocumentType: 1,
+			DocumentNum:  fmt.Sprintf("%018d", rng.Int63n(1e18)),
+			Email:        fmt.Sprintf("%suser%d@example.com", spec.Prefix, i),
+		}
+		d.users = append(d.users, user)
+		for j := 0; j < spec.ContactsPerUser; j++ {
+			d.contacts = append(d.contacts, service.AdminContacts{
+				Id:             id("contact", i, j),
+				AccountId:      user.UserID,
+				Name:           fmt.Sprintf("%scontact%d_%d", spec.Prefix, i, j),
+				DocumentType:   1,
+				DocumentNumber: fmt.Sprintf("%018d", rng.Int63n(1e18)),
+				PhoneNumber:    fmt.Sprintf("1%010d", rng.Int63n(1e10)),
+			})
+		}
+	}
+	return d
+}
+
+// Seed creates the records of spec that don't exist yet, Concurrency at a time. Stations and train
+// types are created first, then routes, trips and prices, then users and their contacts; seeding
+// stops after the first step with errors. The returned Manifest lists the records created, also
+// when seeding failed part way.
+func Seed(ctx context.Context, cli Client, spec Spec) (*Manifest, error) {
+	spec = spec.withDefaults()
+	m := &Manifest{Prefix: spec.Prefix, SeededAt: time.Now()}
+	if err := spec.validate(); err != nil {
+		return m, err
+	}
+	d := generate(spec, m.SeededAt)
+	s := &seeder{cli: cli, concurrency: spec.Concurrency, m: m}
+
+	for _, step := range []func(context.Context, dataset) error{s.stations, s.trains, s.routes, s.trips, s.prices, s.users, s.contacts} {
+		if err := step(ctx, d); err != nil {
+			return m, err
+		}
+	}
+	return m, nil
+}
+
+type seeder struct {
+	cli         Client
+	concurrency int
+	m           *Manifest
+}
+
+func (s *seeder) stations(ctx context.Context, d dataset) error {
+	resp, err := s.cli.AdminGetAllStations()
+	if err != nil {
+		return fmt.Errorf("list stations: %w", err)
+	}
+	var existing []service.AdminStation
+	if err := remarshal(resp.Data, &existing); err != nil {
+		return fmt.Errorf("list stations: %w", err)
+	}
+	exists := set(existing, func(st service.AdminStation) string { return st.Name })
+	return each(ctx, len(d.stations), s.concurrency, func(i int) error {
+		station := d.stations[i]
+		if exists[station.Name] {
+			return nil
+		}
+		resp, err := s.cli.AdminAddStation(&station)
+		if err != nil {
+			return fmt.Errorf("add station %s: %w", station.Name, err)
+		}
+		s.m.add(&s.m.Stations, idOf(resp.Data, "id", station.ID))
+		return nil
+	})
+}
+
+func (s *seeder) trains(ctx context.Context, d dataset) error {
+	resp, err := s.cli.AdminGetAllTrains()
+	if err != nil {
+		return fmt.Errorf("list trains: %w", err)
+	}
+	var existing []service.AdminTrainType
+	if err := remarshal(resp.Data, &existing); err != nil {
+		return fmt.Errorf("list trains: %w", err)
+	}
+	exists := set(existing, func(t service.AdminTrainType) string { return t.Name })
+	return each(ctx, len(d.trains), s.concurrency, func(i int) error {
+		train := d.trains[i]
+		if exists[train.Name] {
+			return nil
+		}
+		resp, err := s.cli.AdminAddTrain(&train)
+		if err != nil {
+			return fmt.Errorf("add train %s: %w", train.Name, err)
+		}
+		s.m.add(&s.m.Trains, idOf(resp.Data, "id", train.ID))
+		return nil
+	})
+}
+
+func (s *seeder) routes(ctx context.Context, d dataset) error {
+	resp, err := s.cli.ReqGetAllRoutes()
+	if err != nil {
+		return fmt.Errorf("list routes: %w", err)
+	}
+	exists := map[string]bool{}
+	for _, route := range resp.Data {
+		exists[route.ID] = true
+	}
+	return each(ctx, len(d.routes), s.concurrency, func(i int) error {
+		route := d.routes[i]
+		if exists[route.ID] {
+			return nil
+		}
+		if _, err := s.cli.ReqAddRoute(&route); err != nil {
+			return fmt.Errorf("add route %s: %w", route.StationList, err)
+		}
+		s.m.add(&s.m.Routes, route.ID)
+		return nil
+	})
+}
+
+func (s *seeder) trips(ctx context.Context, d dataset) error {
+	existing, err := s.cli.GetAllTravels()
+	if err != nil {
+		return fmt.Errorf("list trips: %w", err)
+	}
+	exists := set(existing, func(t service.AdminTravelInfo) string { return t.TripID })
+	return each(ctx, len(d.trips), s.concurrency, func(i int) error {
+		trip := d.trips[i]
+		if exists[trip.TripID] {
+			return nil
+		}
+		if _, err := s.cli.CreateTravel(&trip); err != nil {
+			return fmt.Errorf("add trip %s: %w", trip.TripID, err)
+		}
+		s.m.add(&s.m.Trips, trip.TripID)
+		return nil
+	})
+}
+
+func (s *seeder) prices(ctx context.Context, d dataset) error {
+	resp, err := s.cli.FindAllPriceConfig()
+	if err != nil {
+		return fmt.Errorf("list prices: %w", err)
+	}
+	exists := map[[2]string]bool{}
+	for _, price := range resp.Data {
+		exists[[2]string{price.RouteId, price.TrainType}] = true
+	}
+	return each(ctx, len(d.prices), s.concurrency, func(i int) error {
+		price := d.prices[i]
+		if exists[[2]string{price.RouteID, price.TrainType}] {
+			return nil
+		}
+		resp, err := s.cli.CreateNewPriceConfig(&price)
+		if err != nil {
+			return fmt.Errorf("add price of route %s and train %s: %w", price.RouteID, price.TrainType, err)
+		}
+		id := resp.Data.Id
+		if id == "" {
+			id = price.ID
+		}
+		s.m.add(&s.m.Prices, id)
+		return nil
+	})
+}
+
+func (s *seeder) users(ctx context.Context, d dataset) error {
+	resp, err := s.cli.AdminGetAllUsers()
+	if err != nil {
+		return fmt.Errorf("list users: %w", err)
+	}
+	exists := set(resp.Data, func(u service.UserDtoUser) string { return u.UserName })
+	return each(ctx, len(d.users), s.concurrency, func(i int) error {
+		user := d.users[i]
+		if exists[user.UserName] {
+			return nil
+		}
+		resp, err := s.cli.AdminAddUser(&user)
+		if err != nil {
+			return fmt.Errorf("add user %s: %w", user.UserName, err)
+		}
+		id := resp.Data.UserId
+		if id == "" {
+			id = user.UserID
+		}
+		s.m.add(&s.m.Users, id)
+		return nil
+	})
+}
+
+func (s *seeder) contacts(ctx context.Context, d dataset) error {
+	resp, err := s.cli.AdminGetAllContacts()
+	if err != nil {
+		return fmt.Errorf("list contacts: %w", err)
+	}
+	exists := map[[2]string]bool{}
+	for _, contact := range resp.Data {
+		exists[[2]string{contact.AccountId, contact.Name}] = true
+	}
+	return each(ctx, len(d.contacts), s.concurrency, func(i int) error {
+		contact := d.contacts[i]
+		if exists[[2]string{contact.AccountId, contact.Name}] {
+			return nil
+		}
+		resp, err := s.cli.AdminAddContact(&contact)
+		if err != nil {
+			return fmt.Errorf("add contact %s: %w", contact.Name, err)
+		}
+		id := resp.Data.Id
+		if id == "" {
+			id = contact.Id
+		}
+		s.m.add(&s.m.Contacts, id)
+		return nil
+	})
+}
+
+// Cleanup deletes the records of m, contacts and users first and stations last, concurrency at a
+// time. It returns a Manifest of the records it failed to delete, empty when all were deleted.
+func Cleanup(ctx context.Context, cli Client, m *Manifest, concurrency int) (*Manifest, error) {
+	if concurrency <= 0 {
+		concurrency = 8
+	}
+	left := &Manifest{Prefix: m.Prefix, SeededAt: m.SeededAt}
+	var errs []error
+	for _, step := range []struct {
+		ids, left *[]string
+		del       func(id string) error
+	}{
+		{&m.Contacts, &left.Contacts, func(id string) error { _, err := cli.AdminDeleteContact(id); return err }},
+		{&m.Users, &left.Users, func(id string) error { _, err := cli.AdminDeleteUser(id); return err }},
+		{&m.Prices, &left.Prices, func(id string) error { _, err := cli.DeletePriceConfig(id); return err }},
+		{&m.Trips, &left.Trips, func(id string) error { _, err := cli.DeleteTravel(id); return err }},
+		{&m.Routes, &left.Routes, func(id string) error { _, err := cli.ReqDeleteRoute(id); return err }},
+		{&m.Trains, &left.Trains, func(id string) error { _, err := cli.AdminDeleteTrain(id); return err }},
+		{&m.Stations, &left.Stations, func(id string) error { _, err := cli.AdminDeleteStation(id); return err }},
+	} {
+		ids := *step.ids
+		err := each(ctx, len(ids), concurrency, func(i int) error {
+			if err := step.del(ids[i]); err != nil {
+				left.add(step.left, ids[i])
+				return fmt.Errorf("delete %s: %w", ids[i], err)
+			}
+			return nil
+		})
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return left, errors.Join(errs...)
+}
+
+// each calls fn for 0 to n-1 on at most concurrency goroutines and returns the joined errors.
+// It stops starting calls when ctx is done.
+func each(ctx context.Context, n, concurrency int, fn func(i int) error) error {
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	sem := make(chan struct{}, concurrency)
+	for i := 0; i < n; i++ {
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return errors.Join(append(errs, ctx.Err())...)
+		case sem <- struct{}{}:
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if err := fn(i); err != nil {
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+			}
+		}(i)
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+func set[T any](items []T, key func(T) string) map[string]bool {
+	keys := make(map[string]bool, len(items))
+	for _, item := range items {
+		keys[key(item)] = true
+	}
+	return keys
+}
+
+// remarshal decodes an untyped response body field into v.
+func remarshal(data interface{}, v interface{}) error {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
+// idOf returns the key field of an untyped response object, or fallback when it has none.
+func idOf(data interface{}, key, fallback string) string {
+	if obj, ok := data.(map[string]interface{}); ok {
+		if id, ok := obj[key].(string); ok && id != "" {
+			return id
+		}
+	}
+	return fallback
+}
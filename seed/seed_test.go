@@ -0,0 +1,93 @@
+package seed
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/Lincyaw/loadgenerator/fake"
+	"github.com/Lincyaw/loadgenerator/service"
+)
+
+func TestSeed(t *testing.T) {
+	srv := fake.NewServer()
+	defer srv.Close()
+	t.Setenv("BASE_URL", srv.URL)
+	cli := service.NewSvcClients()
+	if _, err := cli.LoginWithVerifyCode("admin", "222222", service.FixedVerifyCodeSolver("123")); err != nil {
+		t.Fatal(err)
+	}
+
+	spec := Spec{Prefix: "t1", Stations: 6, Trains: 2, Routes: 3, TripsPerRoute: 2, Users: 4, ContactsPerUser: 2, Concurrency: 3}
+	m, err := Seed(context.Background(), cli, spec)
+	if err != nil {
+		t.Fatalf("Seed() err = %v", err)
+	}
+	for kind, got := range map[string][]string{
+		"stations": m.Stations, "trains": m.Trains, "routes": m.Routes, "trips": m.Trips, "users": m.Users, "contacts": m.Contacts,
+	} {
+		want := map[string]int{"stations": 6, "trains": 2, "routes": 3, "trips": 6, "users": 4, "contacts": 8}[kind]
+		if len(got) != want {
+			t.Errorf("Seed() created %d %s, want %d", len(got), kind, want)
+		}
+	}
+	if len(m.Prices) == 0 {
+		t.Error("Seed() created no prices")
+	}
+
+	file := filepath.Join(t.TempDir(), "manifest.json")
+	if err := m.Save(file); err != nil {
+		t.Fatal(err)
+	}
+	m, err = LoadManifest(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	again, err := Seed(context.Background(), cli, spec)
+	if err != nil {
+		t.Fatalf("second Seed() err = %v", err)
+	}
+	if again.Len() != 0 {
+		t.Errorf("second Seed() created %+v, want nothing", again)
+	}
+
+	left, err := Cleanup(context.Background(), cli, m, 3)
+	if err != nil || left.Len() != 0 {
+		t.Fatalf("Cleanup() = %+v, %v, want everything deleted", left, err)
+	}
+	users, err := cli.AdminGetAllUsers()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, user := range users.Data {
+		if user.UserName == "t1user0" {
+			t.Error("Cleanup() left user t1user0")
+		}
+	}
+	trips, err := cli.GetAllTravels()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, trip := range trips {
+		if trip.TrainTypeName == "t1train0" {
+			t.Errorf("Cleanup() left trip %s", trip.TripID)
+		}
+	}
+}
+
+func TestSpec_Validate(t *testing.T) {
+	for _, spec := range []Spec{
+		{Prefix: "Bad-Prefix"},
+		{Prefix: "p", Stations: 3, Trains: 1, Routes: 1},
+		{Prefix: "p", Stations: 4, Routes: 1},
+		{Prefix: "p", Stations: 4, Trains: 1, Routes: 100, TripsPerRoute: 100},
+	} {
+		if err := spec.withDefaults().validate(); err == nil {
+			t.Errorf("validate(%+v) = nil, want error", spec)
+		}
+	}
+	if err := (Spec{Prefix: "p", Stations: 4, Trains: 1, Routes: 2, Users: 1}).withDefaults().validate(); err != nil {
+		t.Errorf("validate() = %v", err)
+	}
+}
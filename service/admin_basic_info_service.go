@@ -156,7 +156,10 @@ func (s *SvcImpl) AdminGetAllContacts() (*AdminGetContactsResp, error) {
 	}
 	var result AdminGetContactsResp
 	err = json.Unmarshal(body, &result)
-	return &result, err
+	if err != nil {
+		return &result, err
+	}
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) AdminDeleteContact(contactsId string) (*AdminDeleteContactResp, error) {
@@ -170,7 +173,10 @@ func (s *SvcImpl) AdminDeleteContact(contactsId string) (*AdminDeleteContactResp
 	}
 	var result AdminDeleteContactResp
 	err = json.Unmarshal(body, &result)
-	return &result, err
+	if err != nil {
+		return &result, err
+	}
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) AdminModifyContact(contacts *AdminContacts) (*AdminContactResponse, error) {
@@ -184,7 +190,10 @@ func (s *SvcImpl) AdminModifyContact(contacts *AdminContacts) (*AdminContactResp
 	}
 	var result AdminContactResponse
 	err = json.Unmarshal(body, &result)
-	return &result, err
+	if err != nil {
+		return &result, err
+	}
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) AdminAddContact(contacts *AdminContacts) (*AdminContactResponse, error) {
@@ -198,7 +207,10 @@ func (s *SvcImpl) AdminAddContact(contacts *AdminContacts) (*AdminContactRespons
 	}
 	var result AdminContactResponse
 	err = json.Unmarshal(body, &result)
-	return &result, err
+	if err != nil {
+		return &result, err
+	}
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) AdminGetAllStations() (*AdminStationResponse, error) {
@@ -212,7 +224,10 @@ func (s *SvcImpl) AdminGetAllStations() (*AdminStationResponse, error) {
 	}
 	var result AdminStationResponse
 	err = json.Unmarshal(body, &result)
-	return &result, err
+	if err != nil {
+		return &result, err
+	}
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) AdminDeleteStation(id string) (*AdminDeleteResponse, error) {
@@ -226,7 +241,10 @@ func (s *SvcImpl) AdminDeleteStation(id string) (*AdminDeleteResponse, error) {
 	}
 	var result AdminDeleteResponse
 	err = json.Unmarshal(body, &result)
-	return &result, err
+	if err != nil {
+		return &result, err
+	}
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) AdminModifyStation(station *AdminStation) (*AdminStationResponse, error) {
@@ -240,7 +258,10 @@ func (s *SvcImpl) AdminModifyStation(station *AdminStation) (*AdminStationRespon
 	}
 	var result AdminStationResponse
 	err = json.Unmarshal(body, &result)
-	return &result, err
+	if err != nil {
+		return &result, err
+	}
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) AdminAddStation(station *AdminStation) (*AdminStationResponse, error) {
@@ -254,7 +275,10 @@ func (s *SvcImpl) AdminAddStation(station *AdminStation) (*AdminStationResponse,
 	}
 	var result AdminStationResponse
 	err = json.Unmarshal(body, &result)
-	return &result, err
+	if err != nil {
+		return &result, err
+	}
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) AdminGetAllTrains() (*AdminTrainResponse, error) {
@@ -268,7 +292,10 @@ func (s *SvcImpl) AdminGetAllTrains() (*AdminTrainResponse, error) {
 	}
 	var result AdminTrainResponse
 	err = json.Unmarshal(body, &result)
-	return &result, err
+	if err != nil {
+		return &result, err
+	}
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) AdminDeleteTrain(id string) (*AdminTrainResponse, error) {
@@ -282,7 +309,10 @@ func (s *SvcImpl) AdminDeleteTrain(id string) (*AdminTrainResponse, error) {
 	}
 	var result AdminTrainResponse
 	err = json.Unmarshal(body, &result)
-	return &result, err
+	if err != nil {
+		return &result, err
+	}
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) AdminModifyTrain(train *AdminTrainType) (*AdminTrainResponse, error) {
@@ -296,7 +326,10 @@ func (s *SvcImpl) AdminModifyTrain(train *AdminTrainType) (*AdminTrainResponse,
 	}
 	var result AdminTrainResponse
 	err = json.Unmarshal(body, &result)
-	return &result, err
+	if err != nil {
+		return &result, err
+	}
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) AdminAddTrain(train *AdminTrainType) (*AdminTrainResponse, error) {
@@ -310,7 +343,10 @@ func (s *SvcImpl) AdminAddTrain(train *AdminTrainType) (*AdminTrainResponse, err
 	}
 	var result AdminTrainResponse
 	err = json.Unmarshal(body, &result)
-	return &result, err
+	if err != nil {
+		return &result, err
+	}
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) AdminGetAllConfigs() (*AdminConfigResponse, error) {
@@ -324,7 +360,10 @@ func (s *SvcImpl) AdminGetAllConfigs() (*AdminConfigResponse, error) {
 	}
 	var result AdminConfigResponse
 	err = json.Unmarshal(body, &result)
-	return &result, err
+	if err != nil {
+		return &result, err
+	}
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) AdminDeleteConfig(name string) (*AdminConfigResponse, error) {
@@ -338,7 +377,10 @@ func (s *SvcImpl) AdminDeleteConfig(name string) (*AdminConfigResponse, error) {
 	}
 	var result AdminConfigResponse
 	err = json.Unmarshal(body, &result)
-	return &result, err
+	if err != nil {
+		return &result, err
+	}
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) AdminModifyConfig(config *AdminConfig) (*AdminConfigResponse, error) {
@@ -352,7 +394,10 @@ func (s *SvcImpl) AdminModifyConfig(config *AdminConfig) (*AdminConfigResponse,
 	}
 	var result AdminConfigResponse
 	err = json.Unmarshal(body, &result)
-	return &result, err
+	if err != nil {
+		return &result, err
+	}
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) AdminAddConfig(config *AdminConfig) (*AdminConfigResponse, error) {
@@ -366,7 +411,10 @@ func (s *SvcImpl) AdminAddConfig(config *AdminConfig) (*AdminConfigResponse, err
 	}
 	var result AdminConfigResponse
 	err = json.Unmarshal(body, &result)
-	return &result, err
+	if err != nil {
+		return &result, err
+	}
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) AdminGetAllPrices() (*AdminPriceResponse, error) {
@@ -380,7 +428,10 @@ func (s *SvcImpl) AdminGetAllPrices() (*AdminPriceResponse, error) {
 	}
 	var result AdminPriceResponse
 	err = json.Unmarshal(body, &result)
-	return &result, err
+	if err != nil {
+		return &result, err
+	}
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) AdminDeletePrice(pricesId string) (*AdminPriceResponse, error) {
@@ -394,7 +445,10 @@ func (s *SvcImpl) AdminDeletePrice(pricesId string) (*AdminPriceResponse, error)
 	}
 	var result AdminPriceResponse
 	err = json.Unmarshal(body, &result)
-	return &result, err
+	if err != nil {
+		return &result, err
+	}
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) AdminModifyPrice(price *AdminPriceInfo) (*AdminPriceResponse, error) {
@@ -408,7 +462,10 @@ func (s *SvcImpl) AdminModifyPrice(price *AdminPriceInfo) (*AdminPriceResponse,
 	}
 	var result AdminPriceResponse
 	err = json.Unmarshal(body, &result)
-	return &result, err
+	if err != nil {
+		return &result, err
+	}
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) AdminAddPrice(price *AdminPriceInfo) (*AdminPriceResponse, error) {
@@ -422,5 +479,8 @@ func (s *SvcImpl) AdminAddPrice(price *AdminPriceInfo) (*AdminPriceResponse, err
 	}
 	var result AdminPriceResponse
 	err = json.Unmarshal(body, &result)
-	return &result, err
+	if err != nil {
+		return &result, err
+	}
+	return &result, checkResponse(resp, body)
 }
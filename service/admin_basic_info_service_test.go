@@ -5,7 +5,8 @@ import (
 )
 
 func TestAdminBasicInfoService_FullIntegration(t *testing.T) {
-	adminClient, _ := GetAdminClient()
+	withCassette := useCassette(t)
+	adminClient, _ := GetAdminClient(withCassette)
 
 	allContacts, err := adminClient.AdminGetAllContacts()
 	if err != nil {
@@ -11,7 +11,7 @@ type AdminOrderService interface {
 	ReqGetAllOrders() (*OrderArrResp, error)
 	ReqAddOrder(input *Order) (*OrderResp, error)
 	ReqUpdateOrder(input *Order) (*OrderResp, error)
-	ReqDeleteOrder(orderId string, trainNumber string) (*OrderResp, error)
+	ReqDeleteOrder(orderId string, trainNumber string) (*ReqDeleteOrderResponse, error)
 }
 
 func (s *SvcImpl) ReqGetAllOrders() (*OrderArrResp, error) {
@@ -28,9 +28,9 @@ func (s *SvcImpl) ReqGetAllOrders() (*OrderArrResp, error) {
 	var result OrderArrResp
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) ReqAddOrder(input *Order) (*OrderResp, error) {
@@ -46,9 +46,9 @@ func (s *SvcImpl) ReqAddOrder(input *Order) (*OrderResp, error) {
 
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) ReqUpdateOrder(input *Order) (*OrderResp, error) {
@@ -65,9 +65,9 @@ func (s *SvcImpl) ReqUpdateOrder(input *Order) (*OrderResp, error) {
 	var result OrderResp
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 type ReqDeleteOrderResponse struct {
@@ -91,7 +91,7 @@ func (s *SvcImpl) ReqDeleteOrder(orderId string, trainNumber string) (*ReqDelete
 	//fmt.Println(result.Data.TrainNumber)
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
@@ -9,7 +9,8 @@ import (
 )
 
 func TestSvcImpl_ReqGetAllOrders(t *testing.T) {
-	cli, _ := GetAdminClient()
+	withCassette := useCassette(t)
+	cli, _ := GetAdminClient(withCassette)
 	GetResp, err := cli.ReqGetAllOrders()
 	if err != nil {
 		t.Errorf("err reponse: %v", err)
@@ -18,7 +19,8 @@ func TestSvcImpl_ReqGetAllOrders(t *testing.T) {
 }
 
 func TestSvcImpl_ReqAddOrder(t *testing.T) {
-	cli, _ := GetAdminClient()
+	withCassette := useCassette(t)
+	cli, _ := GetAdminClient(withCassette)
 	AddResp, err := cli.ReqAddOrder(&Order{
 		AccountId:              uuid.NewString(),
 		BoughtDate:             faker.Date(),
@@ -47,7 +49,8 @@ func TestSvcImpl_ReqAddOrder(t *testing.T) {
 }
 
 func TestSvcImpl_ReqUpdateOrder(t *testing.T) {
-	cli, _ := GetAdminClient()
+	withCassette := useCassette(t)
+	cli, _ := GetAdminClient(withCassette)
 	UpdateResp, err := cli.ReqUpdateOrder(&Order{
 		AccountId:              "test1",
 		BoughtDate:             faker.Date(),
@@ -74,7 +77,8 @@ func TestSvcImpl_ReqUpdateOrder(t *testing.T) {
 }
 
 func TestSvcImpl_ReqDeleteOrder(t *testing.T) {
-	cli, _ := GetAdminClient()
+	withCassette := useCassette(t)
+	cli, _ := GetAdminClient(withCassette)
 	DeleteResp, err := cli.ReqDeleteOrder("790bcfd5-82d2-4717-aa9f-e00bef992268", "G111")
 	if err != nil {
 		fmt.Println(err)
@@ -83,7 +87,8 @@ func TestSvcImpl_ReqDeleteOrder(t *testing.T) {
 }
 
 func TestSvcImpl_End2End(t *testing.T) {
-	cli, _ := GetAdminClient()
+	withCassette := useCassette(t)
+	cli, _ := GetAdminClient(withCassette)
 	newOrder := Order{
 		AccountId:              uuid.New().String(),
 		BoughtDate:             faker.Date(),
@@ -57,7 +57,10 @@ func (s *SvcImpl) ReqGetAllRoutes() (*AdminRouteInfoResp, error) {
 	}
 	var result AdminRouteInfoResp
 	err = json.Unmarshal(body, &result)
-	return &result, err
+	if err != nil {
+		return &result, err
+	}
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) ReqAddRoute(input *AdminRouteInfo) (*AdminAddResponse, error) {
@@ -74,9 +77,9 @@ func (s *SvcImpl) ReqAddRoute(input *AdminRouteInfo) (*AdminAddResponse, error)
 	var result AdminAddResponse
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, err
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) ReqDeleteRoute(routeId string) (*AdminRouteDeleteInfoResp, error) {
@@ -92,7 +95,7 @@ func (s *SvcImpl) ReqDeleteRoute(routeId string) (*AdminRouteDeleteInfoResp, err
 	var result AdminRouteDeleteInfoResp
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
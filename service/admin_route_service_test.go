@@ -6,8 +6,10 @@ import (
 )
 
 func TestAdminRouteService_FullIntegration(t *testing.T) {
+	requireCluster(t)
+	withCassette := useCassette(t)
 	// Create a client for admin user
-	adminClient, _ := GetAdminClient()
+	adminClient, _ := GetAdminClient(withCassette)
 
 	// Test GetAllRoutes for Admin User
 	allRoutesResp, err := adminClient.ReqGetAllRoutes()
@@ -56,10 +56,10 @@ func (s *SvcImpl) CreateTravel(request *AdminTravelInfo) (*AdminTravelResponse,
 	var result AdminTravelResponse
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
 
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) UpdateTravel(request *AdminTravelInfo) (*AdminTravelResponse, error) {
@@ -77,10 +77,10 @@ func (s *SvcImpl) UpdateTravel(request *AdminTravelInfo) (*AdminTravelResponse,
 	var result AdminTravelResponse
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
 
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) DeleteTravel(tripId string) (*AdminTravelResponse, error) {
@@ -99,10 +99,10 @@ func (s *SvcImpl) DeleteTravel(tripId string) (*AdminTravelResponse, error) {
 	var result AdminTravelResponse
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
 
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) GetAllTravels() ([]AdminTravelInfo, error) {
@@ -123,5 +123,5 @@ func (s *SvcImpl) GetAllTravels() ([]AdminTravelInfo, error) {
 		return nil, err
 	}
 
-	return travels, nil
+	return travels, checkResponse(resp, body)
 }
@@ -72,10 +72,10 @@ func (s *SvcImpl) AdminAddUser(user *AdminUserDto) (*AdminUserResponse, error) {
 	var result AdminUserResponse
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
 
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) AdminUpdateUser(user *AdminUserDto) (*AdminUserResponse, error) {
@@ -93,10 +93,10 @@ func (s *SvcImpl) AdminUpdateUser(user *AdminUserDto) (*AdminUserResponse, error
 	var result AdminUserResponse
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
 
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) AdminDeleteUser(userId string) (*AdminDeleteResponseUser, error) {
@@ -115,10 +115,10 @@ func (s *SvcImpl) AdminDeleteUser(userId string) (*AdminDeleteResponseUser, erro
 	var result AdminDeleteResponseUser
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
 
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) AdminGetAllUsers() (*AllUserResponseUser, error) {
@@ -139,5 +139,5 @@ func (s *SvcImpl) AdminGetAllUsers() (*AllUserResponseUser, error) {
 		return nil, err
 	}
 
-	return users, nil
+	return users, checkResponse(resp, body)
 }
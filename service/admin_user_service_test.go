@@ -6,7 +6,8 @@ import (
 )
 
 func TestSvcImpl_AddUpdateDeleteUser(t *testing.T) {
-	cli, _ := GetAdminClient()
+	withCassette := useCassette(t)
+	cli, _ := GetAdminClient(withCassette)
 	MockedID := faker.UUIDHyphenated()
 	MockedUserName := faker.Username()
 
@@ -13,7 +13,7 @@ type AssuranceService interface {
 	DeleteAssuranceByID(assuranceID string) (*AssuranceDeleteResponse, error)
 	DeleteAssuranceByOrderID(orderID string) (*DeleteAssuranceByOrderIDResponse, error)
 	ModifyAssurance(assuranceID string, orderID string, typeIndex int) (*Modify_Response, error)
-	CreateNewAssurance(typeIndex int, orderID string) (*createAssuranceResponse, error)
+	CreateNewAssurance(typeIndex int, orderID string) (*CreateAssuranceResponse, error)
 	GetAssuranceByID(assuranceID string) (*GetAssuranceByIDeInfo, error)
 	FindAssuranceByOrderID(orderId string) (*GetAssuranceByIDeInfo, error)
 }
@@ -103,10 +103,10 @@ func (s *SvcImpl) GetAllAssurances() (*GetAllAssuranceResponse, error) {
 	var result GetAllAssuranceResponse
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
 
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 type GetallAssuranceType struct {
@@ -137,7 +137,7 @@ func (s *SvcImpl) GetAllAssuranceTypes() (*GetallAssuranceType, error) {
 		return nil, err
 	}
 
-	return &types, nil
+	return &types, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) DeleteAssuranceByID(assuranceID string) (*AssuranceDeleteResponse, error) {
@@ -156,10 +156,10 @@ func (s *SvcImpl) DeleteAssuranceByID(assuranceID string) (*AssuranceDeleteRespo
 	var result AssuranceDeleteResponse
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
 
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 type DeleteAssuranceByOrderIDResponse struct {
@@ -184,10 +184,10 @@ func (s *SvcImpl) DeleteAssuranceByOrderID(orderID string) (*DeleteAssuranceByOr
 	var result DeleteAssuranceByOrderIDResponse
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
 
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) ModifyAssurance(assuranceID string, orderID string, typeIndex int) (*Modify_Response, error) {
@@ -206,13 +206,13 @@ func (s *SvcImpl) ModifyAssurance(assuranceID string, orderID string, typeIndex
 	var result Modify_Response
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
 
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
-type createAssuranceResponse struct {
+type CreateAssuranceResponse struct {
 	Status int    `json:"status"`
 	Msg    string `json:"msg"`
 	Data   struct {
@@ -222,7 +222,7 @@ type createAssuranceResponse struct {
 	} `json:"data"`
 }
 
-func (s *SvcImpl) CreateNewAssurance(typeIndex int, orderID string) (*createAssuranceResponse, error) {
+func (s *SvcImpl) CreateNewAssurance(typeIndex int, orderID string) (*CreateAssuranceResponse, error) {
 	url := fmt.Sprintf("%s/api/v1/assuranceservice/assurances/%d/%s", s.BaseUrl, typeIndex, orderID)
 	resp, err := s.cli.SendRequest("GET", url, nil)
 	if err != nil {
@@ -235,13 +235,13 @@ func (s *SvcImpl) CreateNewAssurance(typeIndex int, orderID string) (*createAssu
 		return nil, err
 	}
 
-	var result createAssuranceResponse
+	var result CreateAssuranceResponse
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
 
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) GetAssuranceByID(assuranceID string) (*GetAssuranceByIDeInfo, error) {
@@ -263,7 +263,7 @@ func (s *SvcImpl) GetAssuranceByID(assuranceID string) (*GetAssuranceByIDeInfo,
 		return nil, err
 	}
 
-	return &assurance, nil
+	return &assurance, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) FindAssuranceByOrderID(orderId string) (*GetAssuranceByIDeInfo, error) {
@@ -285,5 +285,5 @@ func (s *SvcImpl) FindAssuranceByOrderID(orderId string) (*GetAssuranceByIDeInfo
 		return nil, err
 	}
 
-	return &assurance, nil
+	return &assurance, checkResponse(resp, body)
 }
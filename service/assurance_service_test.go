@@ -7,8 +7,10 @@ import (
 )
 
 func TestSvcImpl_AddUpdateDeleteAssurance(t *testing.T) {
-	//cli, _ := GetAdminClient()
-	cli, _ := GetBasicClient()
+	requireCluster(t)
+	withCassette := useCassette(t)
+	//cli, _ := GetAdminClient(withCassette)
+	cli, _ := GetBasicClient(withCassette)
 
 	var CreatedExistedOrderID string
 	var orderSvc OrderService = cli
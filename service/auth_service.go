@@ -9,6 +9,7 @@ import (
 
 type AuthService interface {
 	ReqUserLogin(input *UserLoginInfoReq) (*UserLoginInfoResp, error)
+	LoginWithVerifyCode(username, password string, solve VerifyCodeSolver) (*UserLoginInfoResp, error)
 	ReqUserCreate(input *UserCreateInfoReq) (*UserCreateInfoResp, error)
 	ReqUserDelete(userid string) (*UserDeleteInfoResp, error)
 }
@@ -61,12 +62,58 @@ func (s *SvcImpl) ReqUserLogin(input *UserLoginInfoReq) (*UserLoginInfoResp, err
 
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
 	if result.Data.Token != "" {
 		s.cli.AddHeader("Authorization", fmt.Sprintf("Bearer %s", result.Data.Token))
+		if s.auth != nil {
+			s.auth.SetToken(result.Data.Token)
+			credentials := *input
+			s.auth.SetLogin(func() (string, error) {
+				relogin, err := s.ReqUserLogin(&credentials)
+				if err != nil {
+					return "", err
+				}
+				return relogin.Data.Token, nil
+			})
+		}
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
+}
+
+// LoginWithVerifyCode logs in the way the TrainTicket UI does: it fetches a captcha, which sets the
+// YsbCaptcha cookie, solves it and submits the code with the credentials. Re-logins triggered by token expiry
+// go through the same flow. Without solve it fails with ErrNoVerifyCodeSolver.
+func (s *SvcImpl) LoginWithVerifyCode(username, password string, solve VerifyCodeSolver) (*UserLoginInfoResp, error) {
+	if solve == nil {
+		return nil, ErrNoVerifyCodeSolver
+	}
+	image, err := s.GenerateVerifyCode()
+	if err != nil {
+		return nil, err
+	}
+	code, err := solve(image)
+	if err != nil {
+		return nil, fmt.Errorf("solve verify code: %w", err)
+	}
+	result, err := s.ReqUserLogin(&UserLoginInfoReq{
+		Password:         password,
+		UserName:         username,
+		VerificationCode: code,
+	})
+	if err != nil {
+		return result, err
+	}
+	if s.auth != nil && result.Data.Token != "" {
+		s.auth.SetLogin(func() (string, error) {
+			relogin, err := s.LoginWithVerifyCode(username, password, solve)
+			if err != nil {
+				return "", err
+			}
+			return relogin.Data.Token, nil
+		})
+	}
+	return result, nil
 }
 
 func (s *SvcImpl) ReqUserCreate(input *UserCreateInfoReq) (*UserCreateInfoResp, error) {
@@ -82,9 +129,9 @@ func (s *SvcImpl) ReqUserCreate(input *UserCreateInfoReq) (*UserCreateInfoResp,
 
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) ReqUserDelete(userid string) (*UserDeleteInfoResp, error) {
@@ -100,7 +147,7 @@ func (s *SvcImpl) ReqUserDelete(userid string) (*UserDeleteInfoResp, error) {
 
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
@@ -1,13 +1,25 @@
 package service
 
 import (
+	"encoding/base64"
 	"fmt"
-	"github.com/google/uuid"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
+
+	"github.com/Lincyaw/loadgenerator/cassette"
+	"github.com/Lincyaw/loadgenerator/fake"
+	"github.com/google/uuid"
 )
 
-func GetBasicClient() (*SvcImpl, string) {
-	cli := NewSvcClients()
+func GetBasicClient(opts ...ClientOption) (*SvcImpl, string) {
+	cli := NewSvcClients(opts...)
 	loginResp, _ := cli.ReqUserLogin(&UserLoginInfoReq{
 		Password:         "111111",
 		UserName:         "fdse_microservice",
@@ -15,8 +27,8 @@ func GetBasicClient() (*SvcImpl, string) {
 	})
 	return cli, loginResp.Data.UserId
 }
-func GetAdminClient() (*SvcImpl, string) {
-	cli := NewSvcClients()
+func GetAdminClient(opts ...ClientOption) (*SvcImpl, string) {
+	cli := NewSvcClients(opts...)
 	loginResp, err := cli.ReqUserLogin(&UserLoginInfoReq{
 		Password:         "222222",
 		UserName:         "admin",
@@ -27,8 +39,9 @@ func GetAdminClient() (*SvcImpl, string) {
 }
 
 func TestSvcImpl_ReqUserCreate(t *testing.T) {
+	withCassette := useCassette(t)
 	// create
-	cli := NewSvcClients()
+	cli := NewSvcClients(withCassette)
 	RegisterResp, err := cli.ReqUserCreate(&UserCreateInfoReq{
 		Password: "testpasswd",
 		UserName: "testuser",
@@ -66,3 +79,108 @@ func TestSvcImpl_ReqUserCreate(t *testing.T) {
 		t.Errorf("deleteResp.Status != 1")
 	}
 }
+
+func TestSvcImpl_LoginWithVerifyCode(t *testing.T) {
+	withCassette := useCassette(t)
+	cli := NewSvcClients(withCassette)
+	loginResp, err := cli.LoginWithVerifyCode("fdse_microservice", "111111", verifyCodeSolver(t))
+	if err != nil {
+		t.Error(err)
+	}
+	if loginResp.Status != 1 {
+		t.Errorf("loginResp.Status != 1, msg: %s", loginResp.Msg)
+	}
+	if loginResp.Data.Token == "" {
+		t.Errorf("loginResp.Data.Token is empty")
+	}
+}
+
+// TestSvcImpl_LoginWithVerifyCode_Cassette replays the committed cassette of TestSvcImpl_LoginWithVerifyCode
+// with no backend behind BASE_URL, so every test run checks the cassette and the replay path.
+func TestSvcImpl_LoginWithVerifyCode_Cassette(t *testing.T) {
+	c, err := cassette.Open(filepath.Join("testdata", "cassettes", "TestSvcImpl_LoginWithVerifyCode.json"), cassette.Replay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("BASE_URL", "http://127.0.0.1:1")
+	cli := NewSvcClients(WithTransport(c.Transport))
+	loginResp, err := cli.LoginWithVerifyCode("fdse_microservice", "111111", FixedVerifyCodeSolver("123"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loginResp.Data.Username != "fdse_microservice" || loginResp.Data.Token == "" {
+		t.Errorf("Unexpected replayed login response: %+v", loginResp.Data)
+	}
+	for _, problem := range c.Stale() {
+		t.Errorf("cassette is stale: %s", problem)
+	}
+}
+
+// TestSvcImpl_LoginWithVerifyCode_Refresh checks that a token about to expire is refreshed through the
+// captcha login, whose verify code requests must not wait for the refresh they are part of.
+func TestSvcImpl_LoginWithVerifyCode_Refresh(t *testing.T) {
+	withCassette := useCassette(t)
+	cli := NewSvcClients(withCassette)
+	if _, err := cli.LoginWithVerifyCode("fdse_microservice", "111111", verifyCodeSolver(t)); err != nil {
+		t.Fatal(err)
+	}
+	payload := fmt.Sprintf(`{"exp":%d}`, time.Now().Add(10*time.Second).Unix())
+	cli.auth.SetToken("e30." + base64.RawURLEncoding.EncodeToString([]byte(payload)) + ".sig")
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := cli.QueryStations()
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Error(err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("request with an expiring token did not return, the refresh is deadlocked")
+	}
+	if got := cli.auth.RefreshCount(); got != 1 {
+		t.Errorf("RefreshCount() = %d, want 1", got)
+	}
+}
+
+// TestSvcImpl_LoginWithVerifyCode_Gateways logs in through two gateway replicas of a backend that checks
+// the captcha. The verify code cookie set by one replica must reach the other one with the login.
+func TestSvcImpl_LoginWithVerifyCode_Gateways(t *testing.T) {
+	srv := fake.NewServer(fake.WithCaptcha())
+	defer srv.Close()
+	backend, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var hits [2]atomic.Int64
+	gateways := make([]string, len(hits))
+	for i := range gateways {
+		i := i
+		proxy := httputil.NewSingleHostReverseProxy(backend)
+		gw := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			hits[i].Add(1)
+			proxy.ServeHTTP(w, r)
+		}))
+		defer gw.Close()
+		gateways[i] = gw.URL
+	}
+	// Cookies ignore the port, so the second replica needs another host name to tell them apart.
+	gateways[1] = strings.Replace(gateways[1], "127.0.0.1", "localhost", 1)
+	t.Setenv("BASE_URL", strings.Join(gateways, ","))
+	t.Setenv("LB_STRATEGY", "")
+
+	cli := NewSvcClients()
+	solver := func(image *VerifyCodeImage) (string, error) { return string(image.Image), nil }
+	loginResp, err := cli.LoginWithVerifyCode("fdse_microservice", "111111", solver)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loginResp.Data.Token == "" {
+		t.Errorf("loginResp.Data.Token is empty")
+	}
+	if hits[0].Load() == 0 || hits[1].Load() == 0 {
+		t.Errorf("Login did not use both gateways, hits = %d, %d", hits[0].Load(), hits[1].Load())
+	}
+}
@@ -102,10 +102,10 @@ func (s *SvcImpl) QueryForTravel(info *Travel) (*QueryForTravelResponse, error)
 	var result QueryForTravelResponse
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
 
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) QueryTrainService() (*TrainResponseType, error) {
@@ -124,10 +124,10 @@ func (s *SvcImpl) QueryTrainService() (*TrainResponseType, error) {
 	var result TrainResponseType
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
 
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 type QueryForTravelsResponse struct {
@@ -152,10 +152,10 @@ func (s *SvcImpl) QueryForTravels(infos []*Travel) (*QueryForTravelsResponse, er
 	var result QueryForTravelsResponse
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
 
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 type QueryForStationIdResponse struct {
@@ -180,8 +180,8 @@ func (s *SvcImpl) QueryForStationId(stationName string) (*QueryForStationIdRespo
 	var result QueryForStationIdResponse
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
 
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
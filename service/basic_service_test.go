@@ -3,13 +3,13 @@ package service
 import (
 	"fmt"
 	"github.com/go-faker/faker/v4"
-	"math/rand"
 	"strings"
 	"testing"
 )
 
 func TestBasicServiceFullIntegration(t *testing.T) {
-	cli, _ := GetBasicClient()
+	withCassette := useCassette(t)
+	cli, _ := GetBasicClient(withCassette)
 	//var basicSvc BasicService = cli
 
 	var stationSvc StationService = cli
@@ -36,7 +36,7 @@ func TestBasicServiceFullIntegration(t *testing.T) {
 	MockedStartStation := stations.Data[0].Name
 	MockedEndStation := stations.Data[1].Name
 	MockedStationList := fmt.Sprintf("%s,%s,%s", MockedStartStation, stations.Data[2].Name, MockedEndStation)
-	MockedDistanceList := fmt.Sprintf("%d,%d,%d", rand.Intn(30), rand.Intn(30), rand.Intn(30))
+	MockedDistanceList := fmt.Sprintf("%d,%d,%d", random.Intn(30), random.Intn(30), random.Intn(30))
 	input := RouteInfo{
 		ID:           MockedID,
 		StartStation: MockedStartStation,
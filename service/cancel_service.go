@@ -25,9 +25,9 @@ func (s *SvcImpl) ReqCalculate(orderId string) (*DataStringResp, error) {
 
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) ReqCancelTicket(orderId string, loginId string) (*DataStringResp, error) {
@@ -43,7 +43,7 @@ func (s *SvcImpl) ReqCancelTicket(orderId string, loginId string) (*DataStringRe
 
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
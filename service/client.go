@@ -0,0 +1,49 @@
+package service
+
+// Client is the whole TrainTicket API, one embedded interface per service. Behaviours take a Client
+// from their context so their logic can run against a fake such as servicetest.Client instead of a
+// SvcImpl talking HTTP.
+type Client interface {
+	AdminBasicInfoService
+	AdminOrderService
+	AdminRouteService
+	AdminTravelService
+	AdminUserService
+	AssuranceService
+	AuthService
+	BasicService
+	CancelService
+	ConfigService
+	ConsignPriceService
+	ConsignService
+	ContactsService
+	DeliveryService
+	ExecuteService
+	FoodDeliveryService
+	FoodService
+	InsidePaymentService
+	NotificationService
+	OrderOtherService
+	OrderService
+	PaymentService
+	PreserveOtherService
+	PreserveService
+	PriceService
+	RebookService
+	RoutePlanService
+	RouteService
+	SeatService
+	SecurityService
+	StationFoodService
+	StationService
+	TrainFoodService
+	TrainService
+	Travel2Service
+	TravelService
+	TravelplanService
+	UserService
+	VerificationCodeService
+	WaitOrderService
+}
+
+var _ Client = (*SvcImpl)(nil)
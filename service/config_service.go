@@ -7,6 +7,15 @@ import (
 	"io"
 )
 
+// ConfigService defines the methods to manage the system configs of config-service
+type ConfigService interface {
+	QueryAllConfigs() (*ConfigQueryAllConfigsResponse, error)
+	CreateConfig(info *Config_config) (*CreateConfigResponse, error)
+	UpdateConfig(info Config_config) (*UpdateConfigResponse, error)
+	DeleteConfig_config_service(configName string) (*DeleteConfig_config_serviceResponse, error)
+	RetrieveConfig(configName string) (*RetrieveConfigResponse, error)
+}
+
 type Config_config struct {
 	Name        string `json:"name" validate:"required"`  // @Valid, @Id, @NotNull
 	Value       string `json:"value" validate:"required"` // @Valid, @NotNull
@@ -39,10 +48,10 @@ func (s *SvcImpl) QueryAllConfigs() (*ConfigQueryAllConfigsResponse, error) {
 	var result ConfigQueryAllConfigsResponse
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
 
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 type CreateConfigResponse struct {
@@ -67,10 +76,10 @@ func (s *SvcImpl) CreateConfig(info *Config_config) (*CreateConfigResponse, erro
 	var result CreateConfigResponse
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
 
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 type UpdateConfigResponse struct {
@@ -99,10 +108,10 @@ func (s *SvcImpl) UpdateConfig(info Config_config) (*UpdateConfigResponse, error
 	var result UpdateConfigResponse
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
 
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 type DeleteConfig_config_serviceResponse struct {
@@ -131,10 +140,10 @@ func (s *SvcImpl) DeleteConfig_config_service(configName string) (*DeleteConfig_
 	var result DeleteConfig_config_serviceResponse
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
 
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 type RetrieveConfigResponse struct {
@@ -163,8 +172,8 @@ func (s *SvcImpl) RetrieveConfig(configName string) (*RetrieveConfigResponse, er
 	var result RetrieveConfigResponse
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
 
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
@@ -7,7 +7,8 @@ import (
 )
 
 func TestConfigService_FullIntegration(t *testing.T) {
-	cli, _ := GetBasicClient() // Assuming GetBasicClient is implemented elsewhere
+	withCassette := useCassette(t)
+	cli, _ := GetBasicClient(withCassette) // Assuming GetBasicClient is implemented elsewhere
 
 	// Query All Configs Test
 	queryAllResp, err := cli.QueryAllConfigs()
@@ -80,7 +81,8 @@ func TestConfigService_FullIntegration(t *testing.T) {
 }
 
 func TestConfigService_FullIntegration_v2(t *testing.T) {
-	cli, _ := GetBasicClient() // Assuming GetBasicClient is implemented elsewhere
+	withCassette := useCassette(t)
+	cli, _ := GetBasicClient(withCassette) // Assuming GetBasicClient is implemented elsewhere
 
 	// Query All Configs Test
 	queryAllResp, err := cli.QueryAllConfigs()
@@ -75,10 +75,10 @@ func (s *SvcImpl) GetPriceByWeightAndRegion(weight string, isWithinRegion string
 	var result ConsignPriceResponse
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
 
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) GetPriceInfo() (*GetResponse, error) {
@@ -97,10 +97,10 @@ func (s *SvcImpl) GetPriceInfo() (*GetResponse, error) {
 	var result GetResponse
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
 
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) GetPriceConfig() (*GetPriceConfigResponse, error) {
@@ -119,10 +119,10 @@ func (s *SvcImpl) GetPriceConfig() (*GetPriceConfigResponse, error) {
 	var result GetPriceConfigResponse
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
 
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) ModifyPriceConfig(priceConfig *ConsignPrice) (*ModifyConsignPriceResponse, error) {
@@ -141,8 +141,8 @@ func (s *SvcImpl) ModifyPriceConfig(priceConfig *ConsignPrice) (*ModifyConsignPr
 	var result ModifyConsignPriceResponse
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
 
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
@@ -1,12 +1,12 @@
 package service
 
 import (
-	"math/rand"
 	"testing"
 )
 
 func TestSvcImpl_GetModifyDeletePriceConfig(t *testing.T) {
-	cli, _ := GetAdminClient()
+	withCassette := useCassette(t)
+	cli, _ := GetAdminClient(withCassette)
 	var consignSvc ConsignPriceService = cli
 
 	/*	// Mock Data
@@ -52,10 +52,10 @@ func TestSvcImpl_GetModifyDeletePriceConfig(t *testing.T) {
 
 	getID = "39f89515-2d68-4ffb-9214-3c25a73da65f" // The ID here should be updated every redeploy the service since it is randomly generated when deploying.
 	getIndex = 0
-	getInitialWeight = rand.Float64()
-	getInitialPrice = rand.Float64()
-	getWithinPrice = rand.Float64()
-	getBeyondPrice = rand.Float64()
+	getInitialWeight = random.Float64()
+	getInitialPrice = random.Float64()
+	getWithinPrice = random.Float64()
+	getBeyondPrice = random.Float64()
 
 	consignPrice := ConsignPrice{
 		ID:            getID,
@@ -56,10 +56,10 @@ func (s *SvcImpl) InsertConsignRecord(consign *Consign) (*ConsignResponse, error
 	var result ConsignResponse
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
 
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) UpdateConsignRecord(consign *Consign) (*ConsignResponse, error) {
@@ -76,10 +76,10 @@ func (s *SvcImpl) UpdateConsignRecord(consign *Consign) (*ConsignResponse, error
 	var result ConsignResponse
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
 
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) QueryByAccountId(accountId string) (*AllConsignResponse, error) {
@@ -96,10 +96,10 @@ func (s *SvcImpl) QueryByAccountId(accountId string) (*AllConsignResponse, error
 	var result AllConsignResponse
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
 
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 type QueryByOrderIdResponse struct {
@@ -134,10 +134,10 @@ func (s *SvcImpl) QueryByOrderId(orderId string) (*QueryByOrderIdResponse, error
 	var result QueryByOrderIdResponse
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
 
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 type QueryByConsigneeResponse struct {
@@ -172,8 +172,8 @@ func (s *SvcImpl) QueryByConsignee(consignee string) (*QueryByConsigneeResponse,
 	var result QueryByConsigneeResponse
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
 
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
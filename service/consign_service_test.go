@@ -7,7 +7,8 @@ import (
 )
 
 func TestSvcImpl_AddUpdateQueryDeleteConsign(t *testing.T) {
-	cli, _ := GetAdminClient()
+	withCassette := useCassette(t)
+	cli, _ := GetAdminClient(withCassette)
 	var consignSvc ConsignService = cli
 
 	// Mock data
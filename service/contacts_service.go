@@ -45,7 +45,10 @@ func (s *SvcImpl) GetAllContacts() (*AdminGetContactsResp, error) {
 	}
 	var result AdminGetContactsResp
 	err = json.Unmarshal(body, &result)
-	return &result, err
+	if err != nil {
+		return &result, err
+	}
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) AddContact(contacts *AdminContacts) (*AdminContactResponse, error) {
@@ -59,7 +62,10 @@ func (s *SvcImpl) AddContact(contacts *AdminContacts) (*AdminContactResponse, er
 	}
 	var result AdminContactResponse
 	err = json.Unmarshal(body, &result)
-	return &result, err
+	if err != nil {
+		return &result, err
+	}
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) AddAdminContact(contacts *AdminContacts) (*AdminContactResponse, error) {
@@ -73,7 +79,10 @@ func (s *SvcImpl) AddAdminContact(contacts *AdminContacts) (*AdminContactRespons
 	}
 	var result AdminContactResponse
 	err = json.Unmarshal(body, &result)
-	return &result, err
+	if err != nil {
+		return &result, err
+	}
+	return &result, checkResponse(resp, body)
 }
 func (s *SvcImpl) ModifyContact(contacts *AdminContacts) (*AdminContactResponse, error) {
 	resp, err := s.cli.SendRequest("PUT", s.BaseUrl+"/api/v1/contactservice/contacts", contacts)
@@ -86,7 +95,10 @@ func (s *SvcImpl) ModifyContact(contacts *AdminContacts) (*AdminContactResponse,
 	}
 	var result AdminContactResponse
 	err = json.Unmarshal(body, &result)
-	return &result, err
+	if err != nil {
+		return &result, err
+	}
+	return &result, checkResponse(resp, body)
 }
 func (s *SvcImpl) DeleteContact(contactsId string) (*DeleteContactsResp, error) {
 	resp, err := s.cli.SendRequest("DELETE", s.BaseUrl+fmt.Sprintf("/api/v1/contactservice/contacts/%s", contactsId), nil)
@@ -99,7 +111,10 @@ func (s *SvcImpl) DeleteContact(contactsId string) (*DeleteContactsResp, error)
 	}
 	var result DeleteContactsResp
 	err = json.Unmarshal(body, &result)
-	return &result, err
+	if err != nil {
+		return &result, err
+	}
+	return &result, checkResponse(resp, body)
 }
 func (s *SvcImpl) GetContactByContactId(contactsId string) (*AdminContactResponse, error) {
 	resp, err := s.cli.SendRequest("GET", s.BaseUrl+fmt.Sprintf("/api/v1/contactservice/contacts/%s", contactsId), nil)
@@ -112,7 +127,10 @@ func (s *SvcImpl) GetContactByContactId(contactsId string) (*AdminContactRespons
 	}
 	var result AdminContactResponse
 	err = json.Unmarshal(body, &result)
-	return &result, err
+	if err != nil {
+		return &result, err
+	}
+	return &result, checkResponse(resp, body)
 }
 func (s *SvcImpl) GetContactByAccountId(accountId string) (*GetContactsByAccountIdResp, error) {
 	resp, err := s.cli.SendRequest("GET", s.BaseUrl+fmt.Sprintf("/api/v1/contactservice/contacts/account/%s", accountId), nil)
@@ -125,5 +143,8 @@ func (s *SvcImpl) GetContactByAccountId(accountId string) (*GetContactsByAccount
 	}
 	var result GetContactsByAccountIdResp
 	err = json.Unmarshal(body, &result)
-	return &result, err
+	if err != nil {
+		return &result, err
+	}
+	return &result, checkResponse(resp, body)
 }
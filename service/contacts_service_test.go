@@ -7,7 +7,8 @@ import (
 )
 
 func TestSvc_FullIntegration(t *testing.T) {
-	cli, _ := GetAdminClient()
+	withCassette := useCassette(t)
+	cli, _ := GetAdminClient(withCassette)
 	var contactsSvc ContactsService = cli
 
 	// CreateContact
@@ -1 +1,106 @@
 package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+)
+
+// DeliveryService defines the methods to track the deliveries created from food delivery orders
+type DeliveryService interface {
+	ReqGetDeliveriesByOrderId(orderId string) (*DeliveryArrResp, error)
+	ReqGetDeliveriesByStation(stationName string) (*DeliveryArrResp, error)
+	ReqUpdateDeliveryStatus(input *DeliveryStatusInfo) (*DeliveryResp, error)
+}
+
+// Delivery represents a delivery of food to a seat, created when a food delivery order is placed
+type Delivery struct {
+	Id          string `json:"id"`
+	OrderId     string `json:"orderId"`
+	FoodName    string `json:"foodName"`
+	StoreName   string `json:"storeName"`
+	StationName string `json:"stationName"`
+	Status      int    `json:"status"`
+}
+
+// Delivery statuses
+const (
+	DeliveryStatusCreated = iota
+	DeliveryStatusDelivering
+	DeliveryStatusDelivered
+	DeliveryStatusCancelled
+)
+
+// DeliveryStatusInfo represents the body of a delivery status update
+type DeliveryStatusInfo struct {
+	Id     string `json:"id"`
+	Status int    `json:"status"`
+}
+
+type DeliveryResp struct {
+	Status int      `json:"status"`
+	Msg    string   `json:"msg"`
+	Data   Delivery `json:"data"`
+}
+
+type DeliveryArrResp struct {
+	Status int        `json:"status"`
+	Msg    string     `json:"msg"`
+	Data   []Delivery `json:"data"`
+}
+
+func (s *SvcImpl) ReqGetDeliveriesByOrderId(orderId string) (*DeliveryArrResp, error) {
+	resp, err := s.cli.SendRequest("GET", s.BaseUrl+"/api/v1/deliveryservice/deliveries/order/"+orderId, nil)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	var result DeliveryArrResp
+
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
+	}
+	return &result, checkResponse(resp, body)
+}
+
+func (s *SvcImpl) ReqGetDeliveriesByStation(stationName string) (*DeliveryArrResp, error) {
+	resp, err := s.cli.SendRequest("GET", s.BaseUrl+"/api/v1/deliveryservice/deliveries/station/"+url.PathEscape(stationName), nil)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	var result DeliveryArrResp
+
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
+	}
+	return &result, checkResponse(resp, body)
+}
+
+func (s *SvcImpl) ReqUpdateDeliveryStatus(input *DeliveryStatusInfo) (*DeliveryResp, error) {
+	resp, err := s.cli.SendRequest("PUT", s.BaseUrl+"/api/v1/deliveryservice/deliveries/status", input)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	var result DeliveryResp
+
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
+	}
+	return &result, checkResponse(resp, body)
+}
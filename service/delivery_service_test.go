@@ -1 +1,96 @@
 package service
+
+import (
+	"fmt"
+	"github.com/go-faker/faker/v4"
+	"github.com/google/uuid"
+	"testing"
+)
+
+// TestSvcImpl_DeliveryPipeline creates a food delivery order and follows its delivery until it is delivered.
+func TestSvcImpl_DeliveryPipeline(t *testing.T) {
+	withCassette := useCassette(t)
+	cli, _ := GetAdminClient(withCassette)
+
+	orderId := uuid.NewString()
+	AddResp, err := cli.ReqCreateFoodDeliveryOrder(&FoodDeliveryOrder{
+		CreatedTime:  faker.Date(),
+		DeliveryFee:  20,
+		DeliveryTime: faker.Date(),
+		FoodList: []Food{{
+			FoodName: "Hamburger",
+			Price:    5.0,
+		}},
+		Id:                 orderId,
+		SeatNo:             RandomIntBetween(1, 6),
+		StationFoodStoreId: "fc212d9b-4215-40ab-bc66-a02710fd387b",
+		TripId:             uuid.NewString(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fmt.Println(AddResp.Msg)
+
+	GetResp, err := cli.ReqGetDeliveriesByOrderId(AddResp.Data.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fmt.Println(GetResp.Msg)
+	if len(GetResp.Data) == 0 {
+		t.Skip("no delivery created for the food delivery order yet")
+	}
+
+	for _, status := range []int{DeliveryStatusDelivering, DeliveryStatusDelivered} {
+		UpdateResp, err := cli.ReqUpdateDeliveryStatus(&DeliveryStatusInfo{
+			Id:     GetResp.Data[0].Id,
+			Status: status,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		fmt.Println(UpdateResp.Msg)
+	}
+}
+
+func TestSvcImpl_ReqGetDeliveriesByOrderId(t *testing.T) {
+	withCassette := useCassette(t)
+	cli, _ := GetAdminClient(withCassette)
+	GetResp, err := cli.ReqGetDeliveriesByOrderId("8a80811d9031564e0190366bc1950000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fmt.Println(GetResp.Msg)
+}
+
+func TestSvcImpl_ReqGetDeliveriesByStation(t *testing.T) {
+	withCassette := useCassette(t)
+	cli, _ := GetAdminClient(withCassette)
+	GetResp, err := cli.ReqGetDeliveriesByStation("shanghai")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fmt.Println(GetResp.Msg)
+}
+
+// TestSvcImpl_ReqGetDeliveriesByStation_Escaped looks up a station name that is not a valid path segment.
+func TestSvcImpl_ReqGetDeliveriesByStation_Escaped(t *testing.T) {
+	withCassette := useCassette(t)
+	cli, _ := GetAdminClient(withCassette)
+	GetResp, err := cli.ReqGetDeliveriesByStation("shanghai hongqiao/east?")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if GetResp.Status != 1 {
+		t.Errorf("GetResp.Status = %d, msg: %s", GetResp.Status, GetResp.Msg)
+	}
+}
+
+func TestSvcImpl_ReqUpdateDeliveryStatus(t *testing.T) {
+	withCassette := useCassette(t)
+	cli, _ := GetAdminClient(withCassette)
+	UpdateResp, _ := cli.ReqUpdateDeliveryStatus(&DeliveryStatusInfo{
+		Id:     "8a80811d9031564e0190366bc1950000",
+		Status: DeliveryStatusDelivered,
+	})
+	fmt.Println(UpdateResp.Msg)
+}
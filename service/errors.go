@@ -0,0 +1,68 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/Lincyaw/loadgenerator/httpclient"
+)
+
+// StatusSuccess is the business status of a successful TrainTicket response
+const StatusSuccess = 1
+
+// Error is returned by SvcImpl methods when a response indicates failure: a non-2xx HTTP status or
+// a business status other than StatusSuccess. The decoded response is still returned alongside the
+// error, and Body holds the raw response body.
+type Error struct {
+	// Service is the TrainTicket service, e.g. "orderservice"
+	Service string
+	// Endpoint is the method and route template, e.g. "GET /api/v1/orderservice/order/{id}"
+	Endpoint   string
+	HTTPStatus int
+	// Status is the business status, 0 when the body has no status field
+	Status int
+	Msg    string
+	Body   []byte
+}
+
+func (e *Error) Error() string {
+	if e.HTTPStatus < 200 || e.HTTPStatus >= 300 {
+		return fmt.Sprintf("%s %s: http status %d, status %d: %s", e.Service, e.Endpoint, e.HTTPStatus, e.Status, e.Msg)
+	}
+	return fmt.Sprintf("%s %s: status %d: %s", e.Service, e.Endpoint, e.Status, e.Msg)
+}
+
+// IsErrorMsg reports whether err is a service *Error with the given msg, e.g. "Already exists".
+func IsErrorMsg(err error, msg string) bool {
+	var svcErr *Error
+	return errors.As(err, &svcErr) && svcErr.Msg == msg
+}
+
+// checkResponse returns an *Error when resp or its body indicates failure. Bodies without a status
+// field, such as plain arrays, only fail on the HTTP status.
+func checkResponse(resp *http.Response, body []byte) error {
+	var payload struct {
+		Status *int   `json:"status"`
+		Msg    string `json:"msg"`
+	}
+	_ = json.Unmarshal(body, &payload)
+	httpOK := resp.StatusCode >= 200 && resp.StatusCode < 300
+	if httpOK && (payload.Status == nil || *payload.Status == StatusSuccess) {
+		return nil
+	}
+
+	e := &Error{HTTPStatus: resp.StatusCode, Msg: payload.Msg, Body: body}
+	if payload.Status != nil {
+		e.Status = *payload.Status
+	}
+	if e.Msg == "" && !httpOK {
+		e.Msg = http.StatusText(resp.StatusCode)
+	}
+	if req := resp.Request; req != nil {
+		e.Service = httpclient.ServiceName(req.URL.Path)
+		e.Endpoint = req.Method + " " + httpclient.RouteTemplate(req.URL.Path)
+	}
+	return e
+}
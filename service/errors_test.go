@@ -0,0 +1,55 @@
+package service
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Lincyaw/loadgenerator/httpclient"
+)
+
+func TestSvcImpl_ReturnsTypedErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/assuranceservice/assurances/1/8a80811d-9031-564e-0190-366bc1950000":
+			w.Write([]byte(`{"status":0,"msg":"Already exists","data":null}`))
+		case "/api/v1/rebookservice/rebook":
+			w.Write([]byte(`{"status":2,"msg":"Please pay the different money!","data":{"id":"order-1"}}`))
+		case "/api/v1/stationservice/stations":
+			w.Write([]byte(`{"status":1,"msg":"Find all content","data":[]}`))
+		default:
+			w.WriteHeader(http.StatusBadGateway)
+			w.Write([]byte(`<html>bad gateway</html>`))
+		}
+	}))
+	defer server.Close()
+	cli := &SvcImpl{cli: httpclient.NewCustomClient(), BaseUrl: server.URL}
+
+	_, err := cli.CreateNewAssurance(1, "8a80811d-9031-564e-0190-366bc1950000")
+	if !IsErrorMsg(err, "Already exists") {
+		t.Errorf("CreateNewAssurance error = %v, want Already exists", err)
+	}
+	var svcErr *Error
+	if !errors.As(err, &svcErr) || svcErr.Service != "assuranceservice" ||
+		svcErr.Endpoint != "GET /api/v1/assuranceservice/assurances/{id}/{id}" || svcErr.HTTPStatus != 200 {
+		t.Errorf("CreateNewAssurance error = %+v", svcErr)
+	}
+
+	rebookResp, err := cli.ReqRebook(&RebookInfo{OrderId: "order-1"})
+	if !errors.As(err, &svcErr) || svcErr.Status != RebookStatusPayDifference || string(svcErr.Body) == "" {
+		t.Errorf("ReqRebook error = %v", err)
+	}
+	if rebookResp == nil || rebookResp.Data.Id != "order-1" {
+		t.Errorf("ReqRebook response = %+v, want decoded response alongside the error", rebookResp)
+	}
+
+	if _, err := cli.QueryStations(); err != nil {
+		t.Errorf("QueryStations error = %v, want nil", err)
+	}
+
+	_, err = cli.ReqGetDeliveriesByStation("shanghai")
+	if !errors.As(err, &svcErr) || svcErr.HTTPStatus != http.StatusBadGateway || svcErr.Msg != "Bad Gateway" {
+		t.Errorf("ReqGetDeliveriesByStation error = %v, want bad gateway", err)
+	}
+}
@@ -7,6 +7,18 @@ import (
 	"io"
 )
 
+// FoodDeliveryService defines the methods to place and manage food delivery orders
+type FoodDeliveryService interface {
+	ReqCreateFoodDeliveryOrder(input *FoodDeliveryOrder) (*FoodDeliveryOrderResponse, error)
+	ReqGetAllFoodDeliveryOrders() (*FoodDeliveryOrderArrResponse, error)
+	ReqGetFoodDeliveryOrderByStoreId(storeId string) (*FoodDeliveryOrderArrResponse, error)
+	ReqGetFoodDeliveryOrderById(orderId string) (*FoodDeliveryOrderResponse, error)
+	ReqDeleteFoodDeliveryOrderById(orderId string) (*DataStringResp, error)
+	ReqUpdateDeliveryTime(input *DeliveryInfo) (*FoodDeliveryOrderResponse, error)
+	ReqUpdateSeatNo(input *SeatInfo) (*FoodDeliveryOrderResponse, error)
+	ReqUpdateTripId(input *TripOrderInfo) (*FoodDeliveryOrderResponse, error)
+}
+
 func (s *SvcImpl) ReqCreateFoodDeliveryOrder(input *FoodDeliveryOrder) (*FoodDeliveryOrderResponse, error) {
 	resp, err := s.cli.SendRequest("POST", s.BaseUrl+"/api/v1/fooddeliveryservice/orders", input)
 	if err != nil {
@@ -20,9 +32,9 @@ func (s *SvcImpl) ReqCreateFoodDeliveryOrder(input *FoodDeliveryOrder) (*FoodDel
 
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) ReqGetAllFoodDeliveryOrders() (*FoodDeliveryOrderArrResponse, error) {
@@ -38,9 +50,9 @@ func (s *SvcImpl) ReqGetAllFoodDeliveryOrders() (*FoodDeliveryOrderArrResponse,
 
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) ReqGetFoodDeliveryOrderByStoreId(storeId string) (*FoodDeliveryOrderArrResponse, error) {
@@ -56,9 +68,9 @@ func (s *SvcImpl) ReqGetFoodDeliveryOrderByStoreId(storeId string) (*FoodDeliver
 
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) ReqGetFoodDeliveryOrderById(orderId string) (*FoodDeliveryOrderResponse, error) {
@@ -74,9 +86,9 @@ func (s *SvcImpl) ReqGetFoodDeliveryOrderById(orderId string) (*FoodDeliveryOrde
 
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) ReqDeleteFoodDeliveryOrderById(orderId string) (*DataStringResp, error) {
@@ -92,9 +104,9 @@ func (s *SvcImpl) ReqDeleteFoodDeliveryOrderById(orderId string) (*DataStringRes
 
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) ReqUpdateDeliveryTime(input *DeliveryInfo) (*FoodDeliveryOrderResponse, error) {
@@ -110,9 +122,9 @@ func (s *SvcImpl) ReqUpdateDeliveryTime(input *DeliveryInfo) (*FoodDeliveryOrder
 
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) ReqUpdateSeatNo(input *SeatInfo) (*FoodDeliveryOrderResponse, error) {
@@ -128,9 +140,9 @@ func (s *SvcImpl) ReqUpdateSeatNo(input *SeatInfo) (*FoodDeliveryOrderResponse,
 
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) ReqUpdateTripId(input *TripOrderInfo) (*FoodDeliveryOrderResponse, error) {
@@ -146,7 +158,7 @@ func (s *SvcImpl) ReqUpdateTripId(input *TripOrderInfo) (*FoodDeliveryOrderRespo
 
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
@@ -8,7 +8,8 @@ import (
 )
 
 func TestCreateFoodDeliveryOrder(t *testing.T) {
-	cli, _ := GetAdminClient()
+	withCassette := useCassette(t)
+	cli, _ := GetAdminClient(withCassette)
 
 	AddResp, err := cli.ReqCreateFoodDeliveryOrder(&FoodDeliveryOrder{
 		CreatedTime:  faker.Date(),
@@ -32,13 +33,15 @@ func TestCreateFoodDeliveryOrder(t *testing.T) {
 }
 
 func TestSvcImpl_ReqFindAllFoodDeliveryOrders(t *testing.T) {
-	cli, _ := GetAdminClient()
+	withCassette := useCassette(t)
+	cli, _ := GetAdminClient(withCassette)
 	GetResp, _ := cli.ReqGetAllFoodDeliveryOrders()
 	fmt.Println(GetResp.Msg)
 }
 
 func TestSvcImpl_ReqGetFoodDeliveryOrderByStoreId(t *testing.T) {
-	cli, _ := GetAdminClient()
+	withCassette := useCassette(t)
+	cli, _ := GetAdminClient(withCassette)
 	GetResp, err := cli.ReqGetFoodDeliveryOrderByStoreId("fc212d9b-4215-40ab-bc66-a02710fd387b")
 	if err != nil {
 		t.Error(err)
@@ -47,7 +50,8 @@ func TestSvcImpl_ReqGetFoodDeliveryOrderByStoreId(t *testing.T) {
 }
 
 func TestSvcImpl_ReqGetFoodDeliveryOrderById(t *testing.T) {
-	cli, _ := GetAdminClient()
+	withCassette := useCassette(t)
+	cli, _ := GetAdminClient(withCassette)
 	GetResp, err := cli.ReqGetFoodDeliveryOrderById("8a80811d9031564e0190366bc1950000")
 	if err != nil {
 		t.Fatal(err)
@@ -56,13 +60,15 @@ func TestSvcImpl_ReqGetFoodDeliveryOrderById(t *testing.T) {
 }
 
 func TestSvcImpl_ReqDeleteFoodDeliveryOrderById(t *testing.T) {
-	cli, _ := GetAdminClient()
+	withCassette := useCassette(t)
+	cli, _ := GetAdminClient(withCassette)
 	GetResp, _ := cli.ReqDeleteFoodDeliveryOrderById("8a80811d9031564e019036718aab0004")
 	fmt.Println(GetResp.Msg)
 }
 
 func TestSvcImpl_ReqUpdateDeliveryTime(t *testing.T) {
-	cli, _ := GetAdminClient()
+	withCassette := useCassette(t)
+	cli, _ := GetAdminClient(withCassette)
 	UpdateResp, err := cli.ReqUpdateDeliveryTime(&DeliveryInfo{
 		DeliveryTime: faker.TimeString(),
 		OrderId:      "8a80811d9031564e0190366bc1950000",
@@ -74,7 +80,8 @@ func TestSvcImpl_ReqUpdateDeliveryTime(t *testing.T) {
 }
 
 func TestSvcImpl_ReqUpdateSeatNo(t *testing.T) {
-	cli, _ := GetAdminClient()
+	withCassette := useCassette(t)
+	cli, _ := GetAdminClient(withCassette)
 	UpdateResp, err := cli.ReqUpdateSeatNo(&SeatInfo{
 		SeatNo:  0,
 		OrderId: "8a80811d9031564e0190366bc1950000",
@@ -86,7 +93,8 @@ func TestSvcImpl_ReqUpdateSeatNo(t *testing.T) {
 }
 
 func TestSvcImpl_ReqUpdateTripId(t *testing.T) {
-	cli, _ := GetAdminClient()
+	withCassette := useCassette(t)
+	cli, _ := GetAdminClient(withCassette)
 	UpdateResp, err := cli.ReqUpdateTripId(&TripOrderInfo{
 		TripId:  uuid.NewString(),
 		OrderId: "8a80811d9031564e0190366bc1950000",
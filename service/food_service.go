@@ -77,9 +77,9 @@ func (s *SvcImpl) FindAllFoodOrder() (*FindAllFoodOrder, error) {
 	var result FindAllFoodOrder
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) CreateFoodOrder(foodOrder *FoodOrder) (*CreateFoodOrderResp, error) {
@@ -97,9 +97,9 @@ func (s *SvcImpl) CreateFoodOrder(foodOrder *FoodOrder) (*CreateFoodOrderResp, e
 	var result CreateFoodOrderResp
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 type CreateFoodOrdersInBatch struct {
@@ -123,9 +123,9 @@ func (s *SvcImpl) CreateFoodOrdersInBatch(foodOrders []FoodOrder) (*CreateFoodOr
 	var result CreateFoodOrdersInBatch
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) UpdateFoodOrder(foodOrder *FoodOrder) (*FoodOrder, error) {
@@ -143,9 +143,9 @@ func (s *SvcImpl) UpdateFoodOrder(foodOrder *FoodOrder) (*FoodOrder, error) {
 	var result FoodOrder
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) DeleteFoodOrder(orderID string) (*DeleteFoodOrderResp, error) {
@@ -163,9 +163,9 @@ func (s *SvcImpl) DeleteFoodOrder(orderID string) (*DeleteFoodOrderResp, error)
 	var result DeleteFoodOrderResp
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 type FindByOrderIdResponse struct {
@@ -197,9 +197,9 @@ func (s *SvcImpl) FindByOrderId(orderID string) (*FindByOrderIdResponse, error)
 	var result FindByOrderIdResponse
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 type GetAllFoodResponse struct {
@@ -223,7 +223,7 @@ func (s *SvcImpl) GetAllFood(date string, startStation string, endStation string
 	var result GetAllFoodResponse
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
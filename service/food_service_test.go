@@ -1,7 +1,6 @@
 package service
 
 import (
-	"math/rand"
 	"strconv"
 	"testing"
 
@@ -9,7 +8,8 @@ import (
 )
 
 func TestSvcImpl_FoodService(t *testing.T) {
-	cli, _ := GetBasicClient()
+	withCassette := useCassette(t)
+	cli, _ := GetBasicClient(withCassette)
 
 	CreateInpt := &FoodOrder{
 		ID:          faker.UUIDHyphenated(),
@@ -131,13 +131,13 @@ func TestSvcImpl_FoodService(t *testing.T) {
 
 	// Test finding by random train number
 	trainNumber := ""
-	randn := rand.Int() % 15
+	randn := random.Int() % 15
 	if randn > 7 {
-		trainNumber = "G" + strconv.Itoa(rand.Int()%100)
+		trainNumber = "G" + strconv.Itoa(random.Int()%100)
 	} else if randn > 3 {
-		trainNumber = "D" + strconv.Itoa(rand.Int()%100)
+		trainNumber = "D" + strconv.Itoa(random.Int()%100)
 	} else {
-		trainNumber = strconv.Itoa(rand.Int() % 100)
+		trainNumber = strconv.Itoa(random.Int() % 100)
 	}
 	t.Logf("trainNumber: %s", trainNumber)
 
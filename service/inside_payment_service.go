@@ -7,6 +7,18 @@ import (
 	"io"
 )
 
+// InsidePaymentService defines the methods to pay for orders from account balances and manage the balances
+type InsidePaymentService interface {
+	ReqPay_InsidePayment(input *TripPayment) (*TripPaymentResponse, error)
+	ReqCreateAccount(input *AccountInfo) (*TripPaymentResponse, error)
+	ReqPayDifference(input *TripPayment) (*TripPaymentResponse, error)
+	ReqQueryAccount() (*TripPaymentArrResponse, error)
+	ReqDrawBack(userId string, money string) (*MoneyResponse, error)
+	ReqQueryAddMoney() (*MoneyResponse, error)
+	ReqQueryInsidePayment() (*TripPaymentArrResponse, error)
+	ReqAddMoney_Inside(userId string, money string) (*TripPaymentResponse, error)
+}
+
 func (s *SvcImpl) ReqPay_InsidePayment(input *TripPayment) (*TripPaymentResponse, error) {
 	resp, err := s.cli.SendRequest("POST", s.BaseUrl+"/api/v1/inside_pay_service/inside_payment", input)
 	if err != nil {
@@ -20,9 +32,9 @@ func (s *SvcImpl) ReqPay_InsidePayment(input *TripPayment) (*TripPaymentResponse
 
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) ReqCreateAccount(input *AccountInfo) (*TripPaymentResponse, error) {
@@ -38,9 +50,9 @@ func (s *SvcImpl) ReqCreateAccount(input *AccountInfo) (*TripPaymentResponse, er
 
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) ReqPayDifference(input *TripPayment) (*TripPaymentResponse, error) {
@@ -56,9 +68,9 @@ func (s *SvcImpl) ReqPayDifference(input *TripPayment) (*TripPaymentResponse, er
 
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) ReqQueryAccount() (*TripPaymentArrResponse, error) {
@@ -74,9 +86,9 @@ func (s *SvcImpl) ReqQueryAccount() (*TripPaymentArrResponse, error) {
 
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) ReqDrawBack(userId string, money string) (*MoneyResponse, error) {
@@ -92,9 +104,9 @@ func (s *SvcImpl) ReqDrawBack(userId string, money string) (*MoneyResponse, erro
 
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) ReqQueryAddMoney() (*MoneyResponse, error) {
@@ -110,9 +122,9 @@ func (s *SvcImpl) ReqQueryAddMoney() (*MoneyResponse, error) {
 
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) ReqQueryInsidePayment() (*TripPaymentArrResponse, error) {
@@ -128,9 +140,9 @@ func (s *SvcImpl) ReqQueryInsidePayment() (*TripPaymentArrResponse, error) {
 
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) ReqAddMoney_Inside(userId string, money string) (*TripPaymentResponse, error) {
@@ -146,7 +158,7 @@ func (s *SvcImpl) ReqAddMoney_Inside(userId string, money string) (*TripPaymentR
 
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
@@ -7,7 +7,8 @@ import (
 )
 
 func TestSvcImpl_ReqPay_InsidePayment(t *testing.T) {
-	cli, _ := GetAdminClient()
+	withCassette := useCassette(t)
+	cli, _ := GetAdminClient(withCassette)
 	UpdateResp, err := cli.ReqPay_InsidePayment(&TripPayment{
 		TripId:  "G111",
 		OrderId: "f1d1660a-bfb8-4304-9abe-018fef31a484",
@@ -21,7 +22,8 @@ func TestSvcImpl_ReqPay_InsidePayment(t *testing.T) {
 }
 
 func TestSvcImpl_ReqCreateAccount(t *testing.T) {
-	cli, _ := GetAdminClient()
+	withCassette := useCassette(t)
+	cli, _ := GetAdminClient(withCassette)
 	UpdateResp, err := cli.ReqCreateAccount(&AccountInfo{
 		Money:  RandomDecimalStringBetween(1, 100),
 		UserId: uuid.NewString(),
@@ -33,7 +35,8 @@ func TestSvcImpl_ReqCreateAccount(t *testing.T) {
 }
 
 func TestSvcImpl_ReqPayDifference(t *testing.T) {
-	cli, _ := GetAdminClient()
+	withCassette := useCassette(t)
+	cli, _ := GetAdminClient(withCassette)
 	UpdateResp, err := cli.ReqPayDifference(&TripPayment{
 		TripId:  "G111",
 		OrderId: "f1d1660a-bfb8-4304-9abe-018fef31a484",
@@ -47,37 +50,43 @@ func TestSvcImpl_ReqPayDifference(t *testing.T) {
 }
 
 func TestSvcImpl_ReqPay_InsidePayment2(t *testing.T) {
-	cli, _ := GetAdminClient()
+	withCassette := useCassette(t)
+	cli, _ := GetAdminClient(withCassette)
 	GetResp, _ := cli.ReqQueryAccount()
 	fmt.Println(GetResp.Msg)
 }
 
 func TestSvcImpl_ReqQueryAccount(t *testing.T) {
-	cli, _ := GetAdminClient()
+	withCassette := useCassette(t)
+	cli, _ := GetAdminClient(withCassette)
 	GetResp, _ := cli.ReqQueryAccount()
 	fmt.Println(GetResp.Msg)
 }
 
 func TestSvcImpl_ReqDrawBack(t *testing.T) {
-	cli, _ := GetAdminClient()
+	withCassette := useCassette(t)
+	cli, _ := GetAdminClient(withCassette)
 	GetResp, _ := cli.ReqDrawBack("4d2a46c7-71cb-4cf1-b5bb-b68406d9da6f", "12")
 	fmt.Println(GetResp.Msg)
 }
 
 func TestSvcImpl_ReqQueryAddMoney(t *testing.T) {
-	cli, _ := GetAdminClient()
+	withCassette := useCassette(t)
+	cli, _ := GetAdminClient(withCassette)
 	GetResp, _ := cli.ReqQueryAddMoney()
 	fmt.Println(GetResp.Msg)
 }
 
 func TestSvcImpl_ReqQueryInsidePayment(t *testing.T) {
-	cli, _ := GetAdminClient()
+	withCassette := useCassette(t)
+	cli, _ := GetAdminClient(withCassette)
 	GetResp, _ := cli.ReqQueryInsidePayment()
 	fmt.Println(GetResp.Msg)
 }
 
 func TestSvcImpl_ReqAddMoney_Inside(t *testing.T) {
-	cli, _ := GetAdminClient()
+	withCassette := useCassette(t)
+	cli, _ := GetAdminClient(withCassette)
 	GetResp, _ := cli.ReqAddMoney_Inside("4d2a46c7-71cb-4cf1-b5bb-b68406d9da6f", "12")
 	fmt.Println(GetResp.Msg)
 }
@@ -2,7 +2,6 @@ package service
 
 import (
 	"fmt"
-	"math/rand"
 	"time"
 )
 
@@ -201,16 +200,11 @@ type TicketOrderResponse struct {
 	Data   TicketOrder `json:"data"`
 }
 
-// init seeds the random number generator.
-func init() {
-	rand.Seed(time.Now().UnixNano())
-}
-
 // randomTime generates a random time in the format "HH:mm:ss".
 func randomTime() string {
-	hour := rand.Intn(24)   // Hours range from 0 to 23
-	minute := rand.Intn(60) // Minutes range from 0 to 59
-	second := rand.Intn(60) // Seconds range from 0 to 59
+	hour := random.Intn(24)   // Hours range from 0 to 23
+	minute := random.Intn(60) // Minutes range from 0 to 59
+	second := random.Intn(60) // Seconds range from 0 to 59
 
 	// Create a time.Time with the random hour, minute, and second.
 	t := time.Date(0, 1, 1, hour, minute, second, 0, time.UTC)
@@ -233,11 +227,8 @@ func getRandomDish() string {
 		"Burger with Fries",
 	}
 
-	// 设置随机数种子以确保每次运行程序时都能得到不同的结果
-	rand.Seed(time.Now().UnixNano())
-
 	// 从dishes切片中随机选择一个元素
-	randomIndex := rand.Intn(len(dishes))
+	randomIndex := random.Intn(len(dishes))
 	return dishes[randomIndex]
 }
 
@@ -252,11 +243,8 @@ func getRandomContact() AdminContacts {
 		fmt.Println(err)
 	}
 	contacts, _ := cli.GetAllContacts()
-	// 设置随机数种子以确保每次运行程序时都能得到不同的结果
-	rand.Seed(time.Now().UnixNano())
-
 	// 从contacts.Data切片中随机选择一个元素
-	randomIndex := rand.Intn(len(contacts.Data))
+	randomIndex := random.Intn(len(contacts.Data))
 	return contacts.Data[randomIndex]
 }
 
@@ -271,11 +259,8 @@ func getRandomOrder() Order {
 		fmt.Println(err)
 	}
 	orders, _ := cli.ReqFindAllOrder()
-	// 设置随机数种子以确保每次运行程序时都能得到不同的结果
-	rand.Seed(time.Now().UnixNano())
-
 	// 从contacts.Data切片中随机选择一个元素
-	randomIndex := rand.Intn(len(orders.Data))
+	randomIndex := random.Intn(len(orders.Data))
 	return orders.Data[randomIndex]
 }
 
@@ -290,11 +275,8 @@ func getRandomOrder_Other() Order {
 		fmt.Println(err)
 	}
 	orders, _ := cli.ReqFindAllOrderOther()
-	// 设置随机数种子以确保每次运行程序时都能得到不同的结果
-	rand.Seed(time.Now().UnixNano())
-
 	// 从contacts.Data切片中随机选择一个元素
-	randomIndex := rand.Intn(len(orders.Data))
+	randomIndex := random.Intn(len(orders.Data))
 	return orders.Data[randomIndex]
 }
 
@@ -0,0 +1,114 @@
+package service
+
+import (
+	crand "crypto/rand"
+	"errors"
+	"hash/fnv"
+	"io/fs"
+	"log"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Lincyaw/loadgenerator/cassette"
+	"github.com/Lincyaw/loadgenerator/fake"
+	"github.com/go-faker/faker/v4"
+	"github.com/google/uuid"
+)
+
+// offline is set when the tests run against the fake backend.
+var offline bool
+
+// TestMain runs the tests against the in-process fake backend unless BASE_URL points at a cluster.
+func TestMain(m *testing.M) {
+	if os.Getenv("BASE_URL") != "" {
+		os.Exit(m.Run())
+	}
+	offline = true
+	srv := fake.NewServer()
+	os.Setenv("BASE_URL", srv.URL)
+	log.Printf("BASE_URL is not set, running against the fake backend at %s", srv.URL)
+	code := m.Run()
+	srv.Close()
+	os.Exit(code)
+}
+
+// requireCluster skips tests that only pass against a real deployment, or its cassettes: they depend
+// on data or endpoints the fake backend doesn't model, or run until they are stopped.
+func requireCluster(t *testing.T) {
+	t.Helper()
+	if offline && os.Getenv("CASSETTES") != cassette.Replay.String() {
+		t.Skip("needs a TrainTicket cluster, set BASE_URL to run it")
+	}
+}
+
+// verifyCodeSolver returns the solver set by VERIFY_CODE or VERIFY_CODE_SOLVER_URL, or a fixed code that the
+// fake backend accepts.
+func verifyCodeSolver(t *testing.T) VerifyCodeSolver {
+	t.Helper()
+	solve, err := VerifyCodeSolverFromEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if solve == nil {
+		return FixedVerifyCodeSolver("123")
+	}
+	return solve
+}
+
+// useCassette records the HTTP calls of the test to testdata/cassettes/<test>.json when CASSETTES=record,
+// or answers them from that file when CASSETTES=replay, failing the test when the cassette is stale.
+// CASSETTE_MAX_AGE (a duration such as 2160h) also flags old recordings. In both modes the package's random
+// data, faker and uuid are seeded from the test name so the test sends the same requests every run.
+// The clients of the test must be created with the returned option, which does nothing without CASSETTES.
+func useCassette(t *testing.T) ClientOption {
+	t.Helper()
+	env := os.Getenv("CASSETTES")
+	if env == "" {
+		return func(*clientOptions) {}
+	}
+	mode, err := cassette.ParseMode(env)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var opts []cassette.Option
+	if maxAge := os.Getenv("CASSETTE_MAX_AGE"); maxAge != "" {
+		d, err := time.ParseDuration(maxAge)
+		if err != nil {
+			t.Fatalf("invalid CASSETTE_MAX_AGE: %v", err)
+		}
+		opts = append(opts, cassette.WithMaxAge(d))
+	}
+	path := filepath.Join("testdata", "cassettes", strings.ReplaceAll(t.Name(), "/", "_")+".json")
+	c, err := cassette.Open(path, mode, opts...)
+	if errors.Is(err, fs.ErrNotExist) {
+		t.Skipf("no cassette %s, record it with CASSETTES=record against a TrainTicket deployment", path)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h := fnv.New64a()
+	h.Write([]byte(t.Name()))
+	seed := int64(h.Sum64())
+	random.seed(seed)
+	faker.SetRandomSource(faker.NewSafeSource(rand.NewSource(seed)))
+	faker.SetCryptoSource(rand.New(rand.NewSource(seed)))
+	uuid.SetRand(rand.New(rand.NewSource(seed)))
+
+	t.Cleanup(func() {
+		random.seed(time.Now().UnixNano())
+		faker.SetCryptoSource(crand.Reader)
+		uuid.SetRand(nil)
+		if err := c.Save(); err != nil {
+			t.Errorf("save cassette %s: %v", path, err)
+		}
+		for _, problem := range c.Stale() {
+			t.Errorf("cassette %s is stale: %s", path, problem)
+		}
+	})
+	return WithTransport(c.Transport)
+}
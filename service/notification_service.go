@@ -5,6 +5,16 @@ import (
 	"io"
 )
 
+// NotificationService defines the methods to send the order notification mails
+type NotificationService interface {
+	ReqOrderCancelSuccess(input *TicketOrder) (*bool, error)
+	ReqOrderChangedSuccess(input *TicketOrder) (*bool, error)
+	ReqOrderCreateSuccess(input *TicketOrder) (*bool, error)
+	ReqPreserveSuccess(input *TicketOrder) (*bool, error)
+	ReqTestSendMail() (*bool, error)
+	ReqTestSend() (*bool, error)
+}
+
 func (s *SvcImpl) ReqOrderCancelSuccess(input *TicketOrder) (*bool, error) {
 	resp, err := s.cli.SendRequest("POST", s.BaseUrl+"/api/v1/notifyservice/notification/order_cancel_success", input)
 	if err != nil {
@@ -20,7 +30,7 @@ func (s *SvcImpl) ReqOrderCancelSuccess(input *TicketOrder) (*bool, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) ReqOrderChangedSuccess(input *TicketOrder) (*bool, error) {
@@ -38,7 +48,7 @@ func (s *SvcImpl) ReqOrderChangedSuccess(input *TicketOrder) (*bool, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) ReqOrderCreateSuccess(input *TicketOrder) (*bool, error) {
@@ -56,7 +66,7 @@ func (s *SvcImpl) ReqOrderCreateSuccess(input *TicketOrder) (*bool, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) ReqPreserveSuccess(input *TicketOrder) (*bool, error) {
@@ -74,7 +84,7 @@ func (s *SvcImpl) ReqPreserveSuccess(input *TicketOrder) (*bool, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) ReqTestSendMail() (*bool, error) {
@@ -92,7 +102,7 @@ func (s *SvcImpl) ReqTestSendMail() (*bool, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) ReqTestSend() (*bool, error) {
@@ -110,5 +120,5 @@ func (s *SvcImpl) ReqTestSend() (*bool, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
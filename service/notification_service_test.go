@@ -8,7 +8,8 @@ import (
 )
 
 func TestSvcImpl_ReqOrderCancelSuccess(t *testing.T) {
-	cli, _ := GetAdminClient()
+	withCassette := useCassette(t)
+	cli, _ := GetAdminClient(withCassette)
 	AddResp, err := cli.ReqOrderCancelSuccess(&TicketOrder{
 		Date:        randomTime(),
 		Email:       faker.Email(),
@@ -32,7 +33,8 @@ func TestSvcImpl_ReqOrderCancelSuccess(t *testing.T) {
 }
 
 func TestSvcImpl_ReqOrderChangedSuccess(t *testing.T) {
-	cli, _ := GetAdminClient()
+	withCassette := useCassette(t)
+	cli, _ := GetAdminClient(withCassette)
 	AddResp, err := cli.ReqOrderChangedSuccess(&TicketOrder{
 		Date:        randomTime(),
 		Email:       faker.Email(),
@@ -56,7 +58,8 @@ func TestSvcImpl_ReqOrderChangedSuccess(t *testing.T) {
 }
 
 func TestSvcImpl_ReqOrderCreateSuccess(t *testing.T) {
-	cli, _ := GetAdminClient()
+	withCassette := useCassette(t)
+	cli, _ := GetAdminClient(withCassette)
 	AddResp, err := cli.ReqOrderCreateSuccess(&TicketOrder{
 		Date:        randomTime(),
 		Email:       faker.Email(),
@@ -80,7 +83,8 @@ func TestSvcImpl_ReqOrderCreateSuccess(t *testing.T) {
 }
 
 func TestSvcImpl_ReqPreserveSuccess(t *testing.T) {
-	cli, _ := GetAdminClient()
+	withCassette := useCassette(t)
+	cli, _ := GetAdminClient(withCassette)
 	AddResp, err := cli.ReqPreserveSuccess(&TicketOrder{
 		Date:        randomTime(),
 		Email:       faker.Email(),
@@ -104,13 +108,15 @@ func TestSvcImpl_ReqPreserveSuccess(t *testing.T) {
 }
 
 func TestSvcImpl_ReqTestSendMail(t *testing.T) {
-	cli, _ := GetAdminClient()
+	withCassette := useCassette(t)
+	cli, _ := GetAdminClient(withCassette)
 	GetResp, _ := cli.ReqTestSendMail()
 	fmt.Println(*GetResp)
 }
 
 func TestSvcImpl_ReqTestSend(t *testing.T) {
-	cli, _ := GetAdminClient()
+	withCassette := useCassette(t)
+	cli, _ := GetAdminClient(withCassette)
 	GetResp, _ := cli.ReqTestSend()
 	fmt.Println(*GetResp)
 }
@@ -39,9 +39,9 @@ func (s *SvcImpl) ReqFindAllOrderOther() (*OrderArrResp, error) {
 
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) ReqCreateNewOrderOther(input *Order) (*OrderResp, error) {
@@ -57,9 +57,9 @@ func (s *SvcImpl) ReqCreateNewOrderOther(input *Order) (*OrderResp, error) {
 
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) ReqSaveOrderInfoOther(input *Order) (*OrderResp, error) {
@@ -75,9 +75,9 @@ func (s *SvcImpl) ReqSaveOrderInfoOther(input *Order) (*OrderResp, error) {
 
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) ReqAddCreateNewOrderOther(input *Order) (*OrderResp, error) {
@@ -93,9 +93,9 @@ func (s *SvcImpl) ReqAddCreateNewOrderOther(input *Order) (*OrderResp, error) {
 
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) ReqUpdateOrderOrderServiceOther(input *Order) (*OrderResp, error) {
@@ -111,9 +111,9 @@ func (s *SvcImpl) ReqUpdateOrderOrderServiceOther(input *Order) (*OrderResp, err
 
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) ReqPayOrderOther(orderId string) (*OrderResp, error) {
@@ -129,9 +129,9 @@ func (s *SvcImpl) ReqPayOrderOther(orderId string) (*OrderResp, error) {
 
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) ReqGetOrderPriceOther(orderId string) (*GetOrderPriceResp, error) {
@@ -147,9 +147,9 @@ func (s *SvcImpl) ReqGetOrderPriceOther(orderId string) (*GetOrderPriceResp, err
 
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) ReqQueryOrdersOther(input *Qi) (*OrderArrResp, error) {
@@ -165,9 +165,9 @@ func (s *SvcImpl) ReqQueryOrdersOther(input *Qi) (*OrderArrResp, error) {
 
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) ReqQueryOrderForRefreshOther(input *Qi) (*OrderArrResp, error) {
@@ -183,9 +183,9 @@ func (s *SvcImpl) ReqQueryOrderForRefreshOther(input *Qi) (*OrderArrResp, error)
 
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) ReqSecurityInfoCheckOther(checkDate string, accountId string) (*OrderSecurityResp, error) {
@@ -201,9 +201,9 @@ func (s *SvcImpl) ReqSecurityInfoCheckOther(checkDate string, accountId string)
 
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) ReqModifyOrderOther(orderId string, status int) (*OrderResp, error) {
@@ -219,9 +219,9 @@ func (s *SvcImpl) ReqModifyOrderOther(orderId string, status int) (*OrderResp, e
 
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) ReqGetTicketsListOther(input *Seat) (*TicketResp, error) {
@@ -237,9 +237,9 @@ func (s *SvcImpl) ReqGetTicketsListOther(input *Seat) (*TicketResp, error) {
 
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) ReqDeleteOrderOrderServiceOther(orderId string) (*OrderResp, error) {
@@ -255,9 +255,9 @@ func (s *SvcImpl) ReqDeleteOrderOrderServiceOther(orderId string) (*OrderResp, e
 
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) ReqGetOrderByIdOther(orderId string) (*OrderResp, error) {
@@ -273,9 +273,9 @@ func (s *SvcImpl) ReqGetOrderByIdOther(orderId string) (*OrderResp, error) {
 
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) ReqCalculateSoldTicketOther(travelDate string, travelNumber string) (*OrderResp, error) {
@@ -291,7 +291,7 @@ func (s *SvcImpl) ReqCalculateSoldTicketOther(travelDate string, travelNumber st
 
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
@@ -4,20 +4,20 @@ import (
 	"fmt"
 	"github.com/go-faker/faker/v4"
 	"github.com/google/uuid"
-	"math/rand"
 	"strconv"
 	"testing"
-	"time"
 )
 
 func TestSvcImpl_ReqFindAllOeder_Other(t *testing.T) {
-	cli, _ := GetAdminClient()
+	withCassette := useCassette(t)
+	cli, _ := GetAdminClient(withCassette)
 	GetResp, _ := cli.ReqFindAllOrderOther()
 	fmt.Println(GetResp.Msg)
 }
 
 func TestSvcImpl_ReqCreateNewOeder_Other(t *testing.T) {
-	cli, _ := GetAdminClient()
+	withCassette := useCassette(t)
+	cli, _ := GetAdminClient(withCassette)
 	AddResp, err := cli.ReqCreateNewOrderOther(&Order{
 		AccountId:              uuid.NewString(),
 		BoughtDate:             faker.Date(),
@@ -46,7 +46,8 @@ func TestSvcImpl_ReqCreateNewOeder_Other(t *testing.T) {
 }
 
 func TestSvcImpl_ReqSaveOrderInfo_Other(t *testing.T) {
-	cli, _ := GetAdminClient()
+	withCassette := useCassette(t)
+	cli, _ := GetAdminClient(withCassette)
 	UpdateResp, err := cli.ReqSaveOrderInfoOther(&Order{
 		AccountId:              uuid.NewString(),
 		BoughtDate:             faker.Date(),
@@ -73,7 +74,8 @@ func TestSvcImpl_ReqSaveOrderInfo_Other(t *testing.T) {
 }
 
 func TestSvcImpl_ReqAddCreateNewOrder_Other(t *testing.T) {
-	cli, _ := GetAdminClient()
+	withCassette := useCassette(t)
+	cli, _ := GetAdminClient(withCassette)
 	AddResp, err := cli.ReqAddCreateNewOrderOther(&Order{
 		AccountId:              uuid.NewString(),
 		BoughtDate:             faker.Date(),
@@ -102,7 +104,8 @@ func TestSvcImpl_ReqAddCreateNewOrder_Other(t *testing.T) {
 }
 
 func TestSvcImpl_ReqUpdateOrder_OrderService_Other(t *testing.T) {
-	cli, _ := GetAdminClient()
+	withCassette := useCassette(t)
+	cli, _ := GetAdminClient(withCassette)
 	UpdateResp, err := cli.ReqUpdateOrderOrderServiceOther(&Order{
 		AccountId:              uuid.NewString(),
 		BoughtDate:             faker.Date(),
@@ -129,7 +132,8 @@ func TestSvcImpl_ReqUpdateOrder_OrderService_Other(t *testing.T) {
 }
 
 func TestSvcImpl_ReqPayOrder_Other(t *testing.T) {
-	cli, _ := GetBasicClient()
+	withCassette := useCassette(t)
+	cli, _ := GetBasicClient(withCassette)
 	Resp, err := cli.ReqPayOrderOther("ee628cb0-6512-4dd0-ba1e-6eb5ccededaa")
 
 	if err != nil {
@@ -140,7 +144,8 @@ func TestSvcImpl_ReqPayOrder_Other(t *testing.T) {
 }
 
 func TestSvcImpl_ReqGetOrderPrice_Other(t *testing.T) {
-	cli, _ := GetAdminClient()
+	withCassette := useCassette(t)
+	cli, _ := GetAdminClient(withCassette)
 	Resp, err := cli.ReqGetOrderPriceOther("ee628cb0-6512-4dd0-ba1e-6eb5ccededaa")
 
 	if err != nil {
@@ -151,7 +156,8 @@ func TestSvcImpl_ReqGetOrderPrice_Other(t *testing.T) {
 }
 
 func TestSvcImpl_ReqQueryOrders_Other(t *testing.T) {
-	cli, _ := GetAdminClient()
+	withCassette := useCassette(t)
+	cli, _ := GetAdminClient(withCassette)
 	Resp, err := cli.ReqQueryOrdersOther(&Qi{
 		BoughtDateEnd:         faker.Date(),
 		BoughtDateStart:       faker.Date(),
@@ -171,7 +177,8 @@ func TestSvcImpl_ReqQueryOrders_Other(t *testing.T) {
 }
 
 func TestSvcImpl_ReqQueryOrderForRefresh_Other(t *testing.T) {
-	cli, _ := GetAdminClient()
+	withCassette := useCassette(t)
+	cli, _ := GetAdminClient(withCassette)
 	Resp, err := cli.ReqQueryOrderForRefreshOther(&Qi{
 		BoughtDateEnd:         faker.Date(),
 		BoughtDateStart:       faker.Date(),
@@ -191,7 +198,8 @@ func TestSvcImpl_ReqQueryOrderForRefresh_Other(t *testing.T) {
 }
 
 func TestSvcImpl_ReqSecurityInfoCheck_Other(t *testing.T) {
-	cli, _ := GetAdminClient()
+	withCassette := useCassette(t)
+	cli, _ := GetAdminClient(withCassette)
 	Resp, err := cli.ReqSecurityInfoCheckOther(faker.Date(), "4d2a46c7-71cb-4cf1-b5bb-b68406d9da6f")
 	if err != nil {
 		fmt.Println(err)
@@ -201,7 +209,8 @@ func TestSvcImpl_ReqSecurityInfoCheck_Other(t *testing.T) {
 }
 
 func TestSvcImpl_ReqModifyOrder_Other(t *testing.T) {
-	cli, _ := GetAdminClient()
+	withCassette := useCassette(t)
+	cli, _ := GetAdminClient(withCassette)
 	Resp, err := cli.ReqModifyOrderOther("ee628cb0-6512-4dd0-ba1e-6eb5ccededaa", 0)
 	if err != nil {
 		fmt.Println(err)
@@ -211,7 +220,8 @@ func TestSvcImpl_ReqModifyOrder_Other(t *testing.T) {
 }
 
 func TestSvcImpl_ReqGetTicketsList_Other(t *testing.T) {
-	cli, _ := GetAdminClient()
+	withCassette := useCassette(t)
+	cli, _ := GetAdminClient(withCassette)
 	Resp, err := cli.ReqGetTicketsListOther(&Seat{
 		DestStation:  RandomProvincialCapitalEN(),
 		SeatType:     2,
@@ -229,7 +239,8 @@ func TestSvcImpl_ReqGetTicketsList_Other(t *testing.T) {
 }
 
 func TestSvcImpl_ReqCalculateSoldTicket_Other(t *testing.T) {
-	cli, _ := GetAdminClient()
+	withCassette := useCassette(t)
+	cli, _ := GetAdminClient(withCassette)
 	Resp, err := cli.ReqCalculateSoldTicketOther(faker.Date(), GenerateTrainNumber())
 	if err != nil {
 		fmt.Println(err)
@@ -239,7 +250,8 @@ func TestSvcImpl_ReqCalculateSoldTicket_Other(t *testing.T) {
 }
 
 func TestSvcImpl_ReqGetOrderById_Other(t *testing.T) {
-	cli, _ := GetAdminClient()
+	withCassette := useCassette(t)
+	cli, _ := GetAdminClient(withCassette)
 	Resp, err := cli.ReqGetOrderByIdOther("ee628cb0-6512-4dd0-ba1e-6eb5ccededaa")
 	if err != nil {
 		fmt.Println(err)
@@ -249,7 +261,8 @@ func TestSvcImpl_ReqGetOrderById_Other(t *testing.T) {
 }
 
 func TestSvcImpl_ReqDeleteOrder_OrderService_Other(t *testing.T) {
-	cli, _ := GetAdminClient()
+	withCassette := useCassette(t)
+	cli, _ := GetAdminClient(withCassette)
 	Resp, err := cli.ReqDeleteOrderOrderServiceOther("4a766f5d-ef7c-4629-aef4-04492e247503")
 	if err != nil {
 		fmt.Println(err)
@@ -259,7 +272,8 @@ func TestSvcImpl_ReqDeleteOrder_OrderService_Other(t *testing.T) {
 }
 
 func TestSvcImpl_End2End_OrderOtherService(t *testing.T) {
-	cli, _ := GetAdminClient()
+	withCassette := useCassette(t)
+	cli, _ := GetAdminClient(withCassette)
 	var orderSvc OrderOtherService = cli
 
 	Resp, err := orderSvc.ReqFindAllOrderOther()
@@ -284,7 +298,7 @@ func TestSvcImpl_End2End_OrderOtherService(t *testing.T) {
 		Id:                     "nil",
 		Price:                  RandomDecimalStringBetween(1, 10),
 		SeatClass:              GetTrainTicketClass(),
-		SeatNumber:             rand.Intn(30),
+		SeatNumber:             random.Intn(30),
 		Status:                 0,
 		To:                     RandomProvincialCapitalEN(),
 		TrainNumber:            "G111",
@@ -327,7 +341,7 @@ func TestSvcImpl_End2End_OrderOtherService(t *testing.T) {
 		Id:                     returnedOrder0.Id,
 		Price:                  RandomDecimalStringBetween(1, 10),
 		SeatClass:              GetTrainTicketClass(),
-		SeatNumber:             rand.Intn(30),
+		SeatNumber:             random.Intn(30),
 		Status:                 0,
 		To:                     RandomProvincialCapitalEN(),
 		TrainNumber:            "G111",
@@ -381,7 +395,8 @@ func TestSvcImpl_End2End_OrderOtherService(t *testing.T) {
 }
 
 func TestSvcImpl_End2End_OrderOtherService_another(t *testing.T) {
-	cli, _ := GetAdminClient()
+	withCassette := useCassette(t)
+	cli, _ := GetAdminClient(withCassette)
 	var orderSvc OrderOtherService = cli
 
 	randomOrder := getRandomOrder_Other()
@@ -420,7 +435,7 @@ func TestSvcImpl_End2End_OrderOtherService_another(t *testing.T) {
 		Id:                     "nil",
 		Price:                  RandomDecimalStringBetween(1, 10),
 		SeatClass:              GetTrainTicketClass(),
-		SeatNumber:             rand.Intn(30),
+		SeatNumber:             random.Intn(30),
 		Status:                 0,
 		To:                     RandomProvincialCapitalEN(),
 		TrainNumber:            "G111",
@@ -481,11 +496,8 @@ func TestSvcImpl_End2End_OrderOtherService_another(t *testing.T) {
 
 	var stations []string
 
-	// 设置随机数种子，以确保每次运行程序时都能得到不同的随机数
-	rand.Seed(time.Now().UnixNano())
-
 	// 生成一个[0, 1)之间的浮点数
-	randomFloat := rand.Float64()
+	randomFloat := random.Float64()
 
 	// 如果随机数小于0.5，则执行if代码块；否则，执行else代码块
 	if randomFloat < 0.5 {
@@ -499,7 +511,7 @@ func TestSvcImpl_End2End_OrderOtherService_another(t *testing.T) {
 		SeatType:     randomOrder.SeatClass,
 		StartStation: randomOrder.From,
 		Stations:     stations,
-		TotalNum:     rand.Intn(10),
+		TotalNum:     random.Intn(10),
 		TrainNumber:  randomOrder.TrainNumber,
 		TravelDate:   randomOrder.TravelDate,
 	})
@@ -540,7 +552,7 @@ func TestSvcImpl_End2End_OrderOtherService_another(t *testing.T) {
 		Id:                     Resp8.Data.Id,
 		Price:                  RandomDecimalStringBetween(1, 10),
 		SeatClass:              GetTrainTicketClass(),
-		SeatNumber:             rand.Intn(30),
+		SeatNumber:             random.Intn(30),
 		Status:                 0,
 		To:                     RandomProvincialCapitalEN(),
 		TrainNumber:            "G111",
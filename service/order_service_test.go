@@ -2,16 +2,15 @@ package service
 
 import (
 	"fmt"
-	"math/rand"
 	"strconv"
 	"testing"
-	"time"
 
 	"github.com/go-faker/faker/v4"
 )
 
 func TestSvcImpl_End2End_OrderService(t *testing.T) {
-	cli, _ := GetAdminClient()
+	withCassette := useCassette(t)
+	cli, _ := GetAdminClient(withCassette)
 	var orderSvc OrderService = cli
 
 	Resp, err := orderSvc.ReqFindAllOrder()
@@ -36,7 +35,7 @@ func TestSvcImpl_End2End_OrderService(t *testing.T) {
 		Id:                     "nil",
 		Price:                  RandomDecimalStringBetween(1, 10),
 		SeatClass:              GetTrainTicketClass(),
-		SeatNumber:             rand.Intn(30),
+		SeatNumber:             random.Intn(30),
 		Status:                 0,
 		To:                     RandomProvincialCapitalEN(),
 		TrainNumber:            "G111",
@@ -79,7 +78,7 @@ func TestSvcImpl_End2End_OrderService(t *testing.T) {
 		Id:                     returnedOrder0.Id,
 		Price:                  RandomDecimalStringBetween(1, 10),
 		SeatClass:              GetTrainTicketClass(),
-		SeatNumber:             rand.Intn(30),
+		SeatNumber:             random.Intn(30),
 		Status:                 0,
 		To:                     RandomProvincialCapitalEN(),
 		TrainNumber:            "G111",
@@ -133,7 +132,8 @@ func TestSvcImpl_End2End_OrderService(t *testing.T) {
 }
 
 func TestSvcImpl_End2End_OrderService_another(t *testing.T) {
-	cli, _ := GetAdminClient()
+	withCassette := useCassette(t)
+	cli, _ := GetAdminClient(withCassette)
 	var orderSvc OrderService = cli
 
 	randomOrder := getRandomOrder()
@@ -172,7 +172,7 @@ func TestSvcImpl_End2End_OrderService_another(t *testing.T) {
 		Id:                     "nil",
 		Price:                  RandomDecimalStringBetween(1, 10),
 		SeatClass:              GetTrainTicketClass(),
-		SeatNumber:             rand.Intn(30),
+		SeatNumber:             random.Intn(30),
 		Status:                 0,
 		To:                     RandomProvincialCapitalEN(),
 		TrainNumber:            "G111",
@@ -233,11 +233,8 @@ func TestSvcImpl_End2End_OrderService_another(t *testing.T) {
 
 	var stations []string
 
-	// 设置随机数种子，以确保每次运行程序时都能得到不同的随机数
-	rand.Seed(time.Now().UnixNano())
-
 	// 生成一个[0, 1)之间的浮点数
-	randomFloat := rand.Float64()
+	randomFloat := random.Float64()
 
 	// 如果随机数小于0.5，则执行if代码块；否则，执行else代码块
 	if randomFloat < 0.5 {
@@ -251,7 +248,7 @@ func TestSvcImpl_End2End_OrderService_another(t *testing.T) {
 		SeatType:     randomOrder.SeatClass,
 		StartStation: randomOrder.From,
 		Stations:     stations,
-		TotalNum:     rand.Intn(10),
+		TotalNum:     random.Intn(10),
 		TrainNumber:  randomOrder.TrainNumber,
 		TravelDate:   randomOrder.TravelDate,
 	})
@@ -292,7 +289,7 @@ func TestSvcImpl_End2End_OrderService_another(t *testing.T) {
 		Id:                     Resp8.Data.Id,
 		Price:                  RandomDecimalStringBetween(1, 10),
 		SeatClass:              GetTrainTicketClass(),
-		SeatNumber:             rand.Intn(30),
+		SeatNumber:             random.Intn(30),
 		Status:                 0,
 		To:                     RandomProvincialCapitalEN(),
 		TrainNumber:            "G111",
@@ -2,9 +2,7 @@ package service
 
 import (
 	"fmt"
-	"math/rand"
 	"strconv"
-	"time"
 )
 
 // 中国省会城市的英文列表
@@ -18,20 +16,17 @@ var provincialCapitalsEN = []string{
 
 // RandomProvincialCapitalEN 随机返回一个中国省会城市的英文名称
 func RandomProvincialCapitalEN() string {
-	rand.Seed(time.Now().UnixNano())
-	return provincialCapitalsEN[rand.Intn(len(provincialCapitalsEN))]
+	return provincialCapitalsEN[random.Intn(len(provincialCapitalsEN))]
 }
 
 // RandomIntBetween 生成并返回两个整数之间的随机整数，包括边界值。
 func RandomIntBetween(min, max int) int {
-	rand.Seed(time.Now().UnixNano())
-	return rand.Intn(max-min+1) + min
+	return random.Intn(max-min+1) + min
 }
 
 // RandomDecimalStringBetween 生成并返回两个整数之间的一位小数形式的随机数字符串，包括边界值。
 func RandomDecimalStringBetween(min, max int) string {
-	rand.Seed(time.Now().UnixNano())
-	randomInt := rand.Intn(max-min+1) + min              // 生成[min, max]范围内的随机整数
+	randomInt := random.Intn(max-min+1) + min            // 生成[min, max]范围内的随机整数
 	decimalValue := float64(randomInt) * 0.1             // 将整数转换为一位小数
 	return strconv.FormatFloat(decimalValue, 'f', 1, 64) // 转换为一位小数的字符串形式
 }
@@ -39,18 +34,15 @@ func RandomDecimalStringBetween(min, max int) string {
 // GenerateTrainNumber 随机生成火车号次字符串。
 // 火车号次的格式为一个字符（G、U、D之一）后跟三位数字。
 func GenerateTrainNumber() string {
-	// 初始化随机数生成器
-	rand.Seed(time.Now().UnixNano())
-
 	// 可选的首字母集合
 	firstChars := []rune{'G', 'U', 'D'}
 	// 随机选择一个首字母
-	firstChar := firstChars[rand.Intn(len(firstChars))]
+	firstChar := firstChars[random.Intn(len(firstChars))]
 
 	// 生成后续的三位数字
 	var numStr string
 	for i := 0; i < 3; i++ {
-		numStr += fmt.Sprintf("%d", rand.Intn(10))
+		numStr += fmt.Sprintf("%d", random.Intn(10))
 	}
 
 	// 拼接首字母和数字部分
@@ -63,7 +55,7 @@ func GenerateTrainNumber() string {
 // 座位号的格式为一个字符（A、B、C、D、E之一）后跟两位数字。
 func GenerateSeatNumber() int {
 	// 初始化随机数生成器
-	return rand.Intn(30)
+	return random.Intn(30)
 }
 
 // GetTrainTicketClass 随机返回高铁票等级。
@@ -71,9 +63,7 @@ func GenerateSeatNumber() int {
 // 15%的概率返回"BusinessClass"（一等座），
 // 剩余80%的概率返回"EconomyClass"（二等座）。
 func GetTrainTicketClass() int {
-	rand.Seed(time.Now().UnixNano()) // 确保每次运行时随机数种子不同
-
-	probability := rand.Intn(100) // 生成0到99之间的随机数
+	probability := random.Intn(100) // 生成0到99之间的随机数
 
 	switch {
 	case probability < 5:
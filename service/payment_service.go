@@ -7,6 +7,13 @@ import (
 	"io"
 )
 
+// PaymentService defines the methods to pay for orders with the external payment service
+type PaymentService interface {
+	ReqPay(input *Payment) (*PaymentResponse, error)
+	ReqAddMoney(input *Payment) (*PaymentResponse, error)
+	ReqQueryPayment() (*PaymentArrResponse, error)
+}
+
 func (s *SvcImpl) ReqPay(input *Payment) (*PaymentResponse, error) {
 	resp, err := s.cli.SendRequest("POST", s.BaseUrl+"/api/v1/paymentservice/payment", input)
 	if err != nil {
@@ -20,9 +27,9 @@ func (s *SvcImpl) ReqPay(input *Payment) (*PaymentResponse, error) {
 
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) ReqAddMoney(input *Payment) (*PaymentResponse, error) {
@@ -38,9 +45,9 @@ func (s *SvcImpl) ReqAddMoney(input *Payment) (*PaymentResponse, error) {
 
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) ReqQueryPayment() (*PaymentArrResponse, error) {
@@ -56,7 +63,7 @@ func (s *SvcImpl) ReqQueryPayment() (*PaymentArrResponse, error) {
 
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
@@ -6,13 +6,15 @@ import (
 )
 
 func TestSvcImpl_ReqQueryPayment(t *testing.T) {
-	cli, _ := GetAdminClient()
+	withCassette := useCassette(t)
+	cli, _ := GetAdminClient(withCassette)
 	GetResp, _ := cli.ReqQueryPayment()
 	fmt.Println(GetResp.Msg)
 }
 
 func TestSvcImpl_ReqPay(t *testing.T) {
-	cli, _ := GetAdminClient()
+	withCassette := useCassette(t)
+	cli, _ := GetAdminClient(withCassette)
 	UpdateResp, err := cli.ReqPay(&Payment{
 		Id:      "7a9b4f0a-8105-4fa0-b4ca-781d477eea0e",
 		OrderId: "683479c1-757a-4a2c-8dfb-28ab474ee7ad",
@@ -26,7 +28,8 @@ func TestSvcImpl_ReqPay(t *testing.T) {
 }
 
 func TestSvcImpl_ReqAddMoney(t *testing.T) {
-	cli, _ := GetAdminClient()
+	withCassette := useCassette(t)
+	cli, _ := GetAdminClient(withCassette)
 	UpdateResp, err := cli.ReqPay(&Payment{
 		Id:      "7a9b4f0a-8105-4fa0-b4ca-781d477eea0e",
 		OrderId: "c157ad12-9f53-466c-9d54-924d547ad224",
@@ -1 +1,34 @@
 package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// PreserveOtherService defines the methods to book tickets of non-high-speed (Z/T/K) trips
+type PreserveOtherService interface {
+	PreserveOther(orderTicketsInfo *OrderTicketsInfo) (*PreserveResponse, error)
+}
+
+func (s *SvcImpl) PreserveOther(orderTicketsInfo *OrderTicketsInfo) (*PreserveResponse, error) {
+	url := fmt.Sprintf("%s/api/v1/preserveotherservice/preserveOther", s.BaseUrl)
+	resp, err := s.cli.SendRequest("POST", url, orderTicketsInfo)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var result PreserveResponse
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
+	}
+	return &result, checkResponse(resp, body)
+}
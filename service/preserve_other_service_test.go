@@ -1 +1,63 @@
 package service
+
+import (
+	"fmt"
+	"log"
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+)
+
+func TestSvcImpl_PreserveOther(t *testing.T) {
+	withCassette := useCassette(t)
+	cli, _ := GetBasicClient(withCassette)
+	var preserveOtherSvc PreserveOtherService = cli
+
+	loginResult, err := cli.ReqUserLogin(&UserLoginInfoReq{
+		Password:         "111111",
+		UserName:         "fdse_microservice",
+		VerificationCode: "123",
+	})
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	// Contacts of the logged-in account
+	var contactsSvc ContactsService = cli
+	contacts, err := contactsSvc.GetContactByAccountId(loginResult.Data.UserId)
+	if err != nil {
+		t.Fatalf("GetContactByAccountId failed: %v", err)
+	}
+	if len(contacts.Data) == 0 {
+		t.Skip("no contacts for the account")
+	}
+
+	// A Z/T/K trip served by travel2-service
+	var travel2Svc Travel2Service = cli
+	allTrip, err := travel2Svc.QueryAllTravel()
+	if err != nil {
+		t.Fatalf("QueryAllTravel failed: %v", err)
+	}
+	if len(allTrip.Data) == 0 {
+		t.Skip("no trips in travel2-service")
+	}
+	trip := allTrip.Data[0]
+
+	orderTicketsInfo := OrderTicketsInfo{
+		AccountID:  loginResult.Data.UserId,
+		ContactsID: contacts.Data[0].Id,
+		TripID:     trip.TripId.Type + trip.TripId.Number,
+		SeatType:   2,
+		LoginToken: loginResult.Data.Token,
+		Date:       faker.Date(),
+		From:       trip.StartStationName,
+		To:         trip.TerminalStationName,
+		Assurance:  0,
+		FoodType:   0,
+	}
+	result, err := preserveOtherSvc.PreserveOther(&orderTicketsInfo)
+	if err != nil {
+		t.Fatalf("PreserveOther failed: %v", err)
+	}
+	fmt.Println(result.Msg)
+}
@@ -58,7 +58,7 @@ func (s *SvcImpl) Preserve(orderTicketsInfo *OrderTicketsInfo) (*PreserveRespons
 	var result PreserveResponse
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
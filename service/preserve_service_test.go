@@ -9,7 +9,9 @@ import (
 )
 
 func TestSvcImpl_Preserve(t *testing.T) {
-	cli, _ := GetBasicClient()
+	requireCluster(t)
+	withCassette := useCassette(t)
+	cli, _ := GetBasicClient(withCassette)
 	var preserveSvc PreserveService = cli
 
 	// LoginToken
@@ -56,10 +56,10 @@ func (s *SvcImpl) FindByRouteIdAndTrainType(routeId, trainType string) (*AdminPr
 	var result AdminPriceResponse
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
 
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) FindByRouteIdsAndTrainTypes(ridsAndTts []string) (*AllPriceResponse, error) {
@@ -76,10 +76,10 @@ func (s *SvcImpl) FindByRouteIdsAndTrainTypes(ridsAndTts []string) (*AllPriceRes
 	var result AllPriceResponse
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
 
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) FindAllPriceConfig() (*AllPriceResponse, error) {
@@ -96,10 +96,10 @@ func (s *SvcImpl) FindAllPriceConfig() (*AllPriceResponse, error) {
 	var result AllPriceResponse
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
 
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) CreateNewPriceConfig(info *PriceConfig) (*AdminPriceResponse, error) {
@@ -116,10 +116,10 @@ func (s *SvcImpl) CreateNewPriceConfig(info *PriceConfig) (*AdminPriceResponse,
 	var result AdminPriceResponse
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
 
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) DeletePriceConfig(pricesId string) (*AdminPriceResponse, error) {
@@ -136,10 +136,10 @@ func (s *SvcImpl) DeletePriceConfig(pricesId string) (*AdminPriceResponse, error
 	var result AdminPriceResponse
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
 
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) UpdatePriceConfig(info *PriceConfig) (*AdminPriceResponse, error) {
@@ -156,8 +156,8 @@ func (s *SvcImpl) UpdatePriceConfig(info *PriceConfig) (*AdminPriceResponse, err
 	var result AdminPriceResponse
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
 
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
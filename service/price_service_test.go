@@ -1,14 +1,14 @@
 package service
 
 import (
-	"math/rand"
 	"testing"
 
 	"github.com/go-faker/faker/v4"
 )
 
 func TestSvcImpl_AddUpdateQueryDeletePrice(t *testing.T) {
-	cli, _ := GetAdminClient()
+	withCassette := useCassette(t)
+	cli, _ := GetAdminClient(withCassette)
 	var priceSvc PriceService = cli
 
 	MockedID := faker.UUIDHyphenated()
@@ -17,9 +17,9 @@ func TestSvcImpl_AddUpdateQueryDeletePrice(t *testing.T) {
 	// 定义可能的开头字母
 	letters := []rune{'Z', 'T', 'K', 'G', 'D'}
 	// 随机选择一个字母
-	startLetter := letters[rand.Intn(len(letters))]
+	startLetter := letters[random.Intn(len(letters))]
 	if startLetter == 'G' {
-		if rand.Intn(2) == 0 {
+		if random.Intn(2) == 0 {
 			MockedTrainType = "GaoTieOne"
 		} else {
 			MockedTrainType = "GaoTieTwo"
@@ -37,9 +37,9 @@ func TestSvcImpl_AddUpdateQueryDeletePrice(t *testing.T) {
 
 	MockedRouteID := faker.UUIDHyphenated()
 
-	MockedBasicPriceRate := rand.Float64()
+	MockedBasicPriceRate := random.Float64()
 
-	MockedFirstClassPriceRate := rand.Float64()
+	MockedFirstClassPriceRate := random.Float64()
 
 	// Create a new price config
 	createReq := &PriceConfig{
@@ -97,8 +97,8 @@ func TestSvcImpl_AddUpdateQueryDeletePrice(t *testing.T) {
 		ID:                  MockedID,
 		TrainType:           MockedTrainType,
 		RouteID:             MockedRouteID,
-		BasicPriceRate:      rand.Float64(),
-		FirstClassPriceRate: rand.Float64(),
+		BasicPriceRate:      random.Float64(),
+		FirstClassPriceRate: random.Float64(),
 	}
 	updateResp, err := priceSvc.UpdatePriceConfig(updateReq)
 	if err != nil {
@@ -0,0 +1,41 @@
+package service
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// random 是本包生成随机数据使用的随机数生成器，可以被多个 VU 并发使用。
+// 测试用 seed 固定它的序列，录制和回放 cassette 时发送相同的数据。
+var random = &lockedRand{r: rand.New(rand.NewSource(time.Now().UnixNano()))}
+
+type lockedRand struct {
+	mu sync.Mutex
+	r  *rand.Rand
+}
+
+func (l *lockedRand) Int() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.r.Int()
+}
+
+func (l *lockedRand) Intn(n int) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.r.Intn(n)
+}
+
+func (l *lockedRand) Float64() float64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.r.Float64()
+}
+
+// seed 以 seed 重新开始随机数序列。
+func (l *lockedRand) seed(seed int64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.r = rand.New(rand.NewSource(seed))
+}
@@ -1 +1,71 @@
 package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// RebookService defines the methods to move a paid order to another trip or seat class
+type RebookService interface {
+	ReqRebook(input *RebookInfo) (*RebookResp, error)
+	ReqRebookPayDifference(input *RebookInfo) (*RebookResp, error)
+}
+
+// RebookInfo represents a rebook request. The same body is sent again to pay the price difference.
+type RebookInfo struct {
+	LoginId   string `json:"loginId"`
+	OrderId   string `json:"orderId"`
+	OldTripId string `json:"oldTripId"`
+	TripId    string `json:"tripId"`
+	SeatType  int    `json:"seatType"`
+	Date      string `json:"date"`
+}
+
+// RebookResp represents the response of a rebook request; Data is the rebooked order
+type RebookResp struct {
+	Status int    `json:"status"`
+	Msg    string `json:"msg"`
+	Data   Order  `json:"data"`
+}
+
+// RebookStatusPayDifference is returned by ReqRebook when the new ticket is more expensive and
+// ReqRebookPayDifference has to be called to complete the rebook.
+const RebookStatusPayDifference = 2
+
+func (s *SvcImpl) ReqRebook(input *RebookInfo) (*RebookResp, error) {
+	resp, err := s.cli.SendRequest("POST", s.BaseUrl+"/api/v1/rebookservice/rebook", input)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	var result RebookResp
+
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
+	}
+	return &result, checkResponse(resp, body)
+}
+
+func (s *SvcImpl) ReqRebookPayDifference(input *RebookInfo) (*RebookResp, error) {
+	resp, err := s.cli.SendRequest("POST", s.BaseUrl+"/api/v1/rebookservice/rebook/difference", input)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	var result RebookResp
+
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
+	}
+	return &result, checkResponse(resp, body)
+}
@@ -1 +1,105 @@
 package service
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-faker/faker/v4"
+)
+
+// paidOrder books and pays a second class G1234 ticket from nanjing to shanghai for a new contact of the
+// basic user, which G1235 can rebook.
+func paidOrder(t *testing.T, opts ...ClientOption) (*SvcImpl, *Order) {
+	t.Helper()
+	cli, userId := GetBasicClient(opts...)
+	contact, err := cli.AddContact(&AdminContacts{
+		AccountId:      userId,
+		Name:           faker.Name(),
+		DocumentType:   1,
+		DocumentNumber: faker.UUIDDigit(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	date := time.Now().AddDate(0, 0, 1).Format(time.DateOnly)
+	if _, err := cli.Preserve(&OrderTicketsInfo{
+		AccountID:  userId,
+		ContactsID: contact.Data.Id,
+		TripID:     "G1234",
+		SeatType:   3,
+		Date:       date,
+		From:       "nanjing",
+		To:         "shanghai",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	orders, err := cli.ReqQueryOrders(&Qi{LoginId: userId, EnableStateQuery: true, State: 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var order *Order
+	for i := range orders.Data {
+		if orders.Data[i].TrainNumber == "G1234" && strings.HasPrefix(orders.Data[i].TravelDate, date) {
+			order = &orders.Data[i]
+		}
+	}
+	if order == nil {
+		t.Fatalf("preserved order not found in %+v", orders.Data)
+	}
+	if _, err := cli.ReqPayOrder(order.Id); err != nil {
+		t.Fatal(err)
+	}
+	return cli, order
+}
+
+func TestSvcImpl_ReqRebook(t *testing.T) {
+	withCassette := useCassette(t)
+	cli, order := paidOrder(t, withCassette)
+	input := &RebookInfo{
+		LoginId:   order.AccountId,
+		OrderId:   order.Id,
+		OldTripId: order.TrainNumber,
+		TripId:    "G1235",
+		SeatType:  3,
+		Date:      order.TravelDate,
+	}
+	resp, err := cli.ReqRebook(input)
+	var svcErr *Error
+	if errors.As(err, &svcErr) && svcErr.Status == RebookStatusPayDifference {
+		t.Skipf("G1235 is more expensive than G1234 on this deployment: %v", err)
+	}
+	if err != nil {
+		t.Fatalf("ReqRebook failed: %v", err)
+	}
+	if resp.Data.Id != order.Id || resp.Data.TrainNumber != "G1235" || resp.Data.SeatClass != 3 {
+		t.Errorf("Rebooked order = %+v, want order %s on G1235", resp.Data, order.Id)
+	}
+}
+
+func TestSvcImpl_ReqRebookPayDifference(t *testing.T) {
+	withCassette := useCassette(t)
+	cli, order := paidOrder(t, withCassette)
+	// A first class ticket costs more than the second class one, so the difference has to be paid.
+	input := &RebookInfo{
+		LoginId:   order.AccountId,
+		OrderId:   order.Id,
+		OldTripId: order.TrainNumber,
+		TripId:    "G1235",
+		SeatType:  2,
+		Date:      order.TravelDate,
+	}
+	_, err := cli.ReqRebook(input)
+	var svcErr *Error
+	if !errors.As(err, &svcErr) || svcErr.Status != RebookStatusPayDifference {
+		t.Fatalf("ReqRebook to first class err = %v, want status %d", err, RebookStatusPayDifference)
+	}
+	resp, err := cli.ReqRebookPayDifference(input)
+	if err != nil {
+		t.Fatalf("ReqRebookPayDifference failed: %v", err)
+	}
+	if resp.Data.Id != order.Id || resp.Data.TrainNumber != "G1235" || resp.Data.SeatClass != 2 {
+		t.Errorf("Rebooked order = %+v, want order %s on G1235 in first class", resp.Data, order.Id)
+	}
+}
@@ -47,9 +47,9 @@ func (s *SvcImpl) GetCheapestRoutes(input *RoutePlanInfo) (*RoutePlanResponse, e
 
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) GetQuickestRoutes(input *RoutePlanInfo) (*RoutePlanResponse, error) {
@@ -66,9 +66,9 @@ func (s *SvcImpl) GetQuickestRoutes(input *RoutePlanInfo) (*RoutePlanResponse, e
 
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) GetMinStopStations(input *RoutePlanInfo) (*RoutePlanResponse, error) {
@@ -85,7 +85,7 @@ func (s *SvcImpl) GetMinStopStations(input *RoutePlanInfo) (*RoutePlanResponse,
 
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
@@ -5,8 +5,9 @@ import (
 )
 
 func TestPlanService_FullIntegration(t *testing.T) {
+	withCassette := useCassette(t)
 	//Admin
-	cli, _ := GetAdminClient()
+	cli, _ := GetAdminClient(withCassette)
 
 	//Mock
 	var routeSvc RouteService = cli
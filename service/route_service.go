@@ -79,9 +79,9 @@ func (s *SvcImpl) CreateAndModifyRoute(input *RouteInfo) (*CreateRouteResponse,
 
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) DeleteRoute(routeId string) (*DeleteResponse, error) {
@@ -98,9 +98,9 @@ func (s *SvcImpl) DeleteRoute(routeId string) (*DeleteResponse, error) {
 
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) QueryRouteById(routeId string) (*CreateRouteResponse, error) {
@@ -117,9 +117,9 @@ func (s *SvcImpl) QueryRouteById(routeId string) (*CreateRouteResponse, error) {
 
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) QueryRoutesByIds(routeIds []string) (*QueryMultiResponse, error) {
@@ -137,9 +137,9 @@ func (s *SvcImpl) QueryRoutesByIds(routeIds []string) (*QueryMultiResponse, erro
 
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) QueryAllRoutes() (*QueryMultiResponse, error) {
@@ -156,9 +156,9 @@ func (s *SvcImpl) QueryAllRoutes() (*QueryMultiResponse, error) {
 
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) QueryRoutesByStartAndEnd(start, end string) (*QueryMultiResponse, error) {
@@ -176,7 +176,7 @@ func (s *SvcImpl) QueryRoutesByStartAndEnd(start, end string) (*QueryMultiRespon
 
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
@@ -3,12 +3,13 @@ package service
 import (
 	"fmt"
 	"github.com/go-faker/faker/v4"
-	"math/rand"
 	"testing"
 )
 
 func TestRouteService_FullIntegration(t *testing.T) {
-	cli, _ := GetAdminClient()
+	requireCluster(t)
+	withCassette := useCassette(t)
+	cli, _ := GetAdminClient(withCassette)
 	var routeSvc RouteService = cli
 
 	// Create
@@ -16,7 +17,7 @@ func TestRouteService_FullIntegration(t *testing.T) {
 	MockedStartStation := faker.GetRealAddress().City
 	MockedEndStation := faker.GetRealAddress().City
 	MockedStationList := fmt.Sprintf("%s,%s,%s", MockedStartStation, faker.GetRealAddress().City, MockedEndStation)
-	MockedDistanceList := fmt.Sprintf("%d,%d,%d", rand.Intn(30), rand.Intn(30), rand.Intn(30))
+	MockedDistanceList := fmt.Sprintf("%d,%d,%d", random.Intn(30), random.Intn(30), random.Intn(30))
 	input := RouteInfo{
 		ID:           MockedID,
 		StartStation: MockedStartStation,
@@ -49,9 +49,9 @@ func (s *SvcImpl) ReqSeatCreate(input *SeatCreateInfoReq) (*SeatCreateInfoResp,
 
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) ReqGetTicketLeft(input *SeatCreateInfoReq) (*TicketLeftResp, error) {
@@ -67,7 +67,7 @@ func (s *SvcImpl) ReqGetTicketLeft(input *SeatCreateInfoReq) (*TicketLeftResp, e
 
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
@@ -1,14 +1,13 @@
 package service
 
 import (
-	"math/rand"
 	"strconv"
 	"testing"
-	"time"
 )
 
 func TestSvcImpl_ReqSeatCreate(t *testing.T) {
-	cli, _ := GetBasicClient()
+	withCassette := useCassette(t)
+	cli, _ := GetBasicClient(withCassette)
 	resp, err := cli.ReqSeatCreate(&SeatCreateInfoReq{
 		TravelDate:  "2024-06-06 14:16:00",
 		TrainNumber: "777",
@@ -23,13 +22,13 @@ func TestSvcImpl_ReqSeatCreate(t *testing.T) {
 	t.Logf("create response: %+v", resp)
 
 	tranNumber := ""
-	randn := rand.Int() % 15
+	randn := random.Int() % 15
 	if randn > 7 {
-		tranNumber = "G" + strconv.Itoa(rand.Int()%100)
+		tranNumber = "G" + strconv.Itoa(random.Int()%100)
 	} else if randn > 3 {
-		tranNumber = "D" + strconv.Itoa(rand.Int()%100)
+		tranNumber = "D" + strconv.Itoa(random.Int()%100)
 	} else {
-		tranNumber = strconv.Itoa(rand.Int() % 100)
+		tranNumber = strconv.Itoa(random.Int() % 100)
 	}
 	t.Logf("tranNumber: %s", tranNumber)
 
@@ -54,18 +53,16 @@ func TestSvcImpl_ReqSeatCreate(t *testing.T) {
 }
 
 func TestSvcImpl_ReqSeatCreate_v2(t *testing.T) {
-	cli, _ := GetBasicClient()
+	withCassette := useCassette(t)
+	cli, _ := GetBasicClient(withCassette)
 	var seatSvc SeatService = cli
 
 	randomOrder := getRandomOrder()
 	var stations []string
-	totalNum := rand.Intn(10)
-
-	// 设置随机数种子，以确保每次运行程序时都能得到不同的随机数
-	rand.Seed(time.Now().UnixNano())
+	totalNum := random.Intn(10)
 
 	// 生成一个[0, 1)之间的浮点数
-	randomFloat := rand.Float64()
+	randomFloat := random.Float64()
 
 	// 如果随机数小于0.5，则执行if代码块；否则，执行else代码块
 	if randomFloat < 0.5 {
@@ -58,10 +58,10 @@ func (s *SvcImpl) FindAllSecurityConfig() (*FindAllResponse, error) {
 	var result FindAllResponse
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
 
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) AddNewSecurityConfig(config *SecurityConfig) (*SingleResponse, error) {
@@ -79,10 +79,10 @@ func (s *SvcImpl) AddNewSecurityConfig(config *SecurityConfig) (*SingleResponse,
 	var result SingleResponse
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
 
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) ModifySecurityConfig(config *SecurityConfig) (*SingleResponse, error) {
@@ -100,10 +100,10 @@ func (s *SvcImpl) ModifySecurityConfig(config *SecurityConfig) (*SingleResponse,
 	var result SingleResponse
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
 
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) DeleteSecurityConfig(id string) (*DeleteResponse, error) {
@@ -122,10 +122,10 @@ func (s *SvcImpl) DeleteSecurityConfig(id string) (*DeleteResponse, error) {
 	var result DeleteResponse
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
 
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) Check(accountId string) (*SingleResponse, error) {
@@ -144,8 +144,8 @@ func (s *SvcImpl) Check(accountId string) (*SingleResponse, error) {
 	var result SingleResponse
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
 
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
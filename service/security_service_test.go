@@ -7,7 +7,8 @@ import (
 )
 
 func TestSvcImpl_AddUpdateDeleteSecurityConfig(t *testing.T) {
-	cli, _ := GetAdminClient()
+	withCassette := useCassette(t)
+	cli, _ := GetAdminClient(withCassette)
 	var securitySvc SecurityService = cli
 
 	//MockedID := faker.UUIDHyphenated()
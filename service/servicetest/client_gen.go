@@ -0,0 +1,2171 @@
+// Code generated by gen.go; DO NOT EDIT.
+
+package servicetest
+
+import "github.com/Lincyaw/loadgenerator/service"
+
+var (
+	_ service.Client                  = (*Client)(nil)
+	_ service.AdminBasicInfoService   = (*Client)(nil)
+	_ service.AdminOrderService       = (*Client)(nil)
+	_ service.AdminRouteService       = (*Client)(nil)
+	_ service.AdminTravelService      = (*Client)(nil)
+	_ service.AdminUserService        = (*Client)(nil)
+	_ service.AssuranceService        = (*Client)(nil)
+	_ service.AuthService             = (*Client)(nil)
+	_ service.BasicService            = (*Client)(nil)
+	_ service.CancelService           = (*Client)(nil)
+	_ service.ConfigService           = (*Client)(nil)
+	_ service.ConsignPriceService     = (*Client)(nil)
+	_ service.ConsignService          = (*Client)(nil)
+	_ service.ContactsService         = (*Client)(nil)
+	_ service.DeliveryService         = (*Client)(nil)
+	_ service.ExecuteService          = (*Client)(nil)
+	_ service.FoodDeliveryService     = (*Client)(nil)
+	_ service.FoodService             = (*Client)(nil)
+	_ service.InsidePaymentService    = (*Client)(nil)
+	_ service.NotificationService     = (*Client)(nil)
+	_ service.OrderOtherService       = (*Client)(nil)
+	_ service.OrderService            = (*Client)(nil)
+	_ service.PaymentService          = (*Client)(nil)
+	_ service.PreserveOtherService    = (*Client)(nil)
+	_ service.PreserveService         = (*Client)(nil)
+	_ service.PriceService            = (*Client)(nil)
+	_ service.RebookService           = (*Client)(nil)
+	_ service.RoutePlanService        = (*Client)(nil)
+	_ service.RouteService            = (*Client)(nil)
+	_ service.SeatService             = (*Client)(nil)
+	_ service.SecurityService         = (*Client)(nil)
+	_ service.StationFoodService      = (*Client)(nil)
+	_ service.StationService          = (*Client)(nil)
+	_ service.TrainFoodService        = (*Client)(nil)
+	_ service.TrainService            = (*Client)(nil)
+	_ service.Travel2Service          = (*Client)(nil)
+	_ service.TravelService           = (*Client)(nil)
+	_ service.TravelplanService       = (*Client)(nil)
+	_ service.UserService             = (*Client)(nil)
+	_ service.VerificationCodeService = (*Client)(nil)
+	_ service.WaitOrderService        = (*Client)(nil)
+)
+
+// Client is a fake service.Client. Each method records the call and returns the result of the
+// method's Func field, or an empty response and a nil error when the field is nil.
+type Client struct {
+	recorder
+
+	// AdminBasicInfoService
+	AdminGetAllContactsFunc func() (*service.AdminGetContactsResp, error)
+	AdminDeleteContactFunc  func(contactsId string) (*service.AdminDeleteContactResp, error)
+	AdminModifyContactFunc  func(contacts *service.AdminContacts) (*service.AdminContactResponse, error)
+	AdminAddContactFunc     func(contacts *service.AdminContacts) (*service.AdminContactResponse, error)
+	AdminGetAllStationsFunc func() (*service.AdminStationResponse, error)
+	AdminDeleteStationFunc  func(id string) (*service.AdminDeleteResponse, error)
+	AdminModifyStationFunc  func(station *service.AdminStation) (*service.AdminStationResponse, error)
+	AdminAddStationFunc     func(station *service.AdminStation) (*service.AdminStationResponse, error)
+	AdminGetAllTrainsFunc   func() (*service.AdminTrainResponse, error)
+	AdminDeleteTrainFunc    func(id string) (*service.AdminTrainResponse, error)
+	AdminModifyTrainFunc    func(train *service.AdminTrainType) (*service.AdminTrainResponse, error)
+	AdminAddTrainFunc       func(train *service.AdminTrainType) (*service.AdminTrainResponse, error)
+	AdminGetAllConfigsFunc  func() (*service.AdminConfigResponse, error)
+	AdminDeleteConfigFunc   func(name string) (*service.AdminConfigResponse, error)
+	AdminModifyConfigFunc   func(config *service.AdminConfig) (*service.AdminConfigResponse, error)
+	AdminAddConfigFunc      func(config *service.AdminConfig) (*service.AdminConfigResponse, error)
+	AdminGetAllPricesFunc   func() (*service.AdminPriceResponse, error)
+	AdminDeletePriceFunc    func(pricesId string) (*service.AdminPriceResponse, error)
+	AdminModifyPriceFunc    func(price *service.AdminPriceInfo) (*service.AdminPriceResponse, error)
+	AdminAddPriceFunc       func(price *service.AdminPriceInfo) (*service.AdminPriceResponse, error)
+
+	// AdminOrderService
+	ReqGetAllOrdersFunc func() (*service.OrderArrResp, error)
+	ReqAddOrderFunc     func(input *service.Order) (*service.OrderResp, error)
+	ReqUpdateOrderFunc  func(input *service.Order) (*service.OrderResp, error)
+	ReqDeleteOrderFunc  func(orderId string, trainNumber string) (*service.ReqDeleteOrderResponse, error)
+
+	// AdminRouteService
+	ReqGetAllRoutesFunc func() (*service.AdminRouteInfoResp, error)
+	ReqAddRouteFunc     func(input *service.AdminRouteInfo) (*service.AdminAddResponse, error)
+	ReqDeleteRouteFunc  func(routeId string) (*service.AdminRouteDeleteInfoResp, error)
+
+	// AdminTravelService
+	CreateTravelFunc  func(request *service.AdminTravelInfo) (*service.AdminTravelResponse, error)
+	UpdateTravelFunc  func(request *service.AdminTravelInfo) (*service.AdminTravelResponse, error)
+	DeleteTravelFunc  func(tripId string) (*service.AdminTravelResponse, error)
+	GetAllTravelsFunc func() ([]service.AdminTravelInfo, error)
+
+	// AdminUserService
+	AdminAddUserFunc     func(user *service.AdminUserDto) (*service.AdminUserResponse, error)
+	AdminUpdateUserFunc  func(user *service.AdminUserDto) (*service.AdminUserResponse, error)
+	AdminDeleteUserFunc  func(userId string) (*service.AdminDeleteResponseUser, error)
+	AdminGetAllUsersFunc func() (*service.AllUserResponseUser, error)
+
+	// AssuranceService
+	GetAllAssurancesFunc         func() (*service.GetAllAssuranceResponse, error)
+	GetAllAssuranceTypesFunc     func() (*service.GetallAssuranceType, error)
+	DeleteAssuranceByIDFunc      func(assuranceID string) (*service.AssuranceDeleteResponse, error)
+	DeleteAssuranceByOrderIDFunc func(orderID string) (*service.DeleteAssuranceByOrderIDResponse, error)
+	ModifyAssuranceFunc          func(assuranceID string, orderID string, typeIndex int) (*service.Modify_Response, error)
+	CreateNewAssuranceFunc       func(typeIndex int, orderID string) (*service.CreateAssuranceResponse, error)
+	GetAssuranceByIDFunc         func(assuranceID string) (*service.GetAssuranceByIDeInfo, error)
+	FindAssuranceByOrderIDFunc   func(orderId string) (*service.GetAssuranceByIDeInfo, error)
+
+	// AuthService
+	ReqUserLoginFunc        func(input *service.UserLoginInfoReq) (*service.UserLoginInfoResp, error)
+	LoginWithVerifyCodeFunc func(username string, password string, solve service.VerifyCodeSolver) (*service.UserLoginInfoResp, error)
+	ReqUserCreateFunc       func(input *service.UserCreateInfoReq) (*service.UserCreateInfoResp, error)
+	ReqUserDeleteFunc       func(userid string) (*service.UserDeleteInfoResp, error)
+
+	// BasicService
+	QueryForTravelFunc    func(info *service.Travel) (*service.QueryForTravelResponse, error)
+	QueryForTravelsFunc   func(infos []*service.Travel) (*service.QueryForTravelsResponse, error)
+	QueryForStationIdFunc func(stationName string) (*service.QueryForStationIdResponse, error)
+	QueryTrainServiceFunc func() (*service.TrainResponseType, error)
+
+	// CancelService
+	ReqCalculateFunc    func(orderId string) (*service.DataStringResp, error)
+	ReqCancelTicketFunc func(orderId string, loginId string) (*service.DataStringResp, error)
+
+	// ConfigService
+	QueryAllConfigsFunc             func() (*service.ConfigQueryAllConfigsResponse, error)
+	CreateConfigFunc                func(info *service.Config_config) (*service.CreateConfigResponse, error)
+	UpdateConfigFunc                func(info service.Config_config) (*service.UpdateConfigResponse, error)
+	DeleteConfig_config_serviceFunc func(configName string) (*service.DeleteConfig_config_serviceResponse, error)
+	RetrieveConfigFunc              func(configName string) (*service.RetrieveConfigResponse, error)
+
+	// ConsignPriceService
+	GetPriceByWeightAndRegionFunc func(weight string, isWithinRegion string) (*service.ConsignPriceResponse, error)
+	GetPriceInfoFunc              func() (*service.GetResponse, error)
+	GetPriceConfigFunc            func() (*service.GetPriceConfigResponse, error)
+	ModifyPriceConfigFunc         func(priceConfig *service.ConsignPrice) (*service.ModifyConsignPriceResponse, error)
+
+	// ConsignService
+	InsertConsignRecordFunc func(consign *service.Consign) (*service.ConsignResponse, error)
+	UpdateConsignRecordFunc func(consign *service.Consign) (*service.ConsignResponse, error)
+	QueryByAccountIdFunc    func(accountId string) (*service.AllConsignResponse, error)
+	QueryByOrderIdFunc      func(orderId string) (*service.QueryByOrderIdResponse, error)
+	QueryByConsigneeFunc    func(consignee string) (*service.QueryByConsigneeResponse, error)
+
+	// ContactsService
+	GetAllContactsFunc        func() (*service.AdminGetContactsResp, error)
+	AddContactFunc            func(contacts *service.AdminContacts) (*service.AdminContactResponse, error)
+	AddAdminContactFunc       func(contacts *service.AdminContacts) (*service.AdminContactResponse, error)
+	ModifyContactFunc         func(contacts *service.AdminContacts) (*service.AdminContactResponse, error)
+	DeleteContactFunc         func(contactsId string) (*service.DeleteContactsResp, error)
+	GetContactByContactIdFunc func(contactsId string) (*service.AdminContactResponse, error)
+	GetContactByAccountIdFunc func(accountId string) (*service.GetContactsByAccountIdResp, error)
+
+	// DeliveryService
+	ReqGetDeliveriesByOrderIdFunc func(orderId string) (*service.DeliveryArrResp, error)
+	ReqGetDeliveriesByStationFunc func(stationName string) (*service.DeliveryArrResp, error)
+	ReqUpdateDeliveryStatusFunc   func(input *service.DeliveryStatusInfo) (*service.DeliveryResp, error)
+
+	// ExecuteService
+	ReqExecuteTicketFunc func(orderId string) (*service.DataStringResp, error)
+	ReqCollectTicketFunc func(orderId string) (*service.DataStringResp, error)
+
+	// FoodDeliveryService
+	ReqCreateFoodDeliveryOrderFunc       func(input *service.FoodDeliveryOrder) (*service.FoodDeliveryOrderResponse, error)
+	ReqGetAllFoodDeliveryOrdersFunc      func() (*service.FoodDeliveryOrderArrResponse, error)
+	ReqGetFoodDeliveryOrderByStoreIdFunc func(storeId string) (*service.FoodDeliveryOrderArrResponse, error)
+	ReqGetFoodDeliveryOrderByIdFunc      func(orderId string) (*service.FoodDeliveryOrderResponse, error)
+	ReqDeleteFoodDeliveryOrderByIdFunc   func(orderId string) (*service.DataStringResp, error)
+	ReqUpdateDeliveryTimeFunc            func(input *service.DeliveryInfo) (*service.FoodDeliveryOrderResponse, error)
+	ReqUpdateSeatNoFunc                  func(input *service.SeatInfo) (*service.FoodDeliveryOrderResponse, error)
+	ReqUpdateTripIdFunc                  func(input *service.TripOrderInfo) (*service.FoodDeliveryOrderResponse, error)
+
+	// FoodService
+	FindAllFoodOrderFunc        func() (*service.FindAllFoodOrder, error)
+	CreateFoodOrderFunc         func(foodOrder *service.FoodOrder) (*service.CreateFoodOrderResp, error)
+	CreateFoodOrdersInBatchFunc func(foodOrders []service.FoodOrder) (*service.CreateFoodOrdersInBatch, error)
+	UpdateFoodOrderFunc         func(foodOrder *service.FoodOrder) (*service.FoodOrder, error)
+	DeleteFoodOrderFunc         func(orderID string) (*service.DeleteFoodOrderResp, error)
+	FindByOrderIdFunc           func(orderID string) (*service.FindByOrderIdResponse, error)
+	GetAllFoodFunc              func(date string, startStation string, endStation string, tripID string) (*service.GetAllFoodResponse, error)
+
+	// InsidePaymentService
+	ReqPay_InsidePaymentFunc  func(input *service.TripPayment) (*service.TripPaymentResponse, error)
+	ReqCreateAccountFunc      func(input *service.AccountInfo) (*service.TripPaymentResponse, error)
+	ReqPayDifferenceFunc      func(input *service.TripPayment) (*service.TripPaymentResponse, error)
+	ReqQueryAccountFunc       func() (*service.TripPaymentArrResponse, error)
+	ReqDrawBackFunc           func(userId string, money string) (*service.MoneyResponse, error)
+	ReqQueryAddMoneyFunc      func() (*service.MoneyResponse, error)
+	ReqQueryInsidePaymentFunc func() (*service.TripPaymentArrResponse, error)
+	ReqAddMoney_InsideFunc    func(userId string, money string) (*service.TripPaymentResponse, error)
+
+	// NotificationService
+	ReqOrderCancelSuccessFunc  func(input *service.TicketOrder) (*bool, error)
+	ReqOrderChangedSuccessFunc func(input *service.TicketOrder) (*bool, error)
+	ReqOrderCreateSuccessFunc  func(input *service.TicketOrder) (*bool, error)
+	ReqPreserveSuccessFunc     func(input *service.TicketOrder) (*bool, error)
+	ReqTestSendMailFunc        func() (*bool, error)
+	ReqTestSendFunc            func() (*bool, error)
+
+	// OrderOtherService
+	ReqFindAllOrderOtherFunc            func() (*service.OrderArrResp, error)
+	ReqCreateNewOrderOtherFunc          func(input *service.Order) (*service.OrderResp, error)
+	ReqSaveOrderInfoOtherFunc           func(input *service.Order) (*service.OrderResp, error)
+	ReqAddCreateNewOrderOtherFunc       func(input *service.Order) (*service.OrderResp, error)
+	ReqUpdateOrderOrderServiceOtherFunc func(input *service.Order) (*service.OrderResp, error)
+	ReqPayOrderOtherFunc                func(orderId string) (*service.OrderResp, error)
+	ReqGetOrderPriceOtherFunc           func(orderId string) (*service.GetOrderPriceResp, error)
+	ReqQueryOrdersOtherFunc             func(input *service.Qi) (*service.OrderArrResp, error)
+	ReqQueryOrderForRefreshOtherFunc    func(input *service.Qi) (*service.OrderArrResp, error)
+	ReqSecurityInfoCheckOtherFunc       func(checkDate string, accountId string) (*service.OrderSecurityResp, error)
+	ReqModifyOrderOtherFunc             func(orderId string, status int) (*service.OrderResp, error)
+	ReqGetTicketsListOtherFunc          func(input *service.Seat) (*service.TicketResp, error)
+	ReqDeleteOrderOrderServiceOtherFunc func(orderId string) (*service.OrderResp, error)
+	ReqGetOrderByIdOtherFunc            func(orderId string) (*service.OrderResp, error)
+	ReqCalculateSoldTicketOtherFunc     func(travelDate string, travelNumber string) (*service.OrderResp, error)
+
+	// OrderService
+	ReqFindAllOrderFunc             func() (*service.OrderArrResp, error)
+	ReqCreateNewOrderFunc           func(input *service.Order) (*service.OrderResp, error)
+	ReqSaveOrderInfoFunc            func(input *service.Order) (*service.OrderResp, error)
+	ReqAddCreateNewOrderFunc        func(input *service.Order) (*service.OrderResp, error)
+	ReqUpdateOrder_OrderServiceFunc func(input *service.Order) (*service.OrderResp, error)
+	ReqPayOrderFunc                 func(orderId string) (*service.OrderResp, error)
+	ReqGetOrderPriceFunc            func(orderId string) (*service.GetOrderPriceResp, error)
+	ReqQueryOrdersFunc              func(input *service.Qi) (*service.OrderArrResp, error)
+	ReqQueryOrderForRefreshFunc     func(input *service.Qi) (*service.OrderArrResp, error)
+	ReqSecurityInfoCheckFunc        func(checkDate string, accountId string) (*service.OrderSecurityResp, error)
+	ReqModifyOrderFunc              func(orderId string, status int) (*service.OrderResp, error)
+	ReqGetTicketsListFunc           func(input *service.Seat) (*service.TicketResp, error)
+	ReqDeleteOrder_OrderServiceFunc func(orderId string) (*service.OrderResp, error)
+	ReqGetOrderByIdFunc             func(orderId string) (*service.OrderResp, error)
+	ReqCalculateSoldTicketFunc      func(travelDate string, travelNumber string) (*service.OrderResp, error)
+
+	// PaymentService
+	ReqPayFunc          func(input *service.Payment) (*service.PaymentResponse, error)
+	ReqAddMoneyFunc     func(input *service.Payment) (*service.PaymentResponse, error)
+	ReqQueryPaymentFunc func() (*service.PaymentArrResponse, error)
+
+	// PreserveOtherService
+	PreserveOtherFunc func(orderTicketsInfo *service.OrderTicketsInfo) (*service.PreserveResponse, error)
+
+	// PreserveService
+	PreserveFunc func(orderTicketsInfo *service.OrderTicketsInfo) (*service.PreserveResponse, error)
+
+	// PriceService
+	FindByRouteIdAndTrainTypeFunc   func(routeId string, trainType string) (*service.AdminPriceResponse, error)
+	FindByRouteIdsAndTrainTypesFunc func(ridsAndTts []string) (*service.AllPriceResponse, error)
+	FindAllPriceConfigFunc          func() (*service.AllPriceResponse, error)
+	CreateNewPriceConfigFunc        func(info *service.PriceConfig) (*service.AdminPriceResponse, error)
+	DeletePriceConfigFunc           func(pricesId string) (*service.AdminPriceResponse, error)
+	UpdatePriceConfigFunc           func(info *service.PriceConfig) (*service.AdminPriceResponse, error)
+
+	// RebookService
+	ReqRebookFunc              func(input *service.RebookInfo) (*service.RebookResp, error)
+	ReqRebookPayDifferenceFunc func(input *service.RebookInfo) (*service.RebookResp, error)
+
+	// RoutePlanService
+	GetCheapestRoutesFunc  func(input *service.RoutePlanInfo) (*service.RoutePlanResponse, error)
+	GetQuickestRoutesFunc  func(input *service.RoutePlanInfo) (*service.RoutePlanResponse, error)
+	GetMinStopStationsFunc func(input *service.RoutePlanInfo) (*service.RoutePlanResponse, error)
+
+	// RouteService
+	CreateAndModifyRouteFunc     func(input *service.RouteInfo) (*service.CreateRouteResponse, error)
+	DeleteRouteFunc              func(routeId string) (*service.DeleteResponse, error)
+	QueryRouteByIdFunc           func(routeId string) (*service.CreateRouteResponse, error)
+	QueryRoutesByIdsFunc         func(routeIds []string) (*service.QueryMultiResponse, error)
+	QueryAllRoutesFunc           func() (*service.QueryMultiResponse, error)
+	QueryRoutesByStartAndEndFunc func(start string, end string) (*service.QueryMultiResponse, error)
+
+	// SeatService
+	ReqSeatCreateFunc    func(input *service.SeatCreateInfoReq) (*service.SeatCreateInfoResp, error)
+	ReqGetTicketLeftFunc func(input *service.SeatCreateInfoReq) (*service.TicketLeftResp, error)
+
+	// SecurityService
+	FindAllSecurityConfigFunc func() (*service.FindAllResponse, error)
+	AddNewSecurityConfigFunc  func(config *service.SecurityConfig) (*service.SingleResponse, error)
+	ModifySecurityConfigFunc  func(config *service.SecurityConfig) (*service.SingleResponse, error)
+	DeleteSecurityConfigFunc  func(id string) (*service.DeleteResponse, error)
+	CheckFunc                 func(accountId string) (*service.SingleResponse, error)
+
+	// StationFoodService
+	GetAllStationFoodFunc     func() (*service.GetStationFoodResp, error)
+	GetStationFoodByNameFunc  func(stationName string) (*service.GetStationFoodResp, error)
+	GetStationFoodByNamesFunc func(stationNames []string) (*service.GetStationFoodResp, error)
+	GetStationFoodByIdFunc    func(storeId string) (*service.GetStationFoodSingleResp, error)
+
+	// StationService
+	QueryStationsFunc          func() (*service.GetStationResponse, error)
+	CreateStationFunc          func(input *service.Station) (*service.StationCreateResponse, error)
+	UpdateStationFunc          func(input *service.Station) (*service.StationUpdateResponse, error)
+	DeleteStationFunc          func(stationId string) (*service.DeleteStationResponse, error)
+	QueryStationIdByNameFunc   func(stationName string) (*service.StationQueryIdByNameResponse, error)
+	QueryStationIdsByNamesFunc func(stationNameList []string) (*service.QueryStationIdsByNamesResponse, error)
+	QueryStationNameByIdFunc   func(stationId string) (*service.QueryStationNameByIdResponse, error)
+	QueryStationNamesByIdsFunc func(stationIdList []string) (*service.QueryStationNamesByIdsResponse, error)
+
+	// TrainFoodService
+	GetAllTrainFoodFunc      func() (*service.GetTrainFoodResp, error)
+	GetTrainFoodByTripIdFunc func(tripId string) (*service.GetTrainFoodByIdResp, error)
+
+	// TrainService
+	CreateFunc          func(trainType *service.TrainType) (*service.CreateStationResponse, error)
+	RetrieveFunc        func(id string) (*service.TrainServiceRetrieveTrainType, error)
+	RetrieveByNameFunc  func(name string) (*service.TrainRetrieveByNameType, error)
+	RetrieveByNamesFunc func(names []string) (*service.TrainRetrieveByNamesType, error)
+	UpdateFunc          func(trainType *service.TrainType) (*service.TrainUpdateResponse, error)
+	DeleteFunc          func(id string) (*service.TrainDeleteResponse, error)
+	QueryFunc           func() (*service.TrainResponseType, error)
+
+	// Travel2Service
+	GetTrain2TypeByTripIdFunc func(tripId string) (*service.GetTrainTypeByTripId2Response, error)
+	GetRouteByTrip2IdFunc     func(tripId string) (*service.GetRouteByTripIdResponse, error)
+	GetTrip2ByRouteFunc       func(routeIds []string) (*service.GetTripByRouteIdResponse, error)
+	CreateTrip2Func           func(travelInfo *service.TravelInfo) (*service.CreateTripResponse, error)
+	RetrieveTrip2Func         func(tripId string) (*service.RetrieveTripResponse, error)
+	UpdateTrip2Func           func(travelInfo *service.TravelInfo) (*service.UpdateTripResponse, error)
+	DeleteTrip2Func           func(tripId string) (*service.DeleteTripResponse, error)
+	QueryByBatchFunc          func(tripInfo *service.TripInfo) (*service.QueryByBatchResponse, error)
+	GetTrip2AllDetailInfoFunc func(tripAllDetailInfo *service.Trip2AllDetailInfo) (*service.GetTripAllDetailInfoResponse, error)
+	QueryAllTravelFunc        func() (*service.QueryAllResponse, error)
+	AdminQueryAllTravelFunc   func() (*service.AdminQueryAllResponse, error)
+
+	// TravelService
+	GetTrainTypeByTripIdFunc func(tripId string) (*service.GetTrainTypeByTripIdResponse, error)
+	GetRouteByTripIdFunc     func(tripId string) (*service.GetRouteByTripIdResponse, error)
+	GetTripsByRouteIdFunc    func(routeIds []string) (*service.GetTripsByRouteIdResponse, error)
+	CreateTripFunc           func(travelInfo *service.TravelInfo) (*service.TripResponse, error)
+	RetrieveTravelFunc       func(tripId string) (*service.TravelInfo, error)
+	UpdateTripFunc           func(travelInfo *service.TravelInfo) (*service.TripResponse, error)
+	DeleteTripFunc           func(tripId string) (*service.DeleteTripResponse, error)
+	QueryInfoFunc            func(tripInfo service.TripInfo) (*service.QueryInfoResponse, error)
+	QueryInfoInParallelFunc  func(tripInfo service.TripInfo) (*service.QueryInfoInParallelTripResponse, error)
+	GetTripAllDetailInfoFunc func(tripId service.GetTripDetailReq) (*service.GetTripAllDetailInfoResponse, error)
+	QueryAllTripFunc         func() (*service.QueryAllTravelInfo, error)
+	AdminQueryAllFunc        func() (*service.AdminQueryAllTravelInfo, error)
+
+	// TravelplanService
+	ReqGetByCheapestFunc   func(input *service.TravelQueryInfo) (*service.TravelQueryArrResponse, error)
+	ReqGetByMinStationFunc func(input *service.TravelQueryInfo) (*service.TravelQueryArrResponse, error)
+	ReqGetByQuickestFunc   func(input *service.TravelQueryInfo) (*service.TravelQueryArrResponse, error)
+	ReqTransferResultFunc  func(input *service.TransferTravelQueryInfo) (*service.TravelQueryResponse, error)
+
+	// UserService
+	GetAllUsersFunc       func() (*service.GetAllUserResponse, error)
+	GetUserByUserNameFunc func(userName string) (*service.SingleUserResponse, error)
+	GetUserByUserIdFunc   func(userId string) (*service.SingleUserResponse, error)
+	RegisterUserFunc      func(userDto *service.AdminUserDto) (*service.SingleUserResponse, error)
+	DeleteUserFunc        func(userId string) (*service.SingleUserResponse, error)
+	UpdateUserFunc        func(user *service.AdminUserDto) (*service.SingleUserResponse, error)
+
+	// VerificationCodeService
+	GenerateVerifyCodeFunc func() (*service.VerifyCodeImage, error)
+	VerifyCodeFunc         func(verifyCode string) (bool, error)
+
+	// WaitOrderService
+	ReqCreateNewWaitOrderFunc func(input *service.OrderVO) (*service.OrderResp, error)
+	ReqGetAllWaitOrderFunc    func() (*service.OrderArrResp, error)
+	ReqGetWaitListOrdersFunc  func() (*service.OrderArrResp, error)
+}
+
+func (c *Client) AdminGetAllContacts() (*service.AdminGetContactsResp, error) {
+	c.record("AdminGetAllContacts")
+	if c.AdminGetAllContactsFunc != nil {
+		return c.AdminGetAllContactsFunc()
+	}
+	return new(service.AdminGetContactsResp), nil
+}
+
+func (c *Client) AdminDeleteContact(contactsId string) (*service.AdminDeleteContactResp, error) {
+	c.record("AdminDeleteContact", contactsId)
+	if c.AdminDeleteContactFunc != nil {
+		return c.AdminDeleteContactFunc(contactsId)
+	}
+	return new(service.AdminDeleteContactResp), nil
+}
+
+func (c *Client) AdminModifyContact(contacts *service.AdminContacts) (*service.AdminContactResponse, error) {
+	c.record("AdminModifyContact", contacts)
+	if c.AdminModifyContactFunc != nil {
+		return c.AdminModifyContactFunc(contacts)
+	}
+	return new(service.AdminContactResponse), nil
+}
+
+func (c *Client) AdminAddContact(contacts *service.AdminContacts) (*service.AdminContactResponse, error) {
+	c.record("AdminAddContact", contacts)
+	if c.AdminAddContactFunc != nil {
+		return c.AdminAddContactFunc(contacts)
+	}
+	return new(service.AdminContactResponse), nil
+}
+
+func (c *Client) AdminGetAllStations() (*service.AdminStationResponse, error) {
+	c.record("AdminGetAllStations")
+	if c.AdminGetAllStationsFunc != nil {
+		return c.AdminGetAllStationsFunc()
+	}
+	return new(service.AdminStationResponse), nil
+}
+
+func (c *Client) AdminDeleteStation(id string) (*service.AdminDeleteResponse, error) {
+	c.record("AdminDeleteStation", id)
+	if c.AdminDeleteStationFunc != nil {
+		return c.AdminDeleteStationFunc(id)
+	}
+	return new(service.AdminDeleteResponse), nil
+}
+
+func (c *Client) AdminModifyStation(station *service.AdminStation) (*service.AdminStationResponse, error) {
+	c.record("AdminModifyStation", station)
+	if c.AdminModifyStationFunc != nil {
+		return c.AdminModifyStationFunc(station)
+	}
+	return new(service.AdminStationResponse), nil
+}
+
+func (c *Client) AdminAddStation(station *service.AdminStation) (*service.AdminStationResponse, error) {
+	c.record("AdminAddStation", station)
+	if c.AdminAddStationFunc != nil {
+		return c.AdminAddStationFunc(station)
+	}
+	return new(service.AdminStationResponse), nil
+}
+
+func (c *Client) AdminGetAllTrains() (*service.AdminTrainResponse, error) {
+	c.record("AdminGetAllTrains")
+	if c.AdminGetAllTrainsFunc != nil {
+		return c.AdminGetAllTrainsFunc()
+	}
+	return new(service.AdminTrainResponse), nil
+}
+
+func (c *Client) AdminDeleteTrain(id string) (*service.AdminTrainResponse, error) {
+	c.record("AdminDeleteTrain", id)
+	if c.AdminDeleteTrainFunc != nil {
+		return c.AdminDeleteTrainFunc(id)
+	}
+	return new(service.AdminTrainResponse), nil
+}
+
+func (c *Client) AdminModifyTrain(train *service.AdminTrainType) (*service.AdminTrainResponse, error) {
+	c.record("AdminModifyTrain", train)
+	if c.AdminModifyTrainFunc != nil {
+		return c.AdminModifyTrainFunc(train)
+	}
+	return new(service.AdminTrainResponse), nil
+}
+
+func (c *Client) AdminAddTrain(train *service.AdminTrainType) (*service.AdminTrainResponse, error) {
+	c.record("AdminAddTrain", train)
+	if c.AdminAddTrainFunc != nil {
+		return c.AdminAddTrainFunc(train)
+	}
+	return new(service.AdminTrainResponse), nil
+}
+
+func (c *Client) AdminGetAllConfigs() (*service.AdminConfigResponse, error) {
+	c.record("AdminGetAllConfigs")
+	if c.AdminGetAllConfigsFunc != nil {
+		return c.AdminGetAllConfigsFunc()
+	}
+	return new(service.AdminConfigResponse), nil
+}
+
+func (c *Client) AdminDeleteConfig(name string) (*service.AdminConfigResponse, error) {
+	c.record("AdminDeleteConfig", name)
+	if c.AdminDeleteConfigFunc != nil {
+		return c.AdminDeleteConfigFunc(name)
+	}
+	return new(service.AdminConfigResponse), nil
+}
+
+func (c *Client) AdminModifyConfig(config *service.AdminConfig) (*service.AdminConfigResponse, error) {
+	c.record("AdminModifyConfig", config)
+	if c.AdminModifyConfigFunc != nil {
+		return c.AdminModifyConfigFunc(config)
+	}
+	return new(service.AdminConfigResponse), nil
+}
+
+func (c *Client) AdminAddConfig(config *service.AdminConfig) (*service.AdminConfigResponse, error) {
+	c.record("AdminAddConfig", config)
+	if c.AdminAddConfigFunc != nil {
+		return c.AdminAddConfigFunc(config)
+	}
+	return new(service.AdminConfigResponse), nil
+}
+
+func (c *Client) AdminGetAllPrices() (*service.AdminPriceResponse, error) {
+	c.record("AdminGetAllPrices")
+	if c.AdminGetAllPricesFunc != nil {
+		return c.AdminGetAllPricesFunc()
+	}
+	return new(service.AdminPriceResponse), nil
+}
+
+func (c *Client) AdminDeletePrice(pricesId string) (*service.AdminPriceResponse, error) {
+	c.record("AdminDeletePrice", pricesId)
+	if c.AdminDeletePriceFunc != nil {
+		return c.AdminDeletePriceFunc(pricesId)
+	}
+	return new(service.AdminPriceResponse), nil
+}
+
+func (c *Client) AdminModifyPrice(price *service.AdminPriceInfo) (*service.AdminPriceResponse, error) {
+	c.record("AdminModifyPrice", price)
+	if c.AdminModifyPriceFunc != nil {
+		return c.AdminModifyPriceFunc(price)
+	}
+	return new(service.AdminPriceResponse), nil
+}
+
+func (c *Client) AdminAddPrice(price *service.AdminPriceInfo) (*service.AdminPriceResponse, error) {
+	c.record("AdminAddPrice", price)
+	if c.AdminAddPriceFunc != nil {
+		return c.AdminAddPriceFunc(price)
+	}
+	return new(service.AdminPriceResponse), nil
+}
+
+func (c *Client) ReqGetAllOrders() (*service.OrderArrResp, error) {
+	c.record("ReqGetAllOrders")
+	if c.ReqGetAllOrdersFunc != nil {
+		return c.ReqGetAllOrdersFunc()
+	}
+	return new(service.OrderArrResp), nil
+}
+
+func (c *Client) ReqAddOrder(input *service.Order) (*service.OrderResp, error) {
+	c.record("ReqAddOrder", input)
+	if c.ReqAddOrderFunc != nil {
+		return c.ReqAddOrderFunc(input)
+	}
+	return new(service.OrderResp), nil
+}
+
+func (c *Client) ReqUpdateOrder(input *service.Order) (*service.OrderResp, error) {
+	c.record("ReqUpdateOrder", input)
+	if c.ReqUpdateOrderFunc != nil {
+		return c.ReqUpdateOrderFunc(input)
+	}
+	return new(service.OrderResp), nil
+}
+
+func (c *Client) ReqDeleteOrder(orderId string, trainNumber string) (*service.ReqDeleteOrderResponse, error) {
+	c.record("ReqDeleteOrder", orderId, trainNumber)
+	if c.ReqDeleteOrderFunc != nil {
+		return c.ReqDeleteOrderFunc(orderId, trainNumber)
+	}
+	return new(service.ReqDeleteOrderResponse), nil
+}
+
+func (c *Client) ReqGetAllRoutes() (*service.AdminRouteInfoResp, error) {
+	c.record("ReqGetAllRoutes")
+	if c.ReqGetAllRoutesFunc != nil {
+		return c.ReqGetAllRoutesFunc()
+	}
+	return new(service.AdminRouteInfoResp), nil
+}
+
+func (c *Client) ReqAddRoute(input *service.AdminRouteInfo) (*service.AdminAddResponse, error) {
+	c.record("ReqAddRoute", input)
+	if c.ReqAddRouteFunc != nil {
+		return c.ReqAddRouteFunc(input)
+	}
+	return new(service.AdminAddResponse), nil
+}
+
+func (c *Client) ReqDeleteRoute(routeId string) (*service.AdminRouteDeleteInfoResp, error) {
+	c.record("ReqDeleteRoute", routeId)
+	if c.ReqDeleteRouteFunc != nil {
+		return c.ReqDeleteRouteFunc(routeId)
+	}
+	return new(service.AdminRouteDeleteInfoResp), nil
+}
+
+func (c *Client) CreateTravel(request *service.AdminTravelInfo) (*service.AdminTravelResponse, error) {
+	c.record("CreateTravel", request)
+	if c.CreateTravelFunc != nil {
+		return c.CreateTravelFunc(request)
+	}
+	return new(service.AdminTravelResponse), nil
+}
+
+func (c *Client) UpdateTravel(request *service.AdminTravelInfo) (*service.AdminTravelResponse, error) {
+	c.record("UpdateTravel", request)
+	if c.UpdateTravelFunc != nil {
+		return c.UpdateTravelFunc(request)
+	}
+	return new(service.AdminTravelResponse), nil
+}
+
+func (c *Client) DeleteTravel(tripId string) (*service.AdminTravelResponse, error) {
+	c.record("DeleteTravel", tripId)
+	if c.DeleteTravelFunc != nil {
+		return c.DeleteTravelFunc(tripId)
+	}
+	return new(service.AdminTravelResponse), nil
+}
+
+func (c *Client) GetAllTravels() ([]service.AdminTravelInfo, error) {
+	c.record("GetAllTravels")
+	if c.GetAllTravelsFunc != nil {
+		return c.GetAllTravelsFunc()
+	}
+	var r0 []service.AdminTravelInfo
+	return r0, nil
+}
+
+func (c *Client) AdminAddUser(user *service.AdminUserDto) (*service.AdminUserResponse, error) {
+	c.record("AdminAddUser", user)
+	if c.AdminAddUserFunc != nil {
+		return c.AdminAddUserFunc(user)
+	}
+	return new(service.AdminUserResponse), nil
+}
+
+func (c *Client) AdminUpdateUser(user *service.AdminUserDto) (*service.AdminUserResponse, error) {
+	c.record("AdminUpdateUser", user)
+	if c.AdminUpdateUserFunc != nil {
+		return c.AdminUpdateUserFunc(user)
+	}
+	return new(service.AdminUserResponse), nil
+}
+
+func (c *Client) AdminDeleteUser(userId string) (*service.AdminDeleteResponseUser, error) {
+	c.record("AdminDeleteUser", userId)
+	if c.AdminDeleteUserFunc != nil {
+		return c.AdminDeleteUserFunc(userId)
+	}
+	return new(service.AdminDeleteResponseUser), nil
+}
+
+func (c *Client) AdminGetAllUsers() (*service.AllUserResponseUser, error) {
+	c.record("AdminGetAllUsers")
+	if c.AdminGetAllUsersFunc != nil {
+		return c.AdminGetAllUsersFunc()
+	}
+	return new(service.AllUserResponseUser), nil
+}
+
+func (c *Client) GetAllAssurances() (*service.GetAllAssuranceResponse, error) {
+	c.record("GetAllAssurances")
+	if c.GetAllAssurancesFunc != nil {
+		return c.GetAllAssurancesFunc()
+	}
+	return new(service.GetAllAssuranceResponse), nil
+}
+
+func (c *Client) GetAllAssuranceTypes() (*service.GetallAssuranceType, error) {
+	c.record("GetAllAssuranceTypes")
+	if c.GetAllAssuranceTypesFunc != nil {
+		return c.GetAllAssuranceTypesFunc()
+	}
+	return new(service.GetallAssuranceType), nil
+}
+
+func (c *Client) DeleteAssuranceByID(assuranceID string) (*service.AssuranceDeleteResponse, error) {
+	c.record("DeleteAssuranceByID", assuranceID)
+	if c.DeleteAssuranceByIDFunc != nil {
+		return c.DeleteAssuranceByIDFunc(assuranceID)
+	}
+	return new(service.AssuranceDeleteResponse), nil
+}
+
+func (c *Client) DeleteAssuranceByOrderID(orderID string) (*service.DeleteAssuranceByOrderIDResponse, error) {
+	c.record("DeleteAssuranceByOrderID", orderID)
+	if c.DeleteAssuranceByOrderIDFunc != nil {
+		return c.DeleteAssuranceByOrderIDFunc(orderID)
+	}
+	return new(service.DeleteAssuranceByOrderIDResponse), nil
+}
+
+func (c *Client) ModifyAssurance(assuranceID string, orderID string, typeIndex int) (*service.Modify_Response, error) {
+	c.record("ModifyAssurance", assuranceID, orderID, typeIndex)
+	if c.ModifyAssuranceFunc != nil {
+		return c.ModifyAssuranceFunc(assuranceID, orderID, typeIndex)
+	}
+	return new(service.Modify_Response), nil
+}
+
+func (c *Client) CreateNewAssurance(typeIndex int, orderID string) (*service.CreateAssuranceResponse, error) {
+	c.record("CreateNewAssurance", typeIndex, orderID)
+	if c.CreateNewAssuranceFunc != nil {
+		return c.CreateNewAssuranceFunc(typeIndex, orderID)
+	}
+	return new(service.CreateAssuranceResponse), nil
+}
+
+func (c *Client) GetAssuranceByID(assuranceID string) (*service.GetAssuranceByIDeInfo, error) {
+	c.record("GetAssuranceByID", assuranceID)
+	if c.GetAssuranceByIDFunc != nil {
+		return c.GetAssuranceByIDFunc(assuranceID)
+	}
+	return new(service.GetAssuranceByIDeInfo), nil
+}
+
+func (c *Client) FindAssuranceByOrderID(orderId string) (*service.GetAssuranceByIDeInfo, error) {
+	c.record("FindAssuranceByOrderID", orderId)
+	if c.FindAssuranceByOrderIDFunc != nil {
+		return c.FindAssuranceByOrderIDFunc(orderId)
+	}
+	return new(service.GetAssuranceByIDeInfo), nil
+}
+
+func (c *Client) ReqUserLogin(input *service.UserLoginInfoReq) (*service.UserLoginInfoResp, error) {
+	c.record("ReqUserLogin", input)
+	if c.ReqUserLoginFunc != nil {
+		return c.ReqUserLoginFunc(input)
+	}
+	return new(service.UserLoginInfoResp), nil
+}
+
+func (c *Client) LoginWithVerifyCode(username string, password string, solve service.VerifyCodeSolver) (*service.UserLoginInfoResp, error) {
+	c.record("LoginWithVerifyCode", username, password, solve)
+	if c.LoginWithVerifyCodeFunc != nil {
+		return c.LoginWithVerifyCodeFunc(username, password, solve)
+	}
+	return new(service.UserLoginInfoResp), nil
+}
+
+func (c *Client) ReqUserCreate(input *service.UserCreateInfoReq) (*service.UserCreateInfoResp, error) {
+	c.record("ReqUserCreate", input)
+	if c.ReqUserCreateFunc != nil {
+		return c.ReqUserCreateFunc(input)
+	}
+	return new(service.UserCreateInfoResp), nil
+}
+
+func (c *Client) ReqUserDelete(userid string) (*service.UserDeleteInfoResp, error) {
+	c.record("ReqUserDelete", userid)
+	if c.ReqUserDeleteFunc != nil {
+		return c.ReqUserDeleteFunc(userid)
+	}
+	return new(service.UserDeleteInfoResp), nil
+}
+
+func (c *Client) QueryForTravel(info *service.Travel) (*service.QueryForTravelResponse, error) {
+	c.record("QueryForTravel", info)
+	if c.QueryForTravelFunc != nil {
+		return c.QueryForTravelFunc(info)
+	}
+	return new(service.QueryForTravelResponse), nil
+}
+
+func (c *Client) QueryForTravels(infos []*service.Travel) (*service.QueryForTravelsResponse, error) {
+	c.record("QueryForTravels", infos)
+	if c.QueryForTravelsFunc != nil {
+		return c.QueryForTravelsFunc(infos)
+	}
+	return new(service.QueryForTravelsResponse), nil
+}
+
+func (c *Client) QueryForStationId(stationName string) (*service.QueryForStationIdResponse, error) {
+	c.record("QueryForStationId", stationName)
+	if c.QueryForStationIdFunc != nil {
+		return c.QueryForStationIdFunc(stationName)
+	}
+	return new(service.QueryForStationIdResponse), nil
+}
+
+func (c *Client) QueryTrainService() (*service.TrainResponseType, error) {
+	c.record("QueryTrainService")
+	if c.QueryTrainServiceFunc != nil {
+		return c.QueryTrainServiceFunc()
+	}
+	return new(service.TrainResponseType), nil
+}
+
+func (c *Client) ReqCalculate(orderId string) (*service.DataStringResp, error) {
+	c.record("ReqCalculate", orderId)
+	if c.ReqCalculateFunc != nil {
+		return c.ReqCalculateFunc(orderId)
+	}
+	return new(service.DataStringResp), nil
+}
+
+func (c *Client) ReqCancelTicket(orderId string, loginId string) (*service.DataStringResp, error) {
+	c.record("ReqCancelTicket", orderId, loginId)
+	if c.ReqCancelTicketFunc != nil {
+		return c.ReqCancelTicketFunc(orderId, loginId)
+	}
+	return new(service.DataStringResp), nil
+}
+
+func (c *Client) QueryAllConfigs() (*service.ConfigQueryAllConfigsResponse, error) {
+	c.record("QueryAllConfigs")
+	if c.QueryAllConfigsFunc != nil {
+		return c.QueryAllConfigsFunc()
+	}
+	return new(service.ConfigQueryAllConfigsResponse), nil
+}
+
+func (c *Client) CreateConfig(info *service.Config_config) (*service.CreateConfigResponse, error) {
+	c.record("CreateConfig", info)
+	if c.CreateConfigFunc != nil {
+		return c.CreateConfigFunc(info)
+	}
+	return new(service.CreateConfigResponse), nil
+}
+
+func (c *Client) UpdateConfig(info service.Config_config) (*service.UpdateConfigResponse, error) {
+	c.record("UpdateConfig", info)
+	if c.UpdateConfigFunc != nil {
+		return c.UpdateConfigFunc(info)
+	}
+	return new(service.UpdateConfigResponse), nil
+}
+
+func (c *Client) DeleteConfig_config_service(configName string) (*service.DeleteConfig_config_serviceResponse, error) {
+	c.record("DeleteConfig_config_service", configName)
+	if c.DeleteConfig_config_serviceFunc != nil {
+		return c.DeleteConfig_config_serviceFunc(configName)
+	}
+	return new(service.DeleteConfig_config_serviceResponse), nil
+}
+
+func (c *Client) RetrieveConfig(configName string) (*service.RetrieveConfigResponse, error) {
+	c.record("RetrieveConfig", configName)
+	if c.RetrieveConfigFunc != nil {
+		return c.RetrieveConfigFunc(configName)
+	}
+	return new(service.RetrieveConfigResponse), nil
+}
+
+func (c *Client) GetPriceByWeightAndRegion(weight string, isWithinRegion string) (*service.ConsignPriceResponse, error) {
+	c.record("GetPriceByWeightAndRegion", weight, isWithinRegion)
+	if c.GetPriceByWeightAndRegionFunc != nil {
+		return c.GetPriceByWeightAndRegionFunc(weight, isWithinRegion)
+	}
+	return new(service.ConsignPriceResponse), nil
+}
+
+func (c *Client) GetPriceInfo() (*service.GetResponse, error) {
+	c.record("GetPriceInfo")
+	if c.GetPriceInfoFunc != nil {
+		return c.GetPriceInfoFunc()
+	}
+	return new(service.GetResponse), nil
+}
+
+func (c *Client) GetPriceConfig() (*service.GetPriceConfigResponse, error) {
+	c.record("GetPriceConfig")
+	if c.GetPriceConfigFunc != nil {
+		return c.GetPriceConfigFunc()
+	}
+	return new(service.GetPriceConfigResponse), nil
+}
+
+func (c *Client) ModifyPriceConfig(priceConfig *service.ConsignPrice) (*service.ModifyConsignPriceResponse, error) {
+	c.record("ModifyPriceConfig", priceConfig)
+	if c.ModifyPriceConfigFunc != nil {
+		return c.ModifyPriceConfigFunc(priceConfig)
+	}
+	return new(service.ModifyConsignPriceResponse), nil
+}
+
+func (c *Client) InsertConsignRecord(consign *service.Consign) (*service.ConsignResponse, error) {
+	c.record("InsertConsignRecord", consign)
+	if c.InsertConsignRecordFunc != nil {
+		return c.InsertConsignRecordFunc(consign)
+	}
+	return new(service.ConsignResponse), nil
+}
+
+func (c *Client) UpdateConsignRecord(consign *service.Consign) (*service.ConsignResponse, error) {
+	c.record("UpdateConsignRecord", consign)
+	if c.UpdateConsignRecordFunc != nil {
+		return c.UpdateConsignRecordFunc(consign)
+	}
+	return new(service.ConsignResponse), nil
+}
+
+func (c *Client) QueryByAccountId(accountId string) (*service.AllConsignResponse, error) {
+	c.record("QueryByAccountId", accountId)
+	if c.QueryByAccountIdFunc != nil {
+		return c.QueryByAccountIdFunc(accountId)
+	}
+	return new(service.AllConsignResponse), nil
+}
+
+func (c *Client) QueryByOrderId(orderId string) (*service.QueryByOrderIdResponse, error) {
+	c.record("QueryByOrderId", orderId)
+	if c.QueryByOrderIdFunc != nil {
+		return c.QueryByOrderIdFunc(orderId)
+	}
+	return new(service.QueryByOrderIdResponse), nil
+}
+
+func (c *Client) QueryByConsignee(consignee string) (*service.QueryByConsigneeResponse, error) {
+	c.record("QueryByConsignee", consignee)
+	if c.QueryByConsigneeFunc != nil {
+		return c.QueryByConsigneeFunc(consignee)
+	}
+	return new(service.QueryByConsigneeResponse), nil
+}
+
+func (c *Client) GetAllContacts() (*service.AdminGetContactsResp, error) {
+	c.record("GetAllContacts")
+	if c.GetAllContactsFunc != nil {
+		return c.GetAllContactsFunc()
+	}
+	return new(service.AdminGetContactsResp), nil
+}
+
+func (c *Client) AddContact(contacts *service.AdminContacts) (*service.AdminContactResponse, error) {
+	c.record("AddContact", contacts)
+	if c.AddContactFunc != nil {
+		return c.AddContactFunc(contacts)
+	}
+	return new(service.AdminContactResponse), nil
+}
+
+func (c *Client) AddAdminContact(contacts *service.AdminContacts) (*service.AdminContactResponse, error) {
+	c.record("AddAdminContact", contacts)
+	if c.AddAdminContactFunc != nil {
+		return c.AddAdminContactFunc(contacts)
+	}
+	return new(service.AdminContactResponse), nil
+}
+
+func (c *Client) ModifyContact(contacts *service.AdminContacts) (*service.AdminContactResponse, error) {
+	c.record("ModifyContact", contacts)
+	if c.ModifyContactFunc != nil {
+		return c.ModifyContactFunc(contacts)
+	}
+	return new(service.AdminContactResponse), nil
+}
+
+func (c *Client) DeleteContact(contactsId string) (*service.DeleteContactsResp, error) {
+	c.record("DeleteContact", contactsId)
+	if c.DeleteContactFunc != nil {
+		return c.DeleteContactFunc(contactsId)
+	}
+	return new(service.DeleteContactsResp), nil
+}
+
+func (c *Client) GetContactByContactId(contactsId string) (*service.AdminContactResponse, error) {
+	c.record("GetContactByContactId", contactsId)
+	if c.GetContactByContactIdFunc != nil {
+		return c.GetContactByContactIdFunc(contactsId)
+	}
+	return new(service.AdminContactResponse), nil
+}
+
+func (c *Client) GetContactByAccountId(accountId string) (*service.GetContactsByAccountIdResp, error) {
+	c.record("GetContactByAccountId", accountId)
+	if c.GetContactByAccountIdFunc != nil {
+		return c.GetContactByAccountIdFunc(accountId)
+	}
+	return new(service.GetContactsByAccountIdResp), nil
+}
+
+func (c *Client) ReqGetDeliveriesByOrderId(orderId string) (*service.DeliveryArrResp, error) {
+	c.record("ReqGetDeliveriesByOrderId", orderId)
+	if c.ReqGetDeliveriesByOrderIdFunc != nil {
+		return c.ReqGetDeliveriesByOrderIdFunc(orderId)
+	}
+	return new(service.DeliveryArrResp), nil
+}
+
+func (c *Client) ReqGetDeliveriesByStation(stationName string) (*service.DeliveryArrResp, error) {
+	c.record("ReqGetDeliveriesByStation", stationName)
+	if c.ReqGetDeliveriesByStationFunc != nil {
+		return c.ReqGetDeliveriesByStationFunc(stationName)
+	}
+	return new(service.DeliveryArrResp), nil
+}
+
+func (c *Client) ReqUpdateDeliveryStatus(input *service.DeliveryStatusInfo) (*service.DeliveryResp, error) {
+	c.record("ReqUpdateDeliveryStatus", input)
+	if c.ReqUpdateDeliveryStatusFunc != nil {
+		return c.ReqUpdateDeliveryStatusFunc(input)
+	}
+	return new(service.DeliveryResp), nil
+}
+
+func (c *Client) ReqExecuteTicket(orderId string) (*service.DataStringResp, error) {
+	c.record("ReqExecuteTicket", orderId)
+	if c.ReqExecuteTicketFunc != nil {
+		return c.ReqExecuteTicketFunc(orderId)
+	}
+	return new(service.DataStringResp), nil
+}
+
+func (c *Client) ReqCollectTicket(orderId string) (*service.DataStringResp, error) {
+	c.record("ReqCollectTicket", orderId)
+	if c.ReqCollectTicketFunc != nil {
+		return c.ReqCollectTicketFunc(orderId)
+	}
+	return new(service.DataStringResp), nil
+}
+
+func (c *Client) ReqCreateFoodDeliveryOrder(input *service.FoodDeliveryOrder) (*service.FoodDeliveryOrderResponse, error) {
+	c.record("ReqCreateFoodDeliveryOrder", input)
+	if c.ReqCreateFoodDeliveryOrderFunc != nil {
+		return c.ReqCreateFoodDeliveryOrderFunc(input)
+	}
+	return new(service.FoodDeliveryOrderResponse), nil
+}
+
+func (c *Client) ReqGetAllFoodDeliveryOrders() (*service.FoodDeliveryOrderArrResponse, error) {
+	c.record("ReqGetAllFoodDeliveryOrders")
+	if c.ReqGetAllFoodDeliveryOrdersFunc != nil {
+		return c.ReqGetAllFoodDeliveryOrdersFunc()
+	}
+	return new(service.FoodDeliveryOrderArrResponse), nil
+}
+
+func (c *Client) ReqGetFoodDeliveryOrderByStoreId(storeId string) (*service.FoodDeliveryOrderArrResponse, error) {
+	c.record("ReqGetFoodDeliveryOrderByStoreId", storeId)
+	if c.ReqGetFoodDeliveryOrderByStoreIdFunc != nil {
+		return c.ReqGetFoodDeliveryOrderByStoreIdFunc(storeId)
+	}
+	return new(service.FoodDeliveryOrderArrResponse), nil
+}
+
+func (c *Client) ReqGetFoodDeliveryOrderById(orderId string) (*service.FoodDeliveryOrderResponse, error) {
+	c.record("ReqGetFoodDeliveryOrderById", orderId)
+	if c.ReqGetFoodDeliveryOrderByIdFunc != nil {
+		return c.ReqGetFoodDeliveryOrderByIdFunc(orderId)
+	}
+	return new(service.FoodDeliveryOrderResponse), nil
+}
+
+func (c *Client) ReqDeleteFoodDeliveryOrderById(orderId string) (*service.DataStringResp, error) {
+	c.record("ReqDeleteFoodDeliveryOrderById", orderId)
+	if c.ReqDeleteFoodDeliveryOrderByIdFunc != nil {
+		return c.ReqDeleteFoodDeliveryOrderByIdFunc(orderId)
+	}
+	return new(service.DataStringResp), nil
+}
+
+func (c *Client) ReqUpdateDeliveryTime(input *service.DeliveryInfo) (*service.FoodDeliveryOrderResponse, error) {
+	c.record("ReqUpdateDeliveryTime", input)
+	if c.ReqUpdateDeliveryTimeFunc != nil {
+		return c.ReqUpdateDeliveryTimeFunc(input)
+	}
+	return new(service.FoodDeliveryOrderResponse), nil
+}
+
+func (c *Client) ReqUpdateSeatNo(input *service.SeatInfo) (*service.FoodDeliveryOrderResponse, error) {
+	c.record("ReqUpdateSeatNo", input)
+	if c.ReqUpdateSeatNoFunc != nil {
+		return c.ReqUpdateSeatNoFunc(input)
+	}
+	return new(service.FoodDeliveryOrderResponse), nil
+}
+
+func (c *Client) ReqUpdateTripId(input *service.TripOrderInfo) (*service.FoodDeliveryOrderResponse, error) {
+	c.record("ReqUpdateTripId", input)
+	if c.ReqUpdateTripIdFunc != nil {
+		return c.ReqUpdateTripIdFunc(input)
+	}
+	return new(service.FoodDeliveryOrderResponse), nil
+}
+
+func (c *Client) FindAllFoodOrder() (*service.FindAllFoodOrder, error) {
+	c.record("FindAllFoodOrder")
+	if c.FindAllFoodOrderFunc != nil {
+		return c.FindAllFoodOrderFunc()
+	}
+	return new(service.FindAllFoodOrder), nil
+}
+
+func (c *Client) CreateFoodOrder(foodOrder *service.FoodOrder) (*service.CreateFoodOrderResp, error) {
+	c.record("CreateFoodOrder", foodOrder)
+	if c.CreateFoodOrderFunc != nil {
+		return c.CreateFoodOrderFunc(foodOrder)
+	}
+	return new(service.CreateFoodOrderResp), nil
+}
+
+func (c *Client) CreateFoodOrdersInBatch(foodOrders []service.FoodOrder) (*service.CreateFoodOrdersInBatch, error) {
+	c.record("CreateFoodOrdersInBatch", foodOrders)
+	if c.CreateFoodOrdersInBatchFunc != nil {
+		return c.CreateFoodOrdersInBatchFunc(foodOrders)
+	}
+	return new(service.CreateFoodOrdersInBatch), nil
+}
+
+func (c *Client) UpdateFoodOrder(foodOrder *service.FoodOrder) (*service.FoodOrder, error) {
+	c.record("UpdateFoodOrder", foodOrder)
+	if c.UpdateFoodOrderFunc != nil {
+		return c.UpdateFoodOrderFunc(foodOrder)
+	}
+	return new(service.FoodOrder), nil
+}
+
+func (c *Client) DeleteFoodOrder(orderID string) (*service.DeleteFoodOrderResp, error) {
+	c.record("DeleteFoodOrder", orderID)
+	if c.DeleteFoodOrderFunc != nil {
+		return c.DeleteFoodOrderFunc(orderID)
+	}
+	return new(service.DeleteFoodOrderResp), nil
+}
+
+func (c *Client) FindByOrderId(orderID string) (*service.FindByOrderIdResponse, error) {
+	c.record("FindByOrderId", orderID)
+	if c.FindByOrderIdFunc != nil {
+		return c.FindByOrderIdFunc(orderID)
+	}
+	return new(service.FindByOrderIdResponse), nil
+}
+
+func (c *Client) GetAllFood(date string, startStation string, endStation string, tripID string) (*service.GetAllFoodResponse, error) {
+	c.record("GetAllFood", date, startStation, endStation, tripID)
+	if c.GetAllFoodFunc != nil {
+		return c.GetAllFoodFunc(date, startStation, endStation, tripID)
+	}
+	return new(service.GetAllFoodResponse), nil
+}
+
+func (c *Client) ReqPay_InsidePayment(input *service.TripPayment) (*service.TripPaymentResponse, error) {
+	c.record("ReqPay_InsidePayment", input)
+	if c.ReqPay_InsidePaymentFunc != nil {
+		return c.ReqPay_InsidePaymentFunc(input)
+	}
+	return new(service.TripPaymentResponse), nil
+}
+
+func (c *Client) ReqCreateAccount(input *service.AccountInfo) (*service.TripPaymentResponse, error) {
+	c.record("ReqCreateAccount", input)
+	if c.ReqCreateAccountFunc != nil {
+		return c.ReqCreateAccountFunc(input)
+	}
+	return new(service.TripPaymentResponse), nil
+}
+
+func (c *Client) ReqPayDifference(input *service.TripPayment) (*service.TripPaymentResponse, error) {
+	c.record("ReqPayDifference", input)
+	if c.ReqPayDifferenceFunc != nil {
+		return c.ReqPayDifferenceFunc(input)
+	}
+	return new(service.TripPaymentResponse), nil
+}
+
+func (c *Client) ReqQueryAccount() (*service.TripPaymentArrResponse, error) {
+	c.record("ReqQueryAccount")
+	if c.ReqQueryAccountFunc != nil {
+		return c.ReqQueryAccountFunc()
+	}
+	return new(service.TripPaymentArrResponse), nil
+}
+
+func (c *Client) ReqDrawBack(userId string, money string) (*service.MoneyResponse, error) {
+	c.record("ReqDrawBack", userId, money)
+	if c.ReqDrawBackFunc != nil {
+		return c.ReqDrawBackFunc(userId, money)
+	}
+	return new(service.MoneyResponse), nil
+}
+
+func (c *Client) ReqQueryAddMoney() (*service.MoneyResponse, error) {
+	c.record("ReqQueryAddMoney")
+	if c.ReqQueryAddMoneyFunc != nil {
+		return c.ReqQueryAddMoneyFunc()
+	}
+	return new(service.MoneyResponse), nil
+}
+
+func (c *Client) ReqQueryInsidePayment() (*service.TripPaymentArrResponse, error) {
+	c.record("ReqQueryInsidePayment")
+	if c.ReqQueryInsidePaymentFunc != nil {
+		return c.ReqQueryInsidePaymentFunc()
+	}
+	return new(service.TripPaymentArrResponse), nil
+}
+
+func (c *Client) ReqAddMoney_Inside(userId string, money string) (*service.TripPaymentResponse, error) {
+	c.record("ReqAddMoney_Inside", userId, money)
+	if c.ReqAddMoney_InsideFunc != nil {
+		return c.ReqAddMoney_InsideFunc(userId, money)
+	}
+	return new(service.TripPaymentResponse), nil
+}
+
+func (c *Client) ReqOrderCancelSuccess(input *service.TicketOrder) (*bool, error) {
+	c.record("ReqOrderCancelSuccess", input)
+	if c.ReqOrderCancelSuccessFunc != nil {
+		return c.ReqOrderCancelSuccessFunc(input)
+	}
+	return new(bool), nil
+}
+
+func (c *Client) ReqOrderChangedSuccess(input *service.TicketOrder) (*bool, error) {
+	c.record("ReqOrderChangedSuccess", input)
+	if c.ReqOrderChangedSuccessFunc != nil {
+		return c.ReqOrderChangedSuccessFunc(input)
+	}
+	return new(bool), nil
+}
+
+func (c *Client) ReqOrderCreateSuccess(input *service.TicketOrder) (*bool, error) {
+	c.record("ReqOrderCreateSuccess", input)
+	if c.ReqOrderCreateSuccessFunc != nil {
+		return c.ReqOrderCreateSuccessFunc(input)
+	}
+	return new(bool), nil
+}
+
+func (c *Client) ReqPreserveSuccess(input *service.TicketOrder) (*bool, error) {
+	c.record("ReqPreserveSuccess", input)
+	if c.ReqPreserveSuccessFunc != nil {
+		return c.ReqPreserveSuccessFunc(input)
+	}
+	return new(bool), nil
+}
+
+func (c *Client) ReqTestSendMail() (*bool, error) {
+	c.record("ReqTestSendMail")
+	if c.ReqTestSendMailFunc != nil {
+		return c.ReqTestSendMailFunc()
+	}
+	return new(bool), nil
+}
+
+func (c *Client) ReqTestSend() (*bool, error) {
+	c.record("ReqTestSend")
+	if c.ReqTestSendFunc != nil {
+		return c.ReqTestSendFunc()
+	}
+	return new(bool), nil
+}
+
+func (c *Client) ReqFindAllOrderOther() (*service.OrderArrResp, error) {
+	c.record("ReqFindAllOrderOther")
+	if c.ReqFindAllOrderOtherFunc != nil {
+		return c.ReqFindAllOrderOtherFunc()
+	}
+	return new(service.OrderArrResp), nil
+}
+
+func (c *Client) ReqCreateNewOrderOther(input *service.Order) (*service.OrderResp, error) {
+	c.record("ReqCreateNewOrderOther", input)
+	if c.ReqCreateNewOrderOtherFunc != nil {
+		return c.ReqCreateNewOrderOtherFunc(input)
+	}
+	return new(service.OrderResp), nil
+}
+
+func (c *Client) ReqSaveOrderInfoOther(input *service.Order) (*service.OrderResp, error) {
+	c.record("ReqSaveOrderInfoOther", input)
+	if c.ReqSaveOrderInfoOtherFunc != nil {
+		return c.ReqSaveOrderInfoOtherFunc(input)
+	}
+	return new(service.OrderResp), nil
+}
+
+func (c *Client) ReqAddCreateNewOrderOther(input *service.Order) (*service.OrderResp, error) {
+	c.record("ReqAddCreateNewOrderOther", input)
+	if c.ReqAddCreateNewOrderOtherFunc != nil {
+		return c.ReqAddCreateNewOrderOtherFunc(input)
+	}
+	return new(service.OrderResp), nil
+}
+
+func (c *Client) ReqUpdateOrderOrderServiceOther(input *service.Order) (*service.OrderResp, error) {
+	c.record("ReqUpdateOrderOrderServiceOther", input)
+	if c.ReqUpdateOrderOrderServiceOtherFunc != nil {
+		return c.ReqUpdateOrderOrderServiceOtherFunc(input)
+	}
+	return new(service.OrderResp), nil
+}
+
+func (c *Client) ReqPayOrderOther(orderId string) (*service.OrderResp, error) {
+	c.record("ReqPayOrderOther", orderId)
+	if c.ReqPayOrderOtherFunc != nil {
+		return c.ReqPayOrderOtherFunc(orderId)
+	}
+	return new(service.OrderResp), nil
+}
+
+func (c *Client) ReqGetOrderPriceOther(orderId string) (*service.GetOrderPriceResp, error) {
+	c.record("ReqGetOrderPriceOther", orderId)
+	if c.ReqGetOrderPriceOtherFunc != nil {
+		return c.ReqGetOrderPriceOtherFunc(orderId)
+	}
+	return new(service.GetOrderPriceResp), nil
+}
+
+func (c *Client) ReqQueryOrdersOther(input *service.Qi) (*service.OrderArrResp, error) {
+	c.record("ReqQueryOrdersOther", input)
+	if c.ReqQueryOrdersOtherFunc != nil {
+		return c.ReqQueryOrdersOtherFunc(input)
+	}
+	return new(service.OrderArrResp), nil
+}
+
+func (c *Client) ReqQueryOrderForRefreshOther(input *service.Qi) (*service.OrderArrResp, error) {
+	c.record("ReqQueryOrderForRefreshOther", input)
+	if c.ReqQueryOrderForRefreshOtherFunc != nil {
+		return c.ReqQueryOrderForRefreshOtherFunc(input)
+	}
+	return new(service.OrderArrResp), nil
+}
+
+func (c *Client) ReqSecurityInfoCheckOther(checkDate string, accountId string) (*service.OrderSecurityResp, error) {
+	c.record("ReqSecurityInfoCheckOther", checkDate, accountId)
+	if c.ReqSecurityInfoCheckOtherFunc != nil {
+		return c.ReqSecurityInfoCheckOtherFunc(checkDate, accountId)
+	}
+	return new(service.OrderSecurityResp), nil
+}
+
+func (c *Client) ReqModifyOrderOther(orderId string, status int) (*service.OrderResp, error) {
+	c.record("ReqModifyOrderOther", orderId, status)
+	if c.ReqModifyOrderOtherFunc != nil {
+		return c.ReqModifyOrderOtherFunc(orderId, status)
+	}
+	return new(service.OrderResp), nil
+}
+
+func (c *Client) ReqGetTicketsListOther(input *service.Seat) (*service.TicketResp, error) {
+	c.record("ReqGetTicketsListOther", input)
+	if c.ReqGetTicketsListOtherFunc != nil {
+		return c.ReqGetTicketsListOtherFunc(input)
+	}
+	return new(service.TicketResp), nil
+}
+
+func (c *Client) ReqDeleteOrderOrderServiceOther(orderId string) (*service.OrderResp, error) {
+	c.record("ReqDeleteOrderOrderServiceOther", orderId)
+	if c.ReqDeleteOrderOrderServiceOtherFunc != nil {
+		return c.ReqDeleteOrderOrderServiceOtherFunc(orderId)
+	}
+	return new(service.OrderResp), nil
+}
+
+func (c *Client) ReqGetOrderByIdOther(orderId string) (*service.OrderResp, error) {
+	c.record("ReqGetOrderByIdOther", orderId)
+	if c.ReqGetOrderByIdOtherFunc != nil {
+		return c.ReqGetOrderByIdOtherFunc(orderId)
+	}
+	return new(service.OrderResp), nil
+}
+
+func (c *Client) ReqCalculateSoldTicketOther(travelDate string, travelNumber string) (*service.OrderResp, error) {
+	c.record("ReqCalculateSoldTicketOther", travelDate, travelNumber)
+	if c.ReqCalculateSoldTicketOtherFunc != nil {
+		return c.ReqCalculateSoldTicketOtherFunc(travelDate, travelNumber)
+	}
+	return new(service.OrderResp), nil
+}
+
+func (c *Client) ReqFindAllOrder() (*service.OrderArrResp, error) {
+	c.record("ReqFindAllOrder")
+	if c.ReqFindAllOrderFunc != nil {
+		return c.ReqFindAllOrderFunc()
+	}
+	return new(service.OrderArrResp), nil
+}
+
+func (c *Client) ReqCreateNewOrder(input *service.Order) (*service.OrderResp, error) {
+	c.record("ReqCreateNewOrder", input)
+	if c.ReqCreateNewOrderFunc != nil {
+		return c.ReqCreateNewOrderFunc(input)
+	}
+	return new(service.OrderResp), nil
+}
+
+func (c *Client) ReqSaveOrderInfo(input *service.Order) (*service.OrderResp, error) {
+	c.record("ReqSaveOrderInfo", input)
+	if c.ReqSaveOrderInfoFunc != nil {
+		return c.ReqSaveOrderInfoFunc(input)
+	}
+	return new(service.OrderResp), nil
+}
+
+func (c *Client) ReqAddCreateNewOrder(input *service.Order) (*service.OrderResp, error) {
+	c.record("ReqAddCreateNewOrder", input)
+	if c.ReqAddCreateNewOrderFunc != nil {
+		return c.ReqAddCreateNewOrderFunc(input)
+	}
+	return new(service.OrderResp), nil
+}
+
+func (c *Client) ReqUpdateOrder_OrderService(input *service.Order) (*service.OrderResp, error) {
+	c.record("ReqUpdateOrder_OrderService", input)
+	if c.ReqUpdateOrder_OrderServiceFunc != nil {
+		return c.ReqUpdateOrder_OrderServiceFunc(input)
+	}
+	return new(service.OrderResp), nil
+}
+
+func (c *Client) ReqPayOrder(orderId string) (*service.OrderResp, error) {
+	c.record("ReqPayOrder", orderId)
+	if c.ReqPayOrderFunc != nil {
+		return c.ReqPayOrderFunc(orderId)
+	}
+	return new(service.OrderResp), nil
+}
+
+func (c *Client) ReqGetOrderPrice(orderId string) (*service.GetOrderPriceResp, error) {
+	c.record("ReqGetOrderPrice", orderId)
+	if c.ReqGetOrderPriceFunc != nil {
+		return c.ReqGetOrderPriceFunc(orderId)
+	}
+	return new(service.GetOrderPriceResp), nil
+}
+
+func (c *Client) ReqQueryOrders(input *service.Qi) (*service.OrderArrResp, error) {
+	c.record("ReqQueryOrders", input)
+	if c.ReqQueryOrdersFunc != nil {
+		return c.ReqQueryOrdersFunc(input)
+	}
+	return new(service.OrderArrResp), nil
+}
+
+func (c *Client) ReqQueryOrderForRefresh(input *service.Qi) (*service.OrderArrResp, error) {
+	c.record("ReqQueryOrderForRefresh", input)
+	if c.ReqQueryOrderForRefreshFunc != nil {
+		return c.ReqQueryOrderForRefreshFunc(input)
+	}
+	return new(service.OrderArrResp), nil
+}
+
+func (c *Client) ReqSecurityInfoCheck(checkDate string, accountId string) (*service.OrderSecurityResp, error) {
+	c.record("ReqSecurityInfoCheck", checkDate, accountId)
+	if c.ReqSecurityInfoCheckFunc != nil {
+		return c.ReqSecurityInfoCheckFunc(checkDate, accountId)
+	}
+	return new(service.OrderSecurityResp), nil
+}
+
+func (c *Client) ReqModifyOrder(orderId string, status int) (*service.OrderResp, error) {
+	c.record("ReqModifyOrder", orderId, status)
+	if c.ReqModifyOrderFunc != nil {
+		return c.ReqModifyOrderFunc(orderId, status)
+	}
+	return new(service.OrderResp), nil
+}
+
+func (c *Client) ReqGetTicketsList(input *service.Seat) (*service.TicketResp, error) {
+	c.record("ReqGetTicketsList", input)
+	if c.ReqGetTicketsListFunc != nil {
+		return c.ReqGetTicketsListFunc(input)
+	}
+	return new(service.TicketResp), nil
+}
+
+func (c *Client) ReqDeleteOrder_OrderService(orderId string) (*service.OrderResp, error) {
+	c.record("ReqDeleteOrder_OrderService", orderId)
+	if c.ReqDeleteOrder_OrderServiceFunc != nil {
+		return c.ReqDeleteOrder_OrderServiceFunc(orderId)
+	}
+	return new(service.OrderResp), nil
+}
+
+func (c *Client) ReqGetOrderById(orderId string) (*service.OrderResp, error) {
+	c.record("ReqGetOrderById", orderId)
+	if c.ReqGetOrderByIdFunc != nil {
+		return c.ReqGetOrderByIdFunc(orderId)
+	}
+	return new(service.OrderResp), nil
+}
+
+func (c *Client) ReqCalculateSoldTicket(travelDate string, travelNumber string) (*service.OrderResp, error) {
+	c.record("ReqCalculateSoldTicket", travelDate, travelNumber)
+	if c.ReqCalculateSoldTicketFunc != nil {
+		return c.ReqCalculateSoldTicketFunc(travelDate, travelNumber)
+	}
+	return new(service.OrderResp), nil
+}
+
+func (c *Client) ReqPay(input *service.Payment) (*service.PaymentResponse, error) {
+	c.record("ReqPay", input)
+	if c.ReqPayFunc != nil {
+		return c.ReqPayFunc(input)
+	}
+	return new(service.PaymentResponse), nil
+}
+
+func (c *Client) ReqAddMoney(input *service.Payment) (*service.PaymentResponse, error) {
+	c.record("ReqAddMoney", input)
+	if c.ReqAddMoneyFunc != nil {
+		return c.ReqAddMoneyFunc(input)
+	}
+	return new(service.PaymentResponse), nil
+}
+
+func (c *Client) ReqQueryPayment() (*service.PaymentArrResponse, error) {
+	c.record("ReqQueryPayment")
+	if c.ReqQueryPaymentFunc != nil {
+		return c.ReqQueryPaymentFunc()
+	}
+	return new(service.PaymentArrResponse), nil
+}
+
+func (c *Client) PreserveOther(orderTicketsInfo *service.OrderTicketsInfo) (*service.PreserveResponse, error) {
+	c.record("PreserveOther", orderTicketsInfo)
+	if c.PreserveOtherFunc != nil {
+		return c.PreserveOtherFunc(orderTicketsInfo)
+	}
+	return new(service.PreserveResponse), nil
+}
+
+func (c *Client) Preserve(orderTicketsInfo *service.OrderTicketsInfo) (*service.PreserveResponse, error) {
+	c.record("Preserve", orderTicketsInfo)
+	if c.PreserveFunc != nil {
+		return c.PreserveFunc(orderTicketsInfo)
+	}
+	return new(service.PreserveResponse), nil
+}
+
+func (c *Client) FindByRouteIdAndTrainType(routeId string, trainType string) (*service.AdminPriceResponse, error) {
+	c.record("FindByRouteIdAndTrainType", routeId, trainType)
+	if c.FindByRouteIdAndTrainTypeFunc != nil {
+		return c.FindByRouteIdAndTrainTypeFunc(routeId, trainType)
+	}
+	return new(service.AdminPriceResponse), nil
+}
+
+func (c *Client) FindByRouteIdsAndTrainTypes(ridsAndTts []string) (*service.AllPriceResponse, error) {
+	c.record("FindByRouteIdsAndTrainTypes", ridsAndTts)
+	if c.FindByRouteIdsAndTrainTypesFunc != nil {
+		return c.FindByRouteIdsAndTrainTypesFunc(ridsAndTts)
+	}
+	return new(service.AllPriceResponse), nil
+}
+
+func (c *Client) FindAllPriceConfig() (*service.AllPriceResponse, error) {
+	c.record("FindAllPriceConfig")
+	if c.FindAllPriceConfigFunc != nil {
+		return c.FindAllPriceConfigFunc()
+	}
+	return new(service.AllPriceResponse), nil
+}
+
+func (c *Client) CreateNewPriceConfig(info *service.PriceConfig) (*service.AdminPriceResponse, error) {
+	c.record("CreateNewPriceConfig", info)
+	if c.CreateNewPriceConfigFunc != nil {
+		return c.CreateNewPriceConfigFunc(info)
+	}
+	return new(service.AdminPriceResponse), nil
+}
+
+func (c *Client) DeletePriceConfig(pricesId string) (*service.AdminPriceResponse, error) {
+	c.record("DeletePriceConfig", pricesId)
+	if c.DeletePriceConfigFunc != nil {
+		return c.DeletePriceConfigFunc(pricesId)
+	}
+	return new(service.AdminPriceResponse), nil
+}
+
+func (c *Client) UpdatePriceConfig(info *service.PriceConfig) (*service.AdminPriceResponse, error) {
+	c.record("UpdatePriceConfig", info)
+	if c.UpdatePriceConfigFunc != nil {
+		return c.UpdatePriceConfigFunc(info)
+	}
+	return new(service.AdminPriceResponse), nil
+}
+
+func (c *Client) ReqRebook(input *service.RebookInfo) (*service.RebookResp, error) {
+	c.record("ReqRebook", input)
+	if c.ReqRebookFunc != nil {
+		return c.ReqRebookFunc(input)
+	}
+	return new(service.RebookResp), nil
+}
+
+func (c *Client) ReqRebookPayDifference(input *service.RebookInfo) (*service.RebookResp, error) {
+	c.record("ReqRebookPayDifference", input)
+	if c.ReqRebookPayDifferenceFunc != nil {
+		return c.ReqRebookPayDifferenceFunc(input)
+	}
+	return new(service.RebookResp), nil
+}
+
+func (c *Client) GetCheapestRoutes(input *service.RoutePlanInfo) (*service.RoutePlanResponse, error) {
+	c.record("GetCheapestRoutes", input)
+	if c.GetCheapestRoutesFunc != nil {
+		return c.GetCheapestRoutesFunc(input)
+	}
+	return new(service.RoutePlanResponse), nil
+}
+
+func (c *Client) GetQuickestRoutes(input *service.RoutePlanInfo) (*service.RoutePlanResponse, error) {
+	c.record("GetQuickestRoutes", input)
+	if c.GetQuickestRoutesFunc != nil {
+		return c.GetQuickestRoutesFunc(input)
+	}
+	return new(service.RoutePlanResponse), nil
+}
+
+func (c *Client) GetMinStopStations(input *service.RoutePlanInfo) (*service.RoutePlanResponse, error) {
+	c.record("GetMinStopStations", input)
+	if c.GetMinStopStationsFunc != nil {
+		return c.GetMinStopStationsFunc(input)
+	}
+	return new(service.RoutePlanResponse), nil
+}
+
+func (c *Client) CreateAndModifyRoute(input *service.RouteInfo) (*service.CreateRouteResponse, error) {
+	c.record("CreateAndModifyRoute", input)
+	if c.CreateAndModifyRouteFunc != nil {
+		return c.CreateAndModifyRouteFunc(input)
+	}
+	return new(service.CreateRouteResponse), nil
+}
+
+func (c *Client) DeleteRoute(routeId string) (*service.DeleteResponse, error) {
+	c.record("DeleteRoute", routeId)
+	if c.DeleteRouteFunc != nil {
+		return c.DeleteRouteFunc(routeId)
+	}
+	return new(service.DeleteResponse), nil
+}
+
+func (c *Client) QueryRouteById(routeId string) (*service.CreateRouteResponse, error) {
+	c.record("QueryRouteById", routeId)
+	if c.QueryRouteByIdFunc != nil {
+		return c.QueryRouteByIdFunc(routeId)
+	}
+	return new(service.CreateRouteResponse), nil
+}
+
+func (c *Client) QueryRoutesByIds(routeIds []string) (*service.QueryMultiResponse, error) {
+	c.record("QueryRoutesByIds", routeIds)
+	if c.QueryRoutesByIdsFunc != nil {
+		return c.QueryRoutesByIdsFunc(routeIds)
+	}
+	return new(service.QueryMultiResponse), nil
+}
+
+func (c *Client) QueryAllRoutes() (*service.QueryMultiResponse, error) {
+	c.record("QueryAllRoutes")
+	if c.QueryAllRoutesFunc != nil {
+		return c.QueryAllRoutesFunc()
+	}
+	return new(service.QueryMultiResponse), nil
+}
+
+func (c *Client) QueryRoutesByStartAndEnd(start string, end string) (*service.QueryMultiResponse, error) {
+	c.record("QueryRoutesByStartAndEnd", start, end)
+	if c.QueryRoutesByStartAndEndFunc != nil {
+		return c.QueryRoutesByStartAndEndFunc(start, end)
+	}
+	return new(service.QueryMultiResponse), nil
+}
+
+func (c *Client) ReqSeatCreate(input *service.SeatCreateInfoReq) (*service.SeatCreateInfoResp, error) {
+	c.record("ReqSeatCreate", input)
+	if c.ReqSeatCreateFunc != nil {
+		return c.ReqSeatCreateFunc(input)
+	}
+	return new(service.SeatCreateInfoResp), nil
+}
+
+func (c *Client) ReqGetTicketLeft(input *service.SeatCreateInfoReq) (*service.TicketLeftResp, error) {
+	c.record("ReqGetTicketLeft", input)
+	if c.ReqGetTicketLeftFunc != nil {
+		return c.ReqGetTicketLeftFunc(input)
+	}
+	return new(service.TicketLeftResp), nil
+}
+
+func (c *Client) FindAllSecurityConfig() (*service.FindAllResponse, error) {
+	c.record("FindAllSecurityConfig")
+	if c.FindAllSecurityConfigFunc != nil {
+		return c.FindAllSecurityConfigFunc()
+	}
+	return new(service.FindAllResponse), nil
+}
+
+func (c *Client) AddNewSecurityConfig(config *service.SecurityConfig) (*service.SingleResponse, error) {
+	c.record("AddNewSecurityConfig", config)
+	if c.AddNewSecurityConfigFunc != nil {
+		return c.AddNewSecurityConfigFunc(config)
+	}
+	return new(service.SingleResponse), nil
+}
+
+func (c *Client) ModifySecurityConfig(config *service.SecurityConfig) (*service.SingleResponse, error) {
+	c.record("ModifySecurityConfig", config)
+	if c.ModifySecurityConfigFunc != nil {
+		return c.ModifySecurityConfigFunc(config)
+	}
+	return new(service.SingleResponse), nil
+}
+
+func (c *Client) DeleteSecurityConfig(id string) (*service.DeleteResponse, error) {
+	c.record("DeleteSecurityConfig", id)
+	if c.DeleteSecurityConfigFunc != nil {
+		return c.DeleteSecurityConfigFunc(id)
+	}
+	return new(service.DeleteResponse), nil
+}
+
+func (c *Client) Check(accountId string) (*service.SingleResponse, error) {
+	c.record("Check", accountId)
+	if c.CheckFunc != nil {
+		return c.CheckFunc(accountId)
+	}
+	return new(service.SingleResponse), nil
+}
+
+func (c *Client) GetAllStationFood() (*service.GetStationFoodResp, error) {
+	c.record("GetAllStationFood")
+	if c.GetAllStationFoodFunc != nil {
+		return c.GetAllStationFoodFunc()
+	}
+	return new(service.GetStationFoodResp), nil
+}
+
+func (c *Client) GetStationFoodByName(stationName string) (*service.GetStationFoodResp, error) {
+	c.record("GetStationFoodByName", stationName)
+	if c.GetStationFoodByNameFunc != nil {
+		return c.GetStationFoodByNameFunc(stationName)
+	}
+	return new(service.GetStationFoodResp), nil
+}
+
+func (c *Client) GetStationFoodByNames(stationNames []string) (*service.GetStationFoodResp, error) {
+	c.record("GetStationFoodByNames", stationNames)
+	if c.GetStationFoodByNamesFunc != nil {
+		return c.GetStationFoodByNamesFunc(stationNames)
+	}
+	return new(service.GetStationFoodResp), nil
+}
+
+func (c *Client) GetStationFoodById(storeId string) (*service.GetStationFoodSingleResp, error) {
+	c.record("GetStationFoodById", storeId)
+	if c.GetStationFoodByIdFunc != nil {
+		return c.GetStationFoodByIdFunc(storeId)
+	}
+	return new(service.GetStationFoodSingleResp), nil
+}
+
+func (c *Client) QueryStations() (*service.GetStationResponse, error) {
+	c.record("QueryStations")
+	if c.QueryStationsFunc != nil {
+		return c.QueryStationsFunc()
+	}
+	return new(service.GetStationResponse), nil
+}
+
+func (c *Client) CreateStation(input *service.Station) (*service.StationCreateResponse, error) {
+	c.record("CreateStation", input)
+	if c.CreateStationFunc != nil {
+		return c.CreateStationFunc(input)
+	}
+	return new(service.StationCreateResponse), nil
+}
+
+func (c *Client) UpdateStation(input *service.Station) (*service.StationUpdateResponse, error) {
+	c.record("UpdateStation", input)
+	if c.UpdateStationFunc != nil {
+		return c.UpdateStationFunc(input)
+	}
+	return new(service.StationUpdateResponse), nil
+}
+
+func (c *Client) DeleteStation(stationId string) (*service.DeleteStationResponse, error) {
+	c.record("DeleteStation", stationId)
+	if c.DeleteStationFunc != nil {
+		return c.DeleteStationFunc(stationId)
+	}
+	return new(service.DeleteStationResponse), nil
+}
+
+func (c *Client) QueryStationIdByName(stationName string) (*service.StationQueryIdByNameResponse, error) {
+	c.record("QueryStationIdByName", stationName)
+	if c.QueryStationIdByNameFunc != nil {
+		return c.QueryStationIdByNameFunc(stationName)
+	}
+	return new(service.StationQueryIdByNameResponse), nil
+}
+
+func (c *Client) QueryStationIdsByNames(stationNameList []string) (*service.QueryStationIdsByNamesResponse, error) {
+	c.record("QueryStationIdsByNames", stationNameList)
+	if c.QueryStationIdsByNamesFunc != nil {
+		return c.QueryStationIdsByNamesFunc(stationNameList)
+	}
+	return new(service.QueryStationIdsByNamesResponse), nil
+}
+
+func (c *Client) QueryStationNameById(stationId string) (*service.QueryStationNameByIdResponse, error) {
+	c.record("QueryStationNameById", stationId)
+	if c.QueryStationNameByIdFunc != nil {
+		return c.QueryStationNameByIdFunc(stationId)
+	}
+	return new(service.QueryStationNameByIdResponse), nil
+}
+
+func (c *Client) QueryStationNamesByIds(stationIdList []string) (*service.QueryStationNamesByIdsResponse, error) {
+	c.record("QueryStationNamesByIds", stationIdList)
+	if c.QueryStationNamesByIdsFunc != nil {
+		return c.QueryStationNamesByIdsFunc(stationIdList)
+	}
+	return new(service.QueryStationNamesByIdsResponse), nil
+}
+
+func (c *Client) GetAllTrainFood() (*service.GetTrainFoodResp, error) {
+	c.record("GetAllTrainFood")
+	if c.GetAllTrainFoodFunc != nil {
+		return c.GetAllTrainFoodFunc()
+	}
+	return new(service.GetTrainFoodResp), nil
+}
+
+func (c *Client) GetTrainFoodByTripId(tripId string) (*service.GetTrainFoodByIdResp, error) {
+	c.record("GetTrainFoodByTripId", tripId)
+	if c.GetTrainFoodByTripIdFunc != nil {
+		return c.GetTrainFoodByTripIdFunc(tripId)
+	}
+	return new(service.GetTrainFoodByIdResp), nil
+}
+
+func (c *Client) Create(trainType *service.TrainType) (*service.CreateStationResponse, error) {
+	c.record("Create", trainType)
+	if c.CreateFunc != nil {
+		return c.CreateFunc(trainType)
+	}
+	return new(service.CreateStationResponse), nil
+}
+
+func (c *Client) Retrieve(id string) (*service.TrainServiceRetrieveTrainType, error) {
+	c.record("Retrieve", id)
+	if c.RetrieveFunc != nil {
+		return c.RetrieveFunc(id)
+	}
+	return new(service.TrainServiceRetrieveTrainType), nil
+}
+
+func (c *Client) RetrieveByName(name string) (*service.TrainRetrieveByNameType, error) {
+	c.record("RetrieveByName", name)
+	if c.RetrieveByNameFunc != nil {
+		return c.RetrieveByNameFunc(name)
+	}
+	return new(service.TrainRetrieveByNameType), nil
+}
+
+func (c *Client) RetrieveByNames(names []string) (*service.TrainRetrieveByNamesType, error) {
+	c.record("RetrieveByNames", names)
+	if c.RetrieveByNamesFunc != nil {
+		return c.RetrieveByNamesFunc(names)
+	}
+	return new(service.TrainRetrieveByNamesType), nil
+}
+
+func (c *Client) Update(trainType *service.TrainType) (*service.TrainUpdateResponse, error) {
+	c.record("Update", trainType)
+	if c.UpdateFunc != nil {
+		return c.UpdateFunc(trainType)
+	}
+	return new(service.TrainUpdateResponse), nil
+}
+
+func (c *Client) Delete(id string) (*service.TrainDeleteResponse, error) {
+	c.record("Delete", id)
+	if c.DeleteFunc != nil {
+		return c.DeleteFunc(id)
+	}
+	return new(service.TrainDeleteResponse), nil
+}
+
+func (c *Client) Query() (*service.TrainResponseType, error) {
+	c.record("Query")
+	if c.QueryFunc != nil {
+		return c.QueryFunc()
+	}
+	return new(service.TrainResponseType), nil
+}
+
+func (c *Client) GetTrain2TypeByTripId(tripId string) (*service.GetTrainTypeByTripId2Response, error) {
+	c.record("GetTrain2TypeByTripId", tripId)
+	if c.GetTrain2TypeByTripIdFunc != nil {
+		return c.GetTrain2TypeByTripIdFunc(tripId)
+	}
+	return new(service.GetTrainTypeByTripId2Response), nil
+}
+
+func (c *Client) GetRouteByTrip2Id(tripId string) (*service.GetRouteByTripIdResponse, error) {
+	c.record("GetRouteByTrip2Id", tripId)
+	if c.GetRouteByTrip2IdFunc != nil {
+		return c.GetRouteByTrip2IdFunc(tripId)
+	}
+	return new(service.GetRouteByTripIdResponse), nil
+}
+
+func (c *Client) GetTrip2ByRoute(routeIds []string) (*service.GetTripByRouteIdResponse, error) {
+	c.record("GetTrip2ByRoute", routeIds)
+	if c.GetTrip2ByRouteFunc != nil {
+		return c.GetTrip2ByRouteFunc(routeIds)
+	}
+	return new(service.GetTripByRouteIdResponse), nil
+}
+
+func (c *Client) CreateTrip2(travelInfo *service.TravelInfo) (*service.CreateTripResponse, error) {
+	c.record("CreateTrip2", travelInfo)
+	if c.CreateTrip2Func != nil {
+		return c.CreateTrip2Func(travelInfo)
+	}
+	return new(service.CreateTripResponse), nil
+}
+
+func (c *Client) RetrieveTrip2(tripId string) (*service.RetrieveTripResponse, error) {
+	c.record("RetrieveTrip2", tripId)
+	if c.RetrieveTrip2Func != nil {
+		return c.RetrieveTrip2Func(tripId)
+	}
+	return new(service.RetrieveTripResponse), nil
+}
+
+func (c *Client) UpdateTrip2(travelInfo *service.TravelInfo) (*service.UpdateTripResponse, error) {
+	c.record("UpdateTrip2", travelInfo)
+	if c.UpdateTrip2Func != nil {
+		return c.UpdateTrip2Func(travelInfo)
+	}
+	return new(service.UpdateTripResponse), nil
+}
+
+func (c *Client) DeleteTrip2(tripId string) (*service.DeleteTripResponse, error) {
+	c.record("DeleteTrip2", tripId)
+	if c.DeleteTrip2Func != nil {
+		return c.DeleteTrip2Func(tripId)
+	}
+	return new(service.DeleteTripResponse), nil
+}
+
+func (c *Client) QueryByBatch(tripInfo *service.TripInfo) (*service.QueryByBatchResponse, error) {
+	c.record("QueryByBatch", tripInfo)
+	if c.QueryByBatchFunc != nil {
+		return c.QueryByBatchFunc(tripInfo)
+	}
+	return new(service.QueryByBatchResponse), nil
+}
+
+func (c *Client) GetTrip2AllDetailInfo(tripAllDetailInfo *service.Trip2AllDetailInfo) (*service.GetTripAllDetailInfoResponse, error) {
+	c.record("GetTrip2AllDetailInfo", tripAllDetailInfo)
+	if c.GetTrip2AllDetailInfoFunc != nil {
+		return c.GetTrip2AllDetailInfoFunc(tripAllDetailInfo)
+	}
+	return new(service.GetTripAllDetailInfoResponse), nil
+}
+
+func (c *Client) QueryAllTravel() (*service.QueryAllResponse, error) {
+	c.record("QueryAllTravel")
+	if c.QueryAllTravelFunc != nil {
+		return c.QueryAllTravelFunc()
+	}
+	return new(service.QueryAllResponse), nil
+}
+
+func (c *Client) AdminQueryAllTravel() (*service.AdminQueryAllResponse, error) {
+	c.record("AdminQueryAllTravel")
+	if c.AdminQueryAllTravelFunc != nil {
+		return c.AdminQueryAllTravelFunc()
+	}
+	return new(service.AdminQueryAllResponse), nil
+}
+
+func (c *Client) GetTrainTypeByTripId(tripId string) (*service.GetTrainTypeByTripIdResponse, error) {
+	c.record("GetTrainTypeByTripId", tripId)
+	if c.GetTrainTypeByTripIdFunc != nil {
+		return c.GetTrainTypeByTripIdFunc(tripId)
+	}
+	return new(service.GetTrainTypeByTripIdResponse), nil
+}
+
+func (c *Client) GetRouteByTripId(tripId string) (*service.GetRouteByTripIdResponse, error) {
+	c.record("GetRouteByTripId", tripId)
+	if c.GetRouteByTripIdFunc != nil {
+		return c.GetRouteByTripIdFunc(tripId)
+	}
+	return new(service.GetRouteByTripIdResponse), nil
+}
+
+func (c *Client) GetTripsByRouteId(routeIds []string) (*service.GetTripsByRouteIdResponse, error) {
+	c.record("GetTripsByRouteId", routeIds)
+	if c.GetTripsByRouteIdFunc != nil {
+		return c.GetTripsByRouteIdFunc(routeIds)
+	}
+	return new(service.GetTripsByRouteIdResponse), nil
+}
+
+func (c *Client) CreateTrip(travelInfo *service.TravelInfo) (*service.TripResponse, error) {
+	c.record("CreateTrip", travelInfo)
+	if c.CreateTripFunc != nil {
+		return c.CreateTripFunc(travelInfo)
+	}
+	return new(service.TripResponse), nil
+}
+
+func (c *Client) RetrieveTravel(tripId string) (*service.TravelInfo, error) {
+	c.record("RetrieveTravel", tripId)
+	if c.RetrieveTravelFunc != nil {
+		return c.RetrieveTravelFunc(tripId)
+	}
+	return new(service.TravelInfo), nil
+}
+
+func (c *Client) UpdateTrip(travelInfo *service.TravelInfo) (*service.TripResponse, error) {
+	c.record("UpdateTrip", travelInfo)
+	if c.UpdateTripFunc != nil {
+		return c.UpdateTripFunc(travelInfo)
+	}
+	return new(service.TripResponse), nil
+}
+
+func (c *Client) DeleteTrip(tripId string) (*service.DeleteTripResponse, error) {
+	c.record("DeleteTrip", tripId)
+	if c.DeleteTripFunc != nil {
+		return c.DeleteTripFunc(tripId)
+	}
+	return new(service.DeleteTripResponse), nil
+}
+
+func (c *Client) QueryInfo(tripInfo service.TripInfo) (*service.QueryInfoResponse, error) {
+	c.record("QueryInfo", tripInfo)
+	if c.QueryInfoFunc != nil {
+		return c.QueryInfoFunc(tripInfo)
+	}
+	return new(service.QueryInfoResponse), nil
+}
+
+func (c *Client) QueryInfoInParallel(tripInfo service.TripInfo) (*service.QueryInfoInParallelTripResponse, error) {
+	c.record("QueryInfoInParallel", tripInfo)
+	if c.QueryInfoInParallelFunc != nil {
+		return c.QueryInfoInParallelFunc(tripInfo)
+	}
+	return new(service.QueryInfoInParallelTripResponse), nil
+}
+
+func (c *Client) GetTripAllDetailInfo(tripId service.GetTripDetailReq) (*service.GetTripAllDetailInfoResponse, error) {
+	c.record("GetTripAllDetailInfo", tripId)
+	if c.GetTripAllDetailInfoFunc != nil {
+		return c.GetTripAllDetailInfoFunc(tripId)
+	}
+	return new(service.GetTripAllDetailInfoResponse), nil
+}
+
+func (c *Client) QueryAllTrip() (*service.QueryAllTravelInfo, error) {
+	c.record("QueryAllTrip")
+	if c.QueryAllTripFunc != nil {
+		return c.QueryAllTripFunc()
+	}
+	return new(service.QueryAllTravelInfo), nil
+}
+
+func (c *Client) AdminQueryAll() (*service.AdminQueryAllTravelInfo, error) {
+	c.record("AdminQueryAll")
+	if c.AdminQueryAllFunc != nil {
+		return c.AdminQueryAllFunc()
+	}
+	return new(service.AdminQueryAllTravelInfo), nil
+}
+
+func (c *Client) ReqGetByCheapest(input *service.TravelQueryInfo) (*service.TravelQueryArrResponse, error) {
+	c.record("ReqGetByCheapest", input)
+	if c.ReqGetByCheapestFunc != nil {
+		return c.ReqGetByCheapestFunc(input)
+	}
+	return new(service.TravelQueryArrResponse), nil
+}
+
+func (c *Client) ReqGetByMinStation(input *service.TravelQueryInfo) (*service.TravelQueryArrResponse, error) {
+	c.record("ReqGetByMinStation", input)
+	if c.ReqGetByMinStationFunc != nil {
+		return c.ReqGetByMinStationFunc(input)
+	}
+	return new(service.TravelQueryArrResponse), nil
+}
+
+func (c *Client) ReqGetByQuickest(input *service.TravelQueryInfo) (*service.TravelQueryArrResponse, error) {
+	c.record("ReqGetByQuickest", input)
+	if c.ReqGetByQuickestFunc != nil {
+		return c.ReqGetByQuickestFunc(input)
+	}
+	return new(service.TravelQueryArrResponse), nil
+}
+
+func (c *Client) ReqTransferResult(input *service.TransferTravelQueryInfo) (*service.TravelQueryResponse, error) {
+	c.record("ReqTransferResult", input)
+	if c.ReqTransferResultFunc != nil {
+		return c.ReqTransferResultFunc(input)
+	}
+	return new(service.TravelQueryResponse), nil
+}
+
+func (c *Client) GetAllUsers() (*service.GetAllUserResponse, error) {
+	c.record("GetAllUsers")
+	if c.GetAllUsersFunc != nil {
+		return c.GetAllUsersFunc()
+	}
+	return new(service.GetAllUserResponse), nil
+}
+
+func (c *Client) GetUserByUserName(userName string) (*service.SingleUserResponse, error) {
+	c.record("GetUserByUserName", userName)
+	if c.GetUserByUserNameFunc != nil {
+		return c.GetUserByUserNameFunc(userName)
+	}
+	return new(service.SingleUserResponse), nil
+}
+
+func (c *Client) GetUserByUserId(userId string) (*service.SingleUserResponse, error) {
+	c.record("GetUserByUserId", userId)
+	if c.GetUserByUserIdFunc != nil {
+		return c.GetUserByUserIdFunc(userId)
+	}
+	return new(service.SingleUserResponse), nil
+}
+
+func (c *Client) RegisterUser(userDto *service.AdminUserDto) (*service.SingleUserResponse, error) {
+	c.record("RegisterUser", userDto)
+	if c.RegisterUserFunc != nil {
+		return c.RegisterUserFunc(userDto)
+	}
+	return new(service.SingleUserResponse), nil
+}
+
+func (c *Client) DeleteUser(userId string) (*service.SingleUserResponse, error) {
+	c.record("DeleteUser", userId)
+	if c.DeleteUserFunc != nil {
+		return c.DeleteUserFunc(userId)
+	}
+	return new(service.SingleUserResponse), nil
+}
+
+func (c *Client) UpdateUser(user *service.AdminUserDto) (*service.SingleUserResponse, error) {
+	c.record("UpdateUser", user)
+	if c.UpdateUserFunc != nil {
+		return c.UpdateUserFunc(user)
+	}
+	return new(service.SingleUserResponse), nil
+}
+
+func (c *Client) GenerateVerifyCode() (*service.VerifyCodeImage, error) {
+	c.record("GenerateVerifyCode")
+	if c.GenerateVerifyCodeFunc != nil {
+		return c.GenerateVerifyCodeFunc()
+	}
+	return new(service.VerifyCodeImage), nil
+}
+
+func (c *Client) VerifyCode(verifyCode string) (bool, error) {
+	c.record("VerifyCode", verifyCode)
+	if c.VerifyCodeFunc != nil {
+		return c.VerifyCodeFunc(verifyCode)
+	}
+	var r0 bool
+	return r0, nil
+}
+
+func (c *Client) ReqCreateNewWaitOrder(input *service.OrderVO) (*service.OrderResp, error) {
+	c.record("ReqCreateNewWaitOrder", input)
+	if c.ReqCreateNewWaitOrderFunc != nil {
+		return c.ReqCreateNewWaitOrderFunc(input)
+	}
+	return new(service.OrderResp), nil
+}
+
+func (c *Client) ReqGetAllWaitOrder() (*service.OrderArrResp, error) {
+	c.record("ReqGetAllWaitOrder")
+	if c.ReqGetAllWaitOrderFunc != nil {
+		return c.ReqGetAllWaitOrderFunc()
+	}
+	return new(service.OrderArrResp), nil
+}
+
+func (c *Client) ReqGetWaitListOrders() (*service.OrderArrResp, error) {
+	c.record("ReqGetWaitListOrders")
+	if c.ReqGetWaitListOrdersFunc != nil {
+		return c.ReqGetWaitListOrdersFunc()
+	}
+	return new(service.OrderArrResp), nil
+}
@@ -0,0 +1,38 @@
+package servicetest
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/Lincyaw/loadgenerator/service"
+)
+
+func TestClient(t *testing.T) {
+	errDown := errors.New("down")
+	cli := &Client{
+		ReqRebookFunc: func(info *service.RebookInfo) (*service.RebookResp, error) {
+			return nil, errDown
+		},
+	}
+	var svc service.Client = cli
+
+	resp, err := svc.QueryByOrderId("order-1")
+	if err != nil || resp == nil || resp.Status != 0 {
+		t.Errorf("QueryByOrderId() = %v, %v, want empty response", resp, err)
+	}
+	if _, err := svc.ReqRebook(&service.RebookInfo{OrderId: "order-1"}); err != errDown {
+		t.Errorf("ReqRebook() err = %v, want %v", err, errDown)
+	}
+	if ok, err := svc.VerifyCode("1234"); ok || err != nil {
+		t.Errorf("VerifyCode() = %v, %v, want zero values", ok, err)
+	}
+
+	if got, want := cli.Methods(), []string{"QueryByOrderId", "ReqRebook", "VerifyCode"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Methods() = %v, want %v", got, want)
+	}
+	calls := cli.CallsTo("ReqRebook")
+	if len(calls) != 1 || calls[0].Args[0].(*service.RebookInfo).OrderId != "order-1" {
+		t.Errorf("CallsTo(ReqRebook) = %v, want one call for order-1", calls)
+	}
+}
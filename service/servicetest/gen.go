@@ -0,0 +1,175 @@
+//go:build ignore
+
+// gen.go writes client_gen.go: a method and a XxxFunc field on Client for every method of the
+// interfaces in package service. Run it with go generate after changing a service interface.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"sort"
+	"strings"
+)
+
+type method struct {
+	name    string
+	params  []string // "name type"
+	args    []string
+	results []string
+}
+
+func main() {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, "..", func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		log.Fatal(err)
+	}
+	interfaces := map[string][]method{}
+	for _, file := range pkgs["service"].Files {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				spec := spec.(*ast.TypeSpec)
+				iface, ok := spec.Type.(*ast.InterfaceType)
+				if !ok || spec.Name.Name == "Client" {
+					continue
+				}
+				for _, field := range iface.Methods.List {
+					fn, ok := field.Type.(*ast.FuncType)
+					if !ok {
+						continue
+					}
+					interfaces[spec.Name.Name] = append(interfaces[spec.Name.Name], newMethod(fset, field.Names[0].Name, fn))
+				}
+			}
+		}
+	}
+	var names []string
+	for name := range interfaces {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b bytes.Buffer
+	b.WriteString("// Code generated by gen.go; DO NOT EDIT.\n\npackage servicetest\n\n")
+	b.WriteString("import \"github.com/Lincyaw/loadgenerator/service\"\n\n")
+	b.WriteString("var (\n\t_ service.Client = (*Client)(nil)\n")
+	for _, name := range names {
+		fmt.Fprintf(&b, "\t_ service.%s = (*Client)(nil)\n", name)
+	}
+	b.WriteString(")\n\n")
+	b.WriteString("// Client is a fake service.Client. Each method records the call and returns the result of the\n")
+	b.WriteString("// method's Func field, or an empty response and a nil error when the field is nil.\n")
+	b.WriteString("type Client struct {\n\trecorder\n")
+	for _, name := range names {
+		fmt.Fprintf(&b, "\n\t// %s\n", name)
+		for _, m := range interfaces[name] {
+			fmt.Fprintf(&b, "\t%sFunc func(%s) (%s)\n", m.name, strings.Join(m.params, ", "), strings.Join(m.results, ", "))
+		}
+	}
+	b.WriteString("}\n")
+	for _, name := range names {
+		for _, m := range interfaces[name] {
+			fmt.Fprintf(&b, "\nfunc (c *Client) %s(%s) (%s) {\n", m.name, strings.Join(m.params, ", "), strings.Join(m.results, ", "))
+			fmt.Fprintf(&b, "\tc.record(%q%s)\n", m.name, prefixed(m.args))
+			fmt.Fprintf(&b, "\tif c.%sFunc != nil {\n\t\treturn c.%sFunc(%s)\n\t}\n", m.name, m.name, strings.Join(m.args, ", "))
+			var zeros []string
+			for i, result := range m.results {
+				switch {
+				case result == "error":
+					zeros = append(zeros, "nil")
+				case strings.HasPrefix(result, "*"):
+					zeros = append(zeros, "new("+result[1:]+")")
+				default:
+					fmt.Fprintf(&b, "\tvar r%d %s\n", i, result)
+					zeros = append(zeros, fmt.Sprintf("r%d", i))
+				}
+			}
+			fmt.Fprintf(&b, "\treturn %s\n}\n", strings.Join(zeros, ", "))
+		}
+	}
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		log.Fatalf("format: %v\n%s", err, b.Bytes())
+	}
+	if err := os.WriteFile("client_gen.go", src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func newMethod(fset *token.FileSet, name string, fn *ast.FuncType) method {
+	m := method{name: name}
+	for _, field := range fn.Params.List {
+		typ := typeString(fset, field.Type)
+		names := field.Names
+		if len(names) == 0 {
+			names = []*ast.Ident{{Name: "_"}}
+		}
+		for _, ident := range names {
+			arg := ident.Name
+			if arg == "_" {
+				arg = fmt.Sprintf("arg%d", len(m.args))
+			}
+			m.params = append(m.params, arg+" "+typ)
+			if strings.HasPrefix(typ, "...") {
+				arg += "..."
+			}
+			m.args = append(m.args, arg)
+		}
+	}
+	if fn.Results != nil {
+		for _, field := range fn.Results.List {
+			for i := 0; i < max(len(field.Names), 1); i++ {
+				m.results = append(m.results, typeString(fset, field.Type))
+			}
+		}
+	}
+	return m
+}
+
+// typeString prints a type of package service as seen from another package.
+func typeString(fset *token.FileSet, expr ast.Expr) string {
+	expr = qualify(expr)
+	var b bytes.Buffer
+	if err := format.Node(&b, fset, expr); err != nil {
+		log.Fatal(err)
+	}
+	return b.String()
+}
+
+func qualify(expr ast.Expr) ast.Expr {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		if ast.IsExported(e.Name) {
+			return &ast.SelectorExpr{X: ast.NewIdent("service"), Sel: ast.NewIdent(e.Name)}
+		}
+	case *ast.StarExpr:
+		return &ast.StarExpr{X: qualify(e.X)}
+	case *ast.ArrayType:
+		return &ast.ArrayType{Len: e.Len, Elt: qualify(e.Elt)}
+	case *ast.MapType:
+		return &ast.MapType{Key: qualify(e.Key), Value: qualify(e.Value)}
+	case *ast.Ellipsis:
+		return &ast.Ellipsis{Elt: qualify(e.Elt)}
+	}
+	return expr
+}
+
+func prefixed(args []string) string {
+	var b strings.Builder
+	for _, arg := range args {
+		b.WriteString(", " + strings.TrimSuffix(arg, "..."))
+	}
+	return b.String()
+}
@@ -0,0 +1,55 @@
+// Package servicetest provides a fake service.Client for testing behaviours without HTTP.
+package servicetest
+
+//go:generate go run gen.go
+
+import "sync"
+
+// Call is a recorded method call.
+type Call struct {
+	Method string
+	Args   []interface{}
+}
+
+// recorder records the calls made to a fake. It is safe for concurrent use.
+type recorder struct {
+	mu    sync.Mutex
+	calls []Call
+}
+
+func (r *recorder) record(method string, args ...interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, Call{Method: method, Args: args})
+}
+
+// Calls returns the recorded calls in order.
+func (r *recorder) Calls() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Call(nil), r.calls...)
+}
+
+// CallsTo returns the recorded calls of method in order.
+func (r *recorder) CallsTo(method string) []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var calls []Call
+	for _, call := range r.calls {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// Methods returns the names of the called methods in order.
+func (r *recorder) Methods() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	methods := make([]string, len(r.calls))
+	for i, call := range r.calls {
+		methods[i] = call.Method
+	}
+	return methods
+}
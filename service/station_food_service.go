@@ -51,9 +51,9 @@ func (s *SvcImpl) GetAllStationFood() (*GetStationFoodResp, error) {
 
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 func (s *SvcImpl) GetStationFoodByName(stationName string) (*GetStationFoodResp, error) {
 	resp, err := s.cli.SendRequest("GET", fmt.Sprintf("%s/api/v1/stationfoodservice/stationfoodstores/%s", s.BaseUrl, stationName), nil)
@@ -68,9 +68,9 @@ func (s *SvcImpl) GetStationFoodByName(stationName string) (*GetStationFoodResp,
 
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 func (s *SvcImpl) GetStationFoodByNames(stationNames []string) (*GetStationFoodResp, error) {
 	resp, err := s.cli.SendRequest("POST", fmt.Sprintf("%s/api/v1/stationfoodservice/stationfoodstores", s.BaseUrl), stationNames)
@@ -85,9 +85,9 @@ func (s *SvcImpl) GetStationFoodByNames(stationNames []string) (*GetStationFoodR
 
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) GetStationFoodById(storeId string) (*GetStationFoodSingleResp, error) {
@@ -103,7 +103,7 @@ func (s *SvcImpl) GetStationFoodById(storeId string) (*GetStationFoodSingleResp,
 
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
@@ -3,7 +3,8 @@ package service
 import "testing"
 
 func TestSvcImpl_GetAllStationFood(t *testing.T) {
-	cli, _ := GetAdminClient()
+	withCassette := useCassette(t)
+	cli, _ := GetAdminClient(withCassette)
 	var stationFoodSvc StationFoodService = cli
 
 	resp, err := stationFoodSvc.GetAllStationFood()
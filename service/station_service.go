@@ -101,9 +101,9 @@ func (s *SvcImpl) QueryStations() (*GetStationResponse, error) {
 	var result GetStationResponse
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) CreateStation(input *Station) (*StationCreateResponse, error) {
@@ -119,9 +119,9 @@ func (s *SvcImpl) CreateStation(input *Station) (*StationCreateResponse, error)
 	var result StationCreateResponse
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) UpdateStation(input *Station) (*StationUpdateResponse, error) {
@@ -138,9 +138,9 @@ func (s *SvcImpl) UpdateStation(input *Station) (*StationUpdateResponse, error)
 	var result StationUpdateResponse
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) DeleteStation(stationId string) (*DeleteStationResponse, error) {
@@ -157,9 +157,9 @@ func (s *SvcImpl) DeleteStation(stationId string) (*DeleteStationResponse, error
 	var result DeleteStationResponse
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) QueryStationIdByName(stationName string) (*StationQueryIdByNameResponse, error) {
@@ -176,9 +176,9 @@ func (s *SvcImpl) QueryStationIdByName(stationName string) (*StationQueryIdByNam
 	var result StationQueryIdByNameResponse
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) QueryStationIdsByNames(stationNameList []string) (*QueryStationIdsByNamesResponse, error) {
@@ -194,9 +194,9 @@ func (s *SvcImpl) QueryStationIdsByNames(stationNameList []string) (*QueryStatio
 	var result QueryStationIdsByNamesResponse
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) QueryStationNameById(stationId string) (*QueryStationNameByIdResponse, error) {
@@ -212,9 +212,9 @@ func (s *SvcImpl) QueryStationNameById(stationId string) (*QueryStationNameByIdR
 	var result QueryStationNameByIdResponse
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) QueryStationNamesByIds(stationIdList []string) (*QueryStationNamesByIdsResponse, error) {
@@ -231,7 +231,7 @@ func (s *SvcImpl) QueryStationNamesByIds(stationIdList []string) (*QueryStationN
 	var result QueryStationNamesByIdsResponse
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
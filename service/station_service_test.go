@@ -2,15 +2,15 @@ package service
 
 import (
 	"github.com/go-faker/faker/v4"
-	"math/rand"
 	"strings"
 	"testing"
 )
 
 func TestStationService_FullIntegration(t *testing.T) {
+	withCassette := useCassette(t)
 	// Admin Test
 	// Query Test
-	cli, _ := GetAdminClient()
+	cli, _ := GetAdminClient(withCassette)
 	var stationSvc StationService = cli
 
 	resp, err := stationSvc.QueryStations()
@@ -30,7 +30,7 @@ func TestStationService_FullIntegration(t *testing.T) {
 	input := &Station{
 		ID:       faker.UUIDHyphenated(),
 		Name:     MockedCityName,
-		StayTime: rand.Intn(30),
+		StayTime: random.Intn(30),
 	}
 
 	// Create Test
@@ -73,7 +73,7 @@ func TestStationService_FullIntegration(t *testing.T) {
 
 	// Test Update
 	input1 := &Station{}
-	input1.StayTime = rand.Intn(30)
+	input1.StayTime = random.Intn(30)
 	input1.ID = existedStation.Id
 	input1.Name = existedStation.Name
 	resp2, err2 := stationSvc.UpdateStation(input1)
@@ -1,17 +1,21 @@
 package service
 
 import (
+	"context"
 	"fmt"
 	"github.com/Lincyaw/loadgenerator/httpclient"
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
+	"net/http"
 	"os"
 	"sort"
+	"strings"
 	"time"
 )
 
 type SvcImpl struct {
 	cli     *httpclient.HttpClient
+	auth    *httpclient.TokenManager
 	BaseUrl string
 }
 
@@ -21,7 +25,7 @@ func (s *SvcImpl) ShowStats() {
 	table := tview.NewTable().SetBorders(true)
 	table.SetBackgroundColor(tcell.ColorDefault)
 	// 设置表头
-	headers := []string{"URL", "Method", "Success", "Failed", "Request Bodies", "RouteResponse Bodies"}
+	headers := []string{"URL", "Method", "Success", "Failed", "Transport", "HTTP", "Business", "Short-circuited", "Retries", "Top Msgs", "Phases (mean/p50/p99)", "Sent (mean/max)", "Received (mean/max)", "Latest Request", "Latest Response"}
 	for i, header := range headers {
 		table.SetCell(0, i, tview.NewTableCell(header).SetTextColor(tcell.ColorYellow))
 	}
@@ -48,8 +52,20 @@ func (s *SvcImpl) ShowStats() {
 			table.SetCell(row, 1, tview.NewTableCell(key.Method))
 			table.SetCell(row, 2, tview.NewTableCell(fmt.Sprintf("%d", stats.Success)))
 			table.SetCell(row, 3, tview.NewTableCell(fmt.Sprintf("%d", stats.Failed)))
-			table.SetCell(row, 4, tview.NewTableCell(fmt.Sprintf("%v", stats.RequestBody)))
-			table.SetCell(row, 5, tview.NewTableCell(fmt.Sprintf("%v", stats.ResponseBody)))
+			table.SetCell(row, 4, tview.NewTableCell(fmt.Sprintf("%d", stats.TransportFailed)))
+			table.SetCell(row, 5, tview.NewTableCell(fmt.Sprintf("%d", stats.HTTPFailed)))
+			table.SetCell(row, 6, tview.NewTableCell(fmt.Sprintf("%d", stats.BusinessFailed)))
+			table.SetCell(row, 7, tview.NewTableCell(fmt.Sprintf("%d", stats.ShortCircuited)))
+			table.SetCell(row, 8, tview.NewTableCell(fmt.Sprintf("%d", stats.Retries)))
+			table.SetCell(row, 9, tview.NewTableCell(httpclient.FormatTopMsgs(stats.TopMsgs(3), "; ")))
+			table.SetCell(row, 10, tview.NewTableCell(stats.Timings.String()))
+			table.SetCell(row, 11, tview.NewTableCell(stats.RequestSize.String()))
+			table.SetCell(row, 12, tview.NewTableCell(stats.ResponseSize.String()))
+			if n := len(stats.Samples); n > 0 {
+				latest := stats.Samples[n-1]
+				table.SetCell(row, 13, tview.NewTableCell(latest.RequestBody))
+				table.SetCell(row, 14, tview.NewTableCell(fmt.Sprintf("[%d %v] %s", latest.StatusCode, latest.Latency, latest.ResponseBody)))
+			}
 			row++
 		}
 	}
@@ -67,14 +83,121 @@ func (s *SvcImpl) ShowStats() {
 	}
 }
 
+// SetContext 设置后续请求使用的上下文，请求的 span 会成为 ctx 中 span 的子 span。
+func (s *SvcImpl) SetContext(ctx context.Context) {
+	s.cli.SetContext(ctx)
+}
+
+// ClientOption 配置 NewSvcClients 创建的客户端。
+type ClientOption func(*clientOptions)
+
+type clientOptions struct {
+	recorder  *httpclient.Recorder
+	breaker   *httpclient.CircuitBreaker
+	transport func(http.RoundTripper) http.RoundTripper
+}
+
+// WithRecorder 将客户端的每次 HTTP 调用记录到 r，多个客户端可以共享同一个 Recorder。
+func WithRecorder(r *httpclient.Recorder) ClientOption {
+	return func(o *clientOptions) {
+		o.recorder = r
+	}
+}
+
+// WithCircuitBreaker 让客户端使用共享的熔断器 cb。一次压测的所有客户端应共享同一个熔断器，
+// 熔断状态才能跨迭代和 VU 累积。
+func WithCircuitBreaker(cb *httpclient.CircuitBreaker) ClientOption {
+	return func(o *clientOptions) {
+		o.breaker = cb
+	}
+}
+
+// WithTransport 用 wrap 包装客户端的 Transport，它位于重试和 cookie jar 之下。测试用它录制和回放 cassette。
+func WithTransport(wrap func(http.RoundTripper) http.RoundTripper) ClientOption {
+	return func(o *clientOptions) {
+		o.transport = wrap
+	}
+}
+
+// NewCircuitBreakerFromEnv 按 CIRCUIT_BREAKER 创建熔断器：service 按服务熔断，endpoint 按接口熔断，
+// 未设置时返回 nil。
+func NewCircuitBreakerFromEnv() (*httpclient.CircuitBreaker, error) {
+	config := httpclient.DefaultBreakerConfig()
+	switch mode := os.Getenv("CIRCUIT_BREAKER"); mode {
+	case "":
+		return nil, nil
+	case "service":
+	case "endpoint":
+		config.Key = httpclient.EndpointKey
+	default:
+		return nil, fmt.Errorf("invalid CIRCUIT_BREAKER %q, expected service or endpoint", mode)
+	}
+	return httpclient.NewCircuitBreaker(config), nil
+}
+
 func (s *SvcImpl) CleanUp() {
-	stats := httpclient.GenerateMarkdownTable(s.cli.GetRequestStats())
+	stats := httpclient.GenerateMarkdownTable(s.cli.GetRequestStats()) + "\n" +
+		httpclient.GenerateThroughputTable(s.cli.GetThroughput())
 	fmt.Println(stats)
 	os.WriteFile(fmt.Sprintf("data-%d.md", time.Now().UnixNano()), []byte(stats), 0644)
 }
 
-func NewSvcClients() *SvcImpl {
-	cli := httpclient.NewCustomClient()
+// NewSvcClients 创建服务客户端。
+// BASE_URL 可以用逗号分隔多个网关副本，SERVICE_URLS 为按服务路由的地址（见 httpclient.ParseServiceURLs），
+// LB_STRATEGY 为 round-robin、random 或 least-inflight。
+// 登录成功后 token 由 TokenManager 管理，过期前或收到 401/403 时使用相同凭据重新登录；
+// LoadGenerator 为每个 VU 保持一个客户端，因此 token 及其刷新在迭代之间延续。
+// 熔断器由 WithCircuitBreaker 传入，见 NewCircuitBreakerFromEnv。
+func NewSvcClients(opts ...ClientOption) *SvcImpl {
+	var options clientOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	baseUrl := os.Getenv("BASE_URL")
+	if baseUrl == "" {
+		panic("PLEASE use BASE_URL environment variable, example: BASE_URL=http://127.0.0.1:8080")
+	}
+	gateways := strings.Split(baseUrl, ",")
+	authConfig := httpclient.DefaultAuthConfig()
+	// 登录及其验证码请求不需要 token；重新登录时它们在刷新过程中发出，经过认证中间件会等待刷新本身而死锁
+	authConfig.Skip = func(req *http.Request) bool {
+		return req.URL.Path == "/api/v1/users/login" || strings.HasPrefix(req.URL.Path, "/api/v1/verifycode/")
+	}
+	auth := httpclient.NewTokenManager(authConfig)
+	httpOpts := []httpclient.Option{
+		httpclient.WithRetryPolicy(httpclient.DefaultRetryPolicy()),
+		httpclient.WithTokenManager(auth),
+		httpclient.WithCookieJar(),
+	}
+	if serviceUrls := os.Getenv("SERVICE_URLS"); len(gateways) > 1 || serviceUrls != "" {
+		services, err := httpclient.ParseServiceURLs(serviceUrls)
+		if err != nil {
+			panic(err)
+		}
+		router, err := httpclient.NewRouter(httpclient.RoutingConfig{
+			Default:  gateways,
+			Services: services,
+			Strategy: httpclient.Strategy(os.Getenv("LB_STRATEGY")),
+		})
+		if err != nil {
+			panic(err)
+		}
+		httpOpts = append(httpOpts, httpclient.WithRouter(router))
+	}
+	httpOpts = append(httpOpts,
+		httpclient.WithMetrics(nil),
+		httpclient.WithMiddleware(httpclient.TracingMiddleware(nil)),
+	)
+	if options.breaker != nil {
+		httpOpts = append(httpOpts, httpclient.WithSharedCircuitBreaker(options.breaker))
+	}
+	if options.recorder != nil {
+		httpOpts = append(httpOpts, httpclient.WithRecorder(options.recorder))
+	}
+	if options.transport != nil {
+		httpOpts = append(httpOpts, httpclient.WithTransport(options.transport))
+	}
+	cli := httpclient.NewCustomClient(httpOpts...)
 	cli.AddHeader("Proxy-Connection", "keep-alive")
 
 	cli.AddHeader("Accept", "application/json")
@@ -83,12 +206,9 @@ func NewSvcClients() *SvcImpl {
 	cli.AddHeader("Content-Type", "application/json")
 	cli.AddHeader("Accept-Language", "zh-CN,zh;q=0.9,en;q=0.8")
 	cli.AddHeader("Connection", "keep-alive")
-	baseUrl := os.Getenv("BASE_URL")
-	if baseUrl == "" {
-		panic("PLEASE use BASE_URL environment variable, example: BASE_URL=http://127.0.0.1:8080")
-	}
 	return &SvcImpl{
 		cli:     cli,
-		BaseUrl: baseUrl,
+		auth:    auth,
+		BaseUrl: gateways[0],
 	}
 }
@@ -47,9 +47,9 @@ func (s *SvcImpl) GetAllTrainFood() (*GetTrainFoodResp, error) {
 
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) GetTrainFoodByTripId(tripId string) (*GetTrainFoodByIdResp, error) {
@@ -65,7 +65,7 @@ func (s *SvcImpl) GetTrainFoodByTripId(tripId string) (*GetTrainFoodByIdResp, er
 
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
@@ -3,7 +3,8 @@ package service
 import "testing"
 
 func TestSvcImplGetAllTrainFood(t *testing.T) {
-	cli, _ := GetBasicClient()
+	withCassette := useCassette(t)
+	cli, _ := GetBasicClient(withCassette)
 	var trainFoodSvc TrainFoodService = cli
 
 	resp, err := trainFoodSvc.GetAllTrainFood()
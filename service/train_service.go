@@ -78,10 +78,10 @@ func (s *SvcImpl) Create(trainType *TrainType) (*CreateStationResponse, error) {
 	var result CreateStationResponse
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
 
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 type TrainRetrieveTrainType struct {
@@ -112,10 +112,10 @@ func (s *SvcImpl) Retrieve(id string) (*TrainServiceRetrieveTrainType, error) {
 	var result TrainServiceRetrieveTrainType
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
 
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 type TrainRetrieveByNameType struct {
@@ -140,10 +140,10 @@ func (s *SvcImpl) RetrieveByName(name string) (*TrainRetrieveByNameType, error)
 	var result TrainRetrieveByNameType
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
 
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 type TrainRetrieveByNamesType struct {
@@ -168,10 +168,10 @@ func (s *SvcImpl) RetrieveByNames(names []string) (*TrainRetrieveByNamesType, er
 	var result TrainRetrieveByNamesType
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
 
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 type TrainUpdateResponse struct {
@@ -196,10 +196,10 @@ func (s *SvcImpl) Update(trainType *TrainType) (*TrainUpdateResponse, error) {
 	var result TrainUpdateResponse
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
 
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 type TrainDeleteResponse struct {
@@ -224,10 +224,10 @@ func (s *SvcImpl) Delete(id string) (*TrainDeleteResponse, error) {
 	var result TrainDeleteResponse
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
 
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 type TrainResponseType struct {
@@ -252,8 +252,8 @@ func (s *SvcImpl) Query() (*TrainResponseType, error) {
 	var result TrainResponseType
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
 
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
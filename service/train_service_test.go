@@ -1,14 +1,15 @@
 package service
 
 import (
-	"math/rand"
 	"testing"
 
 	"github.com/go-faker/faker/v4"
 )
 
 func TestTrainService_FullIntegration(t *testing.T) {
-	cli, _ := GetAdminClient()
+	requireCluster(t)
+	withCassette := useCassette(t)
+	cli, _ := GetAdminClient(withCassette)
 	var trainSvc TrainService = cli
 
 	// Mock data
@@ -20,7 +21,7 @@ func TestTrainService_FullIntegration(t *testing.T) {
 	MockedName := GenerateTrainTypeName()
 	MockedEconomyClass := 2147483647 // MAX Value
 	MockedConfortClass := 2147483647 // Max Value
-	MockedAverageSpeed := 250 + rand.Intn(20)
+	MockedAverageSpeed := 250 + random.Intn(20)
 	// input
 	trainType := TrainType{
 		AverageSpeed: MockedAverageSpeed,
@@ -88,7 +89,7 @@ func TestTrainService_FullIntegration(t *testing.T) {
 	}
 
 	// Test Update
-	UpdatedAverageSpeed := 275 + rand.Intn(10)
+	UpdatedAverageSpeed := 275 + random.Intn(10)
 	updateTrainType := TrainType{
 		Id:           createResp.Data.Id,
 		Name:         trainType.Name,
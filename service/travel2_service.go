@@ -182,9 +182,9 @@ func (s *SvcImpl) GetTrain2TypeByTripId(tripId string) (*GetTrainTypeByTripId2Re
 	var result GetTrainTypeByTripId2Response
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) GetRouteByTrip2Id(tripId string) (*GetRouteByTripIdResponse, error) {
@@ -202,9 +202,9 @@ func (s *SvcImpl) GetRouteByTrip2Id(tripId string) (*GetRouteByTripIdResponse, e
 	var result GetRouteByTripIdResponse
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) GetTrip2ByRoute(routeIds []string) (*GetTripByRouteIdResponse, error) {
@@ -222,9 +222,9 @@ func (s *SvcImpl) GetTrip2ByRoute(routeIds []string) (*GetTripByRouteIdResponse,
 	var result GetTripByRouteIdResponse
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) CreateTrip2(travelInfo *TravelInfo) (*CreateTripResponse, error) {
@@ -242,9 +242,9 @@ func (s *SvcImpl) CreateTrip2(travelInfo *TravelInfo) (*CreateTripResponse, erro
 	var result CreateTripResponse
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) RetrieveTrip2(tripId string) (*RetrieveTripResponse, error) {
@@ -262,9 +262,9 @@ func (s *SvcImpl) RetrieveTrip2(tripId string) (*RetrieveTripResponse, error) {
 	var result RetrieveTripResponse
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) UpdateTrip2(travelInfo *TravelInfo) (*UpdateTripResponse, error) {
@@ -282,9 +282,9 @@ func (s *SvcImpl) UpdateTrip2(travelInfo *TravelInfo) (*UpdateTripResponse, erro
 	var result UpdateTripResponse
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) DeleteTrip2(tripId string) (*DeleteTripResponse, error) {
@@ -302,9 +302,9 @@ func (s *SvcImpl) DeleteTrip2(tripId string) (*DeleteTripResponse, error) {
 	var result DeleteTripResponse
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) QueryByBatch(tripInfo *TripInfo) (*QueryByBatchResponse, error) {
@@ -322,9 +322,9 @@ func (s *SvcImpl) QueryByBatch(tripInfo *TripInfo) (*QueryByBatchResponse, error
 	var result QueryByBatchResponse
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) GetTrip2AllDetailInfo(tripAllDetailInfo *Trip2AllDetailInfo) (*GetTripAllDetailInfoResponse, error) {
@@ -342,9 +342,9 @@ func (s *SvcImpl) GetTrip2AllDetailInfo(tripAllDetailInfo *Trip2AllDetailInfo) (
 	var result GetTripAllDetailInfoResponse
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) QueryAllTravel() (*QueryAllResponse, error) {
@@ -362,9 +362,9 @@ func (s *SvcImpl) QueryAllTravel() (*QueryAllResponse, error) {
 	var result QueryAllResponse
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) AdminQueryAllTravel() (*AdminQueryAllResponse, error) {
@@ -382,7 +382,7 @@ func (s *SvcImpl) AdminQueryAllTravel() (*AdminQueryAllResponse, error) {
 	var result AdminQueryAllResponse
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
@@ -11,7 +11,9 @@ import (
 )
 
 func TestTravel2Service_FullIntegration(t *testing.T) {
-	cli, _ := GetAdminClient()
+	requireCluster(t)
+	withCassette := useCassette(t)
+	cli, _ := GetAdminClient(withCassette)
 
 	var travelSvc TravelService = cli
 	var travel2Svc Travel2Service = cli
@@ -156,10 +158,10 @@ func TestTravel2Service_FullIntegration(t *testing.T) {
 //	letters := []rune{'Z', 'T', 'K', 'G', 'D'}
 //
 //	// 随机选择一个字母
-//	startLetter := letters[rand.Intn(len(letters))]
+//	startLetter := letters[random.Intn(len(letters))]
 //
 //	// 生成三个随机数字
-//	randomNumber := rand.Intn(1000)
+//	randomNumber := random.Intn(1000)
 //
 //	// 格式化成三位数字，不足三位前面补零
 //	MockedTripID := fmt.Sprintf("%c%03d", startLetter, randomNumber)
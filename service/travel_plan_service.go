@@ -27,7 +27,7 @@ func (s *SvcImpl) ReqGetByCheapest(input *TravelQueryInfo) (*TravelQueryArrRespo
 	if err != nil {
 		return nil, err
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) ReqGetByMinStation(input *TravelQueryInfo) (*TravelQueryArrResponse, error) {
@@ -45,7 +45,7 @@ func (s *SvcImpl) ReqGetByMinStation(input *TravelQueryInfo) (*TravelQueryArrRes
 	if err != nil {
 		return nil, err
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) ReqGetByQuickest(input *TravelQueryInfo) (*TravelQueryArrResponse, error) {
@@ -63,7 +63,7 @@ func (s *SvcImpl) ReqGetByQuickest(input *TravelQueryInfo) (*TravelQueryArrRespo
 	if err != nil {
 		return nil, err
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) ReqTransferResult(input *TransferTravelQueryInfo) (*TravelQueryResponse, error) {
@@ -81,5 +81,5 @@ func (s *SvcImpl) ReqTransferResult(input *TransferTravelQueryInfo) (*TravelQuer
 	if err != nil {
 		return nil, err
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
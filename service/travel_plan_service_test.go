@@ -6,7 +6,8 @@ import (
 )
 
 func TestSvcImpl_ReqGetByCheapest(t *testing.T) {
-	cli, _ := GetAdminClient()
+	withCassette := useCassette(t)
+	cli, _ := GetAdminClient(withCassette)
 	AddResp, err := cli.ReqGetByCheapest(&TravelQueryInfo{
 		DepartureTime: "2024-07-19",
 		EndPlace:      "taiyuan",
@@ -21,7 +22,8 @@ func TestSvcImpl_ReqGetByCheapest(t *testing.T) {
 }
 
 func TestSvcImpl_ReqGetByMinStation(t *testing.T) {
-	cli, _ := GetAdminClient()
+	withCassette := useCassette(t)
+	cli, _ := GetAdminClient(withCassette)
 	AddResp, err := cli.ReqGetByMinStation(&TravelQueryInfo{
 		DepartureTime: "2024-07-19",
 		EndPlace:      "taiyuan",
@@ -36,7 +38,8 @@ func TestSvcImpl_ReqGetByMinStation(t *testing.T) {
 }
 
 func TestSvcImpl_ReqGetByQuickest(t *testing.T) {
-	cli, _ := GetAdminClient()
+	withCassette := useCassette(t)
+	cli, _ := GetAdminClient(withCassette)
 	AddResp, err := cli.ReqGetByQuickest(&TravelQueryInfo{
 		DepartureTime: "2024-07-19",
 		EndPlace:      "taiyuan",
@@ -51,7 +54,8 @@ func TestSvcImpl_ReqGetByQuickest(t *testing.T) {
 }
 
 func TestSvcImpl_ReqTransferResult(t *testing.T) {
-	cli, _ := GetAdminClient()
+	withCassette := useCassette(t)
+	cli, _ := GetAdminClient(withCassette)
 	AddResp, err := cli.ReqTransferResult(&TransferTravelQueryInfo{
 		EndStation:   "taiyuan",
 		StartStation: "nanjing",
@@ -112,10 +112,10 @@ func (s *SvcImpl) GetTrainTypeByTripId(tripId string) (*GetTrainTypeByTripIdResp
 	var result GetTrainTypeByTripIdResponse
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
 
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 type GetRouteByTripIdResponse struct {
@@ -146,10 +146,10 @@ func (s *SvcImpl) GetRouteByTripId(tripId string) (*GetRouteByTripIdResponse, er
 	var result GetRouteByTripIdResponse
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
 
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 type GetTripsByRouteIdResponse struct {
@@ -174,10 +174,10 @@ func (s *SvcImpl) GetTripsByRouteId(routeIds []string) (*GetTripsByRouteIdRespon
 	var result GetTripsByRouteIdResponse
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
 
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) CreateTrip(travelInfo *TravelInfo) (*TripResponse, error) {
@@ -196,10 +196,10 @@ func (s *SvcImpl) CreateTrip(travelInfo *TravelInfo) (*TripResponse, error) {
 	var result TripResponse
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
 
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) RetrieveTravel(tripId string) (*TravelInfo, error) {
@@ -218,10 +218,10 @@ func (s *SvcImpl) RetrieveTravel(tripId string) (*TravelInfo, error) {
 	var result TravelInfo
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
 
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) UpdateTrip(travelInfo *TravelInfo) (*TripResponse, error) {
@@ -240,10 +240,10 @@ func (s *SvcImpl) UpdateTrip(travelInfo *TravelInfo) (*TripResponse, error) {
 	var result TripResponse
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
 
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 //type DeleteTripResponse struct {
@@ -268,10 +268,10 @@ func (s *SvcImpl) DeleteTrip(tripId string) (*DeleteTripResponse, error) {
 	var result DeleteTripResponse
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
 
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 type QueryInfoResponse struct {
@@ -296,10 +296,10 @@ func (s *SvcImpl) QueryInfo(tripInfo TripInfo) (*QueryInfoResponse, error) {
 	var result QueryInfoResponse
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
 
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 type QueryInfoInParallelTripResponse struct {
@@ -338,10 +338,10 @@ func (s *SvcImpl) QueryInfoInParallel(tripInfo TripInfo) (*QueryInfoInParallelTr
 	var result QueryInfoInParallelTripResponse
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
 
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 type GetTripAllDetailInfoResponse struct {
@@ -403,10 +403,10 @@ func (s *SvcImpl) GetTripAllDetailInfo(trip GetTripDetailReq) (*GetTripAllDetail
 	var result GetTripAllDetailInfoResponse
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
 
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) QueryAllTrip() (*QueryAllTravelInfo, error) {
@@ -425,10 +425,10 @@ func (s *SvcImpl) QueryAllTrip() (*QueryAllTravelInfo, error) {
 	var result QueryAllTravelInfo
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
 
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) AdminQueryAll() (*AdminQueryAllTravelInfo, error) {
@@ -447,8 +447,8 @@ func (s *SvcImpl) AdminQueryAll() (*AdminQueryAllTravelInfo, error) {
 	var result AdminQueryAllTravelInfo
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
 
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
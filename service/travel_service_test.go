@@ -4,14 +4,15 @@ import (
 	"fmt"
 	"github.com/go-faker/faker/v4"
 	"log"
-	"math/rand"
 	"strings"
 	"sync"
 	"testing"
 )
 
 func TestTravelService_FullIntegration(t *testing.T) {
-	cli, _ := GetAdminClient() // The loginResult below should also be the corresponding one! Or -> Forbidden.
+	requireCluster(t)
+	withCassette := useCassette(t)
+	cli, _ := GetAdminClient(withCassette) // The loginResult below should also be the corresponding one! Or -> Forbidden.
 	var travelSvc TravelService = cli
 
 	/*	loginResult, err := cli.ReqUserLogin(&UserLoginInfoReq{ // Basic
@@ -46,7 +47,7 @@ func TestTravelService_FullIntegration(t *testing.T) {
 		t.Errorf("AllRoutes_By_Query.Status != 1")
 	}
 
-	routeRandomIndex := rand.Intn(len(AllRoutesByQuery.Data))
+	routeRandomIndex := random.Intn(len(AllRoutesByQuery.Data))
 	randomRoute := AllRoutesByQuery.Data[routeRandomIndex]
 
 	// Mock para
@@ -105,8 +106,8 @@ func TestTravelService_FullIntegration(t *testing.T) {
 	// Create the corresponding price service at the same time
 	var priceSvc PriceService = cli
 	MockedPriceID := faker.UUIDHyphenated()
-	MockedBasicPriceRate := rand.Float64()
-	MockedFirstClassPriceRate := rand.Float64()
+	MockedBasicPriceRate := random.Float64()
+	MockedFirstClassPriceRate := random.Float64()
 	// Create a new price config
 	createReq := &PriceConfig{
 		ID:                  MockedPriceID,
@@ -188,7 +189,7 @@ func TestTravelService_FullIntegration(t *testing.T) {
 	}
 
 	// Generate a random index within the range of Data list length
-	randomIndex := rand.Intn(len(QueryAllStations.Data))
+	randomIndex := random.Intn(len(QueryAllStations.Data))
 	// Access the Name field using the random index
 	randomStationName := QueryAllStations.Data[randomIndex].Name
 
@@ -255,7 +256,7 @@ func TestTravelService_FullIntegration(t *testing.T) {
 	}
 
 	//TripIdForQuery := fmt.Sprintf("%s%s", updatedTravel.TripId.Type, updatedTravel.TripId.Number)
-	randIndexForQuery := rand.Intn(len(allUpdatedTravelInfos.Data))
+	randIndexForQuery := random.Intn(len(allUpdatedTravelInfos.Data))
 	randomGetTravel := allUpdatedTravelInfos.Data[randIndexForQuery]
 	TripIdForQuery := fmt.Sprintf("%s%s", randomGetTravel.TripId.Type, randomGetTravel.TripId.Number)
 	// Test GetTrainTypeByTripId
@@ -349,6 +350,8 @@ func TestTravelService_FullIntegration(t *testing.T) {
 }
 
 func TestTravelServiceQueryAll_InfiniteLoop_ForTesting(t *testing.T) {
+	requireCluster(t)
+	withCassette := useCassette(t)
 	var wg sync.WaitGroup
 	//numIterations := 100
 
@@ -357,7 +360,7 @@ func TestTravelServiceQueryAll_InfiniteLoop_ForTesting(t *testing.T) {
 		go func() {
 			defer wg.Done()
 
-			cli, _ := GetAdminClient()
+			cli, _ := GetAdminClient(withCassette)
 			for {
 				// Query Test
 				_, err := cli.QueryAllTrip()
@@ -375,7 +378,9 @@ func TestTravelServiceQueryAll_InfiniteLoop_ForTesting(t *testing.T) {
 }
 
 func TestGetTripAllDetailInfo(t *testing.T) {
-	cli, _ := GetAdminClient()
+	requireCluster(t)
+	withCassette := useCassette(t)
+	cli, _ := GetAdminClient(withCassette)
 
 	resp, err := cli.QueryAllTrip()
 	if err != nil {
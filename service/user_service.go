@@ -57,9 +57,9 @@ func (s *SvcImpl) GetAllUsers() (*GetAllUserResponse, error) {
 
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) GetUserByUserName(userName string) (*SingleUserResponse, error) {
@@ -76,9 +76,9 @@ func (s *SvcImpl) GetUserByUserName(userName string) (*SingleUserResponse, error
 
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) GetUserByUserId(userId string) (*SingleUserResponse, error) {
@@ -95,9 +95,9 @@ func (s *SvcImpl) GetUserByUserId(userId string) (*SingleUserResponse, error) {
 
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) RegisterUser(userDto *AdminUserDto) (*SingleUserResponse, error) {
@@ -114,9 +114,9 @@ func (s *SvcImpl) RegisterUser(userDto *AdminUserDto) (*SingleUserResponse, erro
 
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) DeleteUser(userId string) (*SingleUserResponse, error) {
@@ -133,9 +133,9 @@ func (s *SvcImpl) DeleteUser(userId string) (*SingleUserResponse, error) {
 
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
 
 func (s *SvcImpl) UpdateUser(user *AdminUserDto) (*SingleUserResponse, error) {
@@ -152,7 +152,7 @@ func (s *SvcImpl) UpdateUser(user *AdminUserDto) (*SingleUserResponse, error) {
 
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, errors.Join(err, fmt.Errorf("body: %v", string(body)))
+		return nil, errors.Join(err, checkResponse(resp, body), fmt.Errorf("body: %v", string(body)))
 	}
-	return &result, nil
+	return &result, checkResponse(resp, body)
 }
@@ -3,14 +3,13 @@ package service
 import (
 	"github.com/go-faker/faker/v4"
 	"github.com/google/uuid"
-	"math/rand"
 	"strconv"
 	"testing"
-	"time"
 )
 
 func TestUserService_FullIntegration(t *testing.T) {
-	cli, _ := GetAdminClient()
+	withCassette := useCassette(t)
+	cli, _ := GetAdminClient(withCassette)
 	MockedID := faker.UUIDHyphenated()
 	MockedUserName := faker.Username()
 
@@ -89,21 +88,21 @@ func TestUserService_FullIntegration(t *testing.T) {
 }
 
 func TestUserService_FullIntegration_v2(t *testing.T) {
+	withCassette := useCassette(t)
 
-	cli, _ := GetAdminClient()
+	cli, _ := GetAdminClient(withCassette)
 	var userSvc UserService = cli
 
 	// 使用当前时间作为随机数生成器的种子，确保每次运行程序时得到不同的结果
-	rand.Seed(time.Now().UnixNano())
 
 	// Test RegisterUser
 	input := &AdminUserDto{
 		UserID:       uuid.NewString(),
 		UserName:     faker.Name(),
 		Password:     faker.Password(),
-		Gender:       rand.Intn(2),
-		DocumentType: rand.Intn(2),
-		DocumentNum:  strconv.Itoa(rand.Intn(9999)),
+		Gender:       random.Intn(2),
+		DocumentType: random.Intn(2),
+		DocumentNum:  strconv.Itoa(random.Intn(9999)),
 		Email:        faker.Email(),
 	}
 
@@ -230,9 +229,9 @@ func TestUserService_FullIntegration_v2(t *testing.T) {
 		UserID:       input.UserID,
 		UserName:     faker.Name(),
 		Password:     faker.Password(),
-		Gender:       rand.Intn(2),
-		DocumentType: rand.Intn(2),
-		DocumentNum:  strconv.Itoa(rand.Intn(9999)),
+		Gender:       random.Intn(2),
+		DocumentType: random.Intn(2),
+		DocumentNum:  strconv.Itoa(random.Intn(9999)),
 		Email:        faker.Email(),
 	}
 
@@ -2,7 +2,6 @@ package service
 
 import (
 	"fmt"
-	"math/rand"
 	"strconv"
 	"strings"
 	"time"
@@ -12,11 +11,9 @@ import (
 func generateVerifyCode() string {
 	const charset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
 	const length = 6
-	var seededRand *rand.Rand = rand.New(rand.NewSource(time.Now().UnixNano()))
-
 	code := make([]byte, length)
 	for i := range code {
-		code[i] = charset[seededRand.Intn(len(charset))]
+		code[i] = charset[random.Intn(len(charset))]
 	}
 	return string(code)
 }
@@ -28,7 +25,7 @@ func generateTrainTypeName(input string) string {
 
 	switch startLetter {
 	case "G":
-		if rand.Intn(2) == 0 {
+		if random.Intn(2) == 0 {
 			MockedTrainType = "GaoTieOne"
 		} else {
 			MockedTrainType = "GaoTieTwo"
@@ -51,9 +48,7 @@ func generateTrainTypeName(input string) string {
 // generateDocumentNumber generates a DocumentNumber with 50% probability for "DocumentNumber_One"
 // and 50% probability for "DocumentNumber_Two".
 func generateDocumentNumber() string {
-	rand.Seed(time.Now().UnixNano()) // Seed the random number generator
-
-	if rand.Intn(2) == 0 {
+	if random.Intn(2) == 0 {
 		return "DocumentNumber_One"
 	} else {
 		return "DocumentNumber_Two"
@@ -61,17 +56,14 @@ func generateDocumentNumber() string {
 }
 
 func GenerateTripId() string {
-	// 设置随机数种子
-	rand.Seed(time.Now().UnixNano())
-
 	// 定义可能的开头字母
 	letters := []rune{'Z', 'T', 'K', 'G', 'D'}
 
 	// 随机选择一个字母
-	startLetter := letters[rand.Intn(len(letters))]
+	startLetter := letters[random.Intn(len(letters))]
 
 	// 生成四个随机数字
-	randomNumber := rand.Intn(10000)
+	randomNumber := random.Intn(10000)
 
 	// 格式化成三位数字，不足三位前面补零
 	MockedTripID := fmt.Sprintf("%c%03d", startLetter, randomNumber)
@@ -88,14 +80,11 @@ func toLowerCaseAndRemoveSpaces(input string) string {
 }
 
 func GenerateTrainTypeName() string {
-	// 设置随机数种子
-	rand.Seed(time.Now().UnixNano())
-
 	// 定义可能的火车类型名称
 	trainTypes := []string{"GaoTieOne", "GaoTieTwo", "DongCheOne", "ZhiDa", "TeKuai", "KuaiSu"}
 
 	// 随机选择一个火车类型名称
-	MockedTrainTypeName := trainTypes[rand.Intn(len(trainTypes))]
+	MockedTrainTypeName := trainTypes[random.Intn(len(trainTypes))]
 
 	return MockedTrainTypeName
 }
@@ -113,14 +102,12 @@ func getMiddleElements(input string) string {
 }
 
 func generateDescription() string {
-	rand.Seed(time.Now().UnixNano())
-
 	// Generate a random number with one decimal place between 0.1 and 10.0
-	randomNumber := rand.Float64()*9.9 + 0.1
+	randomNumber := random.Float64()*9.9 + 0.1
 	numberStr := strconv.FormatFloat(randomNumber, 'f', 1, 64)
 
 	// Determine if 'Max' should be replaced by 'Min' with a probability of 0.3
-	replaceMax := rand.Float64() < 0.3
+	replaceMax := random.Float64() < 0.3
 	description := "Max"
 	if replaceMax {
 		description = "Min"
@@ -130,13 +117,12 @@ func generateDescription() string {
 }
 
 func generateRandomNumberString() string {
-	rand.Seed(time.Now().UnixNano())
 	numberLength := 10 // Length of the number string
 
 	// Generate a random number string of the specified length
 	numberStr := ""
 	for i := 0; i < numberLength; i++ {
-		digit := rand.Intn(10) // Generate a random digit (0-9)
+		digit := random.Intn(10) // Generate a random digit (0-9)
 		numberStr += strconv.Itoa(digit)
 	}
 
@@ -185,9 +171,9 @@ func StringToList(input string) []string {
 
 // generateRandomTime generates a random time in the format "HH:MM:SS".
 func generateRandomTime() string {
-	hour := rand.Intn(24)   // 0-23
-	minute := rand.Intn(60) // 0-59
-	second := rand.Intn(60) // 0-59
+	hour := random.Intn(24)   // 0-23
+	minute := random.Intn(60) // 0-59
+	second := random.Intn(60) // 0-59
 	return fmt.Sprintf("%02d:%02d:%02d", hour, minute, second)
 }
 
@@ -217,14 +203,14 @@ func getRandomTime(opts ...Option) string {
 		} else {
 			now = startTime
 			// 生成1小时到1天之后的时间
-			randomHours := rand.Intn(24) + 1
+			randomHours := random.Intn(24) + 1
 			randomDate := now.Add(time.Duration(randomHours) * time.Hour)
 			return randomDate.Format("2006-01-02 15:04:05")
 		}
 	}
 
 	// 保持原来的逻辑，生成从今天起到未来一个月内的随机日期
-	randomDays := rand.Intn(30) + 1
+	randomDays := random.Intn(30) + 1
 	randomDate := now.AddDate(0, 0, randomDays)
 	return randomDate.Format("2006-01-02 15:04:05")
 }
@@ -275,7 +261,6 @@ func StringSliceToString(strs []string) string {
 
 // RandomSelectString selects a random string from a given slice of strings
 func RandomSelectString(options []string) string {
-	rand.Seed(time.Now().UnixNano()) // Seed the random number generator
-	randomIndex := rand.Intn(len(options))
+	randomIndex := random.Intn(len(options))
 	return options[randomIndex]
 }
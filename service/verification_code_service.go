@@ -1,15 +1,119 @@
 package service
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
 )
 
 type VerificationCodeService interface {
+	GenerateVerifyCode() (*VerifyCodeImage, error)
 	VerifyCode(verifyCode string) (bool, error)
 }
 
+// VerifyCodeImage is the captcha returned by the generate endpoint. The service keeps the
+// expected code keyed by the YsbCaptcha cookie, which the client's cookie jar sends back on verify and login.
+type VerifyCodeImage struct {
+	Image       []byte
+	ContentType string
+	CaptchaId   string
+}
+
+// VerifyCodeSolver reads the code from a captcha image.
+type VerifyCodeSolver func(image *VerifyCodeImage) (string, error)
+
+// ErrNoVerifyCodeSolver is returned by LoginWithVerifyCode without a solver.
+var ErrNoVerifyCodeSolver = errors.New("no verify code solver, set VERIFY_CODE or VERIFY_CODE_SOLVER_URL")
+
+// FixedVerifyCodeSolver submits code without reading the image, for deployments whose verify code service
+// accepts a fixed code, e.g. in a test mode.
+func FixedVerifyCodeSolver(code string) VerifyCodeSolver {
+	return func(*VerifyCodeImage) (string, error) {
+		return code, nil
+	}
+}
+
+// HTTPVerifyCodeSolver posts the image to an OCR endpoint and submits the trimmed response body as the code.
+func HTTPVerifyCodeSolver(endpoint string) VerifyCodeSolver {
+	client := &http.Client{Timeout: 10 * time.Second}
+	return func(image *VerifyCodeImage) (string, error) {
+		contentType := image.ContentType
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		resp, err := client.Post(endpoint, contentType, bytes.NewReader(image.Image))
+		if err != nil {
+			return "", err
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return "", err
+		}
+		if resp.StatusCode != http.StatusOK {
+			return "", fmt.Errorf("verify code solver %s: %s: %s", endpoint, resp.Status, bytes.TrimSpace(body))
+		}
+		code := strings.TrimSpace(string(body))
+		if code == "" {
+			return "", fmt.Errorf("verify code solver %s returned no code", endpoint)
+		}
+		return code, nil
+	}
+}
+
+// VerifyCodeSolverFromEnv returns the solver configured by VERIFY_CODE_SOLVER_URL (an OCR endpoint, see
+// HTTPVerifyCodeSolver) or VERIFY_CODE (a fixed code), nil when neither is set.
+func VerifyCodeSolverFromEnv() (VerifyCodeSolver, error) {
+	endpoint, code := os.Getenv("VERIFY_CODE_SOLVER_URL"), os.Getenv("VERIFY_CODE")
+	switch {
+	case endpoint != "" && code != "":
+		return nil, errors.New("set only one of VERIFY_CODE and VERIFY_CODE_SOLVER_URL")
+	case endpoint != "":
+		if u, err := url.Parse(endpoint); err != nil || u.Scheme == "" || u.Host == "" {
+			return nil, fmt.Errorf("invalid VERIFY_CODE_SOLVER_URL %q", endpoint)
+		}
+		return HTTPVerifyCodeSolver(endpoint), nil
+	case code != "":
+		return FixedVerifyCodeSolver(code), nil
+	}
+	return nil, nil
+}
+
+const captchaCookie = "YsbCaptcha"
+
+func (s *SvcImpl) GenerateVerifyCode() (*VerifyCodeImage, error) {
+	resp, err := s.cli.SendRequest("GET", s.BaseUrl+"/api/v1/verifycode/generate", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkResponse(resp, body); err != nil {
+		return nil, err
+	}
+	result := &VerifyCodeImage{
+		Image:       body,
+		ContentType: resp.Header.Get("Content-Type"),
+	}
+	for _, cookie := range resp.Cookies() {
+		if cookie.Name == captchaCookie {
+			result.CaptchaId = cookie.Value
+		}
+	}
+	return result, nil
+}
+
 func (s *SvcImpl) VerifyCode(verifyCode string) (bool, error) {
 	resp, err := s.cli.SendRequest("GET", s.BaseUrl+fmt.Sprintf("/api/v1/verifycode/verify/%s", verifyCode), nil)
 	if err != nil {
@@ -24,7 +128,7 @@ func (s *SvcImpl) VerifyCode(verifyCode string) (bool, error) {
 	var result bool
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return false, err
+		return false, errors.Join(err, checkResponse(resp, body))
 	}
-	return result, nil
+	return result, checkResponse(resp, body)
 }
@@ -1,11 +1,16 @@
 package service
 
 import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 )
 
 func TestVerifyCodeService_VerifyCode(t *testing.T) {
-	cli, _ := GetAdminClient()
+	withCassette := useCassette(t)
+	cli, _ := GetAdminClient(withCassette)
 	verifyCode := generateVerifyCode()
 	result, err := cli.VerifyCode(verifyCode)
 	if err != nil {
@@ -16,3 +21,70 @@ func TestVerifyCodeService_VerifyCode(t *testing.T) {
 	}
 	t.Logf("Verification code verified. The result is %v and verifyCode: %v", result, verifyCode)
 }
+
+func TestVerifyCodeService_GenerateVerifyCode(t *testing.T) {
+	withCassette := useCassette(t)
+	cli := NewSvcClients(withCassette)
+	image, err := cli.GenerateVerifyCode()
+	if err != nil {
+		t.Errorf("Request failed, err %s", err)
+	}
+	if len(image.Image) == 0 {
+		t.Errorf("Empty verify code image")
+	}
+	if image.CaptchaId == "" {
+		t.Errorf("YsbCaptcha cookie not set")
+	}
+	t.Logf("Verify code image: %v bytes of %v, captcha id: %v", len(image.Image), image.ContentType, image.CaptchaId)
+}
+
+func TestHTTPVerifyCodeSolver(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if r.Header.Get("Content-Type") != "image/jpeg" || string(body) != "captcha" {
+			http.Error(w, "unexpected image", http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte("AB12cd\n"))
+	}))
+	defer srv.Close()
+
+	solve := HTTPVerifyCodeSolver(srv.URL)
+	code, err := solve(&VerifyCodeImage{Image: []byte("captcha"), ContentType: "image/jpeg"})
+	if err != nil || code != "AB12cd" {
+		t.Errorf("solve() = %q, %v, want AB12cd", code, err)
+	}
+	if _, err := solve(&VerifyCodeImage{Image: []byte("other")}); err == nil {
+		t.Error("solve() succeeded on an error response")
+	}
+}
+
+func TestVerifyCodeSolverFromEnv(t *testing.T) {
+	t.Setenv("VERIFY_CODE", "")
+	t.Setenv("VERIFY_CODE_SOLVER_URL", "")
+	if solve, err := VerifyCodeSolverFromEnv(); solve != nil || err != nil {
+		t.Errorf("VerifyCodeSolverFromEnv() without config = %v, %v, want nil", solve != nil, err)
+	}
+	cli := &SvcImpl{}
+	if _, err := cli.LoginWithVerifyCode("admin", "222222", nil); !errors.Is(err, ErrNoVerifyCodeSolver) {
+		t.Errorf("LoginWithVerifyCode without a solver err = %v, want ErrNoVerifyCodeSolver", err)
+	}
+
+	t.Setenv("VERIFY_CODE", "1234")
+	solve, err := VerifyCodeSolverFromEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if code, _ := solve(&VerifyCodeImage{}); code != "1234" {
+		t.Errorf("fixed solver code = %q, want 1234", code)
+	}
+	t.Setenv("VERIFY_CODE_SOLVER_URL", "http://ocr:8000/solve")
+	if _, err := VerifyCodeSolverFromEnv(); err == nil {
+		t.Error("VerifyCodeSolverFromEnv() accepted both VERIFY_CODE and VERIFY_CODE_SOLVER_URL")
+	}
+	t.Setenv("VERIFY_CODE", "")
+	t.Setenv("VERIFY_CODE_SOLVER_URL", "ocr:8000")
+	if _, err := VerifyCodeSolverFromEnv(); err == nil {
+		t.Error("VerifyCodeSolverFromEnv() accepted an endpoint without a scheme")
+	}
+}
@@ -8,7 +8,8 @@ import (
 )
 
 func TestSvcImpl_ReqCreateNewOrder_WaitOrder(t *testing.T) {
-	cli, _ := GetAdminClient()
+	withCassette := useCassette(t)
+	cli, _ := GetAdminClient(withCassette)
 	AddResp, err := cli.ReqCreateNewWaitOrder(&OrderVO{
 		AccountId:  uuid.NewString(),
 		ContactsId: uuid.NewString(),
@@ -28,13 +29,15 @@ func TestSvcImpl_ReqCreateNewOrder_WaitOrder(t *testing.T) {
 }
 
 func TestSvcImpl_ReqGetAllOrders_WaitOrder(t *testing.T) {
-	cli, _ := GetAdminClient()
+	withCassette := useCassette(t)
+	cli, _ := GetAdminClient(withCassette)
 	GetResp, _ := cli.ReqGetAllWaitOrder()
 	fmt.Println(GetResp.Msg)
 }
 
 func TestSvcImpl_ReqGetWaitListOrders(t *testing.T) {
-	cli, _ := GetAdminClient()
+	withCassette := useCassette(t)
+	cli, _ := GetAdminClient(withCassette)
 	GetResp, _ := cli.ReqGetWaitListOrders()
 	fmt.Println(GetResp.Msg)
 }
@@ -0,0 +1,52 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	otelprom "go.opentelemetry.io/otel/exporters/prometheus"
+)
+
+// metricsServer serves the generator's metrics in the Prometheus text format on /metrics.
+type metricsServer struct {
+	server   *http.Server
+	listener net.Listener
+}
+
+// newMetricsServer creates a Prometheus reader for the meter provider and starts serving it on addr.
+func newMetricsServer(addr string) (*otelprom.Exporter, *metricsServer, error) {
+	registry := prometheus.NewRegistry()
+	exporter, err := otelprom.New(otelprom.WithRegisterer(registry))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, nil, err
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+	s := &metricsServer{
+		server:   &http.Server{Handler: mux},
+		listener: listener,
+	}
+	go func() {
+		if err := s.server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("Prometheus metrics server stopped: %v", err)
+		}
+	}()
+	return exporter, s, nil
+}
+
+func (s *metricsServer) shutdown(ctx context.Context) error {
+	if s == nil {
+		return nil
+	}
+	return s.server.Shutdown(ctx)
+}
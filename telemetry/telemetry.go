@@ -0,0 +1,229 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+const (
+	ProtocolHTTP = "http"
+	ProtocolGRPC = "grpc"
+)
+
+// Config configures OTLP export of the generator's own spans and metrics.
+type Config struct {
+	// Endpoint is the collector address (host:port). When empty nothing is exported,
+	// but spans are still created so trace context is propagated.
+	Endpoint string
+	// Protocol is ProtocolHTTP or ProtocolGRPC.
+	Protocol string
+	// Insecure disables TLS towards the collector.
+	Insecure bool
+	// SamplingRatio is the fraction of iteration traces that are sampled, in [0, 1].
+	SamplingRatio float64
+	// BatchTimeout, MaxExportBatchSize and MaxQueueSize configure the span batcher.
+	BatchTimeout       time.Duration
+	MaxExportBatchSize int
+	MaxQueueSize       int
+	// MetricInterval is the period between metric exports.
+	MetricInterval time.Duration
+	// ServiceName, RunID and Scenario are added to the resource of every span and metric.
+	ServiceName string
+	RunID       string
+	Scenario    string
+	// ResourceAttributes are extra resource attributes, e.g. {"vu.range": "0-99"}.
+	ResourceAttributes map[string]string
+	// PrometheusAddr is the listen address of the Prometheus /metrics endpoint, e.g. ":9464".
+	// When empty no endpoint is served.
+	PrometheusAddr string
+}
+
+// DefaultConfig returns a config exporting over OTLP/HTTP without an endpoint.
+func DefaultConfig() Config {
+	return Config{
+		Protocol:           ProtocolHTTP,
+		Insecure:           true,
+		SamplingRatio:      1,
+		BatchTimeout:       5 * time.Second,
+		MaxExportBatchSize: 512,
+		MaxQueueSize:       2048,
+		MetricInterval:     15 * time.Second,
+		ServiceName:        "loadgenerator",
+		RunID:              uuid.New().String(),
+	}
+}
+
+// ConfigFromEnv builds a Config from OTLP_ENDPOINT, OTLP_PROTOCOL, OTLP_INSECURE,
+// TRACE_SAMPLING_RATIO, RUN_ID, SCENARIO, RESOURCE_ATTRIBUTES (key=value,key=value) and METRICS_ADDR.
+func ConfigFromEnv() (Config, error) {
+	cfg := DefaultConfig()
+	cfg.Endpoint = os.Getenv("OTLP_ENDPOINT")
+	if v := os.Getenv("OTLP_PROTOCOL"); v != "" {
+		cfg.Protocol = v
+	}
+	if v := os.Getenv("OTLP_INSECURE"); v != "" {
+		insecure, err := strconv.ParseBool(v)
+		if err != nil {
+			return cfg, fmt.Errorf("invalid OTLP_INSECURE %q: %w", v, err)
+		}
+		cfg.Insecure = insecure
+	}
+	if v := os.Getenv("TRACE_SAMPLING_RATIO"); v != "" {
+		ratio, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return cfg, fmt.Errorf("invalid TRACE_SAMPLING_RATIO %q: %w", v, err)
+		}
+		cfg.SamplingRatio = ratio
+	}
+	if v := os.Getenv("RUN_ID"); v != "" {
+		cfg.RunID = v
+	}
+	cfg.Scenario = os.Getenv("SCENARIO")
+	cfg.PrometheusAddr = os.Getenv("METRICS_ADDR")
+	if v := os.Getenv("RESOURCE_ATTRIBUTES"); v != "" {
+		cfg.ResourceAttributes = make(map[string]string)
+		for _, pair := range strings.Split(v, ",") {
+			key, value, ok := strings.Cut(pair, "=")
+			if !ok {
+				return cfg, fmt.Errorf("invalid RESOURCE_ATTRIBUTES entry %q", pair)
+			}
+			cfg.ResourceAttributes[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+	return cfg, nil
+}
+
+// Telemetry holds the providers created by Setup.
+type Telemetry struct {
+	TracerProvider *sdktrace.TracerProvider
+	MeterProvider  *sdkmetric.MeterProvider
+
+	metrics *metricsServer
+}
+
+// MetricsAddr returns the address the Prometheus endpoint listens on, or "" when disabled.
+func (t *Telemetry) MetricsAddr() string {
+	if t.metrics == nil {
+		return ""
+	}
+	return t.metrics.listener.Addr().String()
+}
+
+// Shutdown flushes and stops both providers and the Prometheus endpoint.
+func (t *Telemetry) Shutdown(ctx context.Context) error {
+	return errors.Join(t.TracerProvider.Shutdown(ctx), t.MeterProvider.Shutdown(ctx), t.metrics.shutdown(ctx))
+}
+
+// Setup creates tracer and meter providers exporting to cfg.Endpoint over OTLP.
+func Setup(ctx context.Context, cfg Config) (*Telemetry, error) {
+	res, err := newResource(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	traceOpts := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SamplingRatio))),
+	}
+	meterOpts := []sdkmetric.Option{sdkmetric.WithResource(res)}
+
+	if cfg.Endpoint != "" {
+		spanExporter, err := newSpanExporter(ctx, cfg)
+		if err != nil {
+			return nil, err
+		}
+		metricExporter, err := newMetricExporter(ctx, cfg)
+		if err != nil {
+			return nil, err
+		}
+		traceOpts = append(traceOpts, sdktrace.WithBatcher(spanExporter,
+			sdktrace.WithBatchTimeout(cfg.BatchTimeout),
+			sdktrace.WithMaxExportBatchSize(cfg.MaxExportBatchSize),
+			sdktrace.WithMaxQueueSize(cfg.MaxQueueSize),
+		))
+		meterOpts = append(meterOpts, sdkmetric.WithReader(
+			sdkmetric.NewPeriodicReader(metricExporter, sdkmetric.WithInterval(cfg.MetricInterval)),
+		))
+	}
+
+	var metrics *metricsServer
+	if cfg.PrometheusAddr != "" {
+		exporter, server, err := newMetricsServer(cfg.PrometheusAddr)
+		if err != nil {
+			return nil, err
+		}
+		meterOpts = append(meterOpts, sdkmetric.WithReader(exporter))
+		metrics = server
+	}
+
+	return &Telemetry{
+		TracerProvider: sdktrace.NewTracerProvider(traceOpts...),
+		MeterProvider:  sdkmetric.NewMeterProvider(meterOpts...),
+		metrics:        metrics,
+	}, nil
+}
+
+func newResource(cfg Config) (*resource.Resource, error) {
+	attrs := []attribute.KeyValue{
+		attribute.String("service.name", cfg.ServiceName),
+		attribute.String("loadgen.run.id", cfg.RunID),
+	}
+	if cfg.Scenario != "" {
+		attrs = append(attrs, attribute.String("loadgen.scenario", cfg.Scenario))
+	}
+	for key, value := range cfg.ResourceAttributes {
+		attrs = append(attrs, attribute.String(key, value))
+	}
+	return resource.Merge(resource.Default(), resource.NewSchemaless(attrs...))
+}
+
+func newSpanExporter(ctx context.Context, cfg Config) (sdktrace.SpanExporter, error) {
+	switch cfg.Protocol {
+	case ProtocolHTTP:
+		opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(cfg.Endpoint)}
+		if cfg.Insecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		return otlptracehttp.New(ctx, opts...)
+	case ProtocolGRPC:
+		opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(cfg.Endpoint)}
+		if cfg.Insecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		return otlptracegrpc.New(ctx, opts...)
+	}
+	return nil, fmt.Errorf("unsupported OTLP protocol %q", cfg.Protocol)
+}
+
+func newMetricExporter(ctx context.Context, cfg Config) (sdkmetric.Exporter, error) {
+	switch cfg.Protocol {
+	case ProtocolHTTP:
+		opts := []otlpmetrichttp.Option{otlpmetrichttp.WithEndpoint(cfg.Endpoint)}
+		if cfg.Insecure {
+			opts = append(opts, otlpmetrichttp.WithInsecure())
+		}
+		return otlpmetrichttp.New(ctx, opts...)
+	case ProtocolGRPC:
+		opts := []otlpmetricgrpc.Option{otlpmetricgrpc.WithEndpoint(cfg.Endpoint)}
+		if cfg.Insecure {
+			opts = append(opts, otlpmetricgrpc.WithInsecure())
+		}
+		return otlpmetricgrpc.New(ctx, opts...)
+	}
+	return nil, fmt.Errorf("unsupported OTLP protocol %q", cfg.Protocol)
+}
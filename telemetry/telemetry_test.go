@@ -0,0 +1,232 @@
+package telemetry
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	colmetricpb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// receiver is an in-process OTLP receiver that collects exported spans and metric names.
+type receiver struct {
+	coltracepb.UnimplementedTraceServiceServer
+	colmetricpb.UnimplementedMetricsServiceServer
+
+	mu       sync.Mutex
+	spans    []string
+	metrics  []string
+	resource []*commonpb.KeyValue
+}
+
+func (r *receiver) addTraces(req *coltracepb.ExportTraceServiceRequest) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, rs := range req.ResourceSpans {
+		r.resource = rs.Resource.Attributes
+		for _, ss := range rs.ScopeSpans {
+			for _, span := range ss.Spans {
+				r.spans = append(r.spans, span.Name)
+			}
+		}
+	}
+}
+
+func (r *receiver) addMetrics(req *colmetricpb.ExportMetricsServiceRequest) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, rm := range req.ResourceMetrics {
+		for _, sm := range rm.ScopeMetrics {
+			for _, m := range sm.Metrics {
+				r.metrics = append(r.metrics, m.Name)
+			}
+		}
+	}
+}
+
+func (r *receiver) Export(ctx context.Context, req *coltracepb.ExportTraceServiceRequest) (*coltracepb.ExportTraceServiceResponse, error) {
+	r.addTraces(req)
+	return &coltracepb.ExportTraceServiceResponse{}, nil
+}
+
+type metricsReceiver struct{ *receiver }
+
+func (r metricsReceiver) Export(ctx context.Context, req *colmetricpb.ExportMetricsServiceRequest) (*colmetricpb.ExportMetricsServiceResponse, error) {
+	r.addMetrics(req)
+	return &colmetricpb.ExportMetricsServiceResponse{}, nil
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	switch req.URL.Path {
+	case "/v1/traces":
+		var msg coltracepb.ExportTraceServiceRequest
+		if err := proto.Unmarshal(body, &msg); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		r.addTraces(&msg)
+	case "/v1/metrics":
+		var msg colmetricpb.ExportMetricsServiceRequest
+		if err := proto.Unmarshal(body, &msg); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		r.addMetrics(&msg)
+	}
+	w.Header().Set("Content-Type", "application/x-protobuf")
+	w.WriteHeader(http.StatusOK)
+}
+
+func (r *receiver) check(t *testing.T) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.spans) != 2 || r.spans[0] != "node" || r.spans[1] != "iteration" {
+		t.Errorf("Unexpected spans: %v", r.spans)
+	}
+	if len(r.metrics) != 1 || r.metrics[0] != "loadgen.test.counter" {
+		t.Errorf("Unexpected metrics: %v", r.metrics)
+	}
+	attrs := make(map[string]string)
+	for _, kv := range r.resource {
+		attrs[kv.Key] = kv.Value.GetStringValue()
+	}
+	if attrs["loadgen.run.id"] != "run-1" || attrs["loadgen.scenario"] != "preserve" || attrs["vu.range"] != "0-9" {
+		t.Errorf("Unexpected resource attributes: %v", attrs)
+	}
+}
+
+func emit(t *testing.T, cfg Config) {
+	ctx := context.Background()
+	tel, err := Setup(ctx, cfg)
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+	tracer := tel.TracerProvider.Tracer("test")
+	iterCtx, iteration := tracer.Start(ctx, "iteration")
+	_, node := tracer.Start(iterCtx, "node")
+	node.End()
+	iteration.End()
+
+	counter, err := tel.MeterProvider.Meter("test").Int64Counter("loadgen.test.counter")
+	if err != nil {
+		t.Fatalf("Create counter failed: %v", err)
+	}
+	counter.Add(ctx, 1, metric.WithAttributes(attribute.Int("vu", 1)))
+
+	if err := tel.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+}
+
+func testConfig(endpoint, protocol string) Config {
+	cfg := DefaultConfig()
+	cfg.Endpoint = endpoint
+	cfg.Protocol = protocol
+	cfg.RunID = "run-1"
+	cfg.Scenario = "preserve"
+	cfg.ResourceAttributes = map[string]string{"vu.range": "0-9"}
+	return cfg
+}
+
+func TestSetup_ExportOverHTTP(t *testing.T) {
+	r := &receiver{}
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	emit(t, testConfig(strings.TrimPrefix(server.URL, "http://"), ProtocolHTTP))
+	r.check(t)
+}
+
+func TestSetup_ExportOverGRPC(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen failed: %v", err)
+	}
+	r := &receiver{}
+	server := grpc.NewServer()
+	coltracepb.RegisterTraceServiceServer(server, r)
+	colmetricpb.RegisterMetricsServiceServer(server, metricsReceiver{r})
+	go server.Serve(lis)
+	defer server.Stop()
+
+	emit(t, testConfig(lis.Addr().String(), ProtocolGRPC))
+	r.check(t)
+}
+
+func TestSetup_SamplingRatio(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.SamplingRatio = 0
+	tel, err := Setup(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+	defer tel.Shutdown(context.Background())
+
+	_, span := tel.TracerProvider.Tracer("test").Start(context.Background(), "iteration")
+	defer span.End()
+	if !span.SpanContext().IsValid() {
+		t.Errorf("Expected a valid span context for propagation")
+	}
+	if span.SpanContext().IsSampled() {
+		t.Errorf("Expected span to be dropped with sampling ratio 0")
+	}
+}
+
+func TestConfigFromEnv(t *testing.T) {
+	t.Setenv("OTLP_ENDPOINT", "collector:4317")
+	t.Setenv("OTLP_PROTOCOL", "grpc")
+	t.Setenv("TRACE_SAMPLING_RATIO", "0.25")
+	t.Setenv("RUN_ID", "run-2")
+	t.Setenv("RESOURCE_ATTRIBUTES", "team=rca, env=test")
+	cfg, err := ConfigFromEnv()
+	if err != nil {
+		t.Fatalf("ConfigFromEnv failed: %v", err)
+	}
+	if cfg.Endpoint != "collector:4317" || cfg.Protocol != ProtocolGRPC || cfg.SamplingRatio != 0.25 ||
+		cfg.RunID != "run-2" || cfg.ResourceAttributes["env"] != "test" {
+		t.Errorf("Unexpected config: %+v", cfg)
+	}
+}
+
+func TestSetup_PrometheusEndpoint(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.PrometheusAddr = "127.0.0.1:0"
+	cfg.RunID = "run-3"
+	tel, err := Setup(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+	defer tel.Shutdown(context.Background())
+
+	counter, err := tel.MeterProvider.Meter("test").Int64Counter("loadgen.http.client.requests")
+	if err != nil {
+		t.Fatalf("Create counter failed: %v", err)
+	}
+	counter.Add(context.Background(), 3, metric.WithAttributes(attribute.String("outcome", "success")))
+
+	resp, err := http.Get("http://" + tel.MetricsAddr() + "/metrics")
+	if err != nil {
+		t.Fatalf("GET /metrics failed: %v", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if !strings.Contains(string(body), `loadgen_http_client_requests_total{otel_scope_name="test",otel_scope_version="",outcome="success"} 3`) {
+		t.Errorf("Counter not found in /metrics output:\n%s", body)
+	}
+}